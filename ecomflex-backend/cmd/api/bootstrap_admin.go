@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/cache"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/database"
+	repo "github.com/naresh6454/ecomflex-backend/internal/infrastructure/database/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+const (
+	// minAdminPasswordLength is the shortest password accepted for a super admin
+	minAdminPasswordLength = 12
+
+	// adminPasswordEnv can hold the password so that it does not show up in the process list
+	adminPasswordEnv = "ECOMFLEX_ADMIN_PASSWORD"
+)
+
+// defaultTenantID is the platform tenant super admins belong to
+var defaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+// Credentials of the super admin seeded in dev mode
+const (
+	devAdminEmail    = "admin@ecomflex.com"
+	devAdminPassword = "Admin@123"
+	devAdminName     = "System Administrator"
+)
+
+// runBootstrapAdmin creates a super admin, or rotates the password of an existing one
+// and signs out its sessions. The password is read from ECOMFLEX_ADMIN_PASSWORD, from
+// stdin with -password-stdin, or generated and printed once.
+func runBootstrapAdmin(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("bootstrap-admin", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the super admin (required)")
+	name := flags.String("name", devAdminName, "full name used when the super admin is created")
+	tenant := flags.String("tenant", defaultTenantID.String(), "tenant the super admin is created in")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from stdin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	tenantID, err := uuid.Parse(*tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	password, generated, err := adminPassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	logger := loggerPkg.NewLogger(cfg.LogLevel)
+
+	db, err := database.NewPostgresConnection(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	redisClient, err := cache.NewRedisClient(cfg.Redis)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis to sign out sessions: %w", err)
+	}
+	defer redisClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	user, created, err := bootstrapAdmin(ctx, repo.NewPostgresUserRepository(db), repo.NewRedisAuthRepository(redisClient.Client), *email, *name, password, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		logger.Info("Super admin created", "user_id", user.ID.String(), "email", user.Email)
+	} else {
+		logger.Info("Super admin password rotated and sessions signed out", "user_id", user.ID.String(), "email", user.Email)
+	}
+
+	if generated {
+		fmt.Printf("Generated password for %s: %s\nStore it now; it is not shown again.\n", user.Email, password)
+	}
+
+	return nil
+}
+
+// bootstrapAdmin creates an active super admin with an email, or sets the password of
+// the existing one, reactivates it and signs out all of its sessions.
+// Accounts with the email and another role are left alone.
+func bootstrapAdmin(ctx context.Context, userRepo repository.UserRepository, authRepo repository.AuthRepository, email, name, password string, tenantID uuid.UUID) (*entity.User, bool, error) {
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	exists, err := userRepo.IsEmailTaken(ctx, email)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	if !exists {
+		user := entity.NewUser(tenantID, email, hashedPassword, name, entity.RoleSuperAdmin, "")
+		user.SetActive()
+		user.MarkEmailVerified()
+		if err := userRepo.CreateUser(ctx, user); err != nil {
+			return nil, false, fmt.Errorf("failed to create super admin: %w", err)
+		}
+		return user, true, nil
+	}
+
+	user, err := userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.Role != entity.RoleSuperAdmin {
+		return nil, false, fmt.Errorf("%s belongs to a %s account, not a super admin", email, user.Role)
+	}
+
+	if err := userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return nil, false, err
+	}
+
+	if !user.IsActive() {
+		user.SetActive()
+		if err := userRepo.UpdateUser(ctx, user); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if !user.IsEmailVerified() {
+		if err := userRepo.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Whoever held the old password is signed out
+	if err := authRepo.DeleteAllSessions(ctx, user.ID); err != nil {
+		return nil, false, fmt.Errorf("failed to sign out sessions: %w", err)
+	}
+
+	return user, false, nil
+}
+
+// ensureDevAdmin makes sure the dev mode super admin exists and can log in with its known password
+func ensureDevAdmin(ctx context.Context, userRepo repository.UserRepository, authRepo repository.AuthRepository) (*entity.User, error) {
+	exists, err := userRepo.IsEmailTaken(ctx, devAdminEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	if exists {
+		user, err := userRepo.GetUserByEmail(ctx, devAdminEmail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		// Rotating on every start would sign the developer out each time
+		if user.IsActive() && util.CheckPasswordHash(devAdminPassword, user.PasswordHash) {
+			return user, nil
+		}
+	}
+
+	user, _, err := bootstrapAdmin(ctx, userRepo, authRepo, devAdminEmail, devAdminName, devAdminPassword, defaultTenantID)
+	return user, err
+}
+
+// adminPassword reads the super admin's password from the environment or stdin,
+// or generates one if neither is given
+func adminPassword(fromStdin bool) (string, bool, error) {
+	password := os.Getenv(adminPasswordEnv)
+
+	if fromStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", false, fmt.Errorf("failed to read password from stdin: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if password == "" {
+		raw := make([]byte, 18)
+		if _, err := rand.Read(raw); err != nil {
+			return "", false, fmt.Errorf("failed to generate password: %w", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(raw), true, nil
+	}
+
+	if len(password) < minAdminPasswordLength {
+		return "", false, fmt.Errorf("the password must have at least %d characters", minAdminPasswordLength)
+	}
+
+	return password, false, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+)
+
+// memoryUserRepo keeps users in memory
+type memoryUserRepo struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (r *memoryUserRepo) IsEmailTaken(ctx context.Context, email string) (bool, error) {
+	_, ok := r.users[email]
+	return ok, nil
+}
+
+func (r *memoryUserRepo) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	user, ok := r.users[email]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *memoryUserRepo) CreateUser(ctx context.Context, user *entity.User) error {
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *memoryUserRepo) UpdateUser(ctx context.Context, user *entity.User) error {
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *memoryUserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	for _, user := range r.users {
+		if user.ID == id {
+			user.PasswordHash = passwordHash
+		}
+	}
+	return nil
+}
+
+func (r *memoryUserRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	for _, user := range r.users {
+		if user.ID == id {
+			user.MarkEmailVerified()
+		}
+	}
+	return nil
+}
+
+// sessionAuthRepo records whose sessions were signed out
+type sessionAuthRepo struct {
+	repository.AuthRepository
+	signedOut []uuid.UUID
+}
+
+func (r *sessionAuthRepo) DeleteAllSessions(ctx context.Context, userID uuid.UUID) error {
+	r.signedOut = append(r.signedOut, userID)
+	return nil
+}
+
+func TestBootstrapAdmin(t *testing.T) {
+	ctx := context.Background()
+	users := &memoryUserRepo{users: map[string]*entity.User{}}
+	sessions := &sessionAuthRepo{}
+
+	created, isNew, err := bootstrapAdmin(ctx, users, sessions, "root@example.com", "Root", "first password", defaultTenantID)
+	if err != nil {
+		t.Fatalf("bootstrapAdmin() error = %v", err)
+	}
+	if !isNew || created.Role != entity.RoleSuperAdmin || !created.IsActive() || !created.IsEmailVerified() {
+		t.Fatalf("created %+v, want an active, verified super admin", created)
+	}
+
+	// Bootstrapping again rotates the password of a deactivated admin and signs it out
+	created.SetInactive()
+	rotated, isNew, err := bootstrapAdmin(ctx, users, sessions, "root@example.com", "Root", "second password", defaultTenantID)
+	if err != nil {
+		t.Fatalf("bootstrapAdmin() again error = %v", err)
+	}
+	if isNew || rotated.ID != created.ID {
+		t.Fatalf("bootstrapped %s, want %s rotated", rotated.ID, created.ID)
+	}
+	if !util.CheckPasswordHash("second password", rotated.PasswordHash) || !rotated.IsActive() {
+		t.Fatal("super admin was not given the new password and reactivated")
+	}
+	if len(sessions.signedOut) != 1 || sessions.signedOut[0] != created.ID {
+		t.Fatalf("signed out %v, want the super admin's sessions", sessions.signedOut)
+	}
+}
+
+func TestBootstrapAdminOtherRole(t *testing.T) {
+	influencer := entity.NewUser(uuid.New(), "user@example.com", "hash", "User", entity.RoleInfluencer, "")
+	users := &memoryUserRepo{users: map[string]*entity.User{influencer.Email: influencer}}
+
+	if _, _, err := bootstrapAdmin(context.Background(), users, &sessionAuthRepo{}, influencer.Email, "User", "a long password", defaultTenantID); err == nil {
+		t.Fatal("bootstrapAdmin() error = nil, want accounts of other roles left alone")
+	}
+	if influencer.Role != entity.RoleInfluencer || influencer.PasswordHash != "hash" {
+		t.Fatalf("influencer changed to %s", influencer.Role)
+	}
+}
+
+func TestEnsureDevAdmin(t *testing.T) {
+	ctx := context.Background()
+	users := &memoryUserRepo{users: map[string]*entity.User{}}
+	sessions := &sessionAuthRepo{}
+
+	admin, err := ensureDevAdmin(ctx, users, sessions)
+	if err != nil {
+		t.Fatalf("ensureDevAdmin() error = %v", err)
+	}
+	if _, err := ensureDevAdmin(ctx, users, sessions); err != nil {
+		t.Fatalf("ensureDevAdmin() again error = %v", err)
+	}
+	if len(sessions.signedOut) != 0 {
+		t.Fatalf("signed out %v on restart, want the developer kept signed in", sessions.signedOut)
+	}
+
+	// A dev admin whose password was changed gets the known one back
+	admin.PasswordHash = "changed"
+	if _, err := ensureDevAdmin(ctx, users, sessions); err != nil {
+		t.Fatalf("ensureDevAdmin() after a password change error = %v", err)
+	}
+	if !util.CheckPasswordHash(devAdminPassword, admin.PasswordHash) {
+		t.Fatal("dev admin password was not restored")
+	}
+}
+
+func TestAdminPassword(t *testing.T) {
+	t.Setenv(adminPasswordEnv, "")
+	generated, isGenerated, err := adminPassword(false)
+	if err != nil || !isGenerated || len(generated) < minAdminPasswordLength {
+		t.Fatalf("adminPassword() = %q, %t, %v, want a generated password", generated, isGenerated, err)
+	}
+
+	t.Setenv(adminPasswordEnv, "short")
+	if _, _, err := adminPassword(false); err == nil {
+		t.Fatal("adminPassword() with a short password: error = nil, want it refused")
+	}
+
+	t.Setenv(adminPasswordEnv, "a long enough password")
+	if password, isGenerated, err := adminPassword(false); err != nil || isGenerated || password != "a long enough password" {
+		t.Fatalf("adminPassword() = %q, %t, %v, want the password from the environment", password, isGenerated, err)
+	}
+}
@@ -7,15 +7,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
-	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/naresh6454/ecomflex-backend/config"
-	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
 	"github.com/naresh6454/ecomflex-backend/internal/api/route"
-	"github.com/naresh6454/ecomflex-backend/internal/app/service"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/cache"
 	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/database"
@@ -43,6 +40,14 @@ func main() {
 		return
 	}
 
+	// Check if the super admin bootstrap command is provided
+	if len(os.Args) > 1 && os.Args[1] == "bootstrap-admin" {
+		if err := runBootstrapAdmin(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Failed to bootstrap super admin: %v", err)
+		}
+		return
+	}
+
 	// Initialize logger
 	logger := loggerPkg.NewLogger(cfg.LogLevel)
 	logger.Info("Starting Ecomflex API server...")
@@ -86,6 +91,18 @@ func main() {
 	}
 	defer redisClient.Close()
 
+	// Seed a super admin with known credentials for local development only
+	if cfg.DevMode {
+		logger.Warn("Dev mode is enabled; do not use it in production")
+		seedCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if _, err := ensureDevAdmin(seedCtx, repo.NewPostgresUserRepository(sqlxDB), repo.NewRedisAuthRepository(redisClient.Client)); err != nil {
+			logger.Error("Failed to seed dev super admin", err)
+		} else {
+			logger.Warn("Dev super admin available", "email", devAdminEmail)
+		}
+		cancel()
+	}
+
 	// Get AWS S3 credentials from environment variables
 	accessKey := os.Getenv("AKIARdbhsbP5LGTLHWW")
 	secretKey := os.Getenv("6EvGUth6Dwebfjbfbj7jYAtfbhrbUMU0LtfApO5atqpGN3")
@@ -112,54 +129,16 @@ func main() {
 	logger.Info("AWS credentials loaded but S3 client is not yet implemented")
 	logger.Info("To implement S3 storage, create the required files in the storage package")
 
-	// Initialize router
-	router := route.SetupRouter(cfg, logger, sqlxDB, redisClient)
-
-	// Initialize product repository and service
+	// Initialize product repository
 	productRepository := repo.NewProductRepository(gormDB)
-	productService := service.NewProductService(productRepository)
-
-	// Initialize product handler
-	productHandler := handler.NewProductHandler(productService)
-
-	// Set up API routes
-	apiGroup := router.Group("/api")
-
-	// Set up all product routes in one group (for backward compatibility)
-	productsGroup := apiGroup.Group("/products")
-	{
-		// Public GET routes
-		productsGroup.GET("", productHandler.GetProducts)
-		productsGroup.GET("/:id", productHandler.GetProduct)
-		productsGroup.GET("/trending", productHandler.GetTrendingProducts)
-		productsGroup.GET("/categories", productHandler.GetProductCategories)
-
-		// Admin routes - added directly to the same group without auth middleware
-		// For development, add a simple middleware that sets tenant ID
-		productsGroup.POST("", addDevTenant, productHandler.CreateProduct)
-		productsGroup.PUT("/:id", addDevTenant, productHandler.UpdateProduct)
-		productsGroup.DELETE("/:id", addDevTenant, productHandler.DeleteProduct)
-		productsGroup.PATCH("/:id/status", addDevTenant, productHandler.ToggleProductStatus)
-		productsGroup.POST("/:id/image", addDevTenant, productHandler.UploadProductImage)
-	}
-
-	// Set up versioned product routes under /api/v1/products
-	v1Group := apiGroup.Group("/v1")
-	v1ProductsGroup := v1Group.Group("/products")
-	{
-		// Public GET routes
-		v1ProductsGroup.GET("", productHandler.GetProducts)
-		v1ProductsGroup.GET("/:id", productHandler.GetProduct)
-		v1ProductsGroup.GET("/trending", productHandler.GetTrendingProducts)
-		v1ProductsGroup.GET("/categories", productHandler.GetProductCategories)
-
-		// Admin routes
-		v1ProductsGroup.POST("", addDevTenant, productHandler.CreateProduct)
-		v1ProductsGroup.PUT("/:id", addDevTenant, productHandler.UpdateProduct)
-		v1ProductsGroup.DELETE("/:id", addDevTenant, productHandler.DeleteProduct)
-		v1ProductsGroup.PATCH("/:id/status", addDevTenant, productHandler.ToggleProductStatus)
-		v1ProductsGroup.POST("/:id/image", addDevTenant, productHandler.UploadProductImage)
-	}
+
+	// Background workers stop once the server has shut down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+
+	// Initialize router
+	router := route.SetupRouter(workerCtx, &workers, cfg, logger, sqlxDB, redisClient, productRepository)
 
 	// Determine port: environment variable overrides config
 	port := cfg.Server.Port
@@ -197,27 +176,9 @@ func main() {
 		logger.Fatal("Server forced to shutdown", err)
 	}
 
-	logger.Info("Server exited properly")
-}
-
-// addDevTenant is a middleware that adds a development tenant ID to the context
-// This is only for development purposes to bypass authentication
-func addDevTenant(c *gin.Context) {
-	// Add a proper UUID for tenant ID to match the expected format
-	// This is important for database operations that expect a valid UUID
-	defaultTenantID := "00000000-0000-0000-0000-000000000000"
-	tenantUUID, err := uuid.Parse(defaultTenantID)
-	if err != nil {
-		// Should never happen with a valid UUID string
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Invalid tenant ID format"})
-		return
-	}
-
-	// Set properly formatted tenant ID in context
-	c.Set("tenantID", tenantUUID.String())
+	// Let the workers finish what requests left queued, such as audit log entries and emails
+	stopWorkers()
+	workers.Wait()
 
-	// Set other context values for development
-	c.Set("userID", uuid.New().String()) // Generate a random UUID for user ID
-	c.Set("role", "super_admin")
-	c.Next()
+	logger.Info("Server exited properly")
 }
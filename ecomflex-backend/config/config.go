@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -17,10 +18,20 @@ type Config struct {
 	Database DatabaseConfig
 	Redis    RedisConfig
 	JWT      JWTConfig
+	Auth     AuthConfig
 	OAuth    OAuthConfig
 	Captcha  CaptchaConfig
+	Throttle LoginThrottleConfig
+	Booking  BookingConfig
+	Referral ReferralConfig
+	Payout   PayoutConfig
+	Mail     MailConfig
+	Tenancy  TenancyConfig
 	LogLevel string
 	Cors     CorsConfig
+
+	// DevMode enables development shortcuts that bypass authentication; never set it in production
+	DevMode bool
 }
 
 // ServerConfig holds server configuration
@@ -50,10 +61,23 @@ type RedisConfig struct {
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret           string
-	AccessTokenExp   time.Duration
-	RefreshTokenExp  time.Duration
-	RefreshTokenSize int
+	Secret                string
+	Algorithm             string
+	KeysDir               string
+	Keys                  []string
+	KeyRetention          time.Duration
+	AccessTokenExp        time.Duration
+	RefreshTokenExp       time.Duration
+	RefreshTokenSize      int
+	ImpersonationTokenExp time.Duration // how long an admin can act as another user
+}
+
+// AuthConfig holds account verification and recovery configuration
+type AuthConfig struct {
+	RequireVerifiedEmail bool
+	EmailVerificationTTL time.Duration
+	PasswordResetTTL     time.Duration
+	AppURL               string
 }
 
 // OAuthConfig holds OAuth configuration
@@ -61,12 +85,95 @@ type OAuthConfig struct {
 	GoogleClientID     string
 	GoogleClientSecret string
 	GoogleRedirectURL  string
+
+	// Provider endpoints; point them at a local OIDC server for tests
+	GoogleTokenURL    string
+	GoogleUserInfoURL string
+	GoogleJWKSURL     string
+	GoogleIssuers     []string
 }
 
-// CaptchaConfig holds reCAPTCHA configuration
+// CaptchaConfig holds reCAPTCHA and hCaptcha configuration
 type CaptchaConfig struct {
+	Provider  string
 	SecretKey string
 	SiteKey   string
+
+	// Siteverify endpoint; point it at a local fake server for tests
+	VerifyURL string
+
+	// reCAPTCHA v3 only: the lowest score accepted and the expected action, if any
+	MinScore float64
+	Action   string
+}
+
+// LoginThrottleConfig holds failed login throttling and lockout configuration.
+// Failures are counted per account and per IP address over a sliding window.
+type LoginThrottleConfig struct {
+	Window              time.Duration
+	DelayAfter          int
+	BaseDelay           time.Duration
+	MaxDelay            time.Duration
+	CaptchaAfter        int
+	AccountLockoutLimit int
+	IPLockoutLimit      int
+	LockoutDuration     time.Duration
+}
+
+// BookingConfig holds booking slot reservation and proof review configuration
+type BookingConfig struct {
+	ReservationTTL time.Duration
+	ExpiryInterval time.Duration
+	ProofClaimTTL  time.Duration
+}
+
+// ReferralConfig holds referral link tracking configuration
+type ReferralConfig struct {
+	LandingURL string
+	IPHashSalt string
+}
+
+// PayoutConfig holds earnings payout configuration
+type PayoutConfig struct {
+	Threshold     float64
+	RunInterval   time.Duration
+	Currency      string
+	Provider      string
+	WebhookSecret string
+	FakeStatePath string
+}
+
+// PayoutProviderDisabled is the payout provider that turns off payout runs and provider webhooks
+const PayoutProviderDisabled = "disabled"
+
+// Enabled checks if payouts are sent through a provider
+func (c PayoutConfig) Enabled() bool {
+	return c.Provider != PayoutProviderDisabled
+}
+
+// MailConfig holds outbound email configuration
+type MailConfig struct {
+	Transport    string
+	From         string
+	FromName     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	OutboxDir    string
+	QueueSize    int
+	Workers      int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// TenancyConfig holds how requests are matched to tenants by their Host header.
+// Platform hosts serve every tenant; subdomains of the base domain and verified custom domains serve one.
+type TenancyConfig struct {
+	BaseDomain       string
+	PlatformHosts    []string
+	CacheTTL         time.Duration
+	NotFoundCacheTTL time.Duration
 }
 
 // CorsConfig holds CORS configuration
@@ -129,21 +236,88 @@ func Load() (*Config, error) {
 			DB:       getEnvOrInt(v, "redis.db"),
 		},
 		JWT: JWTConfig{
-			Secret:           getEnvOrString(v, "jwt.secret"),
-			AccessTokenExp:   getEnvOrDuration(v, "jwt.access_token_exp"),
-			RefreshTokenExp:  getEnvOrDuration(v, "jwt.refresh_token_exp"),
-			RefreshTokenSize: getEnvOrInt(v, "jwt.refresh_token_size"),
+			Secret:                getEnvOrString(v, "jwt.secret"),
+			Algorithm:             getEnvOrString(v, "jwt.algorithm"),
+			KeysDir:               getEnvOrString(v, "jwt.keys_dir"),
+			Keys:                  getEnvOrStringSlice(v, "jwt.keys"),
+			KeyRetention:          getEnvOrDuration(v, "jwt.key_retention"),
+			AccessTokenExp:        getEnvOrDuration(v, "jwt.access_token_exp"),
+			RefreshTokenExp:       getEnvOrDuration(v, "jwt.refresh_token_exp"),
+			RefreshTokenSize:      getEnvOrInt(v, "jwt.refresh_token_size"),
+			ImpersonationTokenExp: getEnvOrDuration(v, "jwt.impersonation_token_exp"),
+		},
+		Auth: AuthConfig{
+			RequireVerifiedEmail: getEnvOrBool(v, "auth.require_verified_email"),
+			EmailVerificationTTL: getEnvOrDuration(v, "auth.email_verification_ttl"),
+			PasswordResetTTL:     getEnvOrDuration(v, "auth.password_reset_ttl"),
+			AppURL:               getEnvOrString(v, "auth.app_url"),
 		},
 		OAuth: OAuthConfig{
 			GoogleClientID:     getEnvOrString(v, "oauth.google.client_id"),
 			GoogleClientSecret: getEnvOrString(v, "oauth.google.client_secret"),
 			GoogleRedirectURL:  getEnvOrString(v, "oauth.google.redirect_url"),
+			GoogleTokenURL:     getEnvOrString(v, "oauth.google.token_url"),
+			GoogleUserInfoURL:  getEnvOrString(v, "oauth.google.userinfo_url"),
+			GoogleJWKSURL:      getEnvOrString(v, "oauth.google.jwks_url"),
+			GoogleIssuers:      getEnvOrStringSlice(v, "oauth.google.issuers"),
 		},
 		Captcha: CaptchaConfig{
+			Provider:  getEnvOrString(v, "captcha.provider"),
 			SecretKey: getEnvOrString(v, "captcha.secret_key"),
 			SiteKey:   getEnvOrString(v, "captcha.site_key"),
+			VerifyURL: getEnvOrString(v, "captcha.verify_url"),
+			MinScore:  getEnvOrFloat(v, "captcha.min_score"),
+			Action:    getEnvOrString(v, "captcha.action"),
+		},
+		Throttle: LoginThrottleConfig{
+			Window:              getEnvOrDuration(v, "login_throttle.window"),
+			DelayAfter:          getEnvOrInt(v, "login_throttle.delay_after"),
+			BaseDelay:           getEnvOrDuration(v, "login_throttle.base_delay"),
+			MaxDelay:            getEnvOrDuration(v, "login_throttle.max_delay"),
+			CaptchaAfter:        getEnvOrInt(v, "login_throttle.captcha_after"),
+			AccountLockoutLimit: getEnvOrInt(v, "login_throttle.account_lockout_limit"),
+			IPLockoutLimit:      getEnvOrInt(v, "login_throttle.ip_lockout_limit"),
+			LockoutDuration:     getEnvOrDuration(v, "login_throttle.lockout_duration"),
+		},
+		Booking: BookingConfig{
+			ReservationTTL: getEnvOrDuration(v, "booking.reservation_ttl"),
+			ExpiryInterval: getEnvOrDuration(v, "booking.expiry_interval"),
+			ProofClaimTTL:  getEnvOrDuration(v, "booking.proof_claim_ttl"),
+		},
+		Referral: ReferralConfig{
+			LandingURL: getEnvOrString(v, "referral.landing_url"),
+			IPHashSalt: getEnvOrString(v, "referral.ip_hash_salt"),
+		},
+		Payout: PayoutConfig{
+			Threshold:     getEnvOrFloat(v, "payout.threshold"),
+			RunInterval:   getEnvOrDuration(v, "payout.run_interval"),
+			Currency:      getEnvOrString(v, "payout.currency"),
+			Provider:      getEnvOrString(v, "payout.provider"),
+			WebhookSecret: getEnvOrString(v, "payout.webhook_secret"),
+			FakeStatePath: getEnvOrString(v, "payout.fake_state_path"),
+		},
+		Mail: MailConfig{
+			Transport:    getEnvOrString(v, "mail.transport"),
+			From:         getEnvOrString(v, "mail.from"),
+			FromName:     getEnvOrString(v, "mail.from_name"),
+			SMTPHost:     getEnvOrString(v, "mail.smtp.host"),
+			SMTPPort:     getEnvOrInt(v, "mail.smtp.port"),
+			SMTPUsername: getEnvOrString(v, "mail.smtp.username"),
+			SMTPPassword: getEnvOrString(v, "mail.smtp.password"),
+			OutboxDir:    getEnvOrString(v, "mail.outbox_dir"),
+			QueueSize:    getEnvOrInt(v, "mail.queue_size"),
+			Workers:      getEnvOrInt(v, "mail.workers"),
+			MaxAttempts:  getEnvOrInt(v, "mail.max_attempts"),
+			RetryBackoff: getEnvOrDuration(v, "mail.retry_backoff"),
+		},
+		Tenancy: TenancyConfig{
+			BaseDomain:       getEnvOrString(v, "tenancy.base_domain"),
+			PlatformHosts:    getEnvOrStringSlice(v, "tenancy.platform_hosts"),
+			CacheTTL:         getEnvOrDuration(v, "tenancy.cache_ttl"),
+			NotFoundCacheTTL: getEnvOrDuration(v, "tenancy.not_found_cache_ttl"),
 		},
 		LogLevel: getEnvOrString(v, "log_level"),
+		DevMode:  getEnvOrBool(v, "dev_mode"),
 		Cors: CorsConfig{
 			AllowOrigins:     getEnvOrStringSlice(v, "cors.allow_origins"),
 			AllowMethods:     getEnvOrStringSlice(v, "cors.allow_methods"),
@@ -154,9 +328,42 @@ func Load() (*Config, error) {
 		},
 	}
 
+	// Without a configured provider, dev mode sends payouts through the fake one and production turns them off
+	if config.Payout.Provider == "" {
+		config.Payout.Provider = PayoutProviderDisabled
+		if config.DevMode {
+			config.Payout.Provider = "fake"
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+// placeholderPayoutWebhookSecret is the example payout webhook secret, which must never sign real webhooks
+const placeholderPayoutWebhookSecret = "your-payout-webhook-secret"
+
+// devReferralIPHashSalt is the public salt click IPs are hashed with in dev mode
+const devReferralIPHashSalt = "your-referral-salt"
+
+// Validate rejects configurations that are unsafe to run with
+func (c *Config) Validate() error {
+	if (c.Payout.Provider == "" || c.Payout.Provider == "fake") && !c.DevMode {
+		return errors.New("the fake payout provider is only allowed in dev mode; set payout.provider to a real provider or disabled")
+	}
+	if c.Payout.Enabled() && (c.Payout.WebhookSecret == "" || c.Payout.WebhookSecret == placeholderPayoutWebhookSecret) {
+		return errors.New("payout.webhook_secret must be set to a secret value")
+	}
+	if (c.Referral.IPHashSalt == "" || c.Referral.IPHashSalt == devReferralIPHashSalt) && !c.DevMode {
+		return errors.New("referral.ip_hash_salt must be set to a secret value outside dev mode")
+	}
+
+	return nil
+}
+
 func setDefaults(v *viper.Viper) {
 	// Server defaults
 	v.SetDefault("server.port", "8080")
@@ -179,17 +386,88 @@ func setDefaults(v *viper.Viper) {
 
 	// JWT defaults
 	v.SetDefault("jwt.secret", "your-secret-key")
+	v.SetDefault("jwt.algorithm", "HS256") // RS256 and EdDSA sign with keys from keys_dir or keys
+	v.SetDefault("jwt.keys_dir", "")
+	v.SetDefault("jwt.keys", []string{})     // kid=<base64 encoded PEM>, for passing keys in the environment
+	v.SetDefault("jwt.key_retention", "24h") // how long a replaced key still verifies tokens
 	v.SetDefault("jwt.access_token_exp", "15m")
 	v.SetDefault("jwt.refresh_token_exp", "168h") // 7 days
 	v.SetDefault("jwt.refresh_token_size", 32)
+	v.SetDefault("jwt.impersonation_token_exp", "15m") // impersonation tokens cannot be refreshed
+
+	// Account verification and recovery defaults
+	v.SetDefault("auth.require_verified_email", false)
+	v.SetDefault("auth.email_verification_ttl", "24h")
+	v.SetDefault("auth.password_reset_ttl", "1h")
+	v.SetDefault("auth.app_url", "http://localhost:5173") // frontend that serves the links in emails
+
+	// Google OAuth defaults; client credentials have to be configured
+	v.SetDefault("oauth.google.token_url", "https://oauth2.googleapis.com/token")
+	v.SetDefault("oauth.google.userinfo_url", "https://openidconnect.googleapis.com/v1/userinfo")
+	v.SetDefault("oauth.google.jwks_url", "https://www.googleapis.com/oauth2/v3/certs")
+	v.SetDefault("oauth.google.issuers", []string{"https://accounts.google.com", "accounts.google.com"})
+
+	// Captcha defaults; the noop provider accepts every request and is meant for development
+	v.SetDefault("captcha.provider", "noop") // noop, recaptcha or hcaptcha
+	v.SetDefault("captcha.verify_url", "")   // empty uses the provider's siteverify endpoint
+	v.SetDefault("captcha.min_score", 0.5)
+	v.SetDefault("captcha.action", "")
+
+	// Login throttling defaults; a zero limit turns that protection off
+	v.SetDefault("login_throttle.window", "15m")
+	v.SetDefault("login_throttle.delay_after", 3)   // failures before each attempt has to wait
+	v.SetDefault("login_throttle.base_delay", "1s") // doubles with every further failure
+	v.SetDefault("login_throttle.max_delay", "30s")
+	v.SetDefault("login_throttle.captcha_after", 0) // failures before a captcha is required; 0 requires it on every login
+	v.SetDefault("login_throttle.account_lockout_limit", 10)
+	v.SetDefault("login_throttle.ip_lockout_limit", 50)
+	v.SetDefault("login_throttle.lockout_duration", "30m")
+
+	// Booking defaults
+	v.SetDefault("booking.reservation_ttl", "48h")
+	v.SetDefault("booking.expiry_interval", "15m")
+	v.SetDefault("booking.proof_claim_ttl", "30m")
+
+	// Referral defaults
+	v.SetDefault("referral.landing_url", "http://localhost:5173")
+	v.SetDefault("referral.ip_hash_salt", devReferralIPHashSalt) // dev mode only; click IPs are hashed with it
+
+	// Payout defaults; a zero run interval leaves payout runs to admins
+	v.SetDefault("payout.threshold", 50.0)
+	v.SetDefault("payout.run_interval", "24h")
+	v.SetDefault("payout.currency", "USD")
+	v.SetDefault("payout.provider", "")       // empty uses the fake in dev mode and disabled otherwise; set fake_state_path to keep the fake's state on disk
+	v.SetDefault("payout.webhook_secret", "") // required unless disabled; webhooks are verified with it
+	v.SetDefault("payout.fake_state_path", "")
+
+	// Mail defaults; the file transport writes emails to outbox_dir instead of sending them
+	v.SetDefault("mail.transport", "file")
+	v.SetDefault("mail.from", "no-reply@ecomflex.com")
+	v.SetDefault("mail.from_name", "EcomFlex")
+	v.SetDefault("mail.smtp.host", "localhost")
+	v.SetDefault("mail.smtp.port", 587)
+	v.SetDefault("mail.outbox_dir", "mail-outbox")
+	v.SetDefault("mail.queue_size", 1000)
+	v.SetDefault("mail.workers", 2)
+	v.SetDefault("mail.max_attempts", 5)
+	v.SetDefault("mail.retry_backoff", "30s")
+
+	// Tenancy defaults; requests to platform hosts act in the default tenant or the signed-in user's
+	v.SetDefault("tenancy.base_domain", "ecomflex.com")
+	v.SetDefault("tenancy.platform_hosts", []string{"ecomflex.com", "www.ecomflex.com", "api.ecomflex.com", "localhost", "127.0.0.1"})
+	v.SetDefault("tenancy.cache_ttl", "5m")
+	v.SetDefault("tenancy.not_found_cache_ttl", "30s") // unknown hosts are remembered briefly too
 
 	// Log level default
 	v.SetDefault("log_level", "info")
 
+	// Dev mode seeds a super admin with known credentials and lets product writes through without a login
+	v.SetDefault("dev_mode", false)
+
 	// CORS defaults
 	v.SetDefault("cors.allow_origins", []string{"http://localhost:5173", "https://yourdomain.com"})
 	v.SetDefault("cors.allow_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
-	v.SetDefault("cors.allow_headers", []string{"Origin", "Content-Type", "Accept", "Authorization"})
+	v.SetDefault("cors.allow_headers", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Tenant-ID"})
 	v.SetDefault("cors.expose_headers", []string{"Content-Length"})
 	v.SetDefault("cors.allow_credentials", true)
 	v.SetDefault("cors.max_age", "12h")
@@ -214,6 +492,16 @@ func getEnvOrInt(v *viper.Viper, key string) int {
 	return v.GetInt(key)
 }
 
+func getEnvOrFloat(v *viper.Viper, key string) float64 {
+	value := os.Getenv("ECOMFLEX_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_")))
+	if value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return v.GetFloat64(key)
+}
+
 func getEnvOrDuration(v *viper.Viper, key string) time.Duration {
 	value := os.Getenv("ECOMFLEX_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_")))
 	if value != "" {
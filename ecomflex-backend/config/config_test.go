@@ -0,0 +1,99 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantErr      bool
+		wantProvider string
+	}{
+		{
+			name: "production without a payout provider",
+			env: map[string]string{
+				"ECOMFLEX_REFERRAL_IP_HASH_SALT": "production-salt",
+			},
+			wantProvider: PayoutProviderDisabled,
+		},
+		{
+			name: "production with payouts disabled",
+			env: map[string]string{
+				"ECOMFLEX_REFERRAL_IP_HASH_SALT": "production-salt",
+				"ECOMFLEX_PAYOUT_PROVIDER":       PayoutProviderDisabled,
+			},
+			wantProvider: PayoutProviderDisabled,
+		},
+		{
+			name: "production with the fake payout provider",
+			env: map[string]string{
+				"ECOMFLEX_REFERRAL_IP_HASH_SALT": "production-salt",
+				"ECOMFLEX_PAYOUT_PROVIDER":       "fake",
+				"ECOMFLEX_PAYOUT_WEBHOOK_SECRET": "webhook-secret",
+			},
+			wantErr: true,
+		},
+		{
+			name: "production with the example referral salt",
+			env: map[string]string{
+				"ECOMFLEX_REFERRAL_IP_HASH_SALT": devReferralIPHashSalt,
+			},
+			wantErr: true,
+		},
+		{
+			name: "dev mode without a payout provider",
+			env: map[string]string{
+				"ECOMFLEX_DEV_MODE":              "true",
+				"ECOMFLEX_PAYOUT_WEBHOOK_SECRET": "webhook-secret",
+			},
+			wantProvider: "fake",
+		},
+		{
+			name: "dev mode without a webhook secret",
+			env: map[string]string{
+				"ECOMFLEX_DEV_MODE": "true",
+			},
+			wantErr: true,
+		},
+		{
+			name: "dev mode with the example webhook secret",
+			env: map[string]string{
+				"ECOMFLEX_DEV_MODE":              "true",
+				"ECOMFLEX_PAYOUT_WEBHOOK_SECRET": placeholderPayoutWebhookSecret,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{
+				"ECOMFLEX_DEV_MODE",
+				"ECOMFLEX_PAYOUT_PROVIDER",
+				"ECOMFLEX_PAYOUT_WEBHOOK_SECRET",
+				"ECOMFLEX_REFERRAL_IP_HASH_SALT",
+			} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg, err := Load()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Load() = %+v, want an error", cfg.Payout)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Payout.Provider != tt.wantProvider {
+				t.Errorf("payout provider = %q, want %q", cfg.Payout.Provider, tt.wantProvider)
+			}
+			if cfg.Payout.Enabled() != (tt.wantProvider != PayoutProviderDisabled) {
+				t.Errorf("payouts enabled = %t with provider %q", cfg.Payout.Enabled(), cfg.Payout.Provider)
+			}
+		})
+	}
+}
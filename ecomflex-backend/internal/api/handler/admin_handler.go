@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -113,6 +114,13 @@ func (h *AdminHandler) UpdateInfluencerStatus(c *gin.Context) {
 	err = h.userService.UpdateInfluencerStatus(c.Request.Context(), influencerID, req)
 	if err != nil {
 		h.logger.Error("Failed to update influencer status", err)
+		if respondQuotaError(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrOtherTenant) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to update influencer status", err)
 		return
 	}
@@ -136,6 +144,10 @@ func (h *AdminHandler) GetInfluencer(c *gin.Context) {
 	influencer, err := h.userService.GetUserByID(c.Request.Context(), influencerID)
 	if err != nil {
 		h.logger.Error("Failed to get influencer", err)
+		if errors.Is(err, service.ErrOtherTenant) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to get influencer", err)
 		return
 	}
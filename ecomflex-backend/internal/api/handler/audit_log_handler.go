@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+const (
+	// auditExportBatchSize is how many entries are read at a time while exporting
+	auditExportBatchSize = 500
+
+	// maxAuditExportRows caps an export; narrower filters are needed beyond it
+	maxAuditExportRows = 50000
+)
+
+// auditExportColumns is the header row of an audit log export
+var auditExportColumns = []string{
+	"id", "created_at", "tenant_id", "user_id", "impersonator_id", "action",
+	"entity_type", "entity_id", "ip_address", "user_agent", "old_value", "new_value",
+}
+
+// AuditLogHandler handles reading a tenant's audit log
+type AuditLogHandler struct {
+	auditLogger  service.AuditLogger
+	quotaService service.QuotaService
+	logger       loggerPkg.Logger
+}
+
+// NewAuditLogHandler creates a new AuditLogHandler
+func NewAuditLogHandler(auditLogger service.AuditLogger, quotaService service.QuotaService, logger loggerPkg.Logger) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditLogger:  auditLogger,
+		quotaService: quotaService,
+		logger:       logger,
+	}
+}
+
+// GetAuditLogs handles listing audit log entries with filters
+func (h *AuditLogHandler) GetAuditLogs(c *gin.Context) {
+	filter, ok := h.parseFilter(c)
+	if !ok {
+		return
+	}
+
+	page, limit := pagination(c)
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	logs, total, err := h.auditLogger.List(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list audit logs", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list audit logs", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Audit logs retrieved successfully", gin.H{
+		"audit_logs": logs,
+		"total":      total,
+		"page":       page,
+		"limit":      limit,
+	})
+}
+
+// ExportAuditLogs handles downloading the audit log entries matching the filters as CSV
+func (h *AuditLogHandler) ExportAuditLogs(c *gin.Context) {
+	filter, ok := h.parseFilter(c)
+	if !ok {
+		return
+	}
+
+	if err := h.quotaService.RequireFeature(c.Request.Context(), filter.TenantID, entity.FeatureAuditLogExport); err != nil {
+		h.logger.Error("Failed to export audit logs", err)
+		if !respondQuotaError(c, err) {
+			response.Error(c, http.StatusInternalServerError, "Failed to export audit logs", err)
+		}
+		return
+	}
+
+	// Entries recorded during the export would shift the pages being read
+	if filter.To == nil {
+		now := time.Now()
+		filter.To = &now
+	}
+
+	filter.Limit = auditExportBatchSize
+	logs, total, err := h.auditLogger.List(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to export audit logs", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to export audit logs", err)
+		return
+	}
+
+	if total > maxAuditExportRows {
+		response.Error(c, http.StatusBadRequest, fmt.Sprintf("Too many audit logs to export (%d), narrow the filters to at most %d", total, maxAuditExportRows), nil)
+		return
+	}
+
+	filename := fmt.Sprintf("audit-logs-%s.csv", time.Now().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Rows are streamed a batch at a time; once the header is sent errors can only be logged
+	w := csv.NewWriter(c.Writer)
+	if err := w.Write(auditExportColumns); err != nil {
+		h.logger.Error("Failed to write audit log export", err)
+		return
+	}
+
+	for {
+		for _, log := range logs {
+			if err := w.Write(auditLogRecord(log)); err != nil {
+				h.logger.Error("Failed to write audit log export", err)
+				return
+			}
+		}
+		w.Flush()
+
+		filter.Offset += len(logs)
+		if len(logs) < auditExportBatchSize || filter.Offset >= total {
+			break
+		}
+
+		logs, _, err = h.auditLogger.List(c.Request.Context(), filter)
+		if err != nil {
+			h.logger.Error("Failed to export audit logs", err)
+			return
+		}
+	}
+
+	if err := w.Error(); err != nil {
+		h.logger.Error("Failed to write audit log export", err)
+	}
+}
+
+// parseFilter reads the audit log filters from the query string.
+// Admins see their own tenant; super admins may pick another one.
+func (h *AuditLogHandler) parseFilter(c *gin.Context) (repository.AuditLogFilter, bool) {
+	user, _ := c.MustGet("user").(*entity.User)
+	filter := repository.AuditLogFilter{
+		TenantID:   user.TenantID,
+		Action:     c.Query("action"),
+		EntityType: c.Query("entityType"),
+	}
+
+	if tenantID := c.Query("tenantId"); tenantID != "" {
+		id, ok := h.parseQueryID(c, tenantID, "tenant")
+		if !ok {
+			return filter, false
+		}
+		if id != user.TenantID && user.Role != entity.RoleSuperAdmin {
+			response.Error(c, http.StatusForbidden, "Cannot read the audit log of another tenant", nil)
+			return filter, false
+		}
+		filter.TenantID = id
+	}
+
+	if userID := c.Query("userId"); userID != "" {
+		if _, ok := h.parseQueryID(c, userID, "user"); !ok {
+			return filter, false
+		}
+		filter.UserID = userID
+	}
+
+	if entityID := c.Query("entityId"); entityID != "" {
+		if _, ok := h.parseQueryID(c, entityID, "entity"); !ok {
+			return filter, false
+		}
+		filter.EntityID = entityID
+	}
+
+	var ok bool
+	if filter.From, ok = h.parseQueryTime(c, "from"); !ok {
+		return filter, false
+	}
+	if filter.To, ok = h.parseQueryTime(c, "to"); !ok {
+		return filter, false
+	}
+
+	return filter, true
+}
+
+// parseQueryID parses a UUID from the query string
+func (h *AuditLogHandler) parseQueryID(c *gin.Context, value, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		h.logger.Error("Invalid "+name+" ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid "+name+" ID", err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseQueryTime parses an RFC 3339 time or a date from the query string; a date means its midnight in UTC
+func (h *AuditLogHandler) parseQueryTime(c *gin.Context, param string) (*time.Time, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, true
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", value)
+	}
+	if err != nil {
+		h.logger.Error("Invalid "+param+" time", err)
+		response.Error(c, http.StatusBadRequest, "Invalid "+param+" time, use RFC 3339 or YYYY-MM-DD", err)
+		return nil, false
+	}
+	return &t, true
+}
+
+// auditLogRecord formats an audit log entry as a CSV row in the order of auditExportColumns
+func auditLogRecord(log *entity.AuditLog) []string {
+	optionalID := func(id *uuid.UUID) string {
+		if id == nil {
+			return ""
+		}
+		return id.String()
+	}
+
+	return []string{
+		log.ID.String(),
+		log.CreatedAt.UTC().Format(time.RFC3339),
+		log.TenantID.String(),
+		optionalID(log.UserID),
+		optionalID(log.ImpersonatorID),
+		string(log.Action),
+		log.EntityType,
+		optionalID(log.EntityID),
+		log.IPAddress,
+		log.UserAgent,
+		string(log.OldValue),
+		string(log.NewValue),
+	}
+}
@@ -1,28 +1,35 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/naresh6454/ecomflex-backend/internal/api/request"
 	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
 	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
 )
 
 // AuthHandler handles authentication-related requests
 type AuthHandler struct {
-	authService service.AuthService
-	logger      loggerPkg.Logger
+	authService     service.AuthService
+	referralService service.ReferralService
+	logger          loggerPkg.Logger
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(authService service.AuthService, logger loggerPkg.Logger) *AuthHandler {
+func NewAuthHandler(authService service.AuthService, referralService service.ReferralService, logger loggerPkg.Logger) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
-		logger:      logger,
+		authService:     authService,
+		referralService: referralService,
+		logger:          logger,
 	}
 }
 
@@ -42,9 +49,6 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		"has_captcha": req.CaptchaToken != "",
 	})
 	
-	// Always provide a dummy captcha token for development
-	req.CaptchaToken = "dev-mode-bypass-captcha"
-	
 	// Get tenant ID from context (set by middleware)
 	tenantIDStr := c.GetString("tenantID")
 	if tenantIDStr == "" {
@@ -77,6 +81,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	if err != nil {
 		h.logger.Error("Failed to register user", err)
 		
+		if h.respondCaptchaError(c, err) {
+			return
+		}
+		
 		// Check for specific errors
 		if strings.Contains(err.Error(), "already exists") {
 			response.Error(c, http.StatusConflict, err.Error(), nil)
@@ -87,15 +95,24 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 	
+	// Credit the influencer whose referral link brought the user here
+	token := attributionToken(c, req.AttributionToken)
+	if token != "" {
+		if _, err := h.referralService.AttributeRegistration(c.Request.Context(), token, user); err != nil {
+			h.logger.Error("Failed to attribute registration", err)
+		}
+	}
+	
 	// Prepare response
 	resp := response.UserResponse{
-		ID:           user.ID.String(),
-		Email:        user.Email,
-		FullName:     user.FullName,
-		Role:         string(user.Role),
-		Status:       user.Status,
-		ReferralCode: user.ReferralCode,
-		CreatedAt:    user.CreatedAt,
+		ID:            user.ID.String(),
+		Email:         user.Email,
+		EmailVerified: user.IsEmailVerified(),
+		FullName:      user.FullName,
+		Role:          string(user.Role),
+		Status:        user.Status,
+		ReferralCode:  user.ReferralCode,
+		CreatedAt:     user.CreatedAt,
 	}
 	
 	// Return success response
@@ -111,10 +128,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 	
-	// Always provide a dummy captcha token for development
-	req.CaptchaToken = "dev-mode-bypass-captcha"
-	
-	// Convert request to service request
+	// Convert request to service request; a captcha is only needed after failed logins
 	serviceReq := service.AuthRequest{
 		Email:        req.Email,
 		Password:     req.Password,
@@ -123,10 +137,22 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	
 	// Login user
 	tokenResp, user, err := h.authService.Login(c.Request.Context(), serviceReq)
+	if h.respondMFAChallenge(c, err) {
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to login user", err)
 		
+		if h.respondThrottleError(c, err) || h.respondCaptchaError(c, err) {
+			return
+		}
+		
 		// Check for specific errors
+		if errors.Is(err, service.ErrEmailVerificationRequired) || errors.Is(err, service.ErrTenantSuspended) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
+		
 		if strings.Contains(err.Error(), "invalid email or password") {
 			response.Error(c, http.StatusUnauthorized, "Invalid email or password", nil)
 			return
@@ -155,13 +181,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			ExpiresIn:    tokenResp.ExpiresIn,
 		},
 		User: response.UserResponse{
-			ID:           user.ID.String(),
-			Email:        user.Email,
-			FullName:     user.FullName,
-			Role:         string(user.Role),
-			Status:       user.Status,
-			ReferralCode: user.ReferralCode,
-			CreatedAt:    user.CreatedAt,
+			ID:            user.ID.String(),
+			Email:         user.Email,
+			EmailVerified: user.IsEmailVerified(),
+			FullName:      user.FullName,
+			Role:          string(user.Role),
+			Status:        user.Status,
+			ReferralCode:  user.ReferralCode,
+			CreatedAt:     user.CreatedAt,
 		},
 	}
 	
@@ -182,7 +209,14 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	tokenResp, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
 		h.logger.Error("Failed to refresh token", err)
-		response.Error(c, http.StatusUnauthorized, "Invalid refresh token", nil)
+		switch {
+		case errors.Is(err, service.ErrAccountInactive), errors.Is(err, service.ErrTenantSuspended):
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+		case errors.Is(err, service.ErrInvalidRefreshToken):
+			response.Error(c, http.StatusUnauthorized, "Invalid refresh token", nil)
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to refresh token", err)
+		}
 		return
 	}
 	
@@ -200,8 +234,9 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// Get token ID from context (set by middleware)
+	// Get token and session IDs from context (set by middleware)
 	tokenID := c.GetString("tokenID")
+	sessionID := c.GetString("sessionID")
 	
 	// Get user ID from context
 	userIDStr := c.GetString("userID")
@@ -213,7 +248,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	}
 	
 	// Logout user
-	err = h.authService.Logout(c.Request.Context(), userID, tokenID)
+	err = h.authService.Logout(c.Request.Context(), userID, tokenID, sessionID)
 	if err != nil {
 		h.logger.Error("Failed to logout user", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to logout", err)
@@ -251,18 +286,308 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 	serviceReq := service.GoogleOAuthRequest{
 		Code:        req.Code,
 		RedirectURI: req.RedirectURI,
+		Role:        req.Role,
 	}
 	
 	// Login with Google
 	tokenResp, user, err := h.authService.GoogleOAuthLogin(c.Request.Context(), serviceReq, tenantID)
+	if h.respondMFAChallenge(c, err) {
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to login with Google", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to login with Google", err)
+		h.respondOAuthError(c, err, "Failed to login with Google")
 		return
 	}
 	
-	// Prepare response
-	resp := response.LoginResponse{
+	// Return success response
+	response.Success(c, http.StatusOK, "Google login successful", loginResponse(tokenResp, user))
+}
+
+// ConfirmGoogleLink handles linking a Google account to an existing account with the same email
+func (h *AuthHandler) ConfirmGoogleLink(c *gin.Context) {
+	var req request.ConfirmGoogleLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind Google link request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	serviceReq := service.ConfirmIdentityLinkRequest{
+		LinkToken: req.LinkToken,
+		Password:  req.Password,
+	}
+	
+	tokenResp, user, err := h.authService.ConfirmIdentityLink(c.Request.Context(), serviceReq)
+	if h.respondMFAChallenge(c, err) {
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to link Google account", err)
+		h.respondOAuthError(c, err, "Failed to link Google account")
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Google account linked successfully", loginResponse(tokenResp, user))
+}
+
+// VerifyMFA handles completing a login with a TOTP or recovery code
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req request.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind MFA verification request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	result, err := h.authService.VerifyMFA(c.Request.Context(), service.VerifyMFARequest{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		RecoveryCode:   req.RecoveryCode,
+	})
+	if err != nil {
+		h.logger.Error("Failed to verify MFA", err)
+		h.respondMFAError(c, err, "Failed to verify MFA")
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Login successful", response.MFALoginResponse{
+		LoginResponse: loginResponse(result.Tokens, result.User),
+		RecoveryCodes: result.RecoveryCodes,
+	})
+}
+
+// EnrollMFA handles setting up an authenticator the tenant requires during login
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	var req request.EnrollMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind MFA enrollment request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	enrollment, err := h.authService.EnrollMFA(c.Request.Context(), req.ChallengeToken)
+	if err != nil {
+		h.logger.Error("Failed to enroll MFA", err)
+		h.respondMFAError(c, err, "Failed to enroll MFA")
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Scan the code with your authenticator app", enrollment)
+}
+
+// RequestPasswordReset handles sending a password reset link
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req request.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind password reset request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	serviceReq := service.PasswordResetRequest{
+		Email:        req.Email,
+		CaptchaToken: req.CaptchaToken,
+	}
+	
+	if err := h.authService.RequestPasswordReset(c.Request.Context(), serviceReq); err != nil {
+		h.logger.Error("Failed to request password reset", err)
+		if h.respondCaptchaError(c, err) {
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to request password reset", err)
+		return
+	}
+	
+	// The same response is given whether or not the account exists
+	response.Success(c, http.StatusOK, "If an account exists for this email, a password reset link has been sent", nil)
+}
+
+// ConfirmPasswordReset handles choosing a new password with a reset token
+func (h *AuthHandler) ConfirmPasswordReset(c *gin.Context) {
+	var req request.ConfirmPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind password reset confirmation", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	serviceReq := service.ConfirmPasswordResetRequest{
+		Token:           req.Token,
+		NewPassword:     req.NewPassword,
+		ConfirmPassword: req.ConfirmPassword,
+	}
+	
+	if err := h.authService.ConfirmPasswordReset(c.Request.Context(), serviceReq); err != nil {
+		h.logger.Error("Failed to reset password", err)
+		h.respondAccountTokenError(c, err, "Failed to reset password")
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Password reset successfully", nil)
+}
+
+// RequestEmailVerification handles sending a new email verification link
+func (h *AuthHandler) RequestEmailVerification(c *gin.Context) {
+	var req request.EmailVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind email verification request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	if err := h.authService.RequestEmailVerification(c.Request.Context(), req.Email); err != nil {
+		h.logger.Error("Failed to request email verification", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to request email verification", err)
+		return
+	}
+	
+	// The same response is given whether or not the account exists
+	response.Success(c, http.StatusOK, "If an unverified account exists for this email, a verification link has been sent", nil)
+}
+
+// ConfirmEmailVerification handles verifying an email address with a verification token
+func (h *AuthHandler) ConfirmEmailVerification(c *gin.Context) {
+	var req request.ConfirmEmailVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind email verification confirmation", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	user, err := h.authService.ConfirmEmailVerification(c.Request.Context(), req.Token)
+	if err != nil {
+		h.logger.Error("Failed to verify email", err)
+		h.respondAccountTokenError(c, err, "Failed to verify email")
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Email verified successfully", gin.H{
+		"email":          user.Email,
+		"email_verified": true,
+	})
+}
+
+// ConfirmAccountUnlock handles lifting a login lockout with the link emailed on lockout
+func (h *AuthHandler) ConfirmAccountUnlock(c *gin.Context) {
+	var req request.UnlockAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind account unlock request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	
+	if err := h.authService.ConfirmAccountUnlock(c.Request.Context(), req.Token); err != nil {
+		h.logger.Error("Failed to unlock account", err)
+		h.respondAccountTokenError(c, err, "Failed to unlock account")
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Your account has been unlocked; you can log in again", nil)
+}
+
+// respondThrottleError responds to a login rejected after too many failures
+func (h *AuthHandler) respondThrottleError(c *gin.Context, err error) bool {
+	var throttled *service.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+	
+	retryAfter := int64(math.Ceil(throttled.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	response.Error(c, http.StatusTooManyRequests, throttled.Error(), gin.H{
+		"retry_after": retryAfter,
+		"locked":      throttled.Locked,
+	})
+	return true
+}
+
+// respondCaptchaError responds to a request whose captcha is missing or was rejected
+func (h *AuthHandler) respondCaptchaError(c *gin.Context, err error) bool {
+	if !errors.Is(err, service.ErrCaptchaRequired) && !errors.Is(err, service.ErrInvalidCaptcha) {
+		return false
+	}
+	
+	response.Error(c, http.StatusBadRequest, err.Error(), gin.H{
+		"captcha_required": true,
+	})
+	return true
+}
+
+// respondMFAChallenge responds with an MFA challenge if a login needs a second factor
+func (h *AuthHandler) respondMFAChallenge(c *gin.Context, err error) bool {
+	var mfaRequired *service.MFARequiredError
+	if !errors.As(err, &mfaRequired) {
+		return false
+	}
+	
+	// The password was right, so this is not an error; the client asks for a code next
+	response.Success(c, http.StatusOK, mfaRequired.Error(), response.MFAChallengeResponse{
+		MFARequired:        true,
+		ChallengeToken:     mfaRequired.ChallengeToken,
+		EnrollmentRequired: mfaRequired.EnrollmentRequired,
+		ExpiresIn:          mfaRequired.ExpiresIn,
+	})
+	return true
+}
+
+// respondMFAError maps second factor login errors to HTTP responses
+func (h *AuthHandler) respondMFAError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFAChallenge), errors.Is(err, service.ErrInvalidMFACode):
+		response.Error(c, http.StatusUnauthorized, err.Error(), nil)
+	case errors.Is(err, service.ErrMFANotEnrolled), errors.Is(err, service.ErrMFAAlreadyEnabled):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, service.ErrAccountPendingApproval), errors.Is(err, service.ErrAccountInactive),
+		errors.Is(err, service.ErrTenantSuspended):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// respondAccountTokenError maps password reset and email verification errors to HTTP responses
+func (h *AuthHandler) respondAccountTokenError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrAccountTokenNotFound), errors.Is(err, service.ErrPasswordMismatch):
+		response.Error(c, http.StatusBadRequest, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// respondOAuthError maps external login errors to HTTP responses
+func (h *AuthHandler) respondOAuthError(c *gin.Context, err error, message string) {
+	var linkRequired *service.IdentityLinkRequiredError
+	switch {
+	case errors.As(err, &linkRequired):
+		// The client confirms the link with the account's password
+		response.Error(c, http.StatusConflict, linkRequired.Error(), gin.H{
+			"link_token": linkRequired.LinkToken,
+			"email":      linkRequired.Email,
+		})
+	case errors.Is(err, service.ErrIdentityProviderNotConfigured):
+		response.Error(c, http.StatusServiceUnavailable, "Google login is not available", nil)
+	case errors.Is(err, service.ErrInvalidRedirectURI),
+		errors.Is(err, service.ErrInvalidAuthorizationCode),
+		errors.Is(err, service.ErrInvalidIdentityToken),
+		errors.Is(err, service.ErrEmailNotVerified),
+		errors.Is(err, service.ErrInvalidPassword),
+		errors.Is(err, repository.ErrIdentityLinkNotFound):
+		response.Error(c, http.StatusUnauthorized, err.Error(), nil)
+	case errors.Is(err, repository.ErrIdentityAlreadyLinked):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, service.ErrAccountPendingApproval), errors.Is(err, service.ErrAccountInactive),
+		errors.Is(err, service.ErrTenantSuspended):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// loginResponse builds the response for a successful login
+func loginResponse(tokenResp *service.TokenResponse, user *entity.User) response.LoginResponse {
+	return response.LoginResponse{
 		Token: response.TokenResponse{
 			AccessToken:  tokenResp.AccessToken,
 			RefreshToken: tokenResp.RefreshToken,
@@ -270,16 +595,14 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 			ExpiresIn:    tokenResp.ExpiresIn,
 		},
 		User: response.UserResponse{
-			ID:           user.ID.String(),
-			Email:        user.Email,
-			FullName:     user.FullName,
-			Role:         string(user.Role),
-			Status:       user.Status,
-			ReferralCode: user.ReferralCode,
-			CreatedAt:    user.CreatedAt,
+			ID:            user.ID.String(),
+			Email:         user.Email,
+			EmailVerified: user.IsEmailVerified(),
+			FullName:      user.FullName,
+			Role:          string(user.Role),
+			Status:        user.Status,
+			ReferralCode:  user.ReferralCode,
+			CreatedAt:     user.CreatedAt,
 		},
 	}
-	
-	// Return success response
-	response.Success(c, http.StatusOK, "Google login successful", resp)
-}
\ No newline at end of file
+}
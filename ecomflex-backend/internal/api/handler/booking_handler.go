@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	appService "github.com/naresh6454/ecomflex-backend/internal/app/service"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// BookingHandler handles booking-related requests
+type BookingHandler struct {
+	bookingService  service.BookingService
+	referralService service.ReferralService
+	storageService  appService.StorageService
+	logger          loggerPkg.Logger
+}
+
+// NewBookingHandler creates a new BookingHandler.
+// storageService may be nil, in which case proofs must be submitted as URLs.
+func NewBookingHandler(
+	bookingService service.BookingService,
+	referralService service.ReferralService,
+	storageService appService.StorageService,
+	logger loggerPkg.Logger,
+) *BookingHandler {
+	return &BookingHandler{
+		bookingService:  bookingService,
+		referralService: referralService,
+		storageService:  storageService,
+		logger:          logger,
+	}
+}
+
+// CreateBooking handles booking creation
+func (h *BookingHandler) CreateBooking(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req service.CreateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind create booking request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	booking, err := h.bookingService.CreateBooking(c.Request.Context(), userID, req)
+	if err != nil {
+		h.logger.Error("Failed to create booking", err)
+		h.respondError(c, err, "Failed to create booking")
+		return
+	}
+
+	// Credit the influencer who referred the booking
+	token := attributionToken(c, req.AttributionToken)
+	if _, err := h.referralService.AttributeBooking(c.Request.Context(), token, booking); err != nil {
+		h.logger.Error("Failed to attribute booking", err)
+	}
+
+	response.Success(c, http.StatusCreated, "Booking created successfully", booking)
+}
+
+// GetUserBookings handles listing the current user's bookings
+func (h *BookingHandler) GetUserBookings(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	bookings, err := h.bookingService.GetUserBookings(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get bookings", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to get bookings", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bookings retrieved successfully", bookings)
+}
+
+// GetBooking handles retrieving one of the current user's bookings
+func (h *BookingHandler) GetBooking(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+
+	booking, err := h.bookingService.GetUserBooking(c.Request.Context(), bookingID, userID)
+	if err != nil {
+		h.logger.Error("Failed to get booking", err)
+		h.respondError(c, err, "Failed to get booking")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Booking retrieved successfully", booking)
+}
+
+// UploadProof handles submitting a proof of purchase for a booking.
+// It accepts either a multipart "proof" file or a proofUrl pointing at an earlier upload.
+func (h *BookingHandler) UploadProof(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+
+	var req service.SubmitProofRequest
+	if file, err := c.FormFile("proof"); err == nil {
+		if h.storageService == nil {
+			response.Error(c, http.StatusServiceUnavailable, "File storage is not configured", nil)
+			return
+		}
+
+		// Make sure the booking belongs to the user before storing anything
+		booking, err := h.bookingService.GetUserBooking(c.Request.Context(), bookingID, userID)
+		if err != nil {
+			h.logger.Error("Failed to get booking", err)
+			h.respondError(c, err, "Failed to get booking")
+			return
+		}
+
+		directory := fmt.Sprintf("bookings/%s/%s", userID.String(), booking.ProductID.String())
+		proofURL, err := h.storageService.UploadFile(c.Request.Context(), file, directory)
+		if err != nil {
+			h.logger.Error("Failed to upload proof", err)
+			response.Error(c, http.StatusInternalServerError, "Failed to upload proof", err)
+			return
+		}
+
+		req = service.SubmitProofRequest{
+			ProofURL: proofURL,
+			OrderID:  c.PostForm("orderId"),
+			Fields:   proofFormFields(c),
+		}
+	} else {
+		if err := c.ShouldBind(&req); err != nil {
+			h.logger.Error("Failed to bind proof request", err)
+			response.Error(c, http.StatusBadRequest, "A proof file or proofUrl is required", err)
+			return
+		}
+		if req.Fields == nil {
+			req.Fields = proofFormFields(c)
+		}
+	}
+
+	booking, err := h.bookingService.SubmitProof(c.Request.Context(), bookingID, userID, req)
+	if err != nil {
+		h.logger.Error("Failed to submit proof", err)
+		h.respondError(c, err, "Failed to submit proof")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Proof submitted successfully", booking)
+}
+
+// CancelBooking handles cancelling one of the current user's bookings
+func (h *BookingHandler) CancelBooking(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bookingService.CancelBooking(c.Request.Context(), bookingID, userID); err != nil {
+		h.logger.Error("Failed to cancel booking", err)
+		h.respondError(c, err, "Failed to cancel booking")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Booking cancelled successfully", nil)
+}
+
+// GetAllBookings lists bookings with pagination and filters for admins
+func (h *BookingHandler) GetAllBookings(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := repository.BookingFilter{
+		Status:    c.Query("status"),
+		UserID:    c.Query("userId"),
+		ProductID: c.Query("productId"),
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+	}
+
+	bookings, total, err := h.bookingService.ListBookings(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list bookings", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list bookings", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bookings retrieved successfully", gin.H{
+		"bookings": bookings,
+		"total":    total,
+		"page":     page,
+		"limit":    limit,
+	})
+}
+
+// ApproveBooking handles approving a pending booking
+func (h *BookingHandler) ApproveBooking(c *gin.Context) {
+	reviewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+
+	booking, err := h.bookingService.ApproveBooking(c.Request.Context(), bookingID, reviewerID)
+	if err != nil {
+		h.logger.Error("Failed to approve booking", err)
+		h.respondError(c, err, "Failed to approve booking")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Booking approved successfully", booking)
+}
+
+// RejectBooking handles rejecting a pending booking
+func (h *BookingHandler) RejectBooking(c *gin.Context) {
+	reviewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+
+	var req service.RejectBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind reject booking request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	booking, err := h.bookingService.RejectBooking(c.Request.Context(), bookingID, reviewerID, req)
+	if err != nil {
+		h.logger.Error("Failed to reject booking", err)
+		h.respondError(c, err, "Failed to reject booking")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Booking rejected successfully", booking)
+}
+
+// UpdateCashback handles marking the cashback of a booking as paid
+func (h *BookingHandler) UpdateCashback(c *gin.Context) {
+	bookingID, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+
+	var req service.CashbackUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind cashback request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	booking, err := h.bookingService.MarkCashbackPaid(c.Request.Context(), bookingID)
+	if err != nil {
+		h.logger.Error("Failed to update cashback", err)
+		h.respondError(c, err, "Failed to update cashback")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Cashback updated successfully", booking)
+}
+
+// currentUserID reads the authenticated user ID from the context
+func (h *BookingHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// bookingID parses the booking ID from the URL
+func (h *BookingHandler) bookingID(c *gin.Context) (uuid.UUID, bool) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid booking ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid booking ID", err)
+		return uuid.Nil, false
+	}
+	return bookingID, true
+}
+
+// proofFormFields collects the extra form fields submitted alongside a proof
+func proofFormFields(c *gin.Context) map[string]string {
+	fields := map[string]string{}
+	if c.Request.PostForm == nil {
+		return fields
+	}
+
+	for key, values := range c.Request.PostForm {
+		switch key {
+		case "proofUrl", "orderId", "bookingId", "productId":
+			continue
+		}
+		if len(values) > 0 && values[0] != "" {
+			fields[key] = values[0]
+		}
+	}
+
+	return fields
+}
+
+// respondError maps booking service errors to HTTP responses
+func (h *BookingHandler) respondError(c *gin.Context, err error, message string) {
+	if respondQuotaError(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrBookingNotFound):
+		response.Error(c, http.StatusNotFound, "Booking not found", nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, repository.ErrNoSlotsAvailable):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, entity.ErrInvalidBookingTransition), errors.Is(err, entity.ErrProofAlreadyClaimed),
+		errors.Is(err, entity.ErrProofAlreadyReviewed):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case strings.Contains(err.Error(), "already exists"),
+		strings.Contains(err.Error(), "not accepting bookings"):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case strings.Contains(err.Error(), "product not found"):
+		response.Error(c, http.StatusNotFound, "Product not found", nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// CommissionHandler handles commission rule management for admins
+type CommissionHandler struct {
+	commissionService service.CommissionService
+	logger            loggerPkg.Logger
+}
+
+// NewCommissionHandler creates a new CommissionHandler
+func NewCommissionHandler(commissionService service.CommissionService, logger loggerPkg.Logger) *CommissionHandler {
+	return &CommissionHandler{
+		commissionService: commissionService,
+		logger:            logger,
+	}
+}
+
+// GetRules handles listing commission rules
+func (h *CommissionHandler) GetRules(c *gin.Context) {
+	rules, err := h.commissionService.ListRules(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list commission rules", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list commission rules", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Commission rules retrieved successfully", rules)
+}
+
+// GetRule handles retrieving a commission rule
+func (h *CommissionHandler) GetRule(c *gin.Context) {
+	ruleID, ok := h.ruleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.commissionService.GetRule(c.Request.Context(), ruleID)
+	if err != nil {
+		h.logger.Error("Failed to get commission rule", err)
+		h.respondError(c, err, "Failed to get commission rule")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Commission rule retrieved successfully", rule)
+}
+
+// CreateRule handles creating a commission rule
+func (h *CommissionHandler) CreateRule(c *gin.Context) {
+	var req service.CommissionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind commission rule request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	rule, err := h.commissionService.CreateRule(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create commission rule", err)
+		h.respondError(c, err, "Failed to create commission rule")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Commission rule created successfully", rule)
+}
+
+// UpdateRule handles updating a commission rule
+func (h *CommissionHandler) UpdateRule(c *gin.Context) {
+	ruleID, ok := h.ruleID(c)
+	if !ok {
+		return
+	}
+
+	var req service.CommissionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind commission rule request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	rule, err := h.commissionService.UpdateRule(c.Request.Context(), ruleID, req)
+	if err != nil {
+		h.logger.Error("Failed to update commission rule", err)
+		h.respondError(c, err, "Failed to update commission rule")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Commission rule updated successfully", rule)
+}
+
+// DeleteRule handles deleting a commission rule
+func (h *CommissionHandler) DeleteRule(c *gin.Context) {
+	ruleID, ok := h.ruleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commissionService.DeleteRule(c.Request.Context(), ruleID); err != nil {
+		h.logger.Error("Failed to delete commission rule", err)
+		h.respondError(c, err, "Failed to delete commission rule")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Commission rule deleted successfully", nil)
+}
+
+// ruleID parses the commission rule ID from the URL
+func (h *CommissionHandler) ruleID(c *gin.Context) (uuid.UUID, bool) {
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid commission rule ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid commission rule ID", err)
+		return uuid.Nil, false
+	}
+	return ruleID, true
+}
+
+// respondError maps commission service errors to HTTP responses
+func (h *CommissionHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrCommissionRuleNotFound):
+		response.Error(c, http.StatusNotFound, "Commission rule not found", nil)
+	case errors.Is(err, entity.ErrInvalidCommissionRule):
+		response.Error(c, http.StatusBadRequest, err.Error(), nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
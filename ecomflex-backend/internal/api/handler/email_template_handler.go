@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// EmailTemplateHandler handles tenants' email template overrides
+type EmailTemplateHandler struct {
+	templateService service.EmailTemplateService
+	logger          loggerPkg.Logger
+}
+
+// NewEmailTemplateHandler creates a new EmailTemplateHandler
+func NewEmailTemplateHandler(templateService service.EmailTemplateService, logger loggerPkg.Logger) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		templateService: templateService,
+		logger:          logger,
+	}
+}
+
+// GetEmailTemplates handles listing a tenant's email template overrides
+func (h *EmailTemplateHandler) GetEmailTemplates(c *gin.Context) {
+	tenantID, ok := h.tenantID(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.templateService.ListEmailTemplates(c.Request.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to list email templates", err)
+		h.respondError(c, err, "Failed to list email templates")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Email templates retrieved successfully", gin.H{
+		"templates": templates,
+		"names":     entity.EmailTemplateNames,
+	})
+}
+
+// SaveEmailTemplate handles overriding an email template for a tenant
+func (h *EmailTemplateHandler) SaveEmailTemplate(c *gin.Context) {
+	tenantID, ok := h.tenantID(c)
+	if !ok {
+		return
+	}
+
+	var req service.SaveEmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind email template request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	template, err := h.templateService.SaveEmailTemplate(c.Request.Context(), tenantID, entity.EmailTemplateName(c.Param("name")), req)
+	if err != nil {
+		h.logger.Error("Failed to save email template", err)
+		h.respondError(c, err, "Failed to save email template")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Email template saved successfully", template)
+}
+
+// DeleteEmailTemplate handles restoring the built-in email template for a tenant
+func (h *EmailTemplateHandler) DeleteEmailTemplate(c *gin.Context) {
+	tenantID, ok := h.tenantID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.templateService.DeleteEmailTemplate(c.Request.Context(), tenantID, entity.EmailTemplateName(c.Param("name"))); err != nil {
+		h.logger.Error("Failed to delete email template", err)
+		h.respondError(c, err, "Failed to delete email template")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Email template deleted successfully", nil)
+}
+
+// tenantID parses the tenant ID from the URL
+func (h *EmailTemplateHandler) tenantID(c *gin.Context) (uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid tenant ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid tenant ID", err)
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
+
+// respondError maps email template service errors to HTTP responses
+func (h *EmailTemplateHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrTenantNotFound):
+		response.Error(c, http.StatusNotFound, "Tenant not found", nil)
+	case errors.Is(err, repository.ErrEmailTemplateNotFound):
+		response.Error(c, http.StatusNotFound, "Email template not found", nil)
+	case errors.Is(err, entity.ErrInvalidEmailTemplate):
+		response.Error(c, http.StatusBadRequest, err.Error(), nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+    "errors"
     "fmt"
     "net/http"
     "path/filepath"
@@ -8,21 +9,30 @@ import (
     "time"
     
     "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
     "github.com/naresh6454/ecomflex-backend/internal/api/response"
     "github.com/naresh6454/ecomflex-backend/internal/app/service"
+    "github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+    "github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+    domainService "github.com/naresh6454/ecomflex-backend/internal/domain/service"
 )
 
 type FileHandler struct {
     storageService service.StorageService
+    bookingService domainService.BookingService
+    quotaService   domainService.QuotaService
 }
 
-func NewFileHandler(storageService service.StorageService) *FileHandler {
+func NewFileHandler(storageService service.StorageService, bookingService domainService.BookingService, quotaService domainService.QuotaService) *FileHandler {
     return &FileHandler{
         storageService: storageService,
+        bookingService: bookingService,
+        quotaService:   quotaService,
     }
 }
 
-// UploadBookingDocument handles booking document uploads
+// UploadBookingDocument handles booking document uploads.
+// When a bookingId is given the file is submitted as that booking's proof of purchase.
 func (h *FileHandler) UploadBookingDocument(c *gin.Context) {
     // Get user ID from context (set by middleware)
     userID, exists := c.Get("userID")
@@ -38,6 +48,17 @@ func (h *FileHandler) UploadBookingDocument(c *gin.Context) {
         return
     }
     
+    // Get the optional booking the document belongs to
+    var bookingID uuid.UUID
+    if bookingIDStr := c.PostForm("bookingId"); bookingIDStr != "" {
+        parsed, err := uuid.Parse(bookingIDStr)
+        if err != nil {
+            response.Error(c, http.StatusBadRequest, "Invalid booking ID", nil)
+            return
+        }
+        bookingID = parsed
+    }
+    
     // Get the uploaded file
     file, err := c.FormFile("file")
     if err != nil {
@@ -52,6 +73,12 @@ func (h *FileHandler) UploadBookingDocument(c *gin.Context) {
     // Directory for booking documents
     directory := fmt.Sprintf("bookings/%s/%s", userID.(string), productID)
     
+    // Uploads count against the tenant's storage
+    tenantID, ok := h.checkStorage(c, file.Size)
+    if !ok {
+        return
+    }
+    
     // Upload file
     fileURL, err := h.storageService.UploadFile(c, file, directory)
     if err != nil {
@@ -59,22 +86,56 @@ func (h *FileHandler) UploadBookingDocument(c *gin.Context) {
         response.Error(c, http.StatusInternalServerError, "Failed to upload file", err)
         return
     }
+    h.recordStoredFile(c, tenantID, userID.(string), directory, fileURL, file.Size)
     
     fmt.Printf("File uploaded successfully: %s\n", fileURL)
     
+    data := gin.H{
+        "fileUrl": fileURL,
+        "fileName": file.Filename,
+        "fileSize": file.Size,
+        "fileType": file.Header.Get("Content-Type"),
+        "s3Path": directory + "/" + filepath.Base(fileURL),
+    }
+    
+    // Link the document to the booking as its proof of purchase
+    if bookingID != uuid.Nil {
+        ownerID, err := uuid.Parse(userID.(string))
+        if err != nil {
+            response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+            return
+        }
+        
+        req := domainService.SubmitProofRequest{
+            ProofURL: fileURL,
+            OrderID:  c.PostForm("orderId"),
+            Fields:   proofFormFields(c),
+        }
+        
+        booking, err := h.bookingService.SubmitProof(c.Request.Context(), bookingID, ownerID, req)
+        if err != nil {
+            fmt.Printf("Failed to submit proof for booking %s: %v\n", bookingID, err)
+            switch {
+            case errors.Is(err, repository.ErrBookingNotFound):
+                response.Error(c, http.StatusNotFound, "Booking not found", nil)
+            case errors.Is(err, entity.ErrInvalidBookingTransition):
+                response.Error(c, http.StatusConflict, err.Error(), nil)
+            default:
+                response.Error(c, http.StatusInternalServerError, "Failed to submit proof for booking", err)
+            }
+            return
+        }
+        
+        data["booking"] = booking
+    }
+    
     // Add CORS headers for direct response handling
     c.Header("Access-Control-Allow-Origin", "*")
     c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
     c.Header("Access-Control-Allow-Headers", "Content-Type")
     
     // Return success response
-    response.Success(c, http.StatusOK, "File uploaded successfully", gin.H{
-        "fileUrl": fileURL,
-        "fileName": file.Filename,
-        "fileSize": file.Size,
-        "fileType": file.Header.Get("Content-Type"),
-        "s3Path": directory + "/" + filepath.Base(fileURL),
-    })
+    response.Success(c, http.StatusOK, "File uploaded successfully", data)
 }
 
 // UploadReviewMedia handles review media uploads
@@ -118,6 +179,16 @@ func (h *FileHandler) UploadReviewMedia(c *gin.Context) {
     fileURLs := make([]string, 0, len(files))
     fileDetails := make([]map[string]interface{}, 0, len(files))
     
+    // The files are checked against the tenant's storage together
+    var totalSize int64
+    for _, file := range files {
+        totalSize += file.Size
+    }
+    tenantID, ok := h.checkStorage(c, totalSize)
+    if !ok {
+        return
+    }
+    
     // Upload each file
     for _, file := range files {
         fileURL, err := h.storageService.UploadFile(c, file, directory)
@@ -126,6 +197,7 @@ func (h *FileHandler) UploadReviewMedia(c *gin.Context) {
             response.Error(c, http.StatusInternalServerError, "Failed to upload file", err)
             return
         }
+        h.recordStoredFile(c, tenantID, userID.(string), directory, fileURL, file.Size)
         
         fileURLs = append(fileURLs, fileURL)
         fileDetails = append(fileDetails, map[string]interface{}{
@@ -182,4 +254,37 @@ func (h *FileHandler) GetPresignedURL(c *gin.Context) {
         "expiration": expirationStr + " hours",
         "expiresAt": time.Now().Add(expiration).Format(time.RFC3339),
     })
-}
\ No newline at end of file
+}
+
+// checkStorage checks that the plan of the request's tenant has room for size more bytes
+func (h *FileHandler) checkStorage(c *gin.Context, size int64) (uuid.UUID, bool) {
+    tenantID, err := uuid.Parse(c.GetString("tenantID"))
+    if err != nil {
+        response.Error(c, http.StatusBadRequest, "Invalid tenant ID", nil)
+        return uuid.Nil, false
+    }
+    
+    if err := h.quotaService.CheckQuota(c.Request.Context(), tenantID, entity.QuotaStorageBytes, size); err != nil {
+        if !respondQuotaError(c, err) {
+            fmt.Printf("Failed to check storage quota: %v\n", err)
+            response.Error(c, http.StatusInternalServerError, "Failed to upload file", err)
+        }
+        return uuid.Nil, false
+    }
+    
+    return tenantID, true
+}
+
+// recordStoredFile counts an uploaded file against its tenant's storage.
+// The file is already uploaded, so failures are logged rather than returned.
+func (h *FileHandler) recordStoredFile(c *gin.Context, tenantID uuid.UUID, userID, directory, fileURL string, size int64) {
+    var uploader *uuid.UUID
+    if id, err := uuid.Parse(userID); err == nil {
+        uploader = &id
+    }
+    
+    file := entity.NewStoredFile(tenantID, uploader, directory+"/"+filepath.Base(fileURL), size)
+    if err := h.quotaService.RecordStoredFile(c.Request.Context(), file); err != nil {
+        fmt.Printf("Failed to record stored file %s: %v\n", file.Key, err)
+    }
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// ImpersonationHandler handles admins acting as other users
+type ImpersonationHandler struct {
+	impersonationService service.ImpersonationService
+	logger               loggerPkg.Logger
+}
+
+// NewImpersonationHandler creates a new ImpersonationHandler
+func NewImpersonationHandler(impersonationService service.ImpersonationService, logger loggerPkg.Logger) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+		logger:               logger,
+	}
+}
+
+// Start handles minting an impersonation token for a user
+func (h *ImpersonationHandler) Start(c *gin.Context) {
+	userID, ok := h.parseID(c, "user")
+	if !ok {
+		return
+	}
+
+	var req service.ImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind impersonation request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	impersonator, _ := c.MustGet("user").(*entity.User)
+
+	token, err := h.impersonationService.Start(c.Request.Context(), impersonator, userID, req.Reason)
+	if err != nil {
+		h.logger.Error("Failed to start impersonation", err)
+		h.respondError(c, err, "Failed to start impersonation")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Impersonation started", token)
+}
+
+// End handles ending an impersonation by ID
+func (h *ImpersonationHandler) End(c *gin.Context) {
+	impersonationID, ok := h.parseID(c, "impersonation")
+	if !ok {
+		return
+	}
+
+	if err := h.impersonationService.End(c.Request.Context(), impersonationID); err != nil {
+		h.logger.Error("Failed to end impersonation", err)
+		h.respondError(c, err, "Failed to end impersonation")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Impersonation ended", nil)
+}
+
+// EndCurrent handles ending the impersonation the request's token belongs to
+func (h *ImpersonationHandler) EndCurrent(c *gin.Context) {
+	value, impersonating := c.Get("impersonation")
+	if !impersonating {
+		response.Error(c, http.StatusBadRequest, "Not impersonating a user", nil)
+		return
+	}
+
+	impersonation := value.(*entity.Impersonation)
+	if err := h.impersonationService.End(c.Request.Context(), impersonation.ID); err != nil {
+		h.logger.Error("Failed to end impersonation", err)
+		h.respondError(c, err, "Failed to end impersonation")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Impersonation ended", nil)
+}
+
+// GetImpersonations handles listing the impersonations of a user or by a user
+func (h *ImpersonationHandler) GetImpersonations(c *gin.Context) {
+	userID, ok := h.parseID(c, "user")
+	if !ok {
+		return
+	}
+
+	page, limit := pagination(c)
+	impersonations, total, err := h.impersonationService.List(c.Request.Context(), userID, limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list impersonations", err)
+		h.respondError(c, err, "Failed to list impersonations")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Impersonations retrieved successfully", gin.H{
+		"impersonations": impersonations,
+		"total":          total,
+		"page":           page,
+		"limit":          limit,
+	})
+}
+
+// parseID parses the ID from the URL
+func (h *ImpersonationHandler) parseID(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid "+name+" ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid "+name+" ID", err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError maps impersonation service errors to HTTP responses
+func (h *ImpersonationHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrImpersonationNotFound):
+		response.Error(c, http.StatusNotFound, "Impersonation not found", nil)
+	case errors.Is(err, service.ErrImpersonationNotAllowed), errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
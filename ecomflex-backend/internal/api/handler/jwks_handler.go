@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// jwksMaxAge is how long verifiers may cache the published keys, in seconds.
+// New keys are published before they sign tokens, so caches pick them up in time.
+const jwksMaxAge = "max-age=3600"
+
+// JWKSHandler publishes the public keys access tokens are verified with
+type JWKSHandler struct {
+	publisher service.TokenKeyPublisher
+}
+
+// NewJWKSHandler creates a new JWKSHandler
+func NewJWKSHandler(publisher service.TokenKeyPublisher) *JWKSHandler {
+	return &JWKSHandler{
+		publisher: publisher,
+	}
+}
+
+// GetJWKS handles serving the JWKS document.
+// It is a standard JWKS document rather than the usual response envelope, so JWT libraries can read it.
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, "+jwksMaxAge)
+	c.JSON(http.StatusOK, h.publisher.PublicKeys())
+}
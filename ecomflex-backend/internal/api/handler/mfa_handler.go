@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/request"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// MFAHandler handles users' two-factor authentication and tenants' MFA policies
+type MFAHandler struct {
+	mfaService service.MFAService
+	logger     loggerPkg.Logger
+}
+
+// NewMFAHandler creates a new MFAHandler
+func NewMFAHandler(mfaService service.MFAService, logger loggerPkg.Logger) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+		logger:     logger,
+	}
+}
+
+// GetStatus handles retrieving the current user's two-factor authentication status
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.mfaService.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get MFA status", err)
+		h.respondError(c, err, "Failed to get MFA status")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA status retrieved successfully", status)
+}
+
+// Enroll handles creating a new authenticator for the current user
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.mfaService.Enroll(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to enroll MFA", err)
+		h.respondError(c, err, "Failed to enroll MFA")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Scan the code with your authenticator app", enrollment)
+}
+
+// Enable handles confirming the current user's new authenticator
+func (h *MFAHandler) Enable(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind MFA code request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	codes, err := h.mfaService.Enable(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.logger.Error("Failed to enable MFA", err)
+		h.respondError(c, err, "Failed to enable MFA")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Two-factor authentication enabled", gin.H{"recovery_codes": codes})
+}
+
+// Disable handles turning off the current user's two-factor authentication
+func (h *MFAHandler) Disable(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.DisableMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind disable MFA request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), userID, req.Code, req.RecoveryCode); err != nil {
+		h.logger.Error("Failed to disable MFA", err)
+		h.respondError(c, err, "Failed to disable MFA")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes handles replacing the current user's recovery codes
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind MFA code request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.logger.Error("Failed to regenerate recovery codes", err)
+		h.respondError(c, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Recovery codes regenerated", gin.H{"recovery_codes": codes})
+}
+
+// GetTenantPolicy handles retrieving a tenant's MFA policy
+func (h *MFAHandler) GetTenantPolicy(c *gin.Context) {
+	tenantID, ok := h.tenantID(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.mfaService.GetTenantPolicy(c.Request.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to get MFA policy", err)
+		h.respondError(c, err, "Failed to get MFA policy")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA policy retrieved successfully", policy)
+}
+
+// UpdateTenantPolicy handles setting a tenant's MFA policy
+func (h *MFAHandler) UpdateTenantPolicy(c *gin.Context) {
+	tenantID, ok := h.tenantID(c)
+	if !ok {
+		return
+	}
+
+	var req request.MFAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind MFA policy request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	policy, err := h.mfaService.UpdateTenantPolicy(c.Request.Context(), tenantID, service.MFAPolicy{
+		RequireSuperAdmin: *req.RequireSuperAdmin,
+	})
+	if err != nil {
+		h.logger.Error("Failed to update MFA policy", err)
+		h.respondError(c, err, "Failed to update MFA policy")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "MFA policy updated successfully", policy)
+}
+
+// currentUserID reads the authenticated user ID from the context
+func (h *MFAHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// tenantID parses the tenant ID from the URL
+func (h *MFAHandler) tenantID(c *gin.Context) (uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid tenant ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid tenant ID", err)
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
+
+// respondError maps MFA service errors to HTTP responses
+func (h *MFAHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidMFACode):
+		response.Error(c, http.StatusUnauthorized, err.Error(), nil)
+	case errors.Is(err, service.ErrMFARequiredByPolicy), errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, service.ErrMFANotEnrolled), errors.Is(err, service.ErrMFAAlreadyEnabled):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, repository.ErrTenantNotFound):
+		response.Error(c, http.StatusNotFound, "Tenant not found", nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
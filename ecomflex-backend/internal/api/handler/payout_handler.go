@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// maxWebhookBodySize bounds the payout webhook bodies read into memory
+const maxWebhookBodySize = 1 << 20
+
+// PayoutHandler handles earnings balances and payouts
+type PayoutHandler struct {
+	payoutService service.PayoutService
+	logger        loggerPkg.Logger
+}
+
+// NewPayoutHandler creates a new PayoutHandler
+func NewPayoutHandler(payoutService service.PayoutService, logger loggerPkg.Logger) *PayoutHandler {
+	return &PayoutHandler{
+		payoutService: payoutService,
+		logger:        logger,
+	}
+}
+
+// GetBalance handles retrieving the current influencer's earnings balance
+func (h *PayoutHandler) GetBalance(c *gin.Context) {
+	influencerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	balance, err := h.payoutService.GetEarningsBalance(c.Request.Context(), influencerID)
+	if err != nil {
+		h.logger.Error("Failed to get earnings balance", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to get earnings balance", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Earnings balance retrieved successfully", balance)
+}
+
+// GetLedger handles listing the current influencer's earnings ledger entries
+func (h *PayoutHandler) GetLedger(c *gin.Context) {
+	influencerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := pagination(c)
+	entries, total, err := h.payoutService.ListEarningsEntries(c.Request.Context(), influencerID, limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list ledger entries", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list ledger entries", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ledger entries retrieved successfully", gin.H{
+		"entries": entries,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// GetMyPayouts handles listing the current influencer's payout history
+func (h *PayoutHandler) GetMyPayouts(c *gin.Context) {
+	influencerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := pagination(c)
+	payouts, total, err := h.payoutService.ListInfluencerPayouts(c.Request.Context(), influencerID, limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list payouts", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list payouts", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payouts retrieved successfully", gin.H{
+		"payouts": payouts,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// RunPayouts handles starting a payout run
+func (h *PayoutHandler) RunPayouts(c *gin.Context) {
+	run, err := h.payoutService.RunPayouts(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to run payouts", err)
+		h.respondError(c, err, "Failed to run payouts")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Payout run completed successfully", run)
+}
+
+// GetRuns handles listing payout runs
+func (h *PayoutHandler) GetRuns(c *gin.Context) {
+	page, limit := pagination(c)
+	runs, total, err := h.payoutService.ListRuns(c.Request.Context(), limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list payout runs", err)
+		h.respondError(c, err, "Failed to list payout runs")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout runs retrieved successfully", gin.H{
+		"runs":  runs,
+		"total": total,
+		"page":  page,
+		"limit": limit,
+	})
+}
+
+// GetPayouts handles listing payouts with filters for admins
+func (h *PayoutHandler) GetPayouts(c *gin.Context) {
+	page, limit := pagination(c)
+	filter := repository.PayoutFilter{
+		UserID: c.Query("userId"),
+		RunID:  c.Query("runId"),
+		Status: c.Query("status"),
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+
+	payouts, total, err := h.payoutService.ListPayouts(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list payouts", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list payouts", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payouts retrieved successfully", gin.H{
+		"payouts": payouts,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// GetPayout handles retrieving a payout
+func (h *PayoutHandler) GetPayout(c *gin.Context) {
+	payoutID, ok := h.payoutID(c)
+	if !ok {
+		return
+	}
+
+	payout, err := h.payoutService.GetPayout(c.Request.Context(), payoutID)
+	if err != nil {
+		h.logger.Error("Failed to get payout", err)
+		h.respondError(c, err, "Failed to get payout")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout retrieved successfully", payout)
+}
+
+// UpdatePayoutStatus handles moving a payout along its lifecycle
+func (h *PayoutHandler) UpdatePayoutStatus(c *gin.Context) {
+	payoutID, ok := h.payoutID(c)
+	if !ok {
+		return
+	}
+
+	var req service.UpdatePayoutStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind payout status request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	payout, err := h.payoutService.UpdatePayoutStatus(c.Request.Context(), payoutID, req)
+	if err != nil {
+		h.logger.Error("Failed to update payout status", err)
+		h.respondError(c, err, "Failed to update payout status")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout status updated successfully", payout)
+}
+
+// DispatchPayouts handles sending pending payouts to the payout provider
+func (h *PayoutHandler) DispatchPayouts(c *gin.Context) {
+	sent, err := h.payoutService.DispatchPayouts(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to dispatch payouts", err)
+		h.respondError(c, err, "Failed to dispatch payouts")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payouts dispatched successfully", gin.H{"dispatched": sent})
+}
+
+// SyncPayouts handles polling the payout provider for payouts in flight
+func (h *PayoutHandler) SyncPayouts(c *gin.Context) {
+	settled, err := h.payoutService.SyncPayouts(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to sync payouts", err)
+		h.respondError(c, err, "Failed to sync payouts")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payouts synced successfully", gin.H{"settled": settled})
+}
+
+// HandleWebhook handles transfer status webhooks from the payout provider
+func (h *PayoutHandler) HandleWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	if err := h.payoutService.HandleWebhook(c.Request.Context(), c.Request.Header, body); err != nil {
+		if errors.Is(err, service.ErrInvalidWebhookSignature) {
+			response.Error(c, http.StatusUnauthorized, "Invalid webhook signature", nil)
+			return
+		}
+		h.logger.Error("Failed to handle payout webhook", err)
+		h.respondError(c, err, "Failed to handle payout webhook")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Webhook processed successfully", nil)
+}
+
+// currentUserID reads the authenticated user ID from the context
+func (h *PayoutHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// payoutID parses the payout ID from the URL
+func (h *PayoutHandler) payoutID(c *gin.Context) (uuid.UUID, bool) {
+	payoutID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid payout ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid payout ID", err)
+		return uuid.Nil, false
+	}
+	return payoutID, true
+}
+
+// respondError maps payout service errors to HTTP responses
+func (h *PayoutHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrPayoutNotFound):
+		response.Error(c, http.StatusNotFound, "Payout not found", nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, entity.ErrInvalidPayoutTransition), errors.Is(err, entity.ErrPayoutRunInProgress):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, service.ErrPayoutsDisabled):
+		response.Error(c, http.StatusServiceUnavailable, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// pagination reads the page and limit query parameters
+func pagination(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return page, limit
+}
@@ -2,6 +2,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/naresh6454/ecomflex-backend/internal/api/response"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
 )
 
@@ -25,13 +27,14 @@ func NewProductHandler(productService service.ProductService) *ProductHandler {
 
 // ProductRequest defines the request for creating/updating products
 type ProductRequest struct {
-	Name             string `json:"name" binding:"required"`
-	ASIN             string `json:"asin" binding:"required"`
-	Founder          string `json:"founder" binding:"required"`
-	ProductLink      string `json:"productLink" binding:"required"`
-	Details          string `json:"details"`
-	RequiredBookings int    `json:"requiredBookings" binding:"required"`
-	IsActive         bool   `json:"isActive"`
+	Name             string  `json:"name" binding:"required"`
+	ASIN             string  `json:"asin" binding:"required"`
+	Founder          string  `json:"founder" binding:"required"`
+	ProductLink      string  `json:"productLink" binding:"required"`
+	Details          string  `json:"details"`
+	Price            float64 `json:"price" binding:"omitempty,min=0"`
+	RequiredBookings int     `json:"requiredBookings" binding:"required"`
+	IsActive         bool    `json:"isActive"`
 }
 
 // CreateProduct handles product creation
@@ -62,12 +65,20 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		Founder:          req.Founder,
 		ProductLink:      req.ProductLink,
 		Details:          req.Details,
+		Price:            req.Price,
 		RequiredBookings: req.RequiredBookings,
 		IsActive:         req.IsActive,
 		TenantID:         tenantUUID,
 	}
 
-	if err := h.productService.CreateProduct(c, product); err != nil {
+	if err := h.productService.CreateProduct(c.Request.Context(), product); err != nil {
+		if respondQuotaError(c, err) {
+			return
+		}
+		if errors.Is(err, entity.ErrMarketplaceNotAllowed) {
+			response.Error(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to create product: "+err.Error(), nil)
 		return
 	}
@@ -78,7 +89,7 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 // GetProduct handles retrieving a single product
 func (h *ProductHandler) GetProduct(c *gin.Context) {
 	id := c.Param("id")
-	product, err := h.productService.GetProductByID(c, id)
+	product, err := h.productService.GetProductByID(c.Request.Context(), id)
 	if err != nil {
 		response.Error(c, http.StatusNotFound, "Product not found: "+err.Error(), nil)
 		return
@@ -125,12 +136,24 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		Founder:          req.Founder,
 		ProductLink:      req.ProductLink,
 		Details:          req.Details,
+		Price:            req.Price,
 		RequiredBookings: req.RequiredBookings,
 		IsActive:         req.IsActive,
 		TenantID:         tenantUUID,
 	}
 
-	if err := h.productService.UpdateProduct(c, product); err != nil {
+	if err := h.productService.UpdateProduct(c.Request.Context(), product); err != nil {
+		if respondQuotaError(c, err) {
+			return
+		}
+		if errors.Is(err, entity.ErrMarketplaceNotAllowed) {
+			response.Error(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, service.ErrOtherTenant) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to update product: "+err.Error(), nil)
 		return
 	}
@@ -141,7 +164,15 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 // DeleteProduct handles product deletion
 func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.productService.DeleteProduct(c, id); err != nil {
+	if err := h.productService.DeleteProduct(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrOtherTenant) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, repository.ErrProductInUse) {
+			response.Error(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to delete product: "+err.Error(), nil)
 		return
 	}
@@ -177,7 +208,7 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		filter.IsActive = &active
 	}
 
-	products, total, err := h.productService.GetProducts(c, filter)
+	products, total, err := h.productService.GetProducts(c.Request.Context(), filter)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "Failed to get products: "+err.Error(), nil)
 		return
@@ -209,7 +240,14 @@ func (h *ProductHandler) ToggleProductStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.productService.ToggleProductStatus(c, id, req.IsActive); err != nil {
+	if err := h.productService.ToggleProductStatus(c.Request.Context(), id, req.IsActive); err != nil {
+		if respondQuotaError(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrOtherTenant) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to update product status: "+err.Error(), nil)
 		return
 	}
@@ -229,8 +267,12 @@ func (h *ProductHandler) UploadProductImage(c *gin.Context) {
 	}
 
 	// Upload the file
-	imageURL, err := h.productService.UploadProductImage(c, id, file)
+	imageURL, err := h.productService.UploadProductImage(c.Request.Context(), id, file)
 	if err != nil {
+		if errors.Is(err, service.ErrOtherTenant) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to upload image: "+err.Error(), nil)
 		return
 	}
@@ -247,7 +289,7 @@ func (h *ProductHandler) GetTrendingProducts(c *gin.Context) {
 		limit = 5
 	}
 
-	products, err := h.productService.GetTrendingProducts(c, limit)
+	products, err := h.productService.GetTrendingProducts(c.Request.Context(), limit)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "Failed to get trending products: "+err.Error(), nil)
 		return
@@ -258,7 +300,7 @@ func (h *ProductHandler) GetTrendingProducts(c *gin.Context) {
 
 // GetProductCategories handles retrieving product categories
 func (h *ProductHandler) GetProductCategories(c *gin.Context) {
-	categories, err := h.productService.GetProductCategories(c)
+	categories, err := h.productService.GetProductCategories(c.Request.Context())
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "Failed to get product categories: "+err.Error(), nil)
 		return
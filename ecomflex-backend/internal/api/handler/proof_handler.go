@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// ProofHandler handles the proof moderation queue
+type ProofHandler struct {
+	proofService service.ProofService
+	logger       loggerPkg.Logger
+}
+
+// NewProofHandler creates a new ProofHandler
+func NewProofHandler(proofService service.ProofService, logger loggerPkg.Logger) *ProofHandler {
+	return &ProofHandler{
+		proofService: proofService,
+		logger:       logger,
+	}
+}
+
+// GetProofQueue lists proofs with pagination and filters for product, status and age
+func (h *ProofHandler) GetProofQueue(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := repository.ProofFilter{
+		Status:    c.Query("status"),
+		ProductID: c.Query("productId"),
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+	}
+
+	// Age filters are given in hours since submission
+	now := time.Now()
+	if hours, err := strconv.Atoi(c.Query("olderThanHours")); err == nil && hours > 0 {
+		before := now.Add(-time.Duration(hours) * time.Hour)
+		filter.SubmittedBefore = &before
+	}
+	if hours, err := strconv.Atoi(c.Query("newerThanHours")); err == nil && hours > 0 {
+		after := now.Add(-time.Duration(hours) * time.Hour)
+		filter.SubmittedAfter = &after
+	}
+
+	proofs, total, err := h.proofService.ListProofQueue(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list proofs", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list proofs", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Proofs retrieved successfully", gin.H{
+		"proofs": proofs,
+		"total":  total,
+		"page":   page,
+		"limit":  limit,
+	})
+}
+
+// GetProof handles retrieving a proof
+func (h *ProofHandler) GetProof(c *gin.Context) {
+	proofID, ok := h.proofID(c)
+	if !ok {
+		return
+	}
+
+	proof, err := h.proofService.GetProof(c.Request.Context(), proofID)
+	if err != nil {
+		h.logger.Error("Failed to get proof", err)
+		h.respondError(c, err, "Failed to get proof")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Proof retrieved successfully", proof)
+}
+
+// GetProofHistory handles retrieving the moderation history of a proof
+func (h *ProofHandler) GetProofHistory(c *gin.Context) {
+	proofID, ok := h.proofID(c)
+	if !ok {
+		return
+	}
+
+	decisions, err := h.proofService.GetProofHistory(c.Request.Context(), proofID)
+	if err != nil {
+		h.logger.Error("Failed to get proof history", err)
+		h.respondError(c, err, "Failed to get proof history")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Proof history retrieved successfully", decisions)
+}
+
+// ClaimProof handles claiming a proof for review
+func (h *ProofHandler) ClaimProof(c *gin.Context) {
+	reviewerID, ok := h.reviewerID(c)
+	if !ok {
+		return
+	}
+
+	proofID, ok := h.proofID(c)
+	if !ok {
+		return
+	}
+
+	proof, err := h.proofService.ClaimProof(c.Request.Context(), proofID, reviewerID)
+	if err != nil {
+		h.logger.Error("Failed to claim proof", err)
+		h.respondError(c, err, "Failed to claim proof")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Proof claimed successfully", proof)
+}
+
+// ApproveProof handles approving a proof and its booking
+func (h *ProofHandler) ApproveProof(c *gin.Context) {
+	reviewerID, ok := h.reviewerID(c)
+	if !ok {
+		return
+	}
+
+	proofID, ok := h.proofID(c)
+	if !ok {
+		return
+	}
+
+	proof, err := h.proofService.ApproveProof(c.Request.Context(), proofID, reviewerID)
+	if err != nil {
+		h.logger.Error("Failed to approve proof", err)
+		h.respondError(c, err, "Failed to approve proof")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Proof approved successfully", proof)
+}
+
+// RejectProof handles rejecting a proof and its booking
+func (h *ProofHandler) RejectProof(c *gin.Context) {
+	reviewerID, ok := h.reviewerID(c)
+	if !ok {
+		return
+	}
+
+	proofID, ok := h.proofID(c)
+	if !ok {
+		return
+	}
+
+	var req service.RejectProofRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind reject proof request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	proof, err := h.proofService.RejectProof(c.Request.Context(), proofID, reviewerID, req)
+	if err != nil {
+		h.logger.Error("Failed to reject proof", err)
+		h.respondError(c, err, "Failed to reject proof")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Proof rejected successfully", proof)
+}
+
+// reviewerID reads the authenticated admin ID from the context
+func (h *ProofHandler) reviewerID(c *gin.Context) (uuid.UUID, bool) {
+	reviewerID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return uuid.Nil, false
+	}
+	return reviewerID, true
+}
+
+// proofID parses the proof ID from the URL
+func (h *ProofHandler) proofID(c *gin.Context) (uuid.UUID, bool) {
+	proofID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid proof ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid proof ID", err)
+		return uuid.Nil, false
+	}
+	return proofID, true
+}
+
+// respondError maps proof service errors to HTTP responses
+func (h *ProofHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrProofNotFound):
+		response.Error(c, http.StatusNotFound, "Proof not found", nil)
+	case errors.Is(err, repository.ErrBookingNotFound):
+		response.Error(c, http.StatusNotFound, "Booking not found", nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, entity.ErrProofAlreadyClaimed),
+		errors.Is(err, entity.ErrProofAlreadyReviewed),
+		errors.Is(err, entity.ErrInvalidBookingTransition):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
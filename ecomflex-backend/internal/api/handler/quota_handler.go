@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// QuotaHandler handles the plans catalogue and tenants' usage of it
+type QuotaHandler struct {
+	quotaService service.QuotaService
+	logger       loggerPkg.Logger
+}
+
+// NewQuotaHandler creates a new QuotaHandler
+func NewQuotaHandler(quotaService service.QuotaService, logger loggerPkg.Logger) *QuotaHandler {
+	return &QuotaHandler{
+		quotaService: quotaService,
+		logger:       logger,
+	}
+}
+
+// GetPlans handles listing the plans tenants can be on
+func (h *QuotaHandler) GetPlans(c *gin.Context) {
+	plans, err := h.quotaService.ListPlans(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list plans", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list plans", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Plans retrieved successfully", plans)
+}
+
+// GetUsage handles reporting the usage of the tenant the request acts in
+func (h *QuotaHandler) GetUsage(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.GetString("tenantID"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid tenant ID", nil)
+		return
+	}
+
+	h.respondUsage(c, tenantID)
+}
+
+// GetTenantUsage handles reporting the usage of any tenant
+func (h *QuotaHandler) GetTenantUsage(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid tenant ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid tenant ID", err)
+		return
+	}
+
+	h.respondUsage(c, tenantID)
+}
+
+// respondUsage responds with the usage report of a tenant
+func (h *QuotaHandler) respondUsage(c *gin.Context, tenantID uuid.UUID) {
+	report, err := h.quotaService.GetUsage(c.Request.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to get tenant usage", err, "tenant_id", tenantID.String())
+		if errors.Is(err, repository.ErrTenantNotFound) {
+			response.Error(c, http.StatusNotFound, "Tenant not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrOtherTenant) {
+			response.Error(c, http.StatusForbidden, err.Error(), nil)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to get tenant usage", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Usage retrieved successfully", report)
+}
+
+// respondQuotaError responds to errors from limits of the tenant's plan, with details the client
+// can show to suggest an upgrade. It reports whether err was one of them.
+func respondQuotaError(c *gin.Context, err error) bool {
+	var quotaErr *service.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		response.Error(c, http.StatusForbidden, quotaErr.Error(), gin.H{
+			"code":      "quota_exceeded",
+			"plan_id":   quotaErr.PlanID,
+			"resource":  quotaErr.Resource,
+			"limit":     quotaErr.Limit,
+			"used":      quotaErr.Used,
+			"requested": quotaErr.Requested,
+		})
+		return true
+	}
+
+	var featureErr *service.FeatureUnavailableError
+	if errors.As(err, &featureErr) {
+		response.Error(c, http.StatusForbidden, featureErr.Error(), gin.H{
+			"code":    "feature_unavailable",
+			"plan_id": featureErr.PlanID,
+			"feature": featureErr.Feature,
+		})
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	appService "github.com/naresh6454/ecomflex-backend/internal/app/service"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// attributionCookie is the cookie holding the attribution token of the last referral click
+const attributionCookie = "ecomflex_ref"
+
+// attributionCookieMaxAge keeps the cookie for the longest attribution window a tenant can choose;
+// whether the click still counts is decided with its tenant's window
+const attributionCookieMaxAge = 365 * 24 * time.Hour
+
+// ReferralHandler handles public referral links and referral moderation
+type ReferralHandler struct {
+	referralService service.ReferralService
+	logger          loggerPkg.Logger
+	landingURL      string
+}
+
+// NewReferralHandler creates a new ReferralHandler.
+// Visitors are redirected to landingURL, the public storefront.
+func NewReferralHandler(
+	referralService service.ReferralService,
+	logger loggerPkg.Logger,
+	landingURL string,
+) *ReferralHandler {
+	return &ReferralHandler{
+		referralService: referralService,
+		logger:          logger,
+		landingURL:      strings.TrimSuffix(landingURL, "/"),
+	}
+}
+
+// TrackClick records a click on a referral link and redirects to the storefront.
+// The attribution token is set as a cookie and passed on as the "attribution" query parameter.
+func (h *ReferralHandler) TrackClick(c *gin.Context) {
+	productID := c.Query("product")
+
+	click, err := h.referralService.TrackClick(c.Request.Context(), service.TrackClickRequest{
+		Code:      c.Param("code"),
+		ProductID: productID,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		// Unknown codes still land on the storefront, just without attribution
+		if !errors.Is(err, appService.ErrReferralCodeNotFound) {
+			h.logger.Error("Failed to track referral click", err)
+		}
+		c.Redirect(http.StatusFound, h.landingURL+"/")
+		return
+	}
+
+	token := click.ID.String()
+	c.SetCookie(attributionCookie, token, int(attributionCookieMaxAge.Seconds()), "/", "", c.Request.TLS != nil, true)
+
+	target := h.landingURL + "/"
+	if click.ProductID != nil {
+		target = h.landingURL + "/product/" + click.ProductID.String()
+	}
+
+	c.Redirect(http.StatusFound, target+"?attribution="+url.QueryEscape(token))
+}
+
+// GetReferrals lists referrals with pagination and filters for admins
+func (h *ReferralHandler) GetReferrals(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := repository.ReferralFilter{
+		Status:       c.Query("status"),
+		Source:       c.Query("source"),
+		InfluencerID: c.Query("influencerId"),
+		Limit:        limit,
+		Offset:       (page - 1) * limit,
+	}
+
+	referrals, total, err := h.referralService.ListReferrals(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list referrals", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list referrals", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Referrals retrieved successfully", gin.H{
+		"referrals": referrals,
+		"total":     total,
+		"page":      page,
+		"limit":     limit,
+	})
+}
+
+// ApproveReferral handles approving a pending referral
+func (h *ReferralHandler) ApproveReferral(c *gin.Context) {
+	referralID, ok := h.referralID(c)
+	if !ok {
+		return
+	}
+
+	referral, err := h.referralService.ApproveReferral(c.Request.Context(), referralID)
+	if err != nil {
+		h.logger.Error("Failed to approve referral", err)
+		h.respondError(c, err, "Failed to approve referral")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Referral approved successfully", referral)
+}
+
+// RejectReferral handles rejecting a referral; approved referrals have their earnings reversed
+func (h *ReferralHandler) RejectReferral(c *gin.Context) {
+	referralID, ok := h.referralID(c)
+	if !ok {
+		return
+	}
+
+	referral, err := h.referralService.RejectReferral(c.Request.Context(), referralID)
+	if err != nil {
+		h.logger.Error("Failed to reject referral", err)
+		h.respondError(c, err, "Failed to reject referral")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Referral rejected successfully", referral)
+}
+
+// referralID parses the referral ID from the URL
+func (h *ReferralHandler) referralID(c *gin.Context) (uuid.UUID, bool) {
+	referralID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid referral ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid referral ID", err)
+		return uuid.Nil, false
+	}
+	return referralID, true
+}
+
+// respondError maps referral service errors to HTTP responses
+func (h *ReferralHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrReferralNotFound):
+		response.Error(c, http.StatusNotFound, "Referral not found", nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, entity.ErrReferralNotPending), errors.Is(err, entity.ErrReferralAlreadyRejected):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// attributionToken returns the token sent in the request body, falling back to the attribution cookie
+func attributionToken(c *gin.Context, token string) string {
+	if token != "" {
+		return token
+	}
+
+	cookie, err := c.Cookie(attributionCookie)
+	if err != nil {
+		return ""
+	}
+	return cookie
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// RoleHandler handles tenants' custom roles and users' role assignments
+type RoleHandler struct {
+	roleService service.RoleService
+	logger      loggerPkg.Logger
+}
+
+// NewRoleHandler creates a new RoleHandler
+func NewRoleHandler(roleService service.RoleService, logger loggerPkg.Logger) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+		logger:      logger,
+	}
+}
+
+// GetRoles handles listing the roles of a tenant
+func (h *RoleHandler) GetRoles(c *gin.Context) {
+	tenantID, ok := h.parseID(c, "id", "tenant")
+	if !ok {
+		return
+	}
+
+	roles, err := h.roleService.ListRoles(c.Request.Context(), h.currentUser(c), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to list roles", err)
+		h.respondError(c, err, "Failed to list roles")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Roles retrieved successfully", roles)
+}
+
+// CreateRole handles creating a custom role for a tenant
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	tenantID, ok := h.parseID(c, "id", "tenant")
+	if !ok {
+		return
+	}
+
+	var req service.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind role request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	role, err := h.roleService.CreateRole(c.Request.Context(), h.currentUser(c), tenantID, req)
+	if err != nil {
+		h.logger.Error("Failed to create role", err)
+		h.respondError(c, err, "Failed to create role")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Role created successfully", role)
+}
+
+// UpdateRole handles changing the permissions of a tenant's custom role
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	tenantID, ok := h.parseID(c, "id", "tenant")
+	if !ok {
+		return
+	}
+
+	roleID, ok := h.parseID(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	var req service.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind role request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	role, err := h.roleService.UpdateRole(c.Request.Context(), h.currentUser(c), tenantID, roleID, req)
+	if err != nil {
+		h.logger.Error("Failed to update role", err)
+		h.respondError(c, err, "Failed to update role")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Role updated successfully", role)
+}
+
+// DeleteRole handles deleting a tenant's custom role
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	tenantID, ok := h.parseID(c, "id", "tenant")
+	if !ok {
+		return
+	}
+
+	roleID, ok := h.parseID(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	if err := h.roleService.DeleteRole(c.Request.Context(), h.currentUser(c), tenantID, roleID); err != nil {
+		h.logger.Error("Failed to delete role", err)
+		h.respondError(c, err, "Failed to delete role")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Role deleted successfully", nil)
+}
+
+// AssignRole handles changing the role of a user
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	userID, ok := h.parseID(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	var req service.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind role assignment request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	user, err := h.roleService.AssignRole(c.Request.Context(), h.currentUser(c), userID, entity.Role(req.Role))
+	if err != nil {
+		h.logger.Error("Failed to assign role", err)
+		h.respondError(c, err, "Failed to assign role")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Role assigned successfully", user)
+}
+
+// currentUser reads the authenticated user from the context
+func (h *RoleHandler) currentUser(c *gin.Context) *entity.User {
+	user, _ := c.MustGet("user").(*entity.User)
+	return user
+}
+
+// parseID parses a UUID parameter from the URL
+func (h *RoleHandler) parseID(c *gin.Context, param, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		h.logger.Error("Invalid "+name+" ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid "+name+" ID", err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError maps role service errors to HTTP responses
+func (h *RoleHandler) respondError(c *gin.Context, err error, message string) {
+	if respondQuotaError(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrTenantRoleNotFound):
+		response.Error(c, http.StatusNotFound, "Role not found", nil)
+	case errors.Is(err, repository.ErrTenantNotFound):
+		response.Error(c, http.StatusNotFound, "Tenant not found", nil)
+	case errors.Is(err, entity.ErrInvalidTenantRole), errors.Is(err, service.ErrUnknownRole):
+		response.Error(c, http.StatusBadRequest, err.Error(), nil)
+	case errors.Is(err, repository.ErrTenantRoleNameTaken), errors.Is(err, service.ErrRoleInUse):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, service.ErrPermissionEscalation), errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// SessionHandler handles the devices users are signed in on
+type SessionHandler struct {
+	sessionService service.SessionService
+	logger         loggerPkg.Logger
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(sessionService service.SessionService, logger loggerPkg.Logger) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		logger:         logger,
+	}
+}
+
+// GetMySessions handles listing the current user's sessions
+func (h *SessionHandler) GetMySessions(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID, c.GetString("sessionID"))
+	if err != nil {
+		h.logger.Error("Failed to list sessions", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list sessions", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeMySession handles signing the current user out of one of their sessions
+func (h *SessionHandler) RevokeMySession(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := h.sessionID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		h.respondError(c, err, "Failed to revoke session")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// GetUserSessions handles listing the sessions of any user
+func (h *SessionHandler) GetUserSessions(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), userID, "")
+	if err != nil {
+		h.respondError(c, err, "Failed to list sessions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeUserSession handles signing any user out of one of their sessions
+func (h *SessionHandler) RevokeUserSession(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := h.sessionID(c, "session_id")
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		h.respondError(c, err, "Failed to revoke session")
+		return
+	}
+
+	h.logger.Info("Admin revoked user session", "user_id", userID.String(), "session_id", sessionID.String(), "admin_id", c.GetString("userID"))
+	response.Success(c, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// RevokeUserSessions handles forcing any user to sign out everywhere
+func (h *SessionHandler) RevokeUserSessions(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.RevokeAllSessions(c.Request.Context(), userID); err != nil {
+		h.respondError(c, err, "Failed to revoke sessions")
+		return
+	}
+
+	h.logger.Info("Admin revoked all user sessions", "user_id", userID.String(), "admin_id", c.GetString("userID"))
+	response.Success(c, http.StatusOK, "Sessions revoked successfully", nil)
+}
+
+// currentUserID reads the authenticated user ID from the context
+func (h *SessionHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// userID parses the user ID from the URL
+func (h *SessionHandler) userID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid user ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid user ID", err)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// sessionID parses a session ID from the URL parameter param
+func (h *SessionHandler) sessionID(c *gin.Context, param string) (uuid.UUID, bool) {
+	sessionID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		h.logger.Error("Invalid session ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid session ID", err)
+		return uuid.Nil, false
+	}
+	return sessionID, true
+}
+
+// respondError maps session service errors to HTTP responses
+func (h *SessionHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrSessionNotFound):
+		response.Error(c, http.StatusNotFound, "Session not found", nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	default:
+		h.logger.Error(message, err)
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
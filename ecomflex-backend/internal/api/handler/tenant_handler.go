@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// maxSettingsSize caps the size of a settings change
+const maxSettingsSize = 64 << 10
+
+// TenantHandler handles administering tenants and their settings
+type TenantHandler struct {
+	tenantService service.TenantService
+	logger        loggerPkg.Logger
+}
+
+// NewTenantHandler creates a new TenantHandler
+func NewTenantHandler(tenantService service.TenantService, logger loggerPkg.Logger) *TenantHandler {
+	return &TenantHandler{
+		tenantService: tenantService,
+		logger:        logger,
+	}
+}
+
+// GetTenants handles listing tenants, optionally searched by name or domain and filtered by status
+func (h *TenantHandler) GetTenants(c *gin.Context) {
+	filter := repository.TenantFilter{
+		Search: strings.TrimSpace(c.Query("search")),
+		Status: c.Query("status"),
+	}
+	if filter.Status != "" && filter.Status != "active" && filter.Status != "suspended" {
+		response.Error(c, http.StatusBadRequest, "Status must be active or suspended", nil)
+		return
+	}
+
+	page, limit := pagination(c)
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	tenants, total, err := h.tenantService.ListTenants(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list tenants", err)
+		h.respondError(c, err, "Failed to list tenants")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenants retrieved successfully", gin.H{
+		"tenants": tenants,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// GetTenant handles getting a tenant
+func (h *TenantHandler) GetTenant(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.tenantService.GetTenant(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get tenant", err)
+		h.respondError(c, err, "Failed to get tenant")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenant retrieved successfully", tenant)
+}
+
+// CreateTenant handles creating a tenant
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	var req service.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind tenant request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	tenant, err := h.tenantService.CreateTenant(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create tenant", err)
+		h.respondError(c, err, "Failed to create tenant")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Tenant created successfully", tenant)
+}
+
+// UpdateTenant handles updating a tenant
+func (h *TenantHandler) UpdateTenant(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req service.UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind tenant request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	tenant, err := h.tenantService.UpdateTenant(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update tenant", err)
+		h.respondError(c, err, "Failed to update tenant")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenant updated successfully", tenant)
+}
+
+// SuspendTenant handles suspending a tenant
+func (h *TenantHandler) SuspendTenant(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.tenantService.SuspendTenant(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to suspend tenant", err)
+		h.respondError(c, err, "Failed to suspend tenant")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenant suspended successfully", tenant)
+}
+
+// ActivateTenant handles lifting the suspension of a tenant
+func (h *TenantHandler) ActivateTenant(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.tenantService.ActivateTenant(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to activate tenant", err)
+		h.respondError(c, err, "Failed to activate tenant")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenant activated successfully", tenant)
+}
+
+// VerifyTenantDomain handles marking a tenant's custom domain as verified
+func (h *TenantHandler) VerifyTenantDomain(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.tenantService.VerifyDomain(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to verify tenant domain", err)
+		h.respondError(c, err, "Failed to verify tenant domain")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenant domain verified successfully", tenant)
+}
+
+// DeleteTenant handles deleting a tenant
+func (h *TenantHandler) DeleteTenant(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tenantService.DeleteTenant(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete tenant", err)
+		h.respondError(c, err, "Failed to delete tenant")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tenant deleted successfully", nil)
+}
+
+// GetSettings handles getting the settings of the tenant the request acts in
+func (h *TenantHandler) GetSettings(c *gin.Context) {
+	tenantID, ok := h.actingTenantID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.tenantService.GetSettings(c.Request.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("Failed to get tenant settings", err)
+		h.respondError(c, err, "Failed to get tenant settings")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Settings retrieved successfully", settings)
+}
+
+// UpdateSettings handles changing some settings of the tenant the request acts in.
+// The body is a partial settings document; settings it leaves out keep their values.
+func (h *TenantHandler) UpdateSettings(c *gin.Context) {
+	tenantID, ok := h.actingTenantID(c)
+	if !ok {
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSettingsSize))
+	if err != nil {
+		h.logger.Error("Failed to read settings request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	settings, err := h.tenantService.UpdateSettings(c.Request.Context(), tenantID, patch)
+	if err != nil {
+		h.logger.Error("Failed to update tenant settings", err)
+		h.respondError(c, err, "Failed to update tenant settings")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Settings updated successfully", settings)
+}
+
+// GetSettingsSchema handles getting the JSON Schema settings changes are validated against
+func (h *TenantHandler) GetSettingsSchema(c *gin.Context) {
+	c.Data(http.StatusOK, "application/schema+json", entity.TenantSettingsSchemaJSON)
+}
+
+// actingTenantID gets the tenant the request acts in
+func (h *TenantHandler) actingTenantID(c *gin.Context) (uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(c.GetString("tenantID"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid tenant ID", nil)
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
+
+// parseID parses the tenant ID in the path
+func (h *TenantHandler) parseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Error("Invalid tenant ID", err)
+		response.Error(c, http.StatusBadRequest, "Invalid tenant ID", err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError maps tenant service errors to HTTP responses
+func (h *TenantHandler) respondError(c *gin.Context, err error, message string) {
+	if respondQuotaError(c, err) {
+		return
+	}
+
+	var settingsErr *entity.TenantSettingsError
+	switch {
+	case errors.As(err, &settingsErr):
+		response.Error(c, http.StatusBadRequest, "Invalid tenant settings", gin.H{"problems": settingsErr.Problems})
+	case errors.Is(err, repository.ErrTenantNotFound):
+		response.Error(c, http.StatusNotFound, "Tenant not found", nil)
+	case errors.Is(err, service.ErrOtherTenant):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, entity.ErrInvalidTenantDomain), errors.Is(err, service.ErrPlatformDomain),
+		errors.Is(err, repository.ErrPlanNotFound):
+		response.Error(c, http.StatusBadRequest, err.Error(), nil)
+	case errors.Is(err, repository.ErrTenantDomainTaken), errors.Is(err, service.ErrDefaultTenant):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// WalletHandler handles the cashback wallet of the current user
+type WalletHandler struct {
+	walletService service.WalletService
+	logger        loggerPkg.Logger
+}
+
+// NewWalletHandler creates a new WalletHandler
+func NewWalletHandler(walletService service.WalletService, logger loggerPkg.Logger) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+		logger:        logger,
+	}
+}
+
+// GetWallet handles retrieving the current user's wallet
+func (h *WalletHandler) GetWallet(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.walletService.GetWallet(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get wallet", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to get wallet", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Wallet retrieved successfully", wallet)
+}
+
+// GetTransactions handles listing the current user's wallet transactions
+func (h *WalletHandler) GetTransactions(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := pagination(c)
+	entries, total, err := h.walletService.ListTransactions(c.Request.Context(), userID, limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list wallet transactions", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list wallet transactions", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Wallet transactions retrieved successfully", gin.H{
+		"transactions": entries,
+		"total":        total,
+		"page":         page,
+		"limit":        limit,
+	})
+}
+
+// GetPayouts handles listing the current user's wallet payouts
+func (h *WalletHandler) GetPayouts(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := pagination(c)
+	payouts, total, err := h.walletService.ListPayouts(c.Request.Context(), userID, limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list wallet payouts", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to list wallet payouts", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Wallet payouts retrieved successfully", gin.H{
+		"payouts": payouts,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+	})
+}
+
+// GetPayoutMethod handles retrieving the current user's payout method
+func (h *WalletHandler) GetPayoutMethod(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	method, err := h.walletService.GetPayoutMethod(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPayoutMethodNotFound) {
+			response.Error(c, http.StatusNotFound, "Payout method not found", nil)
+			return
+		}
+		h.logger.Error("Failed to get payout method", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to get payout method", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout method retrieved successfully", method)
+}
+
+// SavePayoutMethod handles choosing where the current user's payouts are sent
+func (h *WalletHandler) SavePayoutMethod(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req service.SavePayoutMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind payout method request", err)
+		response.Error(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	method, err := h.walletService.SavePayoutMethod(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidPayoutMethod) {
+			response.Error(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		h.logger.Error("Failed to save payout method", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to save payout method", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout method saved successfully", method)
+}
+
+// currentUserID reads the authenticated user ID from the context
+func (h *WalletHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized", nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
@@ -12,16 +12,27 @@ import (
 	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
 )
 
+// devSuperAdminHeader lets a request through as a super admin in dev mode
+const devSuperAdminHeader = "X-Superadmin-Auth"
+
+// devSuperAdminHeaderValue is the value devSuperAdminHeader has to carry
+const devSuperAdminHeaderValue = "ecomflex-superadmin-direct-auth"
+
 // AuthMiddleware handles authentication and authorization
 type AuthMiddleware struct {
 	authService service.AuthService
+	roleService service.RoleService
+	devMode     bool
 	logger      loggerPkg.Logger
 }
 
-// NewAuthMiddleware creates a new AuthMiddleware
-func NewAuthMiddleware(authService service.AuthService, logger loggerPkg.Logger) *AuthMiddleware {
+// NewAuthMiddleware creates a new AuthMiddleware.
+// devMode enables the shortcuts that let requests through as a super admin without logging in.
+func NewAuthMiddleware(authService service.AuthService, roleService service.RoleService, devMode bool, logger loggerPkg.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
 		authService: authService,
+		roleService: roleService,
+		devMode:     devMode,
 		logger:      logger,
 	}
 }
@@ -29,32 +40,9 @@ func NewAuthMiddleware(authService service.AuthService, logger loggerPkg.Logger)
 // Authenticate authenticates a user using JWT token
 func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Check for special superadmin authentication header
-		superadminHeader := c.GetHeader("X-Superadmin-Auth")
-		if superadminHeader == "ecomflex-superadmin-direct-auth" {
-			// This is a superadmin request, create a mock admin user
-			superadminID, _ := uuid.Parse("00000000-0000-0000-0000-000000000000") // Use a fixed UUID for superadmin
-			tenantID, _ := uuid.Parse("00000000-0000-0000-0000-000000000001")     // Use a fixed UUID for tenant
-
-			// Create a superadmin user according to your entity model
-			// Adjust fields as necessary to match your User entity structure
-			superadminUser := &entity.User{
-				ID:       superadminID,
-				Email:    "admin@ecomflex.com",
-				FullName: "System Administrator",
-				Role:     "admin", // Adjust according to your role type
-				TenantID: tenantID,
-			}
-
-			// Set user in context
-			c.Set("user", superadminUser)
-			c.Set("userID", superadminUser.ID.String())
-			c.Set("email", superadminUser.Email)
-			c.Set("role", string(superadminUser.Role))
-			c.Set("tenantID", superadminUser.TenantID.String())
-			c.Set("tokenID", "superadmin-token-id")
-			c.Set("isSuperAdmin", true)
-
+		// Dev mode shortcut for tools that cannot log in; the header does nothing otherwise
+		if m.devMode && c.GetHeader(devSuperAdminHeader) == devSuperAdminHeaderValue {
+			m.setDevSuperAdmin(c)
 			c.Next()
 			return
 		}
@@ -78,7 +66,7 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		token := parts[1]
 		
 		// Validate token and get user
-		user, err := m.authService.GetUserFromToken(c.Request.Context(), token)
+		accessToken, err := m.authService.AuthenticateToken(c.Request.Context(), token)
 		if err != nil {
 			m.logger.Error("Failed to validate token", err)
 			response.Error(c, http.StatusUnauthorized, "Invalid token", nil)
@@ -87,28 +75,48 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		}
 		
 		// Check if user is active
+		user := accessToken.User
 		if !user.IsActive() {
 			response.Error(c, http.StatusForbidden, "Your account is inactive", nil)
 			c.Abort()
 			return
 		}
 		
+		// Tokens only work on their own tenant's hosts
+		tenantID, ok := m.authorizeTenant(c, user)
+		if !ok {
+			return
+		}
+		
 		// Set user in context
 		c.Set("user", user)
 		c.Set("userID", user.ID.String())
 		c.Set("email", user.Email)
 		c.Set("role", string(user.Role))
-		c.Set("tenantID", user.TenantID.String())
+		c.Set("tenantID", tenantID.String())
+		
+		// Set the token and its session in context
+		c.Set("tokenID", accessToken.TokenID)
+		c.Set("sessionID", accessToken.SessionID)
+		
+		// Mark requests an admin makes as another user so clients can show it
+		actor := service.Actor{UserID: user.ID, TenantID: user.TenantID, Role: user.Role}
+		if accessToken.Impersonation != nil {
+			c.Set("impersonation", accessToken.Impersonation)
+			c.Set("impersonatorID", accessToken.Impersonation.ImpersonatorID.String())
+			c.Header("X-Impersonation-ID", accessToken.Impersonation.ID.String())
+			actor.ImpersonatorID = &accessToken.Impersonation.ImpersonatorID
+		}
 		
-		// Extract tokenID from claims and set in context
-		c.Set("tokenID", "token-id-placeholder")
+		// Services attribute the changes the request makes to the actor
+		c.Request = c.Request.WithContext(service.WithActor(c.Request.Context(), actor))
 		
 		c.Next()
 	}
 }
 
-// RequireRole checks if the user has the required role
-func (m *AuthMiddleware) RequireRole(roles ...entity.Role) gin.HandlerFunc {
+// RequirePermission checks if the user's role grants all the given permissions
+func (m *AuthMiddleware) RequirePermission(permissions ...entity.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get user from context
 		user, exists := c.Get("user")
@@ -118,18 +126,21 @@ func (m *AuthMiddleware) RequireRole(roles ...entity.Role) gin.HandlerFunc {
 			return
 		}
 		
-		// Check if user has one of the required roles
-		userObj := user.(*entity.User)
-		hasRole := false
-		
-		for _, role := range roles {
-			if userObj.Role == role {
-				hasRole = true
-				break
+		// Permissions are resolved once per request
+		held, ok := c.Get("permissions")
+		if !ok {
+			set, err := m.roleService.GetPermissions(c.Request.Context(), user.(*entity.User))
+			if err != nil {
+				m.logger.Error("Failed to get permissions", err)
+				response.Error(c, http.StatusInternalServerError, "Failed to check permissions", nil)
+				c.Abort()
+				return
 			}
+			c.Set("permissions", set)
+			held = set
 		}
 		
-		if !hasRole {
+		if !held.(entity.PermissionSet).Has(permissions...) {
 			response.Error(c, http.StatusForbidden, "Insufficient permissions", nil)
 			c.Abort()
 			return
@@ -137,4 +148,88 @@ func (m *AuthMiddleware) RequireRole(roles ...entity.Role) gin.HandlerFunc {
 		
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// DenyImpersonation blocks requests made with an impersonation token.
+// It guards actions an admin must never take on a user's behalf, like moving money or changing credentials.
+func (m *AuthMiddleware) DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, impersonating := c.Get("impersonation"); impersonating {
+			response.Error(c, http.StatusForbidden, "Not allowed while impersonating a user", nil)
+			c.Abort()
+			return
+		}
+		
+		c.Next()
+	}
+}
+
+// AuthenticateOrDev authenticates a user like Authenticate.
+// In dev mode requests without an Authorization header go through as a super admin of the default tenant instead.
+func (m *AuthMiddleware) AuthenticateOrDev() gin.HandlerFunc {
+	authenticate := m.Authenticate()
+	return func(c *gin.Context) {
+		if m.devMode && c.GetHeader("Authorization") == "" {
+			m.setDevSuperAdmin(c)
+			c.Next()
+			return
+		}
+		authenticate(c)
+	}
+}
+
+// authorizeTenant returns the tenant an authenticated request acts in, rejecting it when the user
+// does not belong to the tenant its host serves. Super admins may pick another tenant with X-Tenant-ID.
+func (m *AuthMiddleware) authorizeTenant(c *gin.Context, user *entity.User) (uuid.UUID, bool) {
+	if requested, ok := c.Get("requestedTenant"); ok {
+		tenantID := requested.(*entity.Tenant).ID
+		if user.Role == entity.RoleSuperAdmin {
+			return tenantID, true
+		}
+		if tenantID != user.TenantID {
+			response.Error(c, http.StatusForbidden, tenantHeader+" is only accepted from super admins", nil)
+			c.Abort()
+			return uuid.Nil, false
+		}
+	}
+	
+	if c.GetBool("tenantFromHost") {
+		tenant := c.MustGet("tenant").(*entity.Tenant)
+		if tenant.ID != user.TenantID {
+			response.Error(c, http.StatusForbidden, "Your account does not belong to this tenant", nil)
+			c.Abort()
+			return uuid.Nil, false
+		}
+	}
+	
+	return user.TenantID, true
+}
+
+// setDevSuperAdmin sets a stand-in super admin of the request's tenant in the context
+func (m *AuthMiddleware) setDevSuperAdmin(c *gin.Context) {
+	// The stand-in acts in the tenant the host or X-Tenant-ID picks, or the default one
+	tenantID := defaultTenantID
+	if requested, ok := c.Get("requestedTenant"); ok {
+		tenantID = requested.(*entity.Tenant).ID
+	} else if tenant, ok := c.Get("tenant"); ok {
+		tenantID = tenant.(*entity.Tenant).ID
+	}
+	
+	user := &entity.User{
+		ID:       uuid.New(),
+		TenantID: tenantID,
+		Email:    "dev-admin@localhost",
+		FullName: "Dev Administrator",
+		Role:     entity.RoleSuperAdmin,
+		Status:   "active",
+	}
+	
+	m.logger.Warn("Dev mode request let through as super admin", "path", c.Request.URL.Path)
+	
+	c.Set("user", user)
+	c.Set("userID", user.ID.String())
+	c.Set("email", user.Email)
+	c.Set("role", string(user.Role))
+	c.Set("tenantID", user.TenantID.String())
+	// No actor is set: the stand-in user does not exist, so its changes are audited without one
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// tokenAuthService authenticates fixed access tokens
+type tokenAuthService struct {
+	service.AuthService
+	tokens map[string]*service.AccessToken
+}
+
+func (s *tokenAuthService) AuthenticateToken(ctx context.Context, token string) (*service.AccessToken, error) {
+	accessToken, ok := s.tokens[token]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return accessToken, nil
+}
+
+func TestAuthMiddlewareDenyImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	admin := entity.NewUser(uuid.New(), "admin@example.com", "hash", "Admin", entity.RoleSupport, "")
+	user := entity.NewUser(admin.TenantID, "user@example.com", "hash", "User", entity.RolePublic, "")
+	impersonation := entity.NewImpersonation(admin, user, "support ticket", "", "", 0)
+
+	authService := &tokenAuthService{tokens: map[string]*service.AccessToken{
+		"own":           {User: user, TokenID: "own", SessionID: "session"},
+		"impersonating": {User: user, TokenID: "impersonating", Impersonation: impersonation},
+	}}
+	m := NewAuthMiddleware(authService, nil, false, loggerPkg.NewLogger("error"))
+
+	tests := []struct {
+		name              string
+		token             string
+		guarded           bool
+		wantStatus        int
+		wantImpersonation bool
+	}{
+		{name: "own token on a guarded route", token: "own", guarded: true, wantStatus: http.StatusOK},
+		{name: "impersonation token on a guarded route", token: "impersonating", guarded: true, wantStatus: http.StatusForbidden, wantImpersonation: true},
+		{name: "impersonation token elsewhere", token: "impersonating", wantStatus: http.StatusOK, wantImpersonation: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor service.Actor
+			handlers := []gin.HandlerFunc{m.Authenticate()}
+			if tt.guarded {
+				handlers = append(handlers, m.DenyImpersonation())
+			}
+			handlers = append(handlers, func(c *gin.Context) {
+				actor, _ = service.ActorFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			router := gin.New()
+			router.POST("/", handlers...)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("X-Impersonation-ID") != ""; got != tt.wantImpersonation {
+				t.Errorf("X-Impersonation-ID set = %t, want %t", got, tt.wantImpersonation)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if actor.UserID != user.ID {
+				t.Errorf("actor = %s, want %s", actor.UserID, user.ID)
+			}
+			if (actor.ImpersonatorID != nil) != tt.wantImpersonation {
+				t.Errorf("actor impersonator = %v, want set %t", actor.ImpersonatorID, tt.wantImpersonation)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareDevShortcuts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		devMode    bool
+		dev        bool
+		header     bool
+		wantStatus int
+	}{
+		{name: "super admin header", header: true, wantStatus: http.StatusUnauthorized},
+		{name: "super admin header in dev mode", devMode: true, header: true, wantStatus: http.StatusOK},
+		{name: "no token on a dev route", dev: true, wantStatus: http.StatusUnauthorized},
+		{name: "no token on a dev route in dev mode", devMode: true, dev: true, wantStatus: http.StatusOK},
+		{name: "no token in dev mode", devMode: true, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAuthMiddleware(&tokenAuthService{}, nil, tt.devMode, loggerPkg.NewLogger("error"))
+			authenticate := m.Authenticate()
+			if tt.dev {
+				authenticate = m.AuthenticateOrDev()
+			}
+
+			var role string
+			router := gin.New()
+			router.GET("/", authenticate, func(c *gin.Context) {
+				role = c.GetString("role")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header {
+				req.Header.Set(devSuperAdminHeader, devSuperAdminHeaderValue)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && role != string(entity.RoleSuperAdmin) {
+				t.Errorf("role = %s, want the dev super admin", role)
+			}
+		})
+	}
+}
@@ -1,83 +1,138 @@
 package middleware
 
 import (
-	"fmt"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"strings"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/api/response"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
 	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
 )
 
+// tenantHeader lets super admins act in another tenant than the one the host serves
+const tenantHeader = "X-Tenant-ID"
+
+// defaultTenantID is the tenant requests to platform hosts act in before a user signs in
+var defaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
 // TenantMiddleware handles tenant identification
 type TenantMiddleware struct {
-	logger loggerPkg.Logger
+	tenantRepo    repository.TenantRepository
+	baseDomain    string
+	platformHosts map[string]bool
+	logger        loggerPkg.Logger
 }
 
 // NewTenantMiddleware creates a new TenantMiddleware
-func NewTenantMiddleware(logger loggerPkg.Logger) *TenantMiddleware {
+func NewTenantMiddleware(tenantRepo repository.TenantRepository, cfg config.TenancyConfig, logger loggerPkg.Logger) *TenantMiddleware {
+	platformHosts := make(map[string]bool, len(cfg.PlatformHosts))
+	for _, host := range cfg.PlatformHosts {
+		platformHosts[normalizeHost(host)] = true
+	}
+
 	return &TenantMiddleware{
-		logger: logger,
+		tenantRepo:    tenantRepo,
+		baseDomain:    normalizeHost(cfg.BaseDomain),
+		platformHosts: platformHosts,
+		logger:        logger,
 	}
 }
 
-// ExtractTenantID extracts tenant ID from various sources
+// ExtractTenantID resolves the tenant a request acts in from its Host header.
+// Subdomains of the base domain and verified custom domains serve their tenant; platform hosts
+// serve the default tenant until a user signs in. An X-Tenant-ID header on a request with a token
+// is left for Authenticate to honour, since only super admins may name another tenant than their own.
 func (m *TenantMiddleware) ExtractTenantID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var tenantID string
-		
-		// Try to extract tenant ID from header
-		tenantID = c.GetHeader("X-Tenant-ID")
-		
-		// If not found, check if we have a user in context (set by auth middleware)
-		if tenantID == "" {
-			if user, exists := c.Get("user"); exists {
-				// Get tenant ID from authenticated user
-				if userMap, ok := user.(map[string]interface{}); ok {
-					if tid, exists := userMap["tenant_id"].(string); exists {
-						tenantID = tid
-					}
-				}
+		host := normalizeHost(c.Request.Host)
+		fromHost := !m.isPlatformHost(host)
+
+		var tenant *entity.Tenant
+		var err error
+		if fromHost {
+			tenant, err = m.tenantRepo.GetTenantByDomain(c.Request.Context(), host)
+			if err == nil && !tenant.ServesDomain(m.baseDomain) {
+				err = repository.ErrTenantNotFound
 			}
+		} else {
+			tenant, err = m.tenantRepo.GetTenantByID(c.Request.Context(), defaultTenantID)
 		}
-		
-		// If not found, try to extract from subdomain
-		if tenantID == "" {
-			host := c.Request.Host
-			// Extract subdomain if present
-			if strings.Contains(host, ".") {
-				parts := strings.Split(host, ".")
-				if len(parts) > 2 {
-					subdomain := parts[0]
-					
-					// Skip common subdomains
-					if subdomain != "www" && subdomain != "api" {
-						// TODO: Look up tenant by subdomain from database
-						// For now, we'll just use the default tenant ID
-						// This should be replaced with actual lookup in production
+		if !m.checkTenant(c, tenant, err) {
+			return
+		}
+
+		if header := c.GetHeader(tenantHeader); header != "" {
+			requestedID, err := uuid.Parse(header)
+			if err != nil {
+				response.Error(c, http.StatusBadRequest, "Invalid tenant ID", nil)
+				c.Abort()
+				return
+			}
+
+			switch {
+			case c.GetHeader("Authorization") != "":
+				requested := tenant
+				if requestedID != tenant.ID {
+					requested, err = m.tenantRepo.GetTenantByID(c.Request.Context(), requestedID)
+					if !m.checkTenant(c, requested, err) {
+						return
 					}
 				}
+				c.Set("requestedTenant", requested)
+			case requestedID != tenant.ID:
+				// Requests without a token cannot come from a super admin
+				response.Error(c, http.StatusForbidden, tenantHeader+" is only accepted from super admins", nil)
+				c.Abort()
+				return
 			}
-			
-			// If still no tenant ID found, use default for public routes
-			if tenantID == "" {
-				tenantID = "00000000-0000-0000-0000-000000000000"
-			}
-		}
-		
-		// Validate UUID format
-		_, err := uuid.Parse(tenantID)
-		if err != nil {
-			// Create a proper error message for logging
-			logErr := fmt.Errorf("invalid tenant ID format %s: %w", tenantID, err)
-			m.logger.Error("Invalid tenant ID format", logErr)
-			
-			// Fall back to default tenant ID
-			tenantID = "00000000-0000-0000-0000-000000000000"
 		}
-		
-		// Set tenant ID in context
-		c.Set("tenantID", tenantID)
-		
+
+		// Set tenant in context
+		c.Set("tenant", tenant)
+		c.Set("tenantID", tenant.ID.String())
+		c.Set("tenantFromHost", fromHost)
+
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// checkTenant rejects requests to tenants that do not exist, were deleted or are inactive
+func (m *TenantMiddleware) checkTenant(c *gin.Context, tenant *entity.Tenant, err error) bool {
+	if err != nil {
+		if errors.Is(err, repository.ErrTenantNotFound) {
+			response.Error(c, http.StatusNotFound, "Tenant not found", nil)
+		} else {
+			m.logger.Error("Failed to resolve tenant", err, "host", c.Request.Host)
+			response.Error(c, http.StatusInternalServerError, "Failed to resolve tenant", nil)
+		}
+		c.Abort()
+		return false
+	}
+
+	if !tenant.IsActive {
+		response.Error(c, http.StatusForbidden, "Tenant is inactive", nil)
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+// isPlatformHost checks if a host serves every tenant rather than one
+func (m *TenantMiddleware) isPlatformHost(host string) bool {
+	return host == "" || m.platformHosts[host] || net.ParseIP(host) != nil
+}
+
+// normalizeHost strips the port and trailing dot of a host and lowercases it
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
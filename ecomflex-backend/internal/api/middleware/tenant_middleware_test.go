@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// hostTenantRepo finds tenants by ID and domain
+type hostTenantRepo struct {
+	repository.TenantRepository
+	tenants []*entity.Tenant
+}
+
+func (r *hostTenantRepo) GetTenantByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	for _, tenant := range r.tenants {
+		if tenant.ID == id {
+			return tenant, nil
+		}
+	}
+	return nil, repository.ErrTenantNotFound
+}
+
+func (r *hostTenantRepo) GetTenantByDomain(ctx context.Context, domain string) (*entity.Tenant, error) {
+	for _, tenant := range r.tenants {
+		if tenant.Domain == domain {
+			return tenant, nil
+		}
+	}
+	return nil, repository.ErrTenantNotFound
+}
+
+// newHostTenants creates the default tenant and tenants served from a subdomain, a verified and
+// an unverified custom domain and an inactive subdomain
+func newHostTenants() (*hostTenantRepo, map[string]*entity.Tenant) {
+	verifiedAt := time.Now()
+	tenants := map[string]*entity.Tenant{
+		"default":    {ID: defaultTenantID, Domain: "ecomflex.com", IsActive: true},
+		"subdomain":  {ID: uuid.New(), Domain: "acme.ecomflex.com", IsActive: true},
+		"verified":   {ID: uuid.New(), Domain: "shop.acme.com", IsActive: true, DomainVerifiedAt: &verifiedAt},
+		"unverified": {ID: uuid.New(), Domain: "shop.other.com", IsActive: true},
+		"inactive":   {ID: uuid.New(), Domain: "closed.ecomflex.com"},
+	}
+
+	repo := &hostTenantRepo{}
+	for _, tenant := range tenants {
+		repo.tenants = append(repo.tenants, tenant)
+	}
+	return repo, tenants
+}
+
+func TestTenantMiddlewareExtractTenantID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo, tenants := newHostTenants()
+	m := NewTenantMiddleware(repo, config.TenancyConfig{
+		BaseDomain:    "ecomflex.com",
+		PlatformHosts: []string{"api.ecomflex.com"},
+	}, loggerPkg.NewLogger("error"))
+
+	tests := []struct {
+		name         string
+		host         string
+		tenantHeader string
+		token        bool
+		wantStatus   int
+		wantTenant   string
+		wantFromHost bool
+	}{
+		{name: "subdomain", host: "acme.ecomflex.com", wantStatus: http.StatusOK, wantTenant: "subdomain", wantFromHost: true},
+		{name: "subdomain with port and capitals", host: "ACME.ecomflex.com:8080", wantStatus: http.StatusOK, wantTenant: "subdomain", wantFromHost: true},
+		{name: "verified custom domain", host: "shop.acme.com", wantStatus: http.StatusOK, wantTenant: "verified", wantFromHost: true},
+		{name: "unverified custom domain", host: "shop.other.com", wantStatus: http.StatusNotFound},
+		{name: "unknown host", host: "nobody.ecomflex.com", wantStatus: http.StatusNotFound},
+		{name: "inactive tenant", host: "closed.ecomflex.com", wantStatus: http.StatusForbidden},
+		{name: "platform host", host: "api.ecomflex.com", wantStatus: http.StatusOK, wantTenant: "default"},
+		{name: "IP address", host: "127.0.0.1:8080", wantStatus: http.StatusOK, wantTenant: "default"},
+		{name: "tenant header without a token", host: "api.ecomflex.com", tenantHeader: "subdomain", wantStatus: http.StatusForbidden},
+		{name: "tenant header naming the host's tenant", host: "acme.ecomflex.com", tenantHeader: "subdomain", wantStatus: http.StatusOK, wantTenant: "subdomain", wantFromHost: true},
+		{name: "tenant header with a token", host: "api.ecomflex.com", tenantHeader: "subdomain", token: true, wantStatus: http.StatusOK, wantTenant: "default"},
+		{name: "tenant header naming an inactive tenant", host: "api.ecomflex.com", tenantHeader: "inactive", token: true, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tenantID string
+			var fromHost bool
+			router := gin.New()
+			router.GET("/", m.ExtractTenantID(), func(c *gin.Context) {
+				tenantID = c.GetString("tenantID")
+				fromHost = c.GetBool("tenantFromHost")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+			if tt.tenantHeader != "" {
+				req.Header.Set(tenantHeader, tenants[tt.tenantHeader].ID.String())
+			}
+			if tt.token {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if want := tenants[tt.wantTenant].ID.String(); tenantID != want {
+				t.Errorf("tenant = %s, want the %s tenant %s", tenantID, tt.wantTenant, want)
+			}
+			if fromHost != tt.wantFromHost {
+				t.Errorf("tenant from host = %t, want %t", fromHost, tt.wantFromHost)
+			}
+		})
+	}
+}
+
+func TestTenantMiddlewareAuthorizeTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo, tenants := newHostTenants()
+	tenantMiddleware := NewTenantMiddleware(repo, config.TenancyConfig{
+		BaseDomain:    "ecomflex.com",
+		PlatformHosts: []string{"api.ecomflex.com"},
+	}, loggerPkg.NewLogger("error"))
+
+	member := entity.NewUser(tenants["subdomain"].ID, "member@example.com", "hash", "Member", entity.RoleInfluencer, "")
+	outsider := entity.NewUser(tenants["verified"].ID, "outsider@example.com", "hash", "Outsider", entity.RoleSupport, "")
+	superAdmin := entity.NewUser(defaultTenantID, "root@example.com", "hash", "Root", entity.RoleSuperAdmin, "")
+	authService := &tokenAuthService{tokens: map[string]*service.AccessToken{
+		"member":      {User: member, TokenID: "member"},
+		"outsider":    {User: outsider, TokenID: "outsider"},
+		"super-admin": {User: superAdmin, TokenID: "super-admin"},
+	}}
+	authMiddleware := NewAuthMiddleware(authService, nil, false, loggerPkg.NewLogger("error"))
+
+	tests := []struct {
+		name         string
+		host         string
+		token        string
+		tenantHeader string
+		wantStatus   int
+		wantTenant   uuid.UUID
+	}{
+		{name: "member on the tenant's host", host: "acme.ecomflex.com", token: "member", wantStatus: http.StatusOK, wantTenant: member.TenantID},
+		{name: "user of another tenant on the tenant's host", host: "acme.ecomflex.com", token: "outsider", wantStatus: http.StatusForbidden},
+		{name: "user on a platform host", host: "api.ecomflex.com", token: "outsider", wantStatus: http.StatusOK, wantTenant: outsider.TenantID},
+		{name: "super admin naming a tenant", host: "api.ecomflex.com", token: "super-admin", tenantHeader: "subdomain", wantStatus: http.StatusOK, wantTenant: tenants["subdomain"].ID},
+		{name: "user naming another tenant", host: "api.ecomflex.com", token: "outsider", tenantHeader: "subdomain", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tenantID string
+			router := gin.New()
+			router.GET("/", tenantMiddleware.ExtractTenantID(), authMiddleware.Authenticate(), func(c *gin.Context) {
+				tenantID = c.GetString("tenantID")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			if tt.tenantHeader != "" {
+				req.Header.Set(tenantHeader, tenants[tt.tenantHeader].ID.String())
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && tenantID != tt.wantTenant.String() {
+				t.Errorf("tenant = %s, want %s", tenantID, tt.wantTenant)
+			}
+		})
+	}
+}
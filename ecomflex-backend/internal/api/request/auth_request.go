@@ -11,6 +11,9 @@ type RegisterRequest struct {
 	ReferralCode  string   `json:"referral_code" binding:"omitempty"`
 	SocialLinks   []string `json:"social_links" binding:"omitempty"`
 	FollowerCount int      `json:"follower_count" binding:"omitempty"`
+
+	// Token from a referral link click; the attribution cookie is used when empty
+	AttributionToken string `json:"attribution_token" binding:"omitempty"`
 }
 
 // LoginRequest represents a user login request
@@ -29,4 +32,68 @@ type RefreshTokenRequest struct {
 type GoogleLoginRequest struct {
 	Code        string `json:"code" binding:"required"`
 	RedirectURI string `json:"redirect_uri" binding:"required"`
-}
\ No newline at end of file
+	Role        string `json:"role" binding:"omitempty,oneof=public influencer"`
+}
+
+// ConfirmGoogleLinkRequest represents a request to link a Google account to an existing account
+type ConfirmGoogleLinkRequest struct {
+	LinkToken string `json:"link_token" binding:"required"`
+	Password  string `json:"password" binding:"required"`
+}
+
+// PasswordResetRequest represents a request for a password reset link
+type PasswordResetRequest struct {
+	Email        string `json:"email" binding:"required,email"`
+	CaptchaToken string `json:"captcha_token"`
+}
+
+// ConfirmPasswordResetRequest represents a request to choose a new password with a reset token
+type ConfirmPasswordResetRequest struct {
+	Token           string `json:"token" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+	ConfirmPassword string `json:"confirm_password" binding:"required"`
+	CaptchaToken    string `json:"captcha_token"`
+}
+
+// EmailVerificationRequest represents a request for a new email verification link
+type EmailVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ConfirmEmailVerificationRequest represents a request to verify an email address
+type ConfirmEmailVerificationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UnlockAccountRequest represents a request to lift a login lockout with an emailed token
+type UnlockAccountRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// VerifyMFARequest represents a request to complete a login with a second factor
+type VerifyMFARequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode   string `json:"recovery_code" binding:"omitempty"`
+}
+
+// EnrollMFARequest represents a request to set up a required authenticator during login
+type EnrollMFARequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+// MFACodeRequest represents a request confirmed with a code from the user's authenticator
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableMFARequest represents a request to turn off two-factor authentication
+type DisableMFARequest struct {
+	Code         string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code" binding:"omitempty"`
+}
+
+// MFAPolicyRequest represents a request to set a tenant's two-factor authentication policy
+type MFAPolicyRequest struct {
+	RequireSuperAdmin *bool `json:"require_super_admin" binding:"required"`
+}
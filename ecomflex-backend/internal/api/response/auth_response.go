@@ -14,17 +14,33 @@ type TokenResponse struct {
 
 // UserResponse represents a user response
 type UserResponse struct {
-	ID           string    `json:"id"`
-	Email        string    `json:"email"`
-	FullName     string    `json:"full_name"`
-	Role         string    `json:"role"`
-	Status       string    `json:"status"`
-	ReferralCode string    `json:"referral_code,omitempty"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	FullName      string    `json:"full_name"`
+	Role          string    `json:"role"`
+	Status        string    `json:"status"`
+	ReferralCode  string    `json:"referral_code,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // LoginResponse represents a login response
 type LoginResponse struct {
 	Token TokenResponse `json:"token"`
 	User  UserResponse  `json:"user"`
-}
\ No newline at end of file
+}
+
+// MFAChallengeResponse represents a login that awaits a second factor
+type MFAChallengeResponse struct {
+	MFARequired        bool   `json:"mfa_required"`
+	ChallengeToken     string `json:"challenge_token"`
+	EnrollmentRequired bool   `json:"enrollment_required"`
+	ExpiresIn          int64  `json:"expires_in"` // seconds
+}
+
+// MFALoginResponse represents a login completed with a second factor.
+// RecoveryCodes are only shown once, when the login also completed a required enrollment.
+type MFALoginResponse struct {
+	LoginResponse
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
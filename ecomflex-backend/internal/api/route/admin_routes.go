@@ -4,19 +4,22 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
 	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 )
 
-// ConfigureAdminRoutes sets up admin routes
+// ConfigureAdminRoutes sets up influencer management for admins
 func ConfigureAdminRoutes(
 	router *gin.RouterGroup,
 	adminHandler *handler.AdminHandler,
 	authMiddleware *middleware.AuthMiddleware,
 ) {
-	// Admin routes (requires authentication and admin role)
-	router.Use(authMiddleware.RequireRole("admin")) // Use admin role
-	
-	// Influencer management
-	router.GET("/influencers", adminHandler.GetInfluencers)
-	router.GET("/influencers/:id", adminHandler.GetInfluencer)
-	router.PUT("/influencers/:id/status", adminHandler.UpdateInfluencerStatus)
-}
\ No newline at end of file
+	// Admin routes - viewing and approving influencers need separate permissions
+	admin := router.Group("/admin")
+	admin.Use(authMiddleware.Authenticate())
+	canRead := authMiddleware.RequirePermission(entity.PermissionInfluencersRead)
+	{
+		admin.GET("/influencers", canRead, adminHandler.GetInfluencers)
+		admin.GET("/influencers/:id", canRead, adminHandler.GetInfluencer)
+		admin.PUT("/influencers/:id/status", authMiddleware.RequirePermission(entity.PermissionInfluencersApprove), adminHandler.UpdateInfluencerStatus)
+	}
+}
@@ -0,0 +1,24 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureAuditLogRoutes sets up reading the audit log
+func ConfigureAuditLogRoutes(
+	router *gin.RouterGroup,
+	auditLogHandler *handler.AuditLogHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Admin routes
+	admin := router.Group("/admin/audit-logs")
+	admin.Use(authMiddleware.Authenticate())
+	admin.Use(authMiddleware.RequirePermission(entity.PermissionAuditLogsRead))
+	{
+		admin.GET("", auditLogHandler.GetAuditLogs)
+		admin.GET("/export", auditLogHandler.ExportAuditLogs)
+	}
+}
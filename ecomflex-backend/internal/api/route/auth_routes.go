@@ -12,8 +12,16 @@ func ConfigureAuthRoutes(router *gin.RouterGroup, authHandler *handler.AuthHandl
 	router.POST("/register", authHandler.Register)
 	router.POST("/login", authHandler.Login)
 	router.POST("/refresh", authHandler.RefreshToken)
+	router.POST("/mfa/verify", authHandler.VerifyMFA)
+	router.POST("/mfa/enroll", authHandler.EnrollMFA)
 	router.POST("/google/login", authHandler.GoogleLogin)
+	router.POST("/google/link", authHandler.ConfirmGoogleLink)
+	router.POST("/password-reset", authHandler.RequestPasswordReset)
+	router.POST("/password-reset/confirm", authHandler.ConfirmPasswordReset)
+	router.POST("/verify-email", authHandler.RequestEmailVerification)
+	router.POST("/verify-email/confirm", authHandler.ConfirmEmailVerification)
+	router.POST("/unlock-account", authHandler.ConfirmAccountUnlock)
 	
 	// Protected routes
-	router.POST("/logout", authMiddleware.Authenticate(), authHandler.Logout)
+	router.POST("/logout", authMiddleware.Authenticate(), authMiddleware.DenyImpersonation(), authHandler.Logout)
 }
\ No newline at end of file
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureBookingRoutes sets up booking routes for users and admins
+func ConfigureBookingRoutes(
+	router *gin.RouterGroup,
+	bookingHandler *handler.BookingHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// User routes - any authenticated user can book products
+	bookings := router.Group("/bookings")
+	bookings.Use(authMiddleware.Authenticate())
+	{
+		bookings.GET("", bookingHandler.GetUserBookings)
+		bookings.POST("", bookingHandler.CreateBooking)
+		bookings.GET("/:id", bookingHandler.GetBooking)
+		bookings.POST("/:id/proof", bookingHandler.UploadProof)
+		bookings.DELETE("/:id", bookingHandler.CancelBooking)
+	}
+
+	// Admin routes - viewing and reviewing bookings need separate permissions
+	adminBookings := router.Group("/admin/bookings")
+	adminBookings.Use(authMiddleware.Authenticate())
+	canReview := authMiddleware.RequirePermission(entity.PermissionBookingsReview)
+	{
+		adminBookings.GET("", authMiddleware.RequirePermission(entity.PermissionBookingsRead), bookingHandler.GetAllBookings)
+		adminBookings.PATCH("/:id/approve", canReview, bookingHandler.ApproveBooking)
+		adminBookings.PATCH("/:id/reject", canReview, bookingHandler.RejectBooking)
+		adminBookings.PATCH("/:id/cashback", canReview, bookingHandler.UpdateCashback)
+	}
+}
@@ -0,0 +1,27 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureCommissionRoutes sets up commission rule management for admins
+func ConfigureCommissionRoutes(
+	router *gin.RouterGroup,
+	commissionHandler *handler.CommissionHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Admin routes - managing commission rules needs its own permission
+	rules := router.Group("/admin/commission-rules")
+	rules.Use(authMiddleware.Authenticate())
+	rules.Use(authMiddleware.RequirePermission(entity.PermissionCommissionsManage))
+	{
+		rules.GET("", commissionHandler.GetRules)
+		rules.POST("", commissionHandler.CreateRule)
+		rules.GET("/:id", commissionHandler.GetRule)
+		rules.PUT("/:id", commissionHandler.UpdateRule)
+		rules.DELETE("/:id", commissionHandler.DeleteRule)
+	}
+}
@@ -0,0 +1,25 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureEmailTemplateRoutes sets up tenant email template routes
+func ConfigureEmailTemplateRoutes(
+	router *gin.RouterGroup,
+	templateHandler *handler.EmailTemplateHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Admin routes - changing the emails tenants send needs its own permission
+	admin := router.Group("/admin/tenants/:id/email-templates")
+	admin.Use(authMiddleware.Authenticate())
+	admin.Use(authMiddleware.RequirePermission(entity.PermissionEmailTemplatesManage))
+	{
+		admin.GET("", templateHandler.GetEmailTemplates)
+		admin.PUT("/:name", templateHandler.SaveEmailTemplate)
+		admin.DELETE("/:name", templateHandler.DeleteEmailTemplate)
+	}
+}
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureImpersonationRoutes sets up admins acting as other users
+func ConfigureImpersonationRoutes(
+	router *gin.RouterGroup,
+	impersonationHandler *handler.ImpersonationHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Impersonation tokens end their own impersonation when the admin is done
+	current := router.Group("/auth/impersonation")
+	current.Use(authMiddleware.Authenticate())
+	{
+		current.POST("/end", impersonationHandler.EndCurrent)
+	}
+
+	// Admin routes - impersonating needs its own permission and cannot be chained
+	admin := router.Group("/admin")
+	admin.Use(authMiddleware.Authenticate())
+	admin.Use(authMiddleware.DenyImpersonation())
+	admin.Use(authMiddleware.RequirePermission(entity.PermissionUsersImpersonate))
+	{
+		admin.POST("/users/:id/impersonate", impersonationHandler.Start)
+		admin.GET("/users/:id/impersonations", impersonationHandler.GetImpersonations)
+		admin.POST("/impersonations/:id/end", impersonationHandler.End)
+	}
+}
@@ -0,0 +1,15 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+)
+
+// ConfigureJWKSRoutes sets up the public key discovery endpoint
+func ConfigureJWKSRoutes(
+	router *gin.RouterGroup,
+	jwksHandler *handler.JWKSHandler,
+) {
+	// Public route - other services fetch the keys to verify our access tokens
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+}
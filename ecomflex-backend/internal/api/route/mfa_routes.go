@@ -0,0 +1,37 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureMFARoutes sets up two-factor authentication routes
+func ConfigureMFARoutes(
+	router *gin.RouterGroup,
+	mfaHandler *handler.MFAHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// User routes - any authenticated user can protect their account with an authenticator
+	mfa := router.Group("/users/me/mfa")
+	mfa.Use(authMiddleware.Authenticate())
+	canChange := authMiddleware.DenyImpersonation()
+	{
+		mfa.GET("", mfaHandler.GetStatus)
+		mfa.POST("/enroll", canChange, mfaHandler.Enroll)
+		mfa.POST("/enable", canChange, mfaHandler.Enable)
+		mfa.POST("/disable", canChange, mfaHandler.Disable)
+		mfa.POST("/recovery-codes", canChange, mfaHandler.RegenerateRecoveryCodes)
+	}
+
+	// Admin routes - requiring MFA for a tenant needs its own permission
+	admin := router.Group("/admin/tenants/:id/mfa-policy")
+	admin.Use(authMiddleware.Authenticate())
+	admin.Use(authMiddleware.DenyImpersonation())
+	admin.Use(authMiddleware.RequirePermission(entity.PermissionMFAPolicyManage))
+	{
+		admin.GET("", mfaHandler.GetTenantPolicy)
+		admin.PUT("", mfaHandler.UpdateTenantPolicy)
+	}
+}
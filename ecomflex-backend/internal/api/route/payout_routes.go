@@ -0,0 +1,47 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigurePayoutWebhookRoutes sets up the route payout providers push transfer updates to
+func ConfigurePayoutWebhookRoutes(router *gin.RouterGroup, payoutHandler *handler.PayoutHandler) {
+	// Public route - the payout provider authenticates webhooks with a signature
+	router.POST("/webhooks/payouts", payoutHandler.HandleWebhook)
+}
+
+// ConfigurePayoutRoutes sets up earnings balance and payout routes
+func ConfigurePayoutRoutes(
+	router *gin.RouterGroup,
+	payoutHandler *handler.PayoutHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Influencer routes - earnings and payout history of the current influencer
+	influencer := router.Group("/influencer")
+	influencer.Use(authMiddleware.Authenticate())
+	influencer.Use(authMiddleware.RequirePermission(entity.PermissionInfluencerDashboard))
+	{
+		influencer.GET("/balance", payoutHandler.GetBalance)
+		influencer.GET("/ledger", payoutHandler.GetLedger)
+		influencer.GET("/payouts", payoutHandler.GetMyPayouts)
+	}
+
+	// Admin routes - viewing and running payouts need separate permissions
+	admin := router.Group("/admin")
+	admin.Use(authMiddleware.Authenticate())
+	admin.Use(authMiddleware.DenyImpersonation())
+	canRead := authMiddleware.RequirePermission(entity.PermissionPayoutsRead)
+	canRun := authMiddleware.RequirePermission(entity.PermissionPayoutsRun)
+	{
+		admin.POST("/payout-runs", canRun, payoutHandler.RunPayouts)
+		admin.GET("/payout-runs", canRead, payoutHandler.GetRuns)
+		admin.GET("/payouts", canRead, payoutHandler.GetPayouts)
+		admin.POST("/payouts/dispatch", canRun, payoutHandler.DispatchPayouts)
+		admin.POST("/payouts/sync", canRun, payoutHandler.SyncPayouts)
+		admin.GET("/payouts/:id", canRead, payoutHandler.GetPayout)
+		admin.PATCH("/payouts/:id/status", canRun, payoutHandler.UpdatePayoutStatus)
+	}
+}
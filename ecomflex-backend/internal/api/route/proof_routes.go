@@ -0,0 +1,29 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureProofRoutes sets up the proof moderation queue for admins
+func ConfigureProofRoutes(
+	router *gin.RouterGroup,
+	proofHandler *handler.ProofHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Admin routes - viewing and moderating proofs need separate permissions
+	adminProofs := router.Group("/admin/proofs")
+	adminProofs.Use(authMiddleware.Authenticate())
+	canRead := authMiddleware.RequirePermission(entity.PermissionProofsRead)
+	canReview := authMiddleware.RequirePermission(entity.PermissionProofsReview)
+	{
+		adminProofs.GET("", canRead, proofHandler.GetProofQueue)
+		adminProofs.GET("/:id", canRead, proofHandler.GetProof)
+		adminProofs.GET("/:id/history", canRead, proofHandler.GetProofHistory)
+		adminProofs.POST("/:id/claim", canReview, proofHandler.ClaimProof)
+		adminProofs.PATCH("/:id/approve", canReview, proofHandler.ApproveProof)
+		adminProofs.PATCH("/:id/reject", canReview, proofHandler.RejectProof)
+	}
+}
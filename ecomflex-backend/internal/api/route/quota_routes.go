@@ -0,0 +1,34 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureQuotaRoutes sets up the plans catalogue and tenants' usage
+func ConfigureQuotaRoutes(
+	router *gin.RouterGroup,
+	quotaHandler *handler.QuotaHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Public routes - plans are shown on the pricing page
+	router.GET("/plans", quotaHandler.GetPlans)
+
+	// Admin routes - the usage of the tenant the request acts in
+	usage := router.Group("/admin/usage")
+	usage.Use(authMiddleware.Authenticate())
+	usage.Use(authMiddleware.RequirePermission(entity.PermissionUsageRead))
+	{
+		usage.GET("", quotaHandler.GetUsage)
+	}
+
+	// Super admin routes - the usage of any tenant
+	tenants := router.Group("/admin/tenants/:id/usage")
+	tenants.Use(authMiddleware.Authenticate())
+	tenants.Use(authMiddleware.RequirePermission(entity.PermissionTenantsManage))
+	{
+		tenants.GET("", quotaHandler.GetTenantUsage)
+	}
+}
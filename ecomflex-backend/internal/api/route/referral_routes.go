@@ -0,0 +1,34 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureReferralLinkRoutes sets up the public referral link redirect
+func ConfigureReferralLinkRoutes(
+	router *gin.RouterGroup,
+	referralHandler *handler.ReferralHandler,
+) {
+	// Public route - referral links are shared as <base>/ref/<code>
+	router.GET("/ref/:code", referralHandler.TrackClick)
+}
+
+// ConfigureReferralRoutes sets up referral moderation for admins
+func ConfigureReferralRoutes(
+	router *gin.RouterGroup,
+	referralHandler *handler.ReferralHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Admin routes - viewing and approving referrals need separate permissions
+	adminReferrals := router.Group("/admin/referrals")
+	adminReferrals.Use(authMiddleware.Authenticate())
+	canReview := authMiddleware.RequirePermission(entity.PermissionReferralsReview)
+	{
+		adminReferrals.GET("", authMiddleware.RequirePermission(entity.PermissionReferralsRead), referralHandler.GetReferrals)
+		adminReferrals.PATCH("/:id/approve", canReview, referralHandler.ApproveReferral)
+		adminReferrals.PATCH("/:id/reject", canReview, referralHandler.RejectReferral)
+	}
+}
@@ -0,0 +1,35 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureRoleRoutes sets up tenants' custom roles and role assignment
+func ConfigureRoleRoutes(
+	router *gin.RouterGroup,
+	roleHandler *handler.RoleHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Admin routes - managing roles needs its own permission
+	roles := router.Group("/admin/tenants/:id/roles")
+	roles.Use(authMiddleware.Authenticate())
+	roles.Use(authMiddleware.DenyImpersonation())
+	roles.Use(authMiddleware.RequirePermission(entity.PermissionRolesManage))
+	{
+		roles.GET("", roleHandler.GetRoles)
+		roles.POST("", roleHandler.CreateRole)
+		roles.PUT("/:role_id", roleHandler.UpdateRole)
+		roles.DELETE("/:role_id", roleHandler.DeleteRole)
+	}
+
+	assign := router.Group("/admin/users/:id/role")
+	assign.Use(authMiddleware.Authenticate())
+	assign.Use(authMiddleware.DenyImpersonation())
+	assign.Use(authMiddleware.RequirePermission(entity.PermissionRolesManage))
+	{
+		assign.PUT("", roleHandler.AssignRole)
+	}
+}
@@ -1,20 +1,43 @@
 package route
 
 import (
+	"context"
+	netmail "net/mail"
+	"os"
+	"sync"
+
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	"github.com/naresh6454/ecomflex-backend/config"
 	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
 	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
 	"github.com/naresh6454/ecomflex-backend/internal/app/service"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
 	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/auth"
 	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/cache"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/captcha"
+	infraConfig "github.com/naresh6454/ecomflex-backend/internal/infrastructure/config"
 	dbRepo "github.com/naresh6454/ecomflex-backend/internal/infrastructure/database/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/mail"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/notify"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/oauth"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/payout"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/storage"
 	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
 )
 
-// SetupRouter sets up the router with all routes and middleware
-func SetupRouter(cfg *config.Config, logger loggerPkg.Logger, db *sqlx.DB, redisClient *cache.RedisClient) *gin.Engine {
+// SetupRouter sets up the router with all routes and middleware.
+// Background workers run until ctx is done; workers lets the caller wait for those that
+// still have queued work to finish.
+func SetupRouter(
+	ctx context.Context,
+	workers *sync.WaitGroup,
+	cfg *config.Config,
+	logger loggerPkg.Logger,
+	db *sqlx.DB,
+	redisClient *cache.RedisClient,
+	productRepo repository.ProductRepository,
+) *gin.Engine {
 	// Create router
 	router := gin.New()
 	
@@ -27,33 +50,147 @@ func SetupRouter(cfg *config.Config, logger loggerPkg.Logger, db *sqlx.DB, redis
 	router.Use(gin.Recovery())
 	router.Use(middleware.CorsMiddleware(&cfg.Cors))
 	router.Use(middleware.RequestLogger(logger))
+	router.Use(middleware.ClientInfo())
 	
 	// Create repositories
 	userRepo := dbRepo.NewPostgresUserRepository(db)
 	authRepo := dbRepo.NewRedisAuthRepository(redisClient.Client)
-	tenantRepo := dbRepo.NewTenantRepository(db) // Add tenant repository
+	// Every request looks up its tenant, so tenants are cached in Redis
+	tenantRepo := dbRepo.NewCachedTenantRepository(dbRepo.NewTenantRepository(db), redisClient.Client, cfg.Tenancy.CacheTTL, cfg.Tenancy.NotFoundCacheTTL)
 	
 	// Create a referral repository for influencer functionality
 	referralRepo := dbRepo.NewPostgresReferralRepository(db)
+	bookingRepo := dbRepo.NewPostgresBookingRepository(db)
+	proofRepo := dbRepo.NewPostgresProofRepository(db)
+	commissionRuleRepo := dbRepo.NewPostgresCommissionRuleRepository(db)
+	ledgerRepo := dbRepo.NewPostgresLedgerRepository(db)
+	payoutRepo := dbRepo.NewPostgresPayoutRepository(db)
+	identityRepo := dbRepo.NewPostgresIdentityRepository(db)
+	securityEventRepo := dbRepo.NewPostgresSecurityEventRepository(db)
+	emailTemplateRepo := dbRepo.NewPostgresEmailTemplateRepository(db)
+	mfaRepo := dbRepo.NewPostgresMFARepository(db)
+	tenantRoleRepo := dbRepo.NewPostgresTenantRoleRepository(db)
+	impersonationRepo := dbRepo.NewPostgresImpersonationRepository(db)
+	auditLogRepo := dbRepo.NewPostgresAuditLogRepository(db)
+	planRepo := dbRepo.NewPostgresPlanRepository(db)
+	
+	// File storage is optional in development; without it proofs are submitted as URLs
+	var storageService service.StorageService
+	if os.Getenv("AWS_S3_BUCKET") != "" {
+		storageService = service.NewStorageService(storage.NewS3Client(infraConfig.NewS3Config()))
+	}
+	
+	// Payouts are sent through the configured provider; the fake one keeps transfers local.
+	// Without a provider, payouts are disabled and earnings stay in their balances.
+	payoutProvider, err := payout.NewProvider(cfg.Payout)
+	if err != nil {
+		logger.Fatal("Failed to create payout provider", err)
+	}
+	
+	// Emails are queued and delivered in the background through the configured transport
+	mailTransport, err := mail.NewTransport(cfg.Mail)
+	if err != nil {
+		logger.Fatal("Failed to create mail transport", err)
+	}
+	mailQueue := mail.NewQueue(mailTransport, cfg.Mail.QueueSize, cfg.Mail.MaxAttempts, cfg.Mail.RetryBackoff, logger)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		mailQueue.Run(ctx, cfg.Mail.Workers)
+	}()
+	
+	mailer, err := mail.NewMailer(emailTemplateRepo, tenantRepo, mailQueue, netmail.Address{Name: cfg.Mail.FromName, Address: cfg.Mail.From}, cfg.Auth.AppURL, logger)
+	if err != nil {
+		logger.Fatal("Failed to create mailer", err)
+	}
 	
 	// Create JWT provider
-	jwtProvider := auth.NewJWTProvider(&cfg.JWT)
+	jwtProvider, err := auth.NewJWTProvider(&cfg.JWT)
+	if err != nil {
+		logger.Fatal("Failed to create JWT provider", err)
+	}
+	
+	// Register, login and password reset requests are checked with the configured captcha provider
+	captchaVerifier, err := captcha.NewVerifier(cfg.Captcha)
+	if err != nil {
+		logger.Fatal("Failed to create captcha verifier", err)
+	}
+	
+	// Changes are written to the audit log in the background so they never hold up requests
+	auditLogger := service.NewAuditLogger(auditLogRepo, logger)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		auditLogger.Run(ctx)
+	}()
 	
 	// Create services
-	authService := service.NewAuthService(userRepo, authRepo, tenantRepo, jwtProvider, cfg.OAuth, cfg.Captcha)
-	userService := service.NewUserService(userRepo, logger)
+	mfaService := service.NewMFAService(mfaRepo, userRepo, tenantRepo, cfg.Mail.FromName)
+	quotaService := service.NewQuotaService(planRepo, tenantRepo)
+	tenantService := service.NewTenantService(tenantRepo, planRepo, quotaService, auditLogger, cfg.Tenancy)
+	authService := service.NewAuthService(userRepo, authRepo, tenantRepo, tenantService, identityRepo, securityEventRepo, impersonationRepo, auditLogger, jwtProvider, oauth.NewGoogleProvider(cfg.OAuth), mfaService, notify.NewMailNotifier(mailer, cfg.Auth.AppURL), cfg.Auth, captchaVerifier, cfg.Throttle, logger)
+	userService := service.NewUserService(userRepo, mailer, auditLogger, quotaService, logger)
 	
 	// Create influencer service for the dashboard
 	influencerService := service.NewInfluencerService(userRepo, referralRepo, logger, "https://ecomflex.com")
+	commissionService := service.NewCommissionService(commissionRuleRepo, referralRepo, productRepo, tenantRepo, logger)
+	referralService := service.NewReferralService(referralRepo, userRepo, productRepo, tenantRepo, commissionService, logger, cfg.Referral.IPHashSalt)
+	bookingService := service.NewBookingService(bookingRepo, productRepo, proofRepo, userRepo, tenantRepo, referralService, mailer, auditLogger, quotaService, logger, cfg.Booking.ReservationTTL, cfg.Booking.ProofClaimTTL, cfg.Payout.Currency)
+	proofService := service.NewProofService(proofRepo, bookingService, logger, cfg.Booking.ProofClaimTTL)
+	payoutService := service.NewPayoutService(payoutRepo, ledgerRepo, userRepo, payoutProvider, logger, cfg.Payout.Threshold, cfg.Payout.Currency)
+	walletService := service.NewWalletService(bookingRepo, ledgerRepo, payoutRepo, cfg.Payout.Currency)
+	emailTemplateService := service.NewEmailTemplateService(emailTemplateRepo, tenantRepo)
+	sessionService := service.NewSessionService(authRepo, userRepo)
+	productService := service.NewProductService(productRepo, tenantRepo, auditLogger, quotaService)
+	roleService := service.NewRoleService(tenantRoleRepo, userRepo, auditLogger, quotaService)
+	impersonationService := service.NewImpersonationService(impersonationRepo, userRepo, authRepo, securityEventRepo, roleService, jwtProvider, cfg.JWT.ImpersonationTokenExp, logger)
+	
+	// Release slots held by bookings that never received a proof
+	if cfg.Booking.ExpiryInterval > 0 {
+		go service.StartBookingExpiry(ctx, bookingService, cfg.Booking.ExpiryInterval, logger)
+	}
+	
+	// Pay out earnings above the threshold and settle transfers on a schedule
+	if payoutProvider != nil && cfg.Payout.RunInterval > 0 {
+		go service.StartPayoutRuns(ctx, payoutService, cfg.Payout.RunInterval, logger)
+	}
 	
 	// Create handlers
-	authHandler := handler.NewAuthHandler(authService, logger)
+	authHandler := handler.NewAuthHandler(authService, referralService, logger)
 	adminHandler := handler.NewAdminHandler(userService, logger)
 	influencerHandler := handler.NewInfluencerHandler(influencerService, logger)
+	bookingHandler := handler.NewBookingHandler(bookingService, referralService, storageService, logger)
+	proofHandler := handler.NewProofHandler(proofService, logger)
+	referralHandler := handler.NewReferralHandler(referralService, logger, cfg.Referral.LandingURL)
+	commissionHandler := handler.NewCommissionHandler(commissionService, logger)
+	payoutHandler := handler.NewPayoutHandler(payoutService, logger)
+	walletHandler := handler.NewWalletHandler(walletService, logger)
+	emailTemplateHandler := handler.NewEmailTemplateHandler(emailTemplateService, logger)
+	sessionHandler := handler.NewSessionHandler(sessionService, logger)
+	jwksHandler := handler.NewJWKSHandler(jwtProvider)
+	mfaHandler := handler.NewMFAHandler(mfaService, logger)
+	productHandler := handler.NewProductHandler(productService)
+	roleHandler := handler.NewRoleHandler(roleService, logger)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationService, logger)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogger, quotaService, logger)
+	quotaHandler := handler.NewQuotaHandler(quotaService, logger)
+	tenantHandler := handler.NewTenantHandler(tenantService, logger)
+	
+	// File uploads are only available when storage is configured
+	var fileHandler *handler.FileHandler
+	if storageService != nil {
+		fileHandler = handler.NewFileHandler(storageService, bookingService, quotaService)
+	}
 	
 	// Create middleware
-	authMiddleware := middleware.NewAuthMiddleware(authService, logger)
-	tenantMiddleware := middleware.NewTenantMiddleware(logger)
+	authMiddleware := middleware.NewAuthMiddleware(authService, roleService, cfg.DevMode, logger)
+	tenantMiddleware := middleware.NewTenantMiddleware(tenantRepo, cfg.Tenancy, logger)
+	
+	// Referral links are served from the site root
+	ConfigureReferralLinkRoutes(&router.RouterGroup, referralHandler)
+	
+	// Access token keys are published at the standard location
+	ConfigureJWKSRoutes(&router.RouterGroup, jwksHandler)
 	
 	// API routes
 	api := router.Group("/api")
@@ -69,42 +206,47 @@ func SetupRouter(cfg *config.Config, logger loggerPkg.Logger, db *sqlx.DB, redis
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
 		auth.POST("/google/login", authHandler.GoogleLogin)
+		auth.POST("/google/link", authHandler.ConfirmGoogleLink)
+		auth.POST("/password-reset", authHandler.RequestPasswordReset)
+		auth.POST("/password-reset/confirm", authHandler.ConfirmPasswordReset)
+		auth.POST("/verify-email", authHandler.RequestEmailVerification)
+		auth.POST("/verify-email/confirm", authHandler.ConfirmEmailVerification)
+		auth.POST("/unlock-account", authHandler.ConfirmAccountUnlock)
 		auth.POST("/refresh", authHandler.RefreshToken)
+		auth.POST("/mfa/verify", authHandler.VerifyMFA)
+		auth.POST("/mfa/enroll", authHandler.EnrollMFA)
 		
 		// Authenticated auth routes
 		authProtected := auth.Group("/")
 		authProtected.Use(authMiddleware.Authenticate())
-		authProtected.POST("/logout", authHandler.Logout)
-		
-		// Influencer routes - this is our new addition
-		influencer := v1.Group("/influencer")
-		influencer.Use(authMiddleware.Authenticate())
-		influencer.Use(authMiddleware.RequireRole("influencer"))
-		
-		// Dashboard data
-		influencer.GET("/dashboard", influencerHandler.GetDashboardData)
-		
-		// Referral code
-		influencer.GET("/referral-code", influencerHandler.GetReferralCode)
-		
-		// Referral statistics
-		influencer.GET("/stats", influencerHandler.GetReferralStats)
+		authProtected.POST("/logout", authMiddleware.DenyImpersonation(), authHandler.Logout)
 		
-		// Recent referrals
-		influencer.GET("/recent-referrals", influencerHandler.GetRecentReferrals)
+		// Influencer dashboard and influencer management
+		ConfigureInfluencerRoutes(v1, influencerHandler, authMiddleware)
+		ConfigureAdminRoutes(v1, adminHandler, authMiddleware)
 		
-		// All referrals (with pagination)
-		influencer.GET("/referrals", influencerHandler.GetRecentReferrals)
-		
-		// Admin routes
-		admin := v1.Group("/admin")
-		admin.Use(authMiddleware.Authenticate())
-		admin.Use(authMiddleware.RequireRole("admin"))
-		
-		// Influencer management
-		admin.GET("/influencers", adminHandler.GetInfluencers)
-		admin.GET("/influencers/:id", adminHandler.GetInfluencer)
-		admin.PUT("/influencers/:id/status", adminHandler.UpdateInfluencerStatus)
+		// Booking routes
+		ConfigureBookingRoutes(v1, bookingHandler, authMiddleware)
+		ConfigureProofRoutes(v1, proofHandler, authMiddleware)
+		ConfigureReferralRoutes(v1, referralHandler, authMiddleware)
+		ConfigureCommissionRoutes(v1, commissionHandler, authMiddleware)
+		ConfigurePayoutRoutes(v1, payoutHandler, authMiddleware)
+		if payoutProvider != nil {
+			ConfigurePayoutWebhookRoutes(v1, payoutHandler)
+		}
+		ConfigureWalletRoutes(v1, walletHandler, authMiddleware)
+		ConfigureEmailTemplateRoutes(v1, emailTemplateHandler, authMiddleware)
+		ConfigureSessionRoutes(v1, sessionHandler, authMiddleware)
+		ConfigureMFARoutes(v1, mfaHandler, authMiddleware)
+		ConfigureRoleRoutes(v1, roleHandler, authMiddleware)
+		ConfigureImpersonationRoutes(v1, impersonationHandler, authMiddleware)
+		ConfigureAuditLogRoutes(v1, auditLogHandler, authMiddleware)
+		ConfigureTenantRoutes(v1, tenantHandler, authMiddleware)
+		ConfigureQuotaRoutes(v1, quotaHandler, authMiddleware)
+		ConfigureProductRoutes(v1, productHandler, authMiddleware)
+		if fileHandler != nil {
+			ConfigureFileRoutes(v1, fileHandler, authMiddleware)
+		}
 	}
 	
 	// Setup non-versioned routes for backward compatibility
@@ -114,42 +256,47 @@ func SetupRouter(cfg *config.Config, logger loggerPkg.Logger, db *sqlx.DB, redis
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
 		auth.POST("/google/login", authHandler.GoogleLogin)
+		auth.POST("/google/link", authHandler.ConfirmGoogleLink)
+		auth.POST("/password-reset", authHandler.RequestPasswordReset)
+		auth.POST("/password-reset/confirm", authHandler.ConfirmPasswordReset)
+		auth.POST("/verify-email", authHandler.RequestEmailVerification)
+		auth.POST("/verify-email/confirm", authHandler.ConfirmEmailVerification)
+		auth.POST("/unlock-account", authHandler.ConfirmAccountUnlock)
 		auth.POST("/refresh", authHandler.RefreshToken)
+		auth.POST("/mfa/verify", authHandler.VerifyMFA)
+		auth.POST("/mfa/enroll", authHandler.EnrollMFA)
 		
 		// Authenticated auth routes
 		authProtected := auth.Group("/")
 		authProtected.Use(authMiddleware.Authenticate())
-		authProtected.POST("/logout", authHandler.Logout)
-		
-		// Influencer routes - this is our new addition
-		influencer := api.Group("/influencer")
-		influencer.Use(authMiddleware.Authenticate())
-		influencer.Use(authMiddleware.RequireRole("influencer"))
-		
-		// Dashboard data
-		influencer.GET("/dashboard", influencerHandler.GetDashboardData)
-		
-		// Referral code
-		influencer.GET("/referral-code", influencerHandler.GetReferralCode)
-		
-		// Referral statistics
-		influencer.GET("/stats", influencerHandler.GetReferralStats)
-		
-		// Recent referrals
-		influencer.GET("/recent-referrals", influencerHandler.GetRecentReferrals)
-		
-		// All referrals (with pagination)
-		influencer.GET("/referrals", influencerHandler.GetRecentReferrals)
+		authProtected.POST("/logout", authMiddleware.DenyImpersonation(), authHandler.Logout)
 		
-		// Admin routes
-		admin := api.Group("/admin")
-		admin.Use(authMiddleware.Authenticate())
-		admin.Use(authMiddleware.RequireRole("admin"))
+		// Influencer dashboard and influencer management
+		ConfigureInfluencerRoutes(api, influencerHandler, authMiddleware)
+		ConfigureAdminRoutes(api, adminHandler, authMiddleware)
 		
-		// Influencer management
-		admin.GET("/influencers", adminHandler.GetInfluencers)
-		admin.GET("/influencers/:id", adminHandler.GetInfluencer)
-		admin.PUT("/influencers/:id/status", adminHandler.UpdateInfluencerStatus)
+		// Booking routes
+		ConfigureBookingRoutes(api, bookingHandler, authMiddleware)
+		ConfigureProofRoutes(api, proofHandler, authMiddleware)
+		ConfigureReferralRoutes(api, referralHandler, authMiddleware)
+		ConfigureCommissionRoutes(api, commissionHandler, authMiddleware)
+		ConfigurePayoutRoutes(api, payoutHandler, authMiddleware)
+		if payoutProvider != nil {
+			ConfigurePayoutWebhookRoutes(api, payoutHandler)
+		}
+		ConfigureWalletRoutes(api, walletHandler, authMiddleware)
+		ConfigureEmailTemplateRoutes(api, emailTemplateHandler, authMiddleware)
+		ConfigureSessionRoutes(api, sessionHandler, authMiddleware)
+		ConfigureMFARoutes(api, mfaHandler, authMiddleware)
+		ConfigureRoleRoutes(api, roleHandler, authMiddleware)
+		ConfigureImpersonationRoutes(api, impersonationHandler, authMiddleware)
+		ConfigureAuditLogRoutes(api, auditLogHandler, authMiddleware)
+		ConfigureTenantRoutes(api, tenantHandler, authMiddleware)
+		ConfigureQuotaRoutes(api, quotaHandler, authMiddleware)
+		ConfigureProductRoutes(api, productHandler, authMiddleware)
+		if fileHandler != nil {
+			ConfigureFileRoutes(api, fileHandler, authMiddleware)
+		}
 	}
 	
 	return router
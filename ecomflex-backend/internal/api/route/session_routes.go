@@ -0,0 +1,34 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureSessionRoutes sets up routes for the devices users are signed in on
+func ConfigureSessionRoutes(
+	router *gin.RouterGroup,
+	sessionHandler *handler.SessionHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// User routes - any authenticated user manages their own sessions
+	sessions := router.Group("/users/me/sessions")
+	sessions.Use(authMiddleware.Authenticate())
+	{
+		sessions.GET("", sessionHandler.GetMySessions)
+		sessions.DELETE("/:id", authMiddleware.DenyImpersonation(), sessionHandler.RevokeMySession)
+	}
+
+	// Admin routes - viewing other users' sessions and signing them out need separate permissions
+	admin := router.Group("/admin/users/:id/sessions")
+	admin.Use(authMiddleware.Authenticate())
+	admin.Use(authMiddleware.DenyImpersonation())
+	canRevoke := authMiddleware.RequirePermission(entity.PermissionSessionsRevoke)
+	{
+		admin.GET("", authMiddleware.RequirePermission(entity.PermissionSessionsRead), sessionHandler.GetUserSessions)
+		admin.DELETE("", canRevoke, sessionHandler.RevokeUserSessions)
+		admin.DELETE("/:session_id", canRevoke, sessionHandler.RevokeUserSession)
+	}
+}
@@ -0,0 +1,41 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ConfigureTenantRoutes sets up administering tenants and their settings
+func ConfigureTenantRoutes(
+	router *gin.RouterGroup,
+	tenantHandler *handler.TenantHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// Admin routes - only super admins manage tenants
+	tenants := router.Group("/admin/tenants")
+	tenants.Use(authMiddleware.Authenticate())
+	tenants.Use(authMiddleware.DenyImpersonation())
+	tenants.Use(authMiddleware.RequirePermission(entity.PermissionTenantsManage))
+	{
+		tenants.GET("", tenantHandler.GetTenants)
+		tenants.POST("", tenantHandler.CreateTenant)
+		tenants.GET("/:id", tenantHandler.GetTenant)
+		tenants.PUT("/:id", tenantHandler.UpdateTenant)
+		tenants.DELETE("/:id", tenantHandler.DeleteTenant)
+		tenants.POST("/:id/suspend", tenantHandler.SuspendTenant)
+		tenants.POST("/:id/activate", tenantHandler.ActivateTenant)
+		tenants.POST("/:id/verify-domain", tenantHandler.VerifyTenantDomain)
+	}
+
+	// Admin routes - the settings of the tenant the request acts in
+	settings := router.Group("/admin/settings")
+	settings.Use(authMiddleware.Authenticate())
+	settings.Use(authMiddleware.RequirePermission(entity.PermissionSettingsManage))
+	{
+		settings.GET("", tenantHandler.GetSettings)
+		settings.PATCH("", tenantHandler.UpdateSettings)
+		settings.GET("/schema", tenantHandler.GetSettingsSchema)
+	}
+}
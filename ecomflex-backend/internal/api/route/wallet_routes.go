@@ -0,0 +1,25 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/naresh6454/ecomflex-backend/internal/api/handler"
+	"github.com/naresh6454/ecomflex-backend/internal/api/middleware"
+)
+
+// ConfigureWalletRoutes sets up cashback wallet routes for the current user
+func ConfigureWalletRoutes(
+	router *gin.RouterGroup,
+	walletHandler *handler.WalletHandler,
+	authMiddleware *middleware.AuthMiddleware,
+) {
+	// User routes - any authenticated user earns cashback on their bookings
+	wallet := router.Group("/users/me/wallet")
+	wallet.Use(authMiddleware.Authenticate())
+	{
+		wallet.GET("", walletHandler.GetWallet)
+		wallet.GET("/transactions", walletHandler.GetTransactions)
+		wallet.GET("/payouts", walletHandler.GetPayouts)
+		wallet.GET("/payout-method", walletHandler.GetPayoutMethod)
+		wallet.PUT("/payout-method", authMiddleware.DenyImpersonation(), walletHandler.SavePayoutMethod)
+	}
+}
@@ -187,8 +187,9 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// Get token ID from context (set by middleware)
+	// Get token and session IDs from context (set by middleware)
 	tokenID := c.GetString("tokenID")
+	sessionID := c.GetString("sessionID")
 	
 	// Get user ID from context
 	userIDStr := c.GetString("userID")
@@ -200,7 +201,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	}
 	
 	// Logout user
-	err = h.authService.Logout(c.Request.Context(), userID, tokenID)
+	err = h.authService.Logout(c.Request.Context(), userID, tokenID, sessionID)
 	if err != nil {
 		h.logger.Error("Failed to logout user", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to logout", err)
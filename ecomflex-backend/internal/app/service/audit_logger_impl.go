@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+const (
+	// auditQueueSize is how many entries may wait to be written before new ones are dropped
+	auditQueueSize = 1000
+
+	// auditBatchSize is the most entries written in one statement
+	auditBatchSize = 100
+
+	// auditWriteTimeout bounds how long writing one batch may take
+	auditWriteTimeout = 10 * time.Second
+)
+
+// errAuditQueueFull is logged when an entry is dropped because the writer cannot keep up
+var errAuditQueueFull = errors.New("audit log queue is full")
+
+// AuditLoggerImpl implements AuditLogger interface
+type AuditLoggerImpl struct {
+	auditLogRepo repository.AuditLogRepository
+	logger       loggerPkg.Logger
+	queue        chan *entity.AuditLog
+}
+
+// NewAuditLogger creates a new AuditLoggerImpl. Nothing is written until Run is started.
+func NewAuditLogger(auditLogRepo repository.AuditLogRepository, logger loggerPkg.Logger) service.AuditLogger {
+	return &AuditLoggerImpl{
+		auditLogRepo: auditLogRepo,
+		logger:       logger,
+		queue:        make(chan *entity.AuditLog, auditQueueSize),
+	}
+}
+
+// Record queues a change for the audit log. Snapshots are taken right away so later changes
+// to the entities do not leak into the entry. Entries are dropped rather than block when the queue is full.
+func (s *AuditLoggerImpl) Record(ctx context.Context, entry service.AuditEntry) {
+	before, err := entity.NewAuditSnapshot(entry.Before)
+	if err != nil {
+		s.logger.Error("Failed to record audit log", err, "action", entry.Action)
+		return
+	}
+	after, err := entity.NewAuditSnapshot(entry.After)
+	if err != nil {
+		s.logger.Error("Failed to record audit log", err, "action", entry.Action)
+		return
+	}
+
+	var entityID *uuid.UUID
+	if entry.EntityID != uuid.Nil {
+		id := entry.EntityID
+		entityID = &id
+	}
+
+	log := entity.NewAuditLog(entry.TenantID, entry.Action, entry.EntityType, entityID, before, after)
+
+	if actor, ok := service.ActorFromContext(ctx); ok {
+		userID := actor.UserID
+		log.UserID = &userID
+		log.ImpersonatorID = actor.ImpersonatorID
+	}
+	if entry.UserID != nil {
+		log.UserID = entry.UserID
+	}
+
+	client := service.ClientInfoFromContext(ctx)
+	log.SetClient(client.IPAddress, client.UserAgent)
+
+	select {
+	case s.queue <- log:
+	default:
+		s.logger.Error("Dropped audit log", errAuditQueueFull, "action", log.Action, "entity_id", entityID)
+	}
+}
+
+// List lists a tenant's audit log entries matching a filter, newest first
+func (s *AuditLoggerImpl) List(ctx context.Context, filter repository.AuditLogFilter) ([]*entity.AuditLog, int, error) {
+	return s.auditLogRepo.ListAuditLogs(ctx, filter)
+}
+
+// Run writes queued entries in batches until ctx is done, then writes what is left
+func (s *AuditLoggerImpl) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.flush()
+			return
+		case log := <-s.queue:
+			s.write(s.batch(log))
+		}
+	}
+}
+
+// batch collects the entries already waiting behind first, up to auditBatchSize
+func (s *AuditLoggerImpl) batch(first *entity.AuditLog) []*entity.AuditLog {
+	logs := []*entity.AuditLog{first}
+	for len(logs) < auditBatchSize {
+		select {
+		case log := <-s.queue:
+			logs = append(logs, log)
+		default:
+			return logs
+		}
+	}
+	return logs
+}
+
+// flush writes the entries still queued on shutdown
+func (s *AuditLoggerImpl) flush() {
+	for {
+		select {
+		case log := <-s.queue:
+			s.write(s.batch(log))
+		default:
+			return
+		}
+	}
+}
+
+// write stores a batch of entries; a failed batch is logged and dropped
+func (s *AuditLoggerImpl) write(logs []*entity.AuditLog) {
+	ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+	defer cancel()
+
+	if err := s.auditLogRepo.CreateAuditLogs(ctx, logs); err != nil {
+		s.logger.Error("Failed to write audit logs", err, "count", len(logs))
+	}
+}
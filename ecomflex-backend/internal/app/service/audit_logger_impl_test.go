@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// memoryAuditLogRepo keeps the entries written to it
+type memoryAuditLogRepo struct {
+	repository.AuditLogRepository
+	mu   sync.Mutex
+	logs []*entity.AuditLog
+}
+
+func (r *memoryAuditLogRepo) CreateAuditLogs(ctx context.Context, logs []*entity.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, logs...)
+	return nil
+}
+
+// runAuditLogger records entries and writes them by stopping the writer
+func runAuditLogger(t *testing.T, record func(auditLogger service.AuditLogger)) []*entity.AuditLog {
+	t.Helper()
+
+	repo := &memoryAuditLogRepo{}
+	auditLogger := NewAuditLogger(repo, loggerPkg.NewLogger("error"))
+	record(auditLogger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	auditLogger.Run(ctx)
+
+	return repo.logs
+}
+
+func TestAuditLoggerRecord(t *testing.T) {
+	tenantID := uuid.New()
+	actorID := uuid.New()
+	impersonatorID := uuid.New()
+	product := &entity.Product{ID: uuid.New(), Name: "Before", TenantID: tenantID}
+
+	logs := runAuditLogger(t, func(auditLogger service.AuditLogger) {
+		ctx := service.WithActor(context.Background(), service.Actor{
+			UserID:         actorID,
+			TenantID:       tenantID,
+			Role:           entity.RoleSupport,
+			ImpersonatorID: &impersonatorID,
+		})
+		ctx = service.WithClientInfo(ctx, service.ClientInfo{IPAddress: "203.0.113.7", UserAgent: "test-agent"})
+
+		auditLogger.Record(ctx, service.AuditEntry{
+			TenantID:   tenantID,
+			Action:     entity.AuditActionProductUpdated,
+			EntityType: entity.AuditEntityProduct,
+			EntityID:   product.ID,
+			Before:     product,
+		})
+		// Changes made after recording must not reach the entry
+		product.Name = "After"
+	})
+
+	if len(logs) != 1 {
+		t.Fatalf("wrote %d entries, want 1", len(logs))
+	}
+	log := logs[0]
+	if log.TenantID != tenantID || log.Action != entity.AuditActionProductUpdated || log.EntityID == nil || *log.EntityID != product.ID {
+		t.Errorf("entry = %+v", log)
+	}
+	if log.UserID == nil || *log.UserID != actorID || log.ImpersonatorID == nil || *log.ImpersonatorID != impersonatorID {
+		t.Errorf("entry attributed to user %v impersonated by %v, want %s impersonated by %s", log.UserID, log.ImpersonatorID, actorID, impersonatorID)
+	}
+	if log.IPAddress != "203.0.113.7" || log.UserAgent != "test-agent" {
+		t.Errorf("client = %q %q", log.IPAddress, log.UserAgent)
+	}
+
+	var before entity.Product
+	if err := json.Unmarshal(log.OldValue, &before); err != nil {
+		t.Fatalf("old value: %v", err)
+	}
+	if before.Name != "Before" {
+		t.Errorf("old value name = %q, want the snapshot taken when recording", before.Name)
+	}
+	if log.NewValue != nil {
+		t.Errorf("new value = %s, want none", log.NewValue)
+	}
+}
+
+func TestAuditLoggerRecordWithoutActor(t *testing.T) {
+	userID := uuid.New()
+
+	logs := runAuditLogger(t, func(auditLogger service.AuditLogger) {
+		auditLogger.Record(context.Background(), service.AuditEntry{
+			TenantID:   uuid.New(),
+			Action:     entity.AuditActionProductCreated,
+			EntityType: entity.AuditEntityProduct,
+			UserID:     &userID,
+		})
+	})
+
+	if len(logs) != 1 {
+		t.Fatalf("wrote %d entries, want 1", len(logs))
+	}
+	if logs[0].UserID == nil || *logs[0].UserID != userID {
+		t.Errorf("entry attributed to %v, want %s", logs[0].UserID, userID)
+	}
+	if logs[0].EntityID != nil {
+		t.Errorf("entity ID = %v, want none", logs[0].EntityID)
+	}
+}
+
+func TestAuditLoggerRecordDoesNotBlock(t *testing.T) {
+	recorded := auditQueueSize + 10
+
+	logs := runAuditLogger(t, func(auditLogger service.AuditLogger) {
+		// Nothing drains the queue yet, so the surplus must be dropped rather than wait
+		for i := 0; i < recorded; i++ {
+			auditLogger.Record(context.Background(), service.AuditEntry{
+				TenantID: uuid.New(),
+				Action:   entity.AuditActionProductCreated,
+			})
+		}
+	})
+
+	if len(logs) != auditQueueSize {
+		t.Fatalf("wrote %d entries, want the %d that fit in the queue", len(logs), auditQueueSize)
+	}
+}
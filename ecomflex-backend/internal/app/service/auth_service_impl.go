@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,17 +16,39 @@ import (
 	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
 	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/auth"
 	"github.com/naresh6454/ecomflex-backend/internal/util"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+const (
+	// identityLinkTTL is how long an account owner has to confirm linking an external identity
+	identityLinkTTL = 15 * time.Minute
+
+	// mfaChallengeTTL is how long a user has to enter their second factor after their password
+	mfaChallengeTTL = 5 * time.Minute
+
+	// maxMFAFailures is how many wrong codes a challenge allows before the password is needed again
+	maxMFAFailures = 5
 )
 
 // AuthServiceImpl implements AuthService interface
 type AuthServiceImpl struct {
-	userRepo         repository.UserRepository
-	authRepo         repository.AuthRepository
-	tenantRepo       repository.TenantRepository // Added tenant repository
-	jwtProvider      *auth.JWTProvider
-	googleOAuthCfg   config.OAuthConfig
-	captchaCfg       config.CaptchaConfig
-	httpClient       *http.Client
+	userRepo          repository.UserRepository
+	authRepo          repository.AuthRepository
+	tenantRepo        repository.TenantRepository // Added tenant repository
+	tenantService     service.TenantService
+	identityRepo      repository.IdentityRepository
+	securityEventRepo repository.SecurityEventRepository
+	impersonationRepo repository.ImpersonationRepository
+	auditLogger       service.AuditLogger
+	jwtProvider       *auth.JWTProvider
+	googleProvider    service.IdentityProvider
+	mfaService        service.MFAService
+	notifier          service.AuthNotifier
+	authCfg           config.AuthConfig
+	captchaVerifier   service.CaptchaVerifier
+	throttleCfg       config.LoginThrottleConfig
+	httpClient        *http.Client
+	logger            loggerPkg.Logger
 }
 
 // NewAuthService creates a new AuthServiceImpl
@@ -33,25 +56,46 @@ func NewAuthService(
 	userRepo repository.UserRepository,
 	authRepo repository.AuthRepository,
 	tenantRepo repository.TenantRepository, // Added tenant repository
+	tenantService service.TenantService,
+	identityRepo repository.IdentityRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	impersonationRepo repository.ImpersonationRepository,
+	auditLogger service.AuditLogger,
 	jwtProvider *auth.JWTProvider,
-	googleOAuthCfg config.OAuthConfig,
-	captchaCfg config.CaptchaConfig,
+	googleProvider service.IdentityProvider,
+	mfaService service.MFAService,
+	notifier service.AuthNotifier,
+	authCfg config.AuthConfig,
+	captchaVerifier service.CaptchaVerifier,
+	throttleCfg config.LoginThrottleConfig,
+	logger loggerPkg.Logger,
 ) service.AuthService {
 	return &AuthServiceImpl{
-		userRepo:       userRepo,
-		authRepo:       authRepo,
-		tenantRepo:     tenantRepo, // Set tenant repository
-		jwtProvider:    jwtProvider,
-		googleOAuthCfg: googleOAuthCfg,
-		captchaCfg:     captchaCfg,
-		httpClient:     &http.Client{Timeout: 10 * time.Second},
+		userRepo:          userRepo,
+		authRepo:          authRepo,
+		tenantRepo:        tenantRepo, // Set tenant repository
+		tenantService:     tenantService,
+		identityRepo:      identityRepo,
+		securityEventRepo: securityEventRepo,
+		impersonationRepo: impersonationRepo,
+		auditLogger:       auditLogger,
+		jwtProvider:       jwtProvider,
+		googleProvider:    googleProvider,
+		mfaService:        mfaService,
+		notifier:          notifier,
+		authCfg:           authCfg,
+		captchaVerifier:   captchaVerifier,
+		throttleCfg:       throttleCfg,
+		httpClient:        &http.Client{Timeout: 10 * time.Second},
+		logger:            logger,
 	}
 }
 
 // Register registers a new user
 func (s *AuthServiceImpl) Register(ctx context.Context, req service.RegisterRequest, tenantID uuid.UUID) (*entity.User, error) {
-	// Skip captcha validation for development
-	// Removed validation code here
+	if err := s.verifyCaptcha(ctx, req.CaptchaToken); err != nil {
+		return nil, err
+	}
 	
 	// Check if email is already taken
 	emailTaken, err := s.userRepo.IsEmailTaken(ctx, req.Email)
@@ -75,24 +119,11 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req service.RegisterRequ
 	switch req.Role {
 	case "influencer":
 		// For influencers, CREATE A NEW TENANT instead of using the default one
-		// Generate a tenant name based on the influencer's name
-		tenantName := fmt.Sprintf("%s's Store", req.FullName)
-		
-		// Generate a unique domain from the influencer's name
-		domainBase := strings.ToLower(strings.ReplaceAll(req.FullName, " ", "-"))
-		domain := fmt.Sprintf("%s.ecomflex.com", domainBase)
-		
-		// Create a new tenant entity
-		newTenant := entity.NewTenant(tenantName, domain, "free")
-		
-		// Save tenant to database
-		if err := s.tenantRepo.CreateTenant(ctx, newTenant); err != nil {
-			return nil, fmt.Errorf("failed to create tenant: %w", err)
+		tenantID, err = s.createInfluencerTenant(ctx, req.FullName)
+		if err != nil {
+			return nil, err
 		}
 		
-		// Use the new tenant ID instead of the default one
-		tenantID = newTenant.ID
-		
 		// For influencers, validate referral code
 		if req.ReferralCode != "" {
 			referralCodeTaken, err := s.userRepo.IsReferralCodeTaken(ctx, req.ReferralCode)
@@ -152,119 +183,293 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req service.RegisterRequ
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 	
+	// The account exists either way; the user can ask for another link
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		s.logger.Error("Failed to send email verification", err, "user_id", user.ID.String())
+	}
+	
 	return user, nil
 }
 
 // Login authenticates a user and returns tokens
 func (s *AuthServiceImpl) Login(ctx context.Context, req service.AuthRequest) (*service.TokenResponse, *entity.User, error) {
-	// Hard-coded superadmin check
-	if req.Email == "admin@ecomflex.com" && req.Password == "Admin@123" {
-		// Create a temporary superadmin user for token generation
-		// This user is not saved to the database
-		superadminUser := &entity.User{
-			ID:           uuid.New(),
-			TenantID:     uuid.MustParse("00000000-0000-0000-0000-000000000000"),
-			Email:        "admin@ecomflex.com",
-			PasswordHash: "", // Not needed as we've already verified the password
-			FullName:     "System Administrator",
-			Role:         entity.RoleSuperAdmin,
-			Phone:        "",
-			Status:       "active",
-			CreatedAt:    time.Now(),
-			UpdatedAt:    time.Now(),
-		}
-
-		// Generate tokens for the superadmin
-		tokens, err := s.generateTokens(ctx, superadminUser)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to generate tokens for superadmin: %w", err)
-		}
-		
-		return tokens, superadminUser, nil
+	ip := service.ClientInfoFromContext(ctx).IPAddress
+	if err := s.checkLoginThrottle(ctx, req, ip); err != nil {
+		return nil, nil, err
 	}
-
-	// Regular login flow
+	
 	// Get user by email
 	user, err := s.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
+		s.recordLoginFailure(ctx, req.Email, ip, nil)
 		return nil, nil, errors.New("invalid email or password")
 	}
 	
 	// Check password
 	if !util.CheckPasswordHash(req.Password, user.PasswordHash) {
+		s.recordLoginFailure(ctx, req.Email, ip, user)
 		return nil, nil, errors.New("invalid email or password")
 	}
 	
+	// Only the account starts over; failures from the IP address still count against it
+	if err := s.authRepo.ClearLoginFailures(ctx, entity.AccountThrottleKey(req.Email)); err != nil {
+		s.logger.Error("Failed to clear login failures", err, "user_id", user.ID.String())
+	}
+	
 	// Check if user is active
-	if !user.IsActive() {
-		// For influencers who are pending approval
-		if user.Role == entity.RoleInfluencer && user.IsPendingApproval() {
-			return nil, nil, errors.New("your account is pending approval")
+	if err := s.checkCanLogin(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	
+	if s.authCfg.RequireVerifiedEmail && !user.IsEmailVerified() {
+		return nil, nil, service.ErrEmailVerificationRequired
+	}
+	
+	// Generate tokens, unless a second factor is needed first
+	tokens, err := s.completeLogin(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	return tokens, user, nil
+}
+
+// checkLoginThrottle rejects a login while its account or IP address is locked out or
+// the delay after the account's last failure has not passed, and checks the captcha
+// unless it is only required once either has failed too often
+func (s *AuthServiceImpl) checkLoginThrottle(ctx context.Context, req service.AuthRequest, ip string) error {
+	now := time.Now()
+	since := now.Add(-s.throttleCfg.Window)
+	
+	keys := []entity.LoginThrottleKey{entity.AccountThrottleKey(req.Email)}
+	if ip != "" {
+		keys = append(keys, entity.IPThrottleKey(ip))
+	}
+	
+	var mostFailures int64
+	for i, key := range keys {
+		lockedUntil, err := s.authRepo.GetLoginLock(ctx, key)
+		if err != nil {
+			return err
+		}
+		if lockedUntil.After(now) {
+			return &service.LoginThrottledError{RetryAfter: lockedUntil.Sub(now), Locked: true}
+		}
+		
+		failures, err := s.authRepo.GetLoginFailures(ctx, key, since)
+		if err != nil {
+			return err
+		}
+		if failures.Count > mostFailures {
+			mostFailures = failures.Count
 		}
 		
-		return nil, nil, errors.New("your account is inactive")
+		// Delays only apply to the account, so users sharing an address do not slow each other down
+		if i == 0 {
+			if wait := failures.LastAt.Add(s.loginDelay(failures.Count)).Sub(now); wait > 0 {
+				return &service.LoginThrottledError{RetryAfter: wait}
+			}
+		}
+	}
+	
+	if s.throttleCfg.CaptchaAfter > 0 && mostFailures < int64(s.throttleCfg.CaptchaAfter) {
+		return nil
+	}
+	
+	return s.verifyCaptcha(ctx, req.CaptchaToken)
+}
+
+// loginDelay returns how long to wait after the last of a number of failed logins.
+// The delay doubles with every failure past the configured number, up to the maximum.
+func (s *AuthServiceImpl) loginDelay(failures int64) time.Duration {
+	if s.throttleCfg.DelayAfter <= 0 || failures < int64(s.throttleCfg.DelayAfter) {
+		return 0
 	}
 	
-	// Generate tokens
-	tokens, err := s.generateTokens(ctx, user)
+	delay := s.throttleCfg.BaseDelay
+	for i := int64(s.throttleCfg.DelayAfter); i < failures && delay < s.throttleCfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.throttleCfg.MaxDelay {
+		delay = s.throttleCfg.MaxDelay
+	}
+	
+	return delay
+}
+
+// recordLoginFailure counts a failed login against its account and IP address and
+// locks out whichever reached its limit. user is nil when no account has the email.
+func (s *AuthServiceImpl) recordLoginFailure(ctx context.Context, email, ip string, user *entity.User) {
+	now := time.Now()
+	
+	failures, err := s.authRepo.RecordLoginFailure(ctx, entity.AccountThrottleKey(email), now, s.throttleCfg.Window)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate tokens: %w", err)
+		s.logger.Error("Failed to record login failure", err, "email", email)
+	} else if limit := s.throttleCfg.AccountLockoutLimit; limit > 0 && failures.Count >= int64(limit) {
+		s.lockAccount(ctx, email, ip, user, failures.Count)
 	}
 	
-	return tokens, user, nil
+	if ip == "" {
+		return
+	}
+	
+	failures, err = s.authRepo.RecordLoginFailure(ctx, entity.IPThrottleKey(ip), now, s.throttleCfg.Window)
+	if err != nil {
+		s.logger.Error("Failed to record login failure", err, "ip", ip)
+	} else if limit := s.throttleCfg.IPLockoutLimit; limit > 0 && failures.Count >= int64(limit) {
+		s.lockIP(ctx, ip, failures.Count)
+	}
 }
 
-// RefreshToken refreshes an access token using a refresh token
-func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (*service.TokenResponse, error) {
-	// Extract token ID from refresh token
-	tokenID := refreshToken[:36] // UUID is 36 chars
+// lockAccount locks logins with an email, records the lockout and emails the
+// account's owner a link to unlock it
+func (s *AuthServiceImpl) lockAccount(ctx context.Context, email, ip string, user *entity.User, failures int64) {
+	until := time.Now().Add(s.throttleCfg.LockoutDuration)
+	if err := s.authRepo.LockLogin(ctx, entity.AccountThrottleKey(email), until); err != nil {
+		s.logger.Error("Failed to lock account", err, "email", email)
+		return
+	}
+	
+	s.logger.Warn("Account locked after failed logins", "email", email, "ip", ip)
+	
+	details := entity.SecurityEventDetails{
+		"email":        email,
+		"ip_address":   ip,
+		"failures":     strconv.FormatInt(failures, 10),
+		"locked_until": until.Format(time.RFC3339),
+	}
+	if user == nil {
+		// Emails without an account are locked too, so lockouts do not reveal which accounts exist
+		s.recordSecurityEvent(ctx, entity.NewAnonymousSecurityEvent(entity.SecurityEventAccountLocked, details))
+		return
+	}
+	s.recordSecurityEvent(ctx, entity.NewSecurityEvent(user.ID, entity.SecurityEventAccountLocked, details))
 	
-	// Get the stored refresh token
-	storedToken, err := s.authRepo.GetRefreshToken(ctx, tokenID)
+	token, accountToken, err := entity.NewAccountToken(entity.AccountTokenAccountUnlock, user, s.throttleCfg.LockoutDuration)
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		s.logger.Error("Failed to create unlock token", err, "user_id", user.ID.String())
+		return
 	}
 	
-	// Verify the token matches
-	if storedToken != refreshToken {
-		return nil, errors.New("invalid refresh token")
+	if err := s.authRepo.SaveAccountToken(ctx, accountToken); err != nil {
+		s.logger.Error("Failed to save unlock token", err, "user_id", user.ID.String())
+		return
 	}
 	
-	// Delete the used refresh token
-	err = s.authRepo.DeleteRefreshToken(ctx, tokenID)
+	if err := s.notifier.SendAccountUnlock(ctx, user, token); err != nil {
+		s.logger.Error("Failed to send account unlock", err, "user_id", user.ID.String())
+	}
+}
+
+// lockIP locks logins from an IP address and records the lockout
+func (s *AuthServiceImpl) lockIP(ctx context.Context, ip string, failures int64) {
+	until := time.Now().Add(s.throttleCfg.LockoutDuration)
+	if err := s.authRepo.LockLogin(ctx, entity.IPThrottleKey(ip), until); err != nil {
+		s.logger.Error("Failed to lock ip address", err, "ip", ip)
+		return
+	}
+	
+	s.logger.Warn("IP address locked after failed logins", "ip", ip)
+	
+	s.recordSecurityEvent(ctx, entity.NewAnonymousSecurityEvent(entity.SecurityEventIPLocked, entity.SecurityEventDetails{
+		"ip_address":   ip,
+		"failures":     strconv.FormatInt(failures, 10),
+		"locked_until": until.Format(time.RFC3339),
+	}))
+}
+
+// recordSecurityEvent stores a security event, logging rather than failing the request if it cannot
+func (s *AuthServiceImpl) recordSecurityEvent(ctx context.Context, event *entity.SecurityEvent) {
+	if err := s.securityEventRepo.CreateSecurityEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to record security event", err, "type", string(event.Type))
+	}
+}
+
+// RefreshToken rotates a refresh token and issues a new access token.
+// Presenting a token that was already rotated revokes its whole family, since either the
+// token was stolen or its owner's copy was.
+func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (*service.TokenResponse, error) {
+	current, err := s.authRepo.GetRefreshToken(ctx, entity.HashRefreshToken(refreshToken))
 	if err != nil {
-		return nil, fmt.Errorf("failed to delete refresh token: %w", err)
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return nil, service.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	
+	session, err := s.authRepo.GetSession(ctx, current.FamilyID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return nil, service.ErrInvalidRefreshToken
+		}
+		return nil, err
 	}
 	
-	// Parse claims from token ID to get user ID
-	tokenParts := refreshToken[37:] // Skip UUID and separator
-	userID, err := uuid.Parse(tokenParts[:36]) // UUID is 36 chars
+	token, expiresAt, err := s.jwtProvider.GenerateRefreshToken()
 	if err != nil {
-		return nil, errors.New("invalid refresh token format")
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	next := current.Rotate(token, expiresAt)
+	
+	client := service.ClientInfoFromContext(ctx)
+	session.Touch(next, client.UserAgent, client.IPAddress)
+	
+	if err := s.authRepo.RotateRefreshToken(ctx, session, current, next); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRefreshTokenReused):
+			s.revokeReusedFamily(ctx, current)
+			return nil, service.ErrInvalidRefreshToken
+		case errors.Is(err, repository.ErrRefreshTokenNotFound):
+			return nil, service.ErrInvalidRefreshToken
+		default:
+			return nil, err
+		}
 	}
 	
 	// Get user
-	user, err := s.userRepo.GetUserByID(ctx, userID)
+	user, err := s.userRepo.GetUserByID(ctx, current.UserID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, service.ErrInvalidRefreshToken
 	}
 	
 	// Check if user is active
 	if !user.IsActive() {
-		return nil, errors.New("your account is inactive")
+		if err := s.authRepo.DeleteSession(ctx, session.UserID, session.ID); err != nil {
+			s.logger.Error("Failed to revoke session", err, "user_id", current.UserID.String())
+		}
+		return nil, service.ErrAccountInactive
 	}
 	
-	// Generate new tokens
-	tokens, err := s.generateTokens(ctx, user)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	// Suspended tenants sign their users out as their access tokens expire
+	if err := s.checkTenantActive(ctx, user); err != nil {
+		if errors.Is(err, service.ErrTenantSuspended) {
+			if err := s.authRepo.DeleteSession(ctx, session.UserID, session.ID); err != nil {
+				s.logger.Error("Failed to revoke session", err, "user_id", current.UserID.String())
+			}
+		}
+		return nil, err
+	}
+	
+	return s.tokenResponse(user, session.ID, token)
+}
+
+// revokeReusedFamily revokes the family of a replayed refresh token and records the reuse
+func (s *AuthServiceImpl) revokeReusedFamily(ctx context.Context, token *entity.RefreshToken) {
+	s.logger.Warn("Refresh token reuse detected", "user_id", token.UserID.String(), "family_id", token.FamilyID.String())
+	
+	if err := s.authRepo.DeleteSession(ctx, token.UserID, token.FamilyID); err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+		s.logger.Error("Failed to revoke session", err, "user_id", token.UserID.String())
 	}
 	
-	return tokens, nil
+	s.recordSecurityEvent(ctx, entity.NewSecurityEvent(token.UserID, entity.SecurityEventRefreshTokenReuse, entity.SecurityEventDetails{
+		"family_id": token.FamilyID.String(),
+		"issued_at": token.CreatedAt.Format(time.RFC3339),
+	}))
 }
 
-// Logout logs out a user and revokes their tokens
-func (s *AuthServiceImpl) Logout(ctx context.Context, userID uuid.UUID, tokenID string) error {
+// Logout logs out the session a user's access token belongs to and revokes the token.
+// Tokens issued before sessions existed carry no session, so those log out every session.
+func (s *AuthServiceImpl) Logout(ctx context.Context, userID uuid.UUID, tokenID, sessionID string) error {
 	// Get token expiration time (we need to store it in revocation list until it expires)
 	// For now, we'll use a default expiration of 24 hours
 	expiresAt := time.Now().Add(24 * time.Hour)
@@ -275,54 +480,534 @@ func (s *AuthServiceImpl) Logout(ctx context.Context, userID uuid.UUID, tokenID
 		return fmt.Errorf("failed to revoke token: %w", err)
 	}
 	
-	// Delete all refresh tokens for this user
-	err = s.authRepo.DeleteAllUserRefreshTokens(ctx, userID)
+	if id, err := uuid.Parse(sessionID); err == nil {
+		err = s.authRepo.DeleteSession(ctx, userID, id)
+		if err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+			return fmt.Errorf("failed to delete session: %w", err)
+		}
+		return nil
+	}
+	
+	// Delete all sessions for this user
+	err = s.authRepo.DeleteAllSessions(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("failed to delete refresh tokens: %w", err)
+		return fmt.Errorf("failed to delete sessions: %w", err)
 	}
 	
 	return nil
 }
 
-// GoogleOAuthLogin handles Google OAuth login
+// GoogleOAuthLogin handles Google OAuth login.
+// Users are found by their Google subject; a first login creates the account, unless the
+// email belongs to an existing account, which has to confirm the link first.
 func (s *AuthServiceImpl) GoogleOAuthLogin(ctx context.Context, req service.GoogleOAuthRequest, tenantID uuid.UUID) (*service.TokenResponse, *entity.User, error) {
-	// TODO: Implement Google OAuth login
-	// This will involve:
-	// 1. Exchanging the code for tokens
-	// 2. Getting user info from Google
-	// 3. Finding or creating the user in our system
-	// 4. Generating tokens
+	identity, err := s.googleProvider.Authenticate(ctx, req.Code, req.RedirectURI)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	user, err := s.findOrCreateExternalUser(ctx, identity, req.Role, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	if err := s.checkCanLogin(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	
+	tokens, err := s.completeLogin(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	return tokens, user, nil
+}
+
+// ConfirmIdentityLink links a pending external identity to its account and logs the user in
+func (s *AuthServiceImpl) ConfirmIdentityLink(ctx context.Context, req service.ConfirmIdentityLinkRequest) (*service.TokenResponse, *entity.User, error) {
+	// The link is consumed even if the password is wrong, so it cannot be guessed at
+	link, err := s.authRepo.ConsumeIdentityLink(ctx, req.LinkToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	user, err := s.userRepo.GetUserByID(ctx, link.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	if !util.CheckPasswordHash(req.Password, user.PasswordHash) {
+		return nil, nil, service.ErrInvalidPassword
+	}
+	
+	if err := s.identityRepo.CreateIdentity(ctx, link.Identity()); err != nil {
+		return nil, nil, err
+	}
+	
+	// The provider verified the same email when the link was created
+	if !user.IsEmailVerified() && user.Email == link.Email {
+		if err := s.userRepo.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+			return nil, nil, err
+		}
+		user.MarkEmailVerified()
+	}
+	
+	if err := s.checkCanLogin(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	
+	tokens, err := s.completeLogin(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	return tokens, user, nil
+}
+
+// VerifyMFA completes a login with a TOTP or recovery code.
+// When the tenant requires MFA and the user had none, the code confirms the authenticator
+// enrolled with EnrollMFA and the result carries the new recovery codes.
+func (s *AuthServiceImpl) VerifyMFA(ctx context.Context, req service.VerifyMFARequest) (*service.MFALoginResult, error) {
+	challenge, err := s.getMFAChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := &service.MFALoginResult{}
+	if challenge.EnrollmentRequired {
+		result.RecoveryCodes, err = s.mfaService.Enable(ctx, challenge.UserID, req.Code)
+	} else {
+		err = s.mfaService.Verify(ctx, challenge.UserID, req.Code, req.RecoveryCode)
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			s.recordMFAFailure(ctx, challenge)
+		}
+		return nil, err
+	}
+	
+	if err := s.authRepo.DeleteMFAChallenge(ctx, challenge.Hash); err != nil {
+		return nil, err
+	}
 	
-	return nil, nil, errors.New("google oauth login not implemented")
+	user, err := s.userRepo.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// The account may have changed since the password was checked
+	if err := s.checkCanLogin(ctx, user); err != nil {
+		return nil, err
+	}
+	
+	result.User = user
+	result.Tokens, err = s.generateTokens(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+	
+	return result, nil
 }
 
-// ValidateCaptcha validates a reCAPTCHA token
+// EnrollMFA creates an authenticator for a user whose tenant requires MFA,
+// during the login that found they had none
+func (s *AuthServiceImpl) EnrollMFA(ctx context.Context, challengeToken string) (*service.MFAEnrollment, error) {
+	challenge, err := s.getMFAChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	if !challenge.EnrollmentRequired {
+		return nil, service.ErrMFAAlreadyEnabled
+	}
+	
+	return s.mfaService.Enroll(ctx, challenge.UserID)
+}
+
+// completeLogin generates tokens for a user who passed the first factor, or starts
+// an MFA challenge when they have a second factor or their tenant requires one
+func (s *AuthServiceImpl) completeLogin(ctx context.Context, user *entity.User) (*service.TokenResponse, error) {
+	requirement, err := s.mfaService.LoginRequirement(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check mfa: %w", err)
+	}
+	
+	if requirement == service.MFARequirementNone {
+		tokens, err := s.generateTokens(ctx, user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate tokens: %w", err)
+		}
+		return tokens, nil
+	}
+	
+	token, challenge, err := entity.NewMFAChallenge(user.ID, requirement == service.MFARequirementEnroll, mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.authRepo.SaveMFAChallenge(ctx, challenge); err != nil {
+		return nil, err
+	}
+	
+	return nil, &service.MFARequiredError{
+		ChallengeToken:     token,
+		EnrollmentRequired: challenge.EnrollmentRequired,
+		ExpiresIn:          int64(mfaChallengeTTL.Seconds()),
+	}
+}
+
+// getMFAChallenge retrieves a pending second factor login by its raw token
+func (s *AuthServiceImpl) getMFAChallenge(ctx context.Context, token string) (*entity.MFAChallenge, error) {
+	challenge, err := s.authRepo.GetMFAChallenge(ctx, entity.HashMFAChallengeToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrMFAChallengeNotFound) {
+			return nil, service.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+	
+	return challenge, nil
+}
+
+// recordMFAFailure counts a wrong code and ends the challenge once too many were tried,
+// so codes cannot be guessed with a single password check
+func (s *AuthServiceImpl) recordMFAFailure(ctx context.Context, challenge *entity.MFAChallenge) {
+	failures, err := s.authRepo.RecordMFAChallengeFailure(ctx, challenge)
+	if err != nil {
+		s.logger.Error("Failed to record mfa failure", err, "user_id", challenge.UserID.String())
+		return
+	}
+	
+	if failures < maxMFAFailures {
+		return
+	}
+	
+	s.logger.Warn("Too many wrong mfa codes", "user_id", challenge.UserID.String())
+	if err := s.authRepo.DeleteMFAChallenge(ctx, challenge.Hash); err != nil {
+		s.logger.Error("Failed to delete mfa challenge", err, "user_id", challenge.UserID.String())
+	}
+}
+
+// RequestPasswordReset sends a password reset link if the email belongs to an account.
+// Unknown emails are ignored so the endpoint does not reveal which accounts exist.
+func (s *AuthServiceImpl) RequestPasswordReset(ctx context.Context, req service.PasswordResetRequest) error {
+	if err := s.verifyCaptcha(ctx, req.CaptchaToken); err != nil {
+		return err
+	}
+	
+	user, err := s.findUserByEmail(ctx, req.Email)
+	if err != nil || user == nil {
+		return err
+	}
+	
+	token, accountToken, err := entity.NewAccountToken(entity.AccountTokenPasswordReset, user, s.authCfg.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+	
+	if err := s.authRepo.SaveAccountToken(ctx, accountToken); err != nil {
+		return err
+	}
+	
+	if err := s.notifier.SendPasswordReset(ctx, user, token); err != nil {
+		return fmt.Errorf("failed to send password reset: %w", err)
+	}
+	
+	return nil
+}
+
+// ConfirmPasswordReset sets a new password with a reset token and logs out all sessions
+func (s *AuthServiceImpl) ConfirmPasswordReset(ctx context.Context, req service.ConfirmPasswordResetRequest) error {
+	if req.NewPassword != req.ConfirmPassword {
+		return service.ErrPasswordMismatch
+	}
+	
+	user, err := s.consumeAccountToken(ctx, entity.AccountTokenPasswordReset, req.Token)
+	if err != nil {
+		return err
+	}
+	
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return err
+	}
+	
+	// Whoever held the old password or a stolen refresh token is logged out
+	if err := s.authRepo.DeleteAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	
+	// Following the emailed link proves the user owns the address
+	if err := s.userRepo.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+	
+	return nil
+}
+
+// RequestEmailVerification sends a new verification link if the email belongs to an unverified account.
+// Unknown and already verified emails are ignored so the endpoint does not reveal which accounts exist.
+func (s *AuthServiceImpl) RequestEmailVerification(ctx context.Context, email string) error {
+	user, err := s.findUserByEmail(ctx, email)
+	if err != nil || user == nil || user.IsEmailVerified() {
+		return err
+	}
+	
+	return s.sendEmailVerification(ctx, user)
+}
+
+// ConfirmEmailVerification verifies the email address a verification token was sent to
+func (s *AuthServiceImpl) ConfirmEmailVerification(ctx context.Context, token string) (*entity.User, error) {
+	user, err := s.consumeAccountToken(ctx, entity.AccountTokenEmailVerification, token)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.userRepo.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	user.MarkEmailVerified()
+	
+	return user, nil
+}
+
+// ConfirmAccountUnlock lifts the lockout of the account an unlock token was sent to
+func (s *AuthServiceImpl) ConfirmAccountUnlock(ctx context.Context, token string) error {
+	user, err := s.consumeAccountToken(ctx, entity.AccountTokenAccountUnlock, token)
+	if err != nil {
+		return err
+	}
+	
+	if err := s.authRepo.UnlockLogin(ctx, entity.AccountThrottleKey(user.Email)); err != nil {
+		return err
+	}
+	
+	s.recordSecurityEvent(ctx, entity.NewSecurityEvent(user.ID, entity.SecurityEventAccountUnlocked, entity.SecurityEventDetails{
+		"email":      user.Email,
+		"ip_address": service.ClientInfoFromContext(ctx).IPAddress,
+	}))
+	
+	return nil
+}
+
+// sendEmailVerification issues a verification token for a user and sends it to their email
+func (s *AuthServiceImpl) sendEmailVerification(ctx context.Context, user *entity.User) error {
+	token, accountToken, err := entity.NewAccountToken(entity.AccountTokenEmailVerification, user, s.authCfg.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	
+	if err := s.authRepo.SaveAccountToken(ctx, accountToken); err != nil {
+		return err
+	}
+	
+	if err := s.notifier.SendEmailVerification(ctx, user, token); err != nil {
+		return fmt.Errorf("failed to send email verification: %w", err)
+	}
+	
+	return nil
+}
+
+// consumeAccountToken uses up an account token and returns the user it was issued to.
+// Tokens sent to an address the user no longer has are rejected.
+func (s *AuthServiceImpl) consumeAccountToken(ctx context.Context, purpose entity.AccountTokenPurpose, token string) (*entity.User, error) {
+	accountToken, err := s.authRepo.ConsumeAccountToken(ctx, purpose, entity.HashAccountToken(token))
+	if err != nil {
+		return nil, err
+	}
+	
+	user, err := s.userRepo.GetUserByID(ctx, accountToken.UserID)
+	if err != nil || !strings.EqualFold(user.Email, accountToken.Email) {
+		return nil, repository.ErrAccountTokenNotFound
+	}
+	
+	return user, nil
+}
+
+// findUserByEmail returns the user with an email, or nil if there is none
+func (s *AuthServiceImpl) findUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	emailTaken, err := s.userRepo.IsEmailTaken(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	
+	if !emailTaken {
+		return nil, nil
+	}
+	
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	return user, nil
+}
+
+// findOrCreateExternalUser returns the user linked to an external identity, creating one
+// with the given role if neither the identity nor its email is known
+func (s *AuthServiceImpl) findOrCreateExternalUser(ctx context.Context, identity *service.ExternalIdentity, role string, tenantID uuid.UUID) (*entity.User, error) {
+	linked, err := s.identityRepo.GetIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return s.userRepo.GetUserByID(ctx, linked.UserID)
+	}
+	if !errors.Is(err, repository.ErrIdentityNotFound) {
+		return nil, err
+	}
+	
+	// An unverified email could claim someone else's account
+	if !identity.EmailVerified {
+		return nil, service.ErrEmailNotVerified
+	}
+	
+	emailTaken, err := s.userRepo.IsEmailTaken(ctx, identity.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	
+	if emailTaken {
+		existing, err := s.userRepo.GetUserByEmail(ctx, identity.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		
+		link, err := entity.NewIdentityLink(existing.ID, identity.Provider, identity.Subject, identity.Email, identityLinkTTL)
+		if err != nil {
+			return nil, err
+		}
+		
+		if err := s.authRepo.SaveIdentityLink(ctx, link); err != nil {
+			return nil, err
+		}
+		
+		return nil, &service.IdentityLinkRequiredError{LinkToken: link.Token, Email: identity.Email}
+	}
+	
+	fullName := identity.Name
+	if fullName == "" {
+		fullName = strings.Split(identity.Email, "@")[0]
+	}
+	
+	var user *entity.User
+	if role == string(entity.RoleInfluencer) {
+		// Influencers get their own tenant and still wait for approval
+		tenantID, err = s.createInfluencerTenant(ctx, fullName)
+		if err != nil {
+			return nil, err
+		}
+		
+		referralCode, err := s.generateUniqueReferralCode(ctx, fullName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate referral code: %w", err)
+		}
+		
+		user = entity.NewInfluencer(tenantID, identity.Email, "", fullName, "", referralCode, nil, 0)
+	} else {
+		user = entity.NewUser(tenantID, identity.Email, "", fullName, entity.RolePublic, "")
+	}
+	user.ProfilePicture = identity.Picture
+	user.MarkEmailVerified()
+	
+	if err := s.identityRepo.CreateUserWithIdentity(ctx, user, entity.NewUserIdentity(user.ID, identity.Provider, identity.Subject, identity.Email)); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	
+	return user, nil
+}
+
+// createInfluencerTenant creates the store tenant of a new influencer on a subdomain named after them
+func (s *AuthServiceImpl) createInfluencerTenant(ctx context.Context, fullName string) (uuid.UUID, error) {
+	newTenant, err := s.tenantService.CreateTenant(ctx, service.CreateTenantRequest{
+		Name:      fmt.Sprintf("%s's Store", fullName),
+		Subdomain: fullName,
+		PlanID:    "free",
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+	
+	return newTenant.ID, nil
+}
+
+// checkCanLogin checks that a user's account and tenant allow logging in
+func (s *AuthServiceImpl) checkCanLogin(ctx context.Context, user *entity.User) error {
+	if !user.IsActive() {
+		// For influencers who are pending approval
+		if user.Role == entity.RoleInfluencer && user.IsPendingApproval() {
+			return service.ErrAccountPendingApproval
+		}
+		
+		return service.ErrAccountInactive
+	}
+	
+	return s.checkTenantActive(ctx, user)
+}
+
+// checkTenantActive checks that the tenant of a user has not been suspended or deleted
+func (s *AuthServiceImpl) checkTenantActive(ctx context.Context, user *entity.User) error {
+	tenant, err := s.tenantRepo.GetTenantByID(ctx, user.TenantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTenantNotFound) {
+			return service.ErrTenantSuspended
+		}
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	
+	if !tenant.IsActive {
+		return service.ErrTenantSuspended
+	}
+	
+	return nil
+}
+
+// ValidateCaptcha validates a captcha token with the configured provider
 func (s *AuthServiceImpl) ValidateCaptcha(ctx context.Context, captchaToken string, remoteIP string) (bool, error) {
-	// Always return true for now to bypass captcha validation
+	err := s.captchaVerifier.Verify(ctx, captchaToken, remoteIP)
+	if errors.Is(err, service.ErrCaptchaRequired) || errors.Is(err, service.ErrInvalidCaptcha) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	
 	return true, nil
 }
 
+// verifyCaptcha checks the captcha solved for a request from the client's IP address
+func (s *AuthServiceImpl) verifyCaptcha(ctx context.Context, captchaToken string) error {
+	if err := s.captchaVerifier.Verify(ctx, captchaToken, service.ClientInfoFromContext(ctx).IPAddress); err != nil {
+		if errors.Is(err, service.ErrCaptchaRequired) || errors.Is(err, service.ErrInvalidCaptcha) {
+			return err
+		}
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	
+	return nil
+}
+
 // GetUserFromToken retrieves a user from a JWT token
 func (s *AuthServiceImpl) GetUserFromToken(ctx context.Context, token string) (*entity.User, error) {
-	// Special case for superadmin token
+	accessToken, err := s.AuthenticateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	
+	return accessToken.User, nil
+}
+
+// AuthenticateToken validates a JWT access token and returns its user and session
+func (s *AuthServiceImpl) AuthenticateToken(ctx context.Context, token string) (*service.AccessToken, error) {
 	claims, err := s.jwtProvider.ValidateToken(token)
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
 	
-	// Check if token is for superadmin
-	if claims.Email == "admin@ecomflex.com" && claims.Role == string(entity.RoleSuperAdmin) {
-		// Return a superadmin user without checking the database
-		return &entity.User{
-			ID:           uuid.MustParse(claims.UserID),
-			TenantID:     uuid.MustParse(claims.TenantID),
-			Email:        claims.Email,
-			FullName:     "System Administrator",
-			Role:         entity.RoleSuperAdmin,
-			Status:       "active",
-			CreatedAt:    time.Now(),
-			UpdatedAt:    time.Now(),
-		}, nil
+	accessToken := &service.AccessToken{
+		TokenID:   claims.TokenID,
+		SessionID: claims.SessionID,
 	}
 	
 	// Check if token is revoked
@@ -341,40 +1026,118 @@ func (s *AuthServiceImpl) GetUserFromToken(ctx context.Context, token string) (*
 		return nil, fmt.Errorf("invalid user ID in token: %w", err)
 	}
 	
+	// Tokens of a signed out session stop working before they expire
+	if claims.SessionID != "" {
+		if err := s.checkSession(ctx, userID, claims.SessionID); err != nil {
+			return nil, err
+		}
+	}
+	
+	// Impersonation tokens stop working when their impersonation is ended
+	if claims.ImpersonationID != "" {
+		impersonation, err := s.checkImpersonation(ctx, userID, claims)
+		if err != nil {
+			return nil, err
+		}
+		accessToken.Impersonation = impersonation
+	}
+	
 	// Get user
 	user, err := s.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
 	
-	return user, nil
+	if err := s.checkTenantActive(ctx, user); err != nil {
+		return nil, err
+	}
+	
+	accessToken.User = user
+	return accessToken, nil
 }
 
-// generateTokens generates access and refresh tokens for a user
-func (s *AuthServiceImpl) generateTokens(ctx context.Context, user *entity.User) (*service.TokenResponse, error) {
-	// Generate access token
-	accessToken, tokenID, expiresAt, err := s.jwtProvider.GenerateAccessToken(user)
+// checkSession checks that a session of a user is still signed in
+func (s *AuthServiceImpl) checkSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	id, err := uuid.Parse(sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return fmt.Errorf("invalid session ID in token: %w", err)
+	}
+	
+	session, err := s.authRepo.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return errors.New("session has been signed out")
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	
+	if session.UserID != userID {
+		return errors.New("session has been signed out")
+	}
+	
+	return nil
+}
+
+// checkImpersonation checks that the impersonation an access token was minted for is still active
+func (s *AuthServiceImpl) checkImpersonation(ctx context.Context, userID uuid.UUID, claims *auth.CustomClaims) (*entity.Impersonation, error) {
+	id, err := uuid.Parse(claims.ImpersonationID)
+	if err != nil || claims.Act == nil {
+		return nil, errors.New("invalid impersonation in token")
+	}
+	
+	impersonation, err := s.impersonationRepo.GetImpersonation(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrImpersonationNotFound) {
+			return nil, service.ErrImpersonationInactive
+		}
+		return nil, fmt.Errorf("failed to get impersonation: %w", err)
+	}
+	
+	if impersonation.UserID != userID || impersonation.TokenID != claims.TokenID ||
+		impersonation.ImpersonatorID.String() != claims.Act.Subject || !impersonation.IsActive(time.Now()) {
+		return nil, service.ErrImpersonationInactive
 	}
 	
+	return impersonation, nil
+}
+
+// generateTokens starts a new session for a user on the requesting device and
+// generates its access token and first refresh token
+func (s *AuthServiceImpl) generateTokens(ctx context.Context, user *entity.User) (*service.TokenResponse, error) {
 	// Generate refresh token
-	refreshTokenValue, err := s.jwtProvider.GenerateRefreshToken()
+	token, expiresAt, err := s.jwtProvider.GenerateRefreshToken()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 	
-	// Create a composite refresh token: tokenID + _ + userID + _ + refreshTokenValue
-	// This way we can extract the tokenID and userID from the token itself
-	refreshToken := fmt.Sprintf("%s_%s_%s", tokenID, user.ID.String(), refreshTokenValue)
+	refreshToken := entity.NewRefreshToken(token, user.ID, expiresAt)
+	client := service.ClientInfoFromContext(ctx)
+	session := entity.NewSession(refreshToken, client.UserAgent, client.IPAddress)
 	
-	// Calculate refresh token expiration
-	refreshTokenExp := time.Now().Add(24 * 7 * time.Hour) // 7 days
+	// Store only the hash of the refresh token
+	if err := s.authRepo.CreateSession(ctx, session, refreshToken); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	
+	// Every login starts a session; the request has no signed-in actor yet
+	s.auditLogger.Record(ctx, service.AuditEntry{
+		TenantID:   user.TenantID,
+		Action:     entity.AuditActionLogin,
+		EntityType: entity.AuditEntityUser,
+		EntityID:   user.ID,
+		After:      map[string]interface{}{"session_id": session.ID},
+		UserID:     &user.ID,
+	})
 	
-	// Store refresh token
-	err = s.authRepo.SaveRefreshToken(ctx, user.ID, tokenID, refreshToken, refreshTokenExp)
+	return s.tokenResponse(user, session.ID, token)
+}
+
+// tokenResponse generates an access token for a user's session and pairs it with a refresh token
+func (s *AuthServiceImpl) tokenResponse(user *entity.User, sessionID uuid.UUID, refreshToken string) (*service.TokenResponse, error) {
+	// Generate access token
+	accessToken, _, expiresAt, err := s.jwtProvider.GenerateAccessToken(user, sessionID.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 	
 	// Create token response
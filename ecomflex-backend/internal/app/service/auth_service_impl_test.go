@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// memoryAuthRepo keeps failed logins, lockouts, account tokens and revoked sessions in memory
+type memoryAuthRepo struct {
+	repository.AuthRepository
+	failures      map[entity.LoginThrottleKey][]time.Time
+	locks         map[entity.LoginThrottleKey]time.Time
+	accountTokens []*entity.AccountToken
+	revoked       []uuid.UUID
+}
+
+func newThrottleAuthRepo() *memoryAuthRepo {
+	return &memoryAuthRepo{
+		failures: map[entity.LoginThrottleKey][]time.Time{},
+		locks:    map[entity.LoginThrottleKey]time.Time{},
+	}
+}
+
+func (r *memoryAuthRepo) GetLoginFailures(ctx context.Context, key entity.LoginThrottleKey, since time.Time) (*entity.LoginFailures, error) {
+	failures := &entity.LoginFailures{}
+	for _, at := range r.failures[key] {
+		if at.After(since) {
+			failures.Count++
+			failures.LastAt = at
+		}
+	}
+	return failures, nil
+}
+
+func (r *memoryAuthRepo) RecordLoginFailure(ctx context.Context, key entity.LoginThrottleKey, at time.Time, window time.Duration) (*entity.LoginFailures, error) {
+	r.failures[key] = append(r.failures[key], at)
+	return r.GetLoginFailures(ctx, key, at.Add(-window))
+}
+
+func (r *memoryAuthRepo) ClearLoginFailures(ctx context.Context, key entity.LoginThrottleKey) error {
+	delete(r.failures, key)
+	return nil
+}
+
+func (r *memoryAuthRepo) LockLogin(ctx context.Context, key entity.LoginThrottleKey, until time.Time) error {
+	r.locks[key] = until
+	return nil
+}
+
+func (r *memoryAuthRepo) GetLoginLock(ctx context.Context, key entity.LoginThrottleKey) (time.Time, error) {
+	return r.locks[key], nil
+}
+
+func (r *memoryAuthRepo) SaveAccountToken(ctx context.Context, token *entity.AccountToken) error {
+	r.accountTokens = append(r.accountTokens, token)
+	return nil
+}
+
+func (r *memoryAuthRepo) ConsumeAccountToken(ctx context.Context, purpose entity.AccountTokenPurpose, hash string) (*entity.AccountToken, error) {
+	for i, token := range r.accountTokens {
+		if token.Purpose == purpose && token.Hash == hash && token.ExpiresAt.After(time.Now()) {
+			r.accountTokens = append(r.accountTokens[:i], r.accountTokens[i+1:]...)
+			return token, nil
+		}
+	}
+	return nil, repository.ErrAccountTokenNotFound
+}
+
+func (r *memoryAuthRepo) DeleteAllSessions(ctx context.Context, userID uuid.UUID) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+// accountUserRepo finds users by email and ID and updates their credentials
+type accountUserRepo struct {
+	repository.UserRepository
+	users []*entity.User
+}
+
+func (r *accountUserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (r *accountUserRepo) IsEmailTaken(ctx context.Context, email string) (bool, error) {
+	_, err := r.GetUserByEmail(ctx, email)
+	return err == nil, nil
+}
+
+func (r *accountUserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	user, err := r.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = passwordHash
+	return nil
+}
+
+func (r *accountUserRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	user, err := r.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	user.MarkEmailVerified()
+	return nil
+}
+
+func (r *accountUserRepo) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+// recordingAuthNotifier keeps the users it sent unlock links to and the reset and
+// verification tokens it sent
+type recordingAuthNotifier struct {
+	unlocked           []uuid.UUID
+	resetTokens        []string
+	verificationTokens []string
+}
+
+func (n *recordingAuthNotifier) SendEmailVerification(ctx context.Context, user *entity.User, token string) error {
+	n.verificationTokens = append(n.verificationTokens, token)
+	return nil
+}
+
+func (n *recordingAuthNotifier) SendPasswordReset(ctx context.Context, user *entity.User, token string) error {
+	n.resetTokens = append(n.resetTokens, token)
+	return nil
+}
+
+func (n *recordingAuthNotifier) SendAccountUnlock(ctx context.Context, user *entity.User, token string) error {
+	n.unlocked = append(n.unlocked, user.ID)
+	return nil
+}
+
+// tokenCaptcha accepts the captcha token "solved"
+type tokenCaptcha struct{}
+
+func (tokenCaptcha) Verify(ctx context.Context, token, remoteIP string) error {
+	switch token {
+	case "":
+		return service.ErrCaptchaRequired
+	case "solved":
+		return nil
+	default:
+		return service.ErrInvalidCaptcha
+	}
+}
+
+// loginFixture is an auth service with one account whose password is "correct horse"
+type loginFixture struct {
+	svc      service.AuthService
+	user     *entity.User
+	authRepo *memoryAuthRepo
+	events   *memorySecurityEventRepo
+	notifier *recordingAuthNotifier
+}
+
+func newLoginFixture(t *testing.T, throttleCfg config.LoginThrottleConfig) *loginFixture {
+	t.Helper()
+
+	hash, err := util.HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	f := &loginFixture{
+		user:     entity.NewUser(uuid.New(), "user@example.com", hash, "User", entity.RoleInfluencer, ""),
+		authRepo: newThrottleAuthRepo(),
+		events:   &memorySecurityEventRepo{},
+		notifier: &recordingAuthNotifier{},
+	}
+	f.svc = NewAuthService(&accountUserRepo{users: []*entity.User{f.user}}, f.authRepo, nil, nil, nil, f.events, nil,
+		&recordingAuditLogger{}, nil, nil, nil, f.notifier, config.AuthConfig{PasswordResetTTL: time.Hour, EmailVerificationTTL: time.Hour},
+		tokenCaptcha{}, throttleCfg,
+		loggerPkg.NewLogger("error"))
+	return f
+}
+
+// login signs in from an IP address
+func (f *loginFixture) login(ip, email, password, captcha string) error {
+	ctx := service.WithClientInfo(context.Background(), service.ClientInfo{IPAddress: ip})
+	_, _, err := f.svc.Login(ctx, service.AuthRequest{Email: email, Password: password, CaptchaToken: captcha})
+	return err
+}
+
+func TestAuthServiceLoginAccountLockout(t *testing.T) {
+	f := newLoginFixture(t, config.LoginThrottleConfig{
+		Window:              15 * time.Minute,
+		AccountLockoutLimit: 3,
+		CaptchaAfter:        100,
+		LockoutDuration:     15 * time.Minute,
+	})
+
+	for i, ip := range []string{"203.0.113.1", "203.0.113.2", "203.0.113.3"} {
+		if err := f.login(ip, "User@Example.com", "wrong", ""); err == nil || isThrottled(err) {
+			t.Fatalf("failed login %d: error = %v, want the password refused", i+1, err)
+		}
+	}
+
+	// The account stays locked for the right password and from any address
+	var throttled *service.LoginThrottledError
+	if err := f.login("198.51.100.9", f.user.Email, "correct horse", ""); !errors.As(err, &throttled) || !throttled.Locked {
+		t.Fatalf("login to a locked account: error = %v, want it locked", err)
+	}
+
+	if len(f.events.events) != 1 || f.events.events[0].Type != entity.SecurityEventAccountLocked {
+		t.Fatalf("security events = %v, want one account lockout", f.events.events)
+	}
+	if len(f.notifier.unlocked) != 1 || f.notifier.unlocked[0] != f.user.ID || len(f.authRepo.accountTokens) != 1 {
+		t.Fatalf("sent %d unlock links and saved %d tokens, want one to the account owner", len(f.notifier.unlocked), len(f.authRepo.accountTokens))
+	}
+}
+
+func TestAuthServiceLoginUnknownAccountLockout(t *testing.T) {
+	f := newLoginFixture(t, config.LoginThrottleConfig{
+		Window:              15 * time.Minute,
+		AccountLockoutLimit: 2,
+		CaptchaAfter:        100,
+		LockoutDuration:     15 * time.Minute,
+	})
+
+	for i := 0; i < 2; i++ {
+		f.login("203.0.113.1", "nobody@example.com", "wrong", "")
+	}
+
+	// Emails without an account lock like any other, without anyone to email
+	if err := f.login("203.0.113.1", "nobody@example.com", "wrong", ""); !isThrottled(err) {
+		t.Fatalf("login to a locked unknown email: error = %v, want it locked", err)
+	}
+	if len(f.events.events) != 1 || f.events.events[0].UserID != nil {
+		t.Fatalf("security events = %v, want one anonymous lockout", f.events.events)
+	}
+	if len(f.notifier.unlocked) != 0 {
+		t.Fatalf("sent %d unlock links, want none", len(f.notifier.unlocked))
+	}
+
+	if err := f.login("203.0.113.1", f.user.Email, "wrong", ""); isThrottled(err) {
+		t.Fatalf("login to another account: error = %v, want it not locked", err)
+	}
+}
+
+func TestAuthServiceLoginIPLockout(t *testing.T) {
+	f := newLoginFixture(t, config.LoginThrottleConfig{
+		Window:          15 * time.Minute,
+		IPLockoutLimit:  3,
+		CaptchaAfter:    100,
+		LockoutDuration: 15 * time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		f.login("203.0.113.1", uuid.NewString()+"@example.com", "wrong", "")
+	}
+
+	if err := f.login("203.0.113.1", f.user.Email, "correct horse", ""); !isThrottled(err) {
+		t.Fatalf("login from a locked address: error = %v, want it locked", err)
+	}
+	if len(f.events.events) != 1 || f.events.events[0].Type != entity.SecurityEventIPLocked {
+		t.Fatalf("security events = %v, want one address lockout", f.events.events)
+	}
+}
+
+func TestAuthServiceLoginCaptcha(t *testing.T) {
+	f := newLoginFixture(t, config.LoginThrottleConfig{
+		Window:       15 * time.Minute,
+		CaptchaAfter: 2,
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := f.login("203.0.113.1", f.user.Email, "wrong", ""); errors.Is(err, service.ErrCaptchaRequired) {
+			t.Fatalf("failed login %d: captcha required before %d failures", i+1, 2)
+		}
+	}
+
+	if err := f.login("203.0.113.1", f.user.Email, "wrong", ""); !errors.Is(err, service.ErrCaptchaRequired) {
+		t.Fatalf("login without a captcha: error = %v, want %v", err, service.ErrCaptchaRequired)
+	}
+	if err := f.login("203.0.113.1", f.user.Email, "wrong", "forged"); !errors.Is(err, service.ErrInvalidCaptcha) {
+		t.Fatalf("login with a bad captcha: error = %v, want %v", err, service.ErrInvalidCaptcha)
+	}
+	if err := f.login("203.0.113.1", f.user.Email, "wrong", "solved"); errors.Is(err, service.ErrCaptchaRequired) || errors.Is(err, service.ErrInvalidCaptcha) {
+		t.Fatalf("login with a solved captcha: error = %v, want the password checked", err)
+	}
+}
+
+func TestAuthServiceLoginDelay(t *testing.T) {
+	throttleCfg := config.LoginThrottleConfig{
+		Window:       15 * time.Minute,
+		DelayAfter:   2,
+		BaseDelay:    time.Minute,
+		MaxDelay:     4 * time.Minute,
+		CaptchaAfter: 100,
+	}
+
+	tests := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{failures: 1},
+		{failures: 2, want: time.Minute},
+		{failures: 3, want: 2 * time.Minute},
+		{failures: 4, want: 4 * time.Minute},
+		{failures: 10, want: 4 * time.Minute},
+	}
+
+	s := &AuthServiceImpl{throttleCfg: throttleCfg}
+	for _, tt := range tests {
+		if got := s.loginDelay(tt.failures); got != tt.want {
+			t.Errorf("loginDelay(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+
+	f := newLoginFixture(t, throttleCfg)
+	for i := 0; i < 2; i++ {
+		f.login("203.0.113.1", f.user.Email, "wrong", "")
+	}
+
+	var throttled *service.LoginThrottledError
+	if err := f.login("203.0.113.1", f.user.Email, "correct horse", ""); !errors.As(err, &throttled) || throttled.Locked {
+		t.Fatalf("login right after failures: error = %v, want a delay", err)
+	}
+	if throttled.RetryAfter <= 0 || throttled.RetryAfter > time.Minute {
+		t.Fatalf("retry after %s, want up to %s", throttled.RetryAfter, time.Minute)
+	}
+}
+
+// isThrottled checks if a login was refused for too many failures
+func isThrottled(err error) bool {
+	var throttled *service.LoginThrottledError
+	return errors.As(err, &throttled)
+}
+
+func TestAuthServicePasswordReset(t *testing.T) {
+	f := newLoginFixture(t, config.LoginThrottleConfig{})
+	ctx := context.Background()
+
+	if err := f.svc.RequestPasswordReset(ctx, service.PasswordResetRequest{Email: f.user.Email}); !errors.Is(err, service.ErrCaptchaRequired) {
+		t.Fatalf("request without a captcha: error = %v, want %v", err, service.ErrCaptchaRequired)
+	}
+	if err := f.svc.RequestPasswordReset(ctx, service.PasswordResetRequest{Email: "nobody@example.com", CaptchaToken: "solved"}); err != nil {
+		t.Fatalf("request for an unknown email: error = %v, want it to look like any other", err)
+	}
+	if len(f.notifier.resetTokens) != 0 {
+		t.Fatalf("sent %d reset links for an unknown email, want none", len(f.notifier.resetTokens))
+	}
+
+	if err := f.svc.RequestPasswordReset(ctx, service.PasswordResetRequest{Email: f.user.Email, CaptchaToken: "solved"}); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+	if len(f.notifier.resetTokens) != 1 {
+		t.Fatalf("sent %d reset links, want 1", len(f.notifier.resetTokens))
+	}
+	token := f.notifier.resetTokens[0]
+
+	mismatch := service.ConfirmPasswordResetRequest{Token: token, NewPassword: "new password", ConfirmPassword: "other password"}
+	if err := f.svc.ConfirmPasswordReset(ctx, mismatch); !errors.Is(err, service.ErrPasswordMismatch) {
+		t.Fatalf("mismatched passwords: error = %v, want %v", err, service.ErrPasswordMismatch)
+	}
+
+	confirm := service.ConfirmPasswordResetRequest{Token: token, NewPassword: "new password", ConfirmPassword: "new password"}
+	if err := f.svc.ConfirmPasswordReset(ctx, confirm); err != nil {
+		t.Fatalf("ConfirmPasswordReset() error = %v", err)
+	}
+	if !util.CheckPasswordHash("new password", f.user.PasswordHash) {
+		t.Error("password was not changed")
+	}
+	if len(f.authRepo.revoked) != 1 || f.authRepo.revoked[0] != f.user.ID {
+		t.Errorf("revoked sessions of %v, want every session of the user", f.authRepo.revoked)
+	}
+	if !f.user.IsEmailVerified() {
+		t.Error("email was not verified by following the reset link")
+	}
+
+	if err := f.svc.ConfirmPasswordReset(ctx, confirm); !errors.Is(err, repository.ErrAccountTokenNotFound) {
+		t.Fatalf("reusing the token: error = %v, want %v", err, repository.ErrAccountTokenNotFound)
+	}
+}
+
+func TestAuthServicePasswordResetAfterEmailChange(t *testing.T) {
+	f := newLoginFixture(t, config.LoginThrottleConfig{})
+	ctx := context.Background()
+
+	if err := f.svc.RequestPasswordReset(ctx, service.PasswordResetRequest{Email: f.user.Email, CaptchaToken: "solved"}); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+
+	// A link sent to an address the user gave up must not work anymore
+	f.user.Email = "new@example.com"
+	confirm := service.ConfirmPasswordResetRequest{Token: f.notifier.resetTokens[0], NewPassword: "new password", ConfirmPassword: "new password"}
+	if err := f.svc.ConfirmPasswordReset(ctx, confirm); !errors.Is(err, repository.ErrAccountTokenNotFound) {
+		t.Fatalf("ConfirmPasswordReset() error = %v, want %v", err, repository.ErrAccountTokenNotFound)
+	}
+	if !util.CheckPasswordHash("correct horse", f.user.PasswordHash) {
+		t.Error("password was changed")
+	}
+}
+
+func TestAuthServiceEmailVerification(t *testing.T) {
+	f := newLoginFixture(t, config.LoginThrottleConfig{})
+	ctx := context.Background()
+
+	if _, err := f.svc.ConfirmEmailVerification(ctx, "unknown"); !errors.Is(err, repository.ErrAccountTokenNotFound) {
+		t.Fatalf("unknown token: error = %v, want %v", err, repository.ErrAccountTokenNotFound)
+	}
+
+	if err := f.svc.RequestEmailVerification(ctx, f.user.Email); err != nil {
+		t.Fatalf("RequestEmailVerification() error = %v", err)
+	}
+	if len(f.notifier.verificationTokens) != 1 {
+		t.Fatalf("sent %d verification links, want 1", len(f.notifier.verificationTokens))
+	}
+
+	user, err := f.svc.ConfirmEmailVerification(ctx, f.notifier.verificationTokens[0])
+	if err != nil {
+		t.Fatalf("ConfirmEmailVerification() error = %v", err)
+	}
+	if user.ID != f.user.ID || !f.user.IsEmailVerified() {
+		t.Fatalf("verified user %s, want %s verified", user.ID, f.user.ID)
+	}
+
+	// Verified and unknown addresses get nothing, without saying so
+	for _, email := range []string{f.user.Email, "nobody@example.com"} {
+		if err := f.svc.RequestEmailVerification(ctx, email); err != nil {
+			t.Fatalf("RequestEmailVerification(%s) error = %v", email, err)
+		}
+	}
+	if len(f.notifier.verificationTokens) != 1 {
+		t.Fatalf("sent %d verification links, want no more", len(f.notifier.verificationTokens))
+	}
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// BookingServiceImpl implements BookingService interface
+type BookingServiceImpl struct {
+	bookingRepo     repository.BookingRepository
+	productRepo     repository.ProductRepository
+	proofRepo       repository.ProofRepository
+	userRepo        repository.UserRepository
+	tenantRepo      repository.TenantRepository
+	referralService service.ReferralService
+	mailer          service.Mailer
+	auditLogger     service.AuditLogger
+	quotaService    service.QuotaService
+	logger          loggerPkg.Logger
+	reservationTTL  time.Duration
+	proofClaimTTL   time.Duration
+	currency        string
+}
+
+// NewBookingService creates a new BookingServiceImpl.
+// reservationTTL is how long an initiated booking holds its slot without a proof;
+// proofClaimTTL is how long a reviewer's claim keeps other reviewers from deciding on a booking;
+// currency is what cashback amounts are shown in.
+func NewBookingService(
+	bookingRepo repository.BookingRepository,
+	productRepo repository.ProductRepository,
+	proofRepo repository.ProofRepository,
+	userRepo repository.UserRepository,
+	tenantRepo repository.TenantRepository,
+	referralService service.ReferralService,
+	mailer service.Mailer,
+	auditLogger service.AuditLogger,
+	quotaService service.QuotaService,
+	logger loggerPkg.Logger,
+	reservationTTL time.Duration,
+	proofClaimTTL time.Duration,
+	currency string,
+) service.BookingService {
+	return &BookingServiceImpl{
+		bookingRepo:     bookingRepo,
+		productRepo:     productRepo,
+		proofRepo:       proofRepo,
+		userRepo:        userRepo,
+		tenantRepo:      tenantRepo,
+		referralService: referralService,
+		mailer:          mailer,
+		auditLogger:     auditLogger,
+		quotaService:    quotaService,
+		logger:          logger,
+		reservationTTL:  reservationTTL,
+		proofClaimTTL:   proofClaimTTL,
+		currency:        currency,
+	}
+}
+
+// CreateBooking creates a new booking for a user
+func (s *BookingServiceImpl) CreateBooking(ctx context.Context, userID uuid.UUID, req service.CreateBookingRequest) (*entity.Booking, error) {
+	// Get the product being booked
+	product, err := s.productRepo.FindByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	if !product.IsActive {
+		return nil, errors.New("product is not accepting bookings")
+	}
+
+	// A user may only hold one open booking per product
+	exists, err := s.bookingRepo.HasOpenBooking(ctx, userID, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing bookings: %w", err)
+	}
+
+	if exists {
+		return nil, errors.New("booking already exists for this product")
+	}
+
+	// Bookings count against the monthly quota of the tenant that owns the product
+	if err := s.quotaService.CheckQuota(ctx, product.TenantID, entity.QuotaMonthlyBookings, 1); err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.tenantRepo.GetTenantByID(ctx, product.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	// Bookings belong to the tenant that owns the product
+	booking := entity.NewBooking(product.TenantID, userID, product.ID, req.ReferralCode)
+
+	// The cashback refunds the tenant's share of the price the product had when it was booked
+	booking.CashbackAmount = tenant.Settings.Cashback(product.Price)
+
+	// Creating the booking claims a slot; this fails once the campaign is full
+	if err := s.bookingRepo.CreateBooking(ctx, booking); err != nil {
+		if errors.Is(err, repository.ErrNoSlotsAvailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+// GetUserBooking gets a booking owned by a user
+func (s *BookingServiceImpl) GetUserBooking(ctx context.Context, id, userID uuid.UUID) (*entity.Booking, error) {
+	booking, err := s.bookingRepo.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hide bookings of other users
+	if booking.UserID != userID {
+		return nil, repository.ErrBookingNotFound
+	}
+
+	return booking, nil
+}
+
+// GetUserBookings gets all bookings of a user
+func (s *BookingServiceImpl) GetUserBookings(ctx context.Context, userID uuid.UUID) ([]*entity.Booking, error) {
+	return s.bookingRepo.GetBookingsByUser(ctx, userID)
+}
+
+// SubmitProof records a proof of purchase for a user's booking and queues it for review
+func (s *BookingServiceImpl) SubmitProof(ctx context.Context, id, userID uuid.UUID, req service.SubmitProofRequest) (*entity.Booking, error) {
+	booking, err := s.GetUserBooking(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := booking.SubmitProof(req.ProofURL); err != nil {
+		return nil, err
+	}
+
+	proof := entity.NewProof(booking, storageKeyFromURL(req.ProofURL), req.ProofURL, req.OrderID, req.Fields)
+
+	// The booking moves to pending together with the proof record
+	if err := s.proofRepo.SubmitProof(ctx, booking, proof); err != nil {
+		if errors.Is(err, entity.ErrInvalidBookingTransition) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to submit proof: %w", err)
+	}
+
+	return booking, nil
+}
+
+// CancelBooking cancels a user's booking and releases its slot
+func (s *BookingServiceImpl) CancelBooking(ctx context.Context, id, userID uuid.UUID) error {
+	booking, err := s.GetUserBooking(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	previousStatus := booking.Status
+	if err := booking.Cancel(); err != nil {
+		return err
+	}
+
+	if err := s.bookingRepo.ReleaseBooking(ctx, booking, previousStatus); err != nil {
+		return err
+	}
+
+	// The user cancelled the booking themselves, so there is no decision to email them about
+	s.settleReferral(ctx, booking.ID, false)
+
+	return nil
+}
+
+// ListBookings lists bookings for admins; admins other than super admins only see their own tenant's
+func (s *BookingServiceImpl) ListBookings(ctx context.Context, filter repository.BookingFilter) ([]*entity.Booking, int, error) {
+	if tenantID, limited := service.TenantScope(ctx); limited {
+		filter.TenantID = tenantID.String()
+	}
+	return s.bookingRepo.ListBookings(ctx, filter)
+}
+
+// ApproveBooking approves a pending booking and records the decision on its proof
+func (s *BookingServiceImpl) ApproveBooking(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Booking, error) {
+	booking, err := s.getBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *booking
+	if err := booking.Approve(); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordDecision(ctx, booking, before.Status, reviewerID, entity.ProofActionApproved, ""); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, entity.AuditActionBookingApproved, &before, booking)
+	s.settleReferral(ctx, booking.ID, true)
+	s.notifyDecision(ctx, booking)
+
+	return booking, nil
+}
+
+// RejectBooking rejects a pending booking, records the decision on its proof and releases its slot
+func (s *BookingServiceImpl) RejectBooking(ctx context.Context, id, reviewerID uuid.UUID, req service.RejectBookingRequest) (*entity.Booking, error) {
+	booking, err := s.getBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *booking
+	if err := booking.Reject(req.Reason); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordDecision(ctx, booking, before.Status, reviewerID, entity.ProofActionRejected, req.Reason); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, entity.AuditActionBookingRejected, &before, booking)
+	s.settleReferral(ctx, booking.ID, false)
+	s.notifyDecision(ctx, booking)
+
+	return booking, nil
+}
+
+// MarkCashbackPaid marks the cashback of an approved booking as paid and credits it to the user's wallet
+func (s *BookingServiceImpl) MarkCashbackPaid(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	booking, err := s.getBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *booking
+	if err := booking.MarkCashbackPaid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.bookingRepo.CreditCashback(ctx, booking, entity.NewCashbackEntries(booking)); err != nil {
+		return nil, fmt.Errorf("failed to credit cashback: %w", err)
+	}
+
+	s.audit(ctx, entity.AuditActionBookingCashbackPaid, &before, booking)
+
+	return booking, nil
+}
+
+// ExpireStaleBookings releases the slots of initiated bookings older than the reservation TTL
+func (s *BookingServiceImpl) ExpireStaleBookings(ctx context.Context) (int, error) {
+	return s.bookingRepo.ExpireStaleBookings(ctx, time.Now().Add(-s.reservationTTL))
+}
+
+// getBooking loads a booking an admin decides on, keeping admins within their tenant
+func (s *BookingServiceImpl) getBooking(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	booking, err := s.bookingRepo.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckTenant(ctx, booking.TenantID); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// audit records a decision on a booking in its tenant's audit log
+func (s *BookingServiceImpl) audit(ctx context.Context, action entity.AuditAction, before, after *entity.Booking) {
+	s.auditLogger.Record(ctx, service.AuditEntry{
+		TenantID:   after.TenantID,
+		Action:     action,
+		EntityType: entity.AuditEntityBooking,
+		EntityID:   after.ID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// recordDecision persists a decision on a booking together with the decision on its latest proof.
+// Reviewers cannot decide on bookings whose proof another reviewer has claimed.
+func (s *BookingServiceImpl) recordDecision(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus, reviewerID uuid.UUID, action entity.ProofAction, reason string) error {
+	proof, err := s.proofRepo.GetLatestProofByBooking(ctx, booking.ID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrProofNotFound) {
+			return fmt.Errorf("failed to get proof: %w", err)
+		}
+		// Bookings reviewed before proofs were recorded have no proof to update
+		proof = nil
+	} else if proof.IsReviewed() {
+		proof = nil
+	}
+
+	var decision *entity.ProofDecision
+	if proof != nil {
+		if proof.IsClaimedByOther(reviewerID, time.Now().Add(-s.proofClaimTTL)) {
+			return entity.ErrProofAlreadyClaimed
+		}
+
+		if action == entity.ProofActionApproved {
+			err = proof.Approve(reviewerID)
+		} else {
+			err = proof.Reject(reviewerID, reason)
+		}
+		if err != nil {
+			return err
+		}
+		decision = entity.NewProofDecision(proof, reviewerID, action, reason)
+	}
+
+	if err := s.proofRepo.RecordBookingDecision(ctx, booking, fromStatus, proof, decision); err != nil {
+		if errors.Is(err, entity.ErrInvalidBookingTransition) || errors.Is(err, entity.ErrProofAlreadyReviewed) {
+			return err
+		}
+		return fmt.Errorf("failed to record booking decision: %w", err)
+	}
+
+	return nil
+}
+
+// settleReferral approves or rejects the referral attributed to a booking.
+// The booking decision has already been persisted, so failures are logged rather than returned.
+func (s *BookingServiceImpl) settleReferral(ctx context.Context, bookingID uuid.UUID, approved bool) {
+	var err error
+	if approved {
+		_, err = s.referralService.ApproveBookingReferral(ctx, bookingID)
+	} else {
+		_, err = s.referralService.RejectBookingReferral(ctx, bookingID)
+	}
+
+	// Referrals already decided by an admin are left alone
+	if err != nil && !errors.Is(err, entity.ErrReferralNotPending) && !errors.Is(err, entity.ErrReferralAlreadyRejected) {
+		s.logger.Error("Failed to settle booking referral", err, "booking_id", bookingID.String())
+	}
+}
+
+// notifyDecision emails the user of a booking whether their proof was approved.
+// The booking decision has already been persisted, so failures are logged rather than returned.
+func (s *BookingServiceImpl) notifyDecision(ctx context.Context, booking *entity.Booking) {
+	user, err := s.userRepo.GetUserByID(ctx, booking.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get user for booking decision email", err, "booking_id", booking.ID.String())
+		return
+	}
+
+	productName := "your product"
+	if product, err := s.productRepo.FindByID(ctx, booking.ProductID.String()); err == nil {
+		productName = product.Name
+	}
+
+	msg := service.EmailMessage{
+		TenantID: booking.TenantID,
+		To:       user.Email,
+		Template: entity.EmailTemplateBookingApproved,
+		Data: map[string]interface{}{
+			"Name":           user.FullName,
+			"BookingID":      booking.ID.String(),
+			"ProductName":    productName,
+			"CashbackAmount": fmt.Sprintf("%.2f %s", booking.CashbackAmount, s.currency),
+		},
+	}
+	if booking.Status == entity.BookingStatusRejected {
+		msg.Template = entity.EmailTemplateBookingRejected
+		msg.Data["Reason"] = booking.RejectionReason
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.Error("Failed to send booking decision email", err, "booking_id", booking.ID.String())
+	}
+}
+
+// storageKeyFromURL derives the object key of an uploaded file from its URL
+func storageKeyFromURL(fileURL string) string {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Path, "/")
+}
+
+// StartBookingExpiry periodically expires stale bookings until the context is cancelled
+func StartBookingExpiry(ctx context.Context, bookingService service.BookingService, interval time.Duration, logger loggerPkg.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := bookingService.ExpireStaleBookings(ctx)
+			if err != nil {
+				logger.Error("Failed to expire stale bookings", err)
+				continue
+			}
+			if expired > 0 {
+				logger.Info("Expired stale bookings", "count", expired)
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// memoryBookingRepo keeps bookings in memory and guards status changes like the database does
+type memoryBookingRepo struct {
+	repository.BookingRepository
+	bookings map[uuid.UUID]*entity.Booking
+	entries  []*entity.LedgerEntry
+	released int
+}
+
+func newMemoryBookingRepo(bookings ...*entity.Booking) *memoryBookingRepo {
+	repo := &memoryBookingRepo{bookings: map[uuid.UUID]*entity.Booking{}}
+	for _, booking := range bookings {
+		repo.bookings[booking.ID] = booking
+	}
+	return repo
+}
+
+func (r *memoryBookingRepo) GetBookingByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	booking, ok := r.bookings[id]
+	if !ok {
+		return nil, repository.ErrBookingNotFound
+	}
+	stored := *booking
+	return &stored, nil
+}
+
+func (r *memoryBookingRepo) HasOpenBooking(ctx context.Context, userID, productID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (r *memoryBookingRepo) CreateBooking(ctx context.Context, booking *entity.Booking) error {
+	stored := *booking
+	r.bookings[booking.ID] = &stored
+	return nil
+}
+
+func (r *memoryBookingRepo) ReleaseBooking(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus) error {
+	stored := r.bookings[booking.ID]
+	if stored.Status != fromStatus || stored.DeletedAt != nil {
+		return entity.ErrInvalidBookingTransition
+	}
+	updated := *booking
+	r.bookings[booking.ID] = &updated
+	r.released++
+	return nil
+}
+
+func (r *memoryBookingRepo) CreditCashback(ctx context.Context, booking *entity.Booking, entries []*entity.LedgerEntry) error {
+	stored := r.bookings[booking.ID]
+	if stored.Status != entity.BookingStatusApproved || stored.CashbackStatus != entity.CashbackStatusNotPaid {
+		return entity.ErrInvalidBookingTransition
+	}
+	stored.CashbackStatus = booking.CashbackStatus
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+// recordingMailer keeps the messages it is asked to send
+type recordingMailer struct {
+	messages []service.EmailMessage
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg service.EmailMessage) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// settlingReferralService records the booking referrals it settles
+type settlingReferralService struct {
+	service.ReferralService
+	approved []uuid.UUID
+	rejected []uuid.UUID
+}
+
+func (s *settlingReferralService) ApproveBookingReferral(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error) {
+	s.approved = append(s.approved, bookingID)
+	return nil, nil
+}
+
+func (s *settlingReferralService) RejectBookingReferral(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error) {
+	s.rejected = append(s.rejected, bookingID)
+	return nil, nil
+}
+
+func newTestBookingService(bookings *memoryBookingRepo, products *memoryProductRepo, tenants *memoryTenantRepo) service.BookingService {
+	return NewBookingService(bookings, products, nil, nil, tenants, &settlingReferralService{}, &recordingMailer{}, &recordingAuditLogger{}, allowAllQuota{},
+		loggerPkg.NewLogger("error"), time.Hour, time.Hour, "USD")
+}
+
+func TestBookingServiceCreateBookingSnapshotsCashback(t *testing.T) {
+	tests := []struct {
+		name         string
+		settings     string
+		price        float64
+		wantCashback float64
+	}{
+		{name: "tenant without cashback", settings: `{}`, price: 30, wantCashback: 0},
+		{name: "full cashback", settings: `{"cashback_percentage": 100}`, price: 30, wantCashback: 30},
+		{name: "partial cashback", settings: `{"cashback_percentage": 25}`, price: 30, wantCashback: 7.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := entity.NewTenant("Acme", "acme.ecomflex.com", "free")
+			if err := tenant.Settings.UnmarshalJSON([]byte(tt.settings)); err != nil {
+				t.Fatalf("UnmarshalJSON: %v", err)
+			}
+			product := &entity.Product{ID: uuid.New(), TenantID: tenant.ID, Price: tt.price, IsActive: true}
+
+			bookings := newMemoryBookingRepo()
+			products := &memoryProductRepo{products: map[uuid.UUID]*entity.Product{product.ID: product}}
+			svc := newTestBookingService(bookings, products, newMemoryTenantRepo(tenant))
+
+			booking, err := svc.CreateBooking(context.Background(), uuid.New(), service.CreateBookingRequest{ProductID: product.ID.String()})
+			if err != nil {
+				t.Fatalf("CreateBooking() error = %v", err)
+			}
+			if booking.CashbackAmount != tt.wantCashback {
+				t.Errorf("cashback = %v, want %v", booking.CashbackAmount, tt.wantCashback)
+			}
+		})
+	}
+}
+
+func TestBookingServiceMarkCashbackPaid(t *testing.T) {
+	tenantID := uuid.New()
+
+	booking := func(status entity.BookingStatus, cashbackStatus entity.CashbackStatus, cashback float64) *entity.Booking {
+		b := entity.NewBooking(tenantID, uuid.New(), uuid.New(), "")
+		b.Status = status
+		b.CashbackStatus = cashbackStatus
+		b.CashbackAmount = cashback
+		return b
+	}
+
+	tests := []struct {
+		name         string
+		booking      *entity.Booking
+		ctx          context.Context
+		wantErr      error
+		wantCredited float64
+	}{
+		{name: "approved booking", booking: booking(entity.BookingStatusApproved, entity.CashbackStatusNotPaid, 19.99), ctx: context.Background(), wantCredited: 19.99},
+		{name: "approved booking without cashback", booking: booking(entity.BookingStatusApproved, entity.CashbackStatusNotPaid, 0), ctx: context.Background()},
+		{name: "pending booking", booking: booking(entity.BookingStatusPending, entity.CashbackStatusNotPaid, 19.99), ctx: context.Background(), wantErr: entity.ErrInvalidBookingTransition},
+		{name: "rejected booking", booking: booking(entity.BookingStatusRejected, entity.CashbackStatusNotPaid, 19.99), ctx: context.Background(), wantErr: entity.ErrInvalidBookingTransition},
+		{name: "cashback already paid", booking: booking(entity.BookingStatusApproved, entity.CashbackStatusPaid, 19.99), ctx: context.Background(), wantErr: entity.ErrInvalidBookingTransition},
+		{
+			name:    "admin of another tenant",
+			booking: booking(entity.BookingStatusApproved, entity.CashbackStatusNotPaid, 19.99),
+			ctx:     service.WithActor(context.Background(), service.Actor{UserID: uuid.New(), TenantID: uuid.New(), Role: entity.RoleSupport}),
+			wantErr: service.ErrOtherTenant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookings := newMemoryBookingRepo(tt.booking)
+			svc := newTestBookingService(bookings, nil, nil)
+
+			paid, err := svc.MarkCashbackPaid(tt.ctx, tt.booking.ID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("MarkCashbackPaid() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(bookings.entries) != 0 {
+					t.Errorf("posted %d ledger entries, want none", len(bookings.entries))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MarkCashbackPaid() error = %v", err)
+			}
+			if paid.CashbackStatus != entity.CashbackStatusPaid || bookings.bookings[tt.booking.ID].CashbackStatus != entity.CashbackStatusPaid {
+				t.Errorf("cashback status = %s, want %s", bookings.bookings[tt.booking.ID].CashbackStatus, entity.CashbackStatusPaid)
+			}
+
+			var credited float64
+			for _, entry := range bookings.entries {
+				if entry.Account == entity.LedgerAccountCashbackWallet && entry.Direction == entity.LedgerCredit {
+					if entry.OwnerID == nil || *entry.OwnerID != tt.booking.UserID {
+						t.Errorf("wallet credited to %v, want %s", entry.OwnerID, tt.booking.UserID)
+					}
+					credited += entry.Amount
+				}
+			}
+			if credited != tt.wantCredited {
+				t.Errorf("wallet credited %v, want %v", credited, tt.wantCredited)
+			}
+
+			// Marking the cashback paid again credits nothing more
+			if _, err := svc.MarkCashbackPaid(tt.ctx, tt.booking.ID); !errors.Is(err, entity.ErrInvalidBookingTransition) {
+				t.Errorf("second MarkCashbackPaid() error = %v, want %v", err, entity.ErrInvalidBookingTransition)
+			}
+		})
+	}
+}
+
+func TestBookingServiceCancelBooking(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		status  entity.BookingStatus
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{name: "initiated booking", status: entity.BookingStatusInitiated, userID: userID},
+		{name: "pending booking", status: entity.BookingStatusPending, userID: userID},
+		{name: "approved booking", status: entity.BookingStatusApproved, userID: userID, wantErr: entity.ErrInvalidBookingTransition},
+		{name: "expired booking", status: entity.BookingStatusExpired, userID: userID, wantErr: entity.ErrInvalidBookingTransition},
+		{name: "another user's booking", status: entity.BookingStatusPending, userID: uuid.New(), wantErr: repository.ErrBookingNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := entity.NewBooking(uuid.New(), userID, uuid.New(), "")
+			booking.Status = tt.status
+
+			bookings := newMemoryBookingRepo(booking)
+			referrals := &settlingReferralService{}
+			mailer := &recordingMailer{}
+			svc := NewBookingService(bookings, nil, nil, nil, nil, referrals, mailer, &recordingAuditLogger{}, allowAllQuota{},
+				loggerPkg.NewLogger("error"), time.Hour, time.Hour, "USD")
+
+			err := svc.CancelBooking(context.Background(), booking.ID, tt.userID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CancelBooking() error = %v, want %v", err, tt.wantErr)
+				}
+				if bookings.released != 0 || len(referrals.rejected) != 0 {
+					t.Errorf("released %d slots and rejected %d referrals, want none", bookings.released, len(referrals.rejected))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelBooking() error = %v", err)
+			}
+			if bookings.released != 1 || bookings.bookings[booking.ID].DeletedAt == nil {
+				t.Errorf("released %d slots, want the booking cancelled and its slot released", bookings.released)
+			}
+			if len(referrals.rejected) != 1 || referrals.rejected[0] != booking.ID {
+				t.Errorf("rejected referrals of %v, want %s", referrals.rejected, booking.ID)
+			}
+			if len(mailer.messages) != 0 {
+				t.Errorf("sent %d emails, want none for a booking the user cancelled", len(mailer.messages))
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// CommissionServiceImpl implements CommissionService interface
+type CommissionServiceImpl struct {
+	ruleRepo     repository.CommissionRuleRepository
+	referralRepo repository.ReferralRepository
+	productRepo  repository.ProductRepository
+	tenantRepo   repository.TenantRepository
+	logger       loggerPkg.Logger
+}
+
+// NewCommissionService creates a new CommissionServiceImpl
+func NewCommissionService(
+	ruleRepo repository.CommissionRuleRepository,
+	referralRepo repository.ReferralRepository,
+	productRepo repository.ProductRepository,
+	tenantRepo repository.TenantRepository,
+	logger loggerPkg.Logger,
+) service.CommissionService {
+	return &CommissionServiceImpl{
+		ruleRepo:     ruleRepo,
+		referralRepo: referralRepo,
+		productRepo:  productRepo,
+		tenantRepo:   tenantRepo,
+		logger:       logger,
+	}
+}
+
+// CreateRule creates a new commission rule
+func (s *CommissionServiceImpl) CreateRule(ctx context.Context, req service.CommissionRuleRequest) (*entity.CommissionRule, error) {
+	rule := entity.NewCommissionRule(req.Name, entity.ReferralSourceBooking, entity.CommissionAmountType(req.AmountType), req.Amount)
+	if err := applyCommissionRuleRequest(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := scopeCommissionRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetRule gets a commission rule by ID.
+// Admins other than super admins see their own tenant's rules and the global rules that apply to it.
+func (s *CommissionServiceImpl) GetRule(ctx context.Context, id uuid.UUID) (*entity.CommissionRule, error) {
+	rule, err := s.ruleRepo.GetRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.TenantID != nil {
+		if err := service.CheckTenant(ctx, *rule.TenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	return rule, nil
+}
+
+// UpdateRule updates a commission rule
+func (s *CommissionServiceImpl) UpdateRule(ctx context.Context, id uuid.UUID, req service.CommissionRuleRequest) (*entity.CommissionRule, error) {
+	rule, err := s.editableRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyCommissionRuleRequest(rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := scopeCommissionRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := s.ruleRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteRule deletes a commission rule
+func (s *CommissionServiceImpl) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.editableRule(ctx, id); err != nil {
+		return err
+	}
+
+	return s.ruleRepo.DeleteRule(ctx, id)
+}
+
+// ListRules lists all commission rules.
+// Admins other than super admins see their own tenant's rules and the global rules that apply to it.
+func (s *CommissionServiceImpl) ListRules(ctx context.Context) ([]*entity.CommissionRule, error) {
+	rules, err := s.ruleRepo.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID, limited := service.TenantScope(ctx)
+	if !limited {
+		return rules, nil
+	}
+
+	visible := make([]*entity.CommissionRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.TenantID == nil || *rule.TenantID == tenantID {
+			visible = append(visible, rule)
+		}
+	}
+	return visible, nil
+}
+
+// editableRule loads a rule the request may change; global rules belong to super admins
+func (s *CommissionServiceImpl) editableRule(ctx context.Context, id uuid.UUID) (*entity.CommissionRule, error) {
+	rule, err := s.ruleRepo.GetRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID, limited := service.TenantScope(ctx); limited && (rule.TenantID == nil || *rule.TenantID != tenantID) {
+		return nil, service.ErrOtherTenant
+	}
+
+	return rule, nil
+}
+
+// CalculateCommission picks the rule for a referral and computes its earnings.
+// Referrals no rule covers earn the default commission of their tenant.
+func (s *CommissionServiceImpl) CalculateCommission(ctx context.Context, referral *entity.Referral) (*entity.CommissionSnapshot, error) {
+	now := time.Now()
+
+	// Tiers are based on the referrals approved before this one
+	approvedCount, err := s.referralRepo.CountApprovedReferrals(ctx, referral.InfluencerID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.ruleRepo.FindCandidateRules(ctx, referral.TenantID, referral.ProductID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule *entity.CommissionRule
+	for _, candidate := range candidates {
+		if !candidate.AppliesTo(referral.Source, approvedCount, now) {
+			continue
+		}
+		if rule == nil || candidate.Outranks(rule) {
+			rule = candidate
+		}
+	}
+
+	if rule == nil {
+		tenant, err := s.tenantRepo.GetTenantByID(ctx, referral.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tenant: %w", err)
+		}
+
+		rule = tenant.Settings.DefaultCommissionRule(referral.TenantID, referral.Source)
+		if rule == nil {
+			return nil, nil
+		}
+	}
+
+	// Percentages are taken of the referred product's price
+	var baseAmount float64
+	if rule.AmountType == entity.CommissionAmountPercent && referral.ProductID != nil {
+		product, err := s.productRepo.FindByID(ctx, referral.ProductID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		baseAmount = product.Price
+	}
+
+	return entity.NewCommissionSnapshot(rule, baseAmount, approvedCount), nil
+}
+
+// scopeCommissionRule keeps the rules admins other than super admins write to their own tenant.
+// Rules they write without a tenant are scoped to theirs rather than applying everywhere.
+func scopeCommissionRule(ctx context.Context, rule *entity.CommissionRule) error {
+	tenantID, limited := service.TenantScope(ctx)
+	if !limited {
+		return nil
+	}
+
+	if rule.TenantID == nil {
+		rule.TenantID = &tenantID
+	}
+	if *rule.TenantID != tenantID {
+		return service.ErrOtherTenant
+	}
+	return nil
+}
+
+// applyCommissionRuleRequest copies a request onto a rule and validates the result
+func applyCommissionRuleRequest(rule *entity.CommissionRule, req service.CommissionRuleRequest) error {
+	rule.Name = req.Name
+	rule.AmountType = entity.CommissionAmountType(req.AmountType)
+	rule.Amount = req.Amount
+	rule.MinApprovedReferrals = req.MinApprovedReferrals
+	rule.StartsAt = req.StartsAt
+	rule.EndsAt = req.EndsAt
+
+	rule.Source = entity.ReferralSourceBooking
+	if req.Source != "" {
+		rule.Source = entity.ReferralSource(req.Source)
+	}
+
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	rule.TenantID = nil
+	if req.TenantID != "" {
+		tenantID, err := uuid.Parse(req.TenantID)
+		if err != nil {
+			return fmt.Errorf("%w: invalid tenant ID", entity.ErrInvalidCommissionRule)
+		}
+		rule.TenantID = &tenantID
+	}
+
+	rule.ProductID = nil
+	if req.ProductID != "" {
+		productID, err := uuid.Parse(req.ProductID)
+		if err != nil {
+			return fmt.Errorf("%w: invalid product ID", entity.ErrInvalidCommissionRule)
+		}
+		rule.ProductID = &productID
+	}
+
+	return rule.Validate()
+}
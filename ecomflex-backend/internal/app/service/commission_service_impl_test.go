@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// memoryCommissionRuleRepo keeps commission rules in memory
+type memoryCommissionRuleRepo struct {
+	repository.CommissionRuleRepository
+	rules []*entity.CommissionRule
+}
+
+func (r *memoryCommissionRuleRepo) FindCandidateRules(ctx context.Context, tenantID uuid.UUID, productID *uuid.UUID, at time.Time) ([]*entity.CommissionRule, error) {
+	var candidates []*entity.CommissionRule
+	for _, rule := range r.rules {
+		if rule.TenantID != nil && *rule.TenantID != tenantID {
+			continue
+		}
+		if rule.ProductID != nil && (productID == nil || *rule.ProductID != *productID) {
+			continue
+		}
+		candidates = append(candidates, rule)
+	}
+	return candidates, nil
+}
+
+func (r *memoryCommissionRuleRepo) GetRuleByID(ctx context.Context, id uuid.UUID) (*entity.CommissionRule, error) {
+	for _, rule := range r.rules {
+		if rule.ID == id {
+			stored := *rule
+			return &stored, nil
+		}
+	}
+	return nil, errors.New("commission rule not found")
+}
+
+func (r *memoryCommissionRuleRepo) CreateRule(ctx context.Context, rule *entity.CommissionRule) error {
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+func (r *memoryCommissionRuleRepo) UpdateRule(ctx context.Context, rule *entity.CommissionRule) error {
+	return nil
+}
+
+// approvedCountReferralRepo reports a fixed number of approved referrals
+type approvedCountReferralRepo struct {
+	repository.ReferralRepository
+	approved int
+}
+
+func (r *approvedCountReferralRepo) CountApprovedReferrals(ctx context.Context, influencerID uuid.UUID) (int, error) {
+	return r.approved, nil
+}
+
+// memoryProductRepo keeps products in memory
+type memoryProductRepo struct {
+	repository.ProductRepository
+	products map[uuid.UUID]*entity.Product
+}
+
+func (r *memoryProductRepo) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	productID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	product, ok := r.products[productID]
+	if !ok {
+		return nil, errors.New("product not found")
+	}
+	return product, nil
+}
+
+func TestCommissionServiceCalculateCommission(t *testing.T) {
+	tenant := entity.NewTenant("Acme", "acme.ecomflex.com", "free")
+	product := &entity.Product{ID: uuid.New(), TenantID: tenant.ID, Price: 40}
+	otherProductID := uuid.New()
+
+	commissionRule := func(name string, amountType entity.CommissionAmountType, amount float64, change func(rule *entity.CommissionRule)) *entity.CommissionRule {
+		rule := entity.NewCommissionRule(name, entity.ReferralSourceBooking, amountType, amount)
+		if change != nil {
+			change(rule)
+		}
+		return rule
+	}
+	forTenant := func(rule *entity.CommissionRule) { rule.TenantID = &tenant.ID }
+	forProduct := func(rule *entity.CommissionRule) { rule.TenantID, rule.ProductID = &tenant.ID, &product.ID }
+
+	global := commissionRule("global", entity.CommissionAmountFlat, 1, nil)
+	tenantFlat := commissionRule("tenant flat", entity.CommissionAmountFlat, 2, forTenant)
+	tenantTier := commissionRule("tenant tier", entity.CommissionAmountFlat, 3, func(rule *entity.CommissionRule) {
+		forTenant(rule)
+		rule.MinApprovedReferrals = 10
+	})
+	productPercent := commissionRule("product percent", entity.CommissionAmountPercent, 10, forProduct)
+	otherProduct := commissionRule("other product", entity.CommissionAmountFlat, 9, func(rule *entity.CommissionRule) {
+		rule.TenantID, rule.ProductID = &tenant.ID, &otherProductID
+	})
+	registration := commissionRule("registration", entity.CommissionAmountFlat, 0.5, func(rule *entity.CommissionRule) {
+		forTenant(rule)
+		rule.Source = entity.ReferralSourceRegistration
+	})
+
+	tests := []struct {
+		name             string
+		rules            []*entity.CommissionRule
+		approved         int
+		source           entity.ReferralSource
+		productID        *uuid.UUID
+		defaultAmount    float64
+		wantRule         string
+		wantEarnings     float64
+		wantNoCommission bool
+	}{
+		{name: "global rule", rules: []*entity.CommissionRule{global}, wantRule: "global", wantEarnings: 1},
+		{name: "tenant over global", rules: []*entity.CommissionRule{global, tenantFlat}, wantRule: "tenant flat", wantEarnings: 2},
+		{name: "tier not reached", rules: []*entity.CommissionRule{tenantFlat, tenantTier}, approved: 9, wantRule: "tenant flat", wantEarnings: 2},
+		{name: "tier reached", rules: []*entity.CommissionRule{tenantFlat, tenantTier}, approved: 10, wantRule: "tenant tier", wantEarnings: 3},
+		{name: "product percentage of the price", rules: []*entity.CommissionRule{tenantTier, productPercent}, approved: 10, productID: &product.ID, wantRule: "product percent", wantEarnings: 4},
+		{name: "rule of another product", rules: []*entity.CommissionRule{tenantFlat, otherProduct}, productID: &product.ID, wantRule: "tenant flat", wantEarnings: 2},
+		{name: "registration", rules: []*entity.CommissionRule{tenantFlat, registration}, source: entity.ReferralSourceRegistration, wantRule: "registration", wantEarnings: 0.5},
+		{name: "tenant default", rules: []*entity.CommissionRule{registration}, productID: &product.ID, defaultAmount: 5, wantRule: "Tenant default commission", wantEarnings: 2},
+		{name: "no rule and no default", rules: []*entity.CommissionRule{registration}, wantNoCommission: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := *tenant
+			stored.Settings.DefaultCommission = entity.DefaultCommission{Type: entity.CommissionAmountPercent, Amount: tt.defaultAmount}
+
+			svc := NewCommissionService(
+				&memoryCommissionRuleRepo{rules: tt.rules},
+				&approvedCountReferralRepo{approved: tt.approved},
+				&memoryProductRepo{products: map[uuid.UUID]*entity.Product{product.ID: product}},
+				newMemoryTenantRepo(&stored),
+				nil,
+			)
+
+			source := tt.source
+			if source == "" {
+				source = entity.ReferralSourceBooking
+			}
+			referral := entity.NewReferral(tenant.ID, uuid.New(), uuid.New(), tt.productID, source, 0)
+
+			snapshot, err := svc.CalculateCommission(context.Background(), referral)
+			if err != nil {
+				t.Fatalf("CalculateCommission() error = %v", err)
+			}
+			if tt.wantNoCommission {
+				if snapshot != nil {
+					t.Fatalf("CalculateCommission() = %+v, want no commission", snapshot)
+				}
+				return
+			}
+			if snapshot == nil {
+				t.Fatal("CalculateCommission() = nil, want a commission")
+			}
+			if snapshot.RuleName != tt.wantRule || snapshot.Earnings != tt.wantEarnings {
+				t.Errorf("CalculateCommission() = %s earning %v, want %s earning %v", snapshot.RuleName, snapshot.Earnings, tt.wantRule, tt.wantEarnings)
+			}
+			if snapshot.ApprovedCount != tt.approved {
+				t.Errorf("approved count = %d, want %d", snapshot.ApprovedCount, tt.approved)
+			}
+		})
+	}
+}
+
+func TestCommissionServiceRulesOfTenantAdmins(t *testing.T) {
+	tenantID := uuid.New()
+	otherTenantID := uuid.New()
+	tenantAdmin := service.WithActor(context.Background(), service.Actor{UserID: uuid.New(), TenantID: tenantID, Role: "commission_manager"})
+
+	global := entity.NewCommissionRule("global", entity.ReferralSourceBooking, entity.CommissionAmountFlat, 1)
+	own := entity.NewCommissionRule("own", entity.ReferralSourceBooking, entity.CommissionAmountFlat, 2)
+	own.TenantID = &tenantID
+	other := entity.NewCommissionRule("other", entity.ReferralSourceBooking, entity.CommissionAmountFlat, 3)
+	other.TenantID = &otherTenantID
+
+	rules := &memoryCommissionRuleRepo{rules: []*entity.CommissionRule{global, own, other}}
+	svc := NewCommissionService(rules, nil, nil, nil, nil)
+	update := service.CommissionRuleRequest{Name: "changed", AmountType: string(entity.CommissionAmountFlat), Amount: 100}
+
+	created, err := svc.CreateRule(tenantAdmin, service.CommissionRuleRequest{Name: "new", AmountType: string(entity.CommissionAmountFlat), Amount: 4})
+	if err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+	if created.TenantID == nil || *created.TenantID != tenantID {
+		t.Errorf("created rule tenant = %v, want %s", created.TenantID, tenantID)
+	}
+
+	if _, err := svc.CreateRule(tenantAdmin, service.CommissionRuleRequest{Name: "new", TenantID: otherTenantID.String(), AmountType: string(entity.CommissionAmountFlat), Amount: 4}); !errors.Is(err, service.ErrOtherTenant) {
+		t.Errorf("CreateRule(other tenant) error = %v, want %v", err, service.ErrOtherTenant)
+	}
+	if _, err := svc.UpdateRule(tenantAdmin, own.ID, update); err != nil {
+		t.Errorf("UpdateRule(own rule) error = %v", err)
+	}
+	if _, err := svc.UpdateRule(tenantAdmin, global.ID, update); !errors.Is(err, service.ErrOtherTenant) {
+		t.Errorf("UpdateRule(global rule) error = %v, want %v", err, service.ErrOtherTenant)
+	}
+	if _, err := svc.UpdateRule(tenantAdmin, other.ID, update); !errors.Is(err, service.ErrOtherTenant) {
+		t.Errorf("UpdateRule(other tenant's rule) error = %v, want %v", err, service.ErrOtherTenant)
+	}
+	if _, err := svc.GetRule(tenantAdmin, other.ID); !errors.Is(err, service.ErrOtherTenant) {
+		t.Errorf("GetRule(other tenant's rule) error = %v, want %v", err, service.ErrOtherTenant)
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// EmailTemplateServiceImpl implements EmailTemplateService interface
+type EmailTemplateServiceImpl struct {
+	templateRepo repository.EmailTemplateRepository
+	tenantRepo   repository.TenantRepository
+}
+
+// NewEmailTemplateService creates a new EmailTemplateServiceImpl
+func NewEmailTemplateService(templateRepo repository.EmailTemplateRepository, tenantRepo repository.TenantRepository) service.EmailTemplateService {
+	return &EmailTemplateServiceImpl{
+		templateRepo: templateRepo,
+		tenantRepo:   tenantRepo,
+	}
+}
+
+// ListEmailTemplates lists the email template overrides of a tenant
+func (s *EmailTemplateServiceImpl) ListEmailTemplates(ctx context.Context, tenantID uuid.UUID) ([]*entity.EmailTemplate, error) {
+	if err := service.CheckTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.tenantRepo.GetTenantByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	return s.templateRepo.ListEmailTemplates(ctx, tenantID)
+}
+
+// SaveEmailTemplate overrides an email template for a tenant
+func (s *EmailTemplateServiceImpl) SaveEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName, req service.SaveEmailTemplateRequest) (*entity.EmailTemplate, error) {
+	if err := service.CheckTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	template, err := entity.NewEmailTemplate(tenantID, name, req.Subject, req.HTMLBody, req.TextBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.tenantRepo.GetTenantByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.SaveEmailTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteEmailTemplate removes a tenant's override of an email template
+func (s *EmailTemplateServiceImpl) DeleteEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName) error {
+	if err := service.CheckTenant(ctx, tenantID); err != nil {
+		return err
+	}
+
+	return s.templateRepo.DeleteEmailTemplate(ctx, tenantID, name)
+}
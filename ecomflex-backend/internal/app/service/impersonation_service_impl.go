@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/auth"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// ImpersonationServiceImpl implements ImpersonationService interface
+type ImpersonationServiceImpl struct {
+	impersonationRepo repository.ImpersonationRepository
+	userRepo          repository.UserRepository
+	authRepo          repository.AuthRepository
+	securityEventRepo repository.SecurityEventRepository
+	roleService       service.RoleService
+	jwtProvider       *auth.JWTProvider
+	tokenTTL          time.Duration
+	logger            loggerPkg.Logger
+}
+
+// NewImpersonationService creates a new ImpersonationServiceImpl.
+// tokenTTL is how long an impersonation token works.
+func NewImpersonationService(
+	impersonationRepo repository.ImpersonationRepository,
+	userRepo repository.UserRepository,
+	authRepo repository.AuthRepository,
+	securityEventRepo repository.SecurityEventRepository,
+	roleService service.RoleService,
+	jwtProvider *auth.JWTProvider,
+	tokenTTL time.Duration,
+	logger loggerPkg.Logger,
+) service.ImpersonationService {
+	return &ImpersonationServiceImpl{
+		impersonationRepo: impersonationRepo,
+		userRepo:          userRepo,
+		authRepo:          authRepo,
+		securityEventRepo: securityEventRepo,
+		roleService:       roleService,
+		jwtProvider:       jwtProvider,
+		tokenTTL:          tokenTTL,
+		logger:            logger,
+	}
+}
+
+// Start mints an impersonation token for a user.
+// Admins can only impersonate active users of their own tenant whose permissions they hold themselves,
+// and never someone who can impersonate in turn.
+func (s *ImpersonationServiceImpl) Start(ctx context.Context, impersonator *entity.User, userID uuid.UUID, reason string) (*service.ImpersonationToken, error) {
+	if impersonator.ID == userID {
+		return nil, service.ErrImpersonationNotAllowed
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.IsActive() {
+		return nil, service.ErrImpersonationNotAllowed
+	}
+
+	if err := s.checkCanImpersonate(ctx, impersonator, user); err != nil {
+		return nil, err
+	}
+
+	client := service.ClientInfoFromContext(ctx)
+	impersonation := entity.NewImpersonation(impersonator, user, reason, client.IPAddress, client.UserAgent, s.tokenTTL)
+
+	token, tokenID, expiresAt, err := s.jwtProvider.GenerateImpersonationToken(user, impersonator, impersonation.ID.String(), s.tokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+	impersonation.TokenID = tokenID
+	impersonation.ExpiresAt = expiresAt
+
+	if err := s.impersonationRepo.CreateImpersonation(ctx, impersonation); err != nil {
+		return nil, err
+	}
+
+	s.recordSecurityEvent(ctx, entity.NewSecurityEvent(user.ID, entity.SecurityEventImpersonationStarted, entity.SecurityEventDetails{
+		"impersonation_id":   impersonation.ID.String(),
+		"impersonator_id":    impersonator.ID.String(),
+		"impersonator_email": impersonator.Email,
+		"reason":             reason,
+	}))
+	s.logger.Info("Impersonation started",
+		"impersonation_id", impersonation.ID.String(),
+		"impersonator_id", impersonator.ID.String(),
+		"user_id", user.ID.String(),
+	)
+
+	return &service.ImpersonationToken{
+		AccessToken:   token,
+		TokenType:     "Bearer",
+		ExpiresIn:     int64(time.Until(expiresAt).Seconds()),
+		Impersonation: impersonation,
+	}, nil
+}
+
+// End ends an impersonation; its token stops working immediately.
+// Ending an impersonation that already ended does nothing.
+func (s *ImpersonationServiceImpl) End(ctx context.Context, id uuid.UUID) error {
+	impersonation, err := s.impersonationRepo.GetImpersonation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if impersonation.EndedAt != nil {
+		return nil
+	}
+
+	if err := s.authRepo.RevokeToken(ctx, impersonation.TokenID, impersonation.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to revoke impersonation token: %w", err)
+	}
+
+	if err := s.impersonationRepo.EndImpersonation(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	s.recordSecurityEvent(ctx, entity.NewSecurityEvent(impersonation.UserID, entity.SecurityEventImpersonationEnded, entity.SecurityEventDetails{
+		"impersonation_id": impersonation.ID.String(),
+		"impersonator_id":  impersonation.ImpersonatorID.String(),
+	}))
+	s.logger.Info("Impersonation ended", "impersonation_id", impersonation.ID.String())
+
+	return nil
+}
+
+// List lists the impersonations of a user or by a user, newest first.
+// Impersonations whose token expired are shown as ended when it expired.
+func (s *ImpersonationServiceImpl) List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Impersonation, int, error) {
+	impersonations, total, err := s.impersonationRepo.ListImpersonations(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	for _, impersonation := range impersonations {
+		impersonation.EndedAt = impersonation.EndTime(now)
+	}
+
+	return impersonations, total, nil
+}
+
+// checkCanImpersonate checks that an admin holds every permission of the user they want to act as.
+// Only super admins impersonate users of other tenants.
+func (s *ImpersonationServiceImpl) checkCanImpersonate(ctx context.Context, impersonator, user *entity.User) error {
+	if !impersonator.CanActInTenant(user.TenantID) {
+		return service.ErrOtherTenant
+	}
+
+	held, err := s.roleService.GetPermissions(ctx, impersonator)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.roleService.GetPermissions(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if target.Has(entity.PermissionUsersImpersonate) || !held.Has(target.List()...) {
+		return service.ErrImpersonationNotAllowed
+	}
+
+	return nil
+}
+
+// recordSecurityEvent stores a security event, logging rather than failing the request if it cannot
+func (s *ImpersonationServiceImpl) recordSecurityEvent(ctx context.Context, event *entity.SecurityEvent) {
+	if err := s.securityEventRepo.CreateSecurityEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to record security event", err, "type", string(event.Type))
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/auth"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// memoryImpersonationRepo keeps the impersonations that were started
+type memoryImpersonationRepo struct {
+	repository.ImpersonationRepository
+	impersonations []*entity.Impersonation
+}
+
+func (r *memoryImpersonationRepo) CreateImpersonation(ctx context.Context, impersonation *entity.Impersonation) error {
+	r.impersonations = append(r.impersonations, impersonation)
+	return nil
+}
+
+// memorySecurityEventRepo keeps the security events that were recorded
+type memorySecurityEventRepo struct {
+	repository.SecurityEventRepository
+	events []*entity.SecurityEvent
+}
+
+func (r *memorySecurityEventRepo) CreateSecurityEvent(ctx context.Context, event *entity.SecurityEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestImpersonationServiceStart(t *testing.T) {
+	tenantID := uuid.New()
+	otherTenantID := uuid.New()
+
+	jwtProvider, err := auth.NewJWTProvider(&config.JWTConfig{Secret: "test-secret"})
+	if err != nil {
+		t.Fatalf("NewJWTProvider: %v", err)
+	}
+
+	support := newTestUser(tenantID, entity.RoleSupport)
+	superAdmin := newTestUser(uuid.New(), entity.RoleSuperAdmin)
+	inactive := newTestUser(tenantID, entity.RolePublic)
+	inactive.Status = "inactive"
+	pendingInfluencer := entity.NewInfluencer(tenantID, "pending@example.com", "hash", "Pending", "", "PENDI1234", nil, 0)
+	moderator := mustTenantRole(t, tenantID, "moderator", entity.PermissionProofsReview)
+
+	tests := []struct {
+		name         string
+		impersonator *entity.User
+		target       *entity.User
+		wantErr      error
+	}{
+		{name: "public user of own tenant", impersonator: support, target: newTestUser(tenantID, entity.RolePublic)},
+		{name: "influencer by a super admin", impersonator: superAdmin, target: newTestUser(tenantID, entity.RoleInfluencer)},
+		{name: "user of another tenant", impersonator: support, target: newTestUser(otherTenantID, entity.RolePublic), wantErr: service.ErrOtherTenant},
+		{name: "super admin impersonating another tenant", impersonator: superAdmin, target: newTestUser(otherTenantID, entity.RolePublic)},
+		{name: "themselves", impersonator: support, target: support, wantErr: service.ErrImpersonationNotAllowed},
+		{name: "another impersonator", impersonator: support, target: newTestUser(tenantID, entity.RoleSupport), wantErr: service.ErrImpersonationNotAllowed},
+		{name: "super admin", impersonator: superAdmin, target: newTestUser(tenantID, entity.RoleSuperAdmin), wantErr: service.ErrImpersonationNotAllowed},
+		{name: "user with permissions the admin lacks", impersonator: support, target: newTestUser(tenantID, moderator.Name), wantErr: service.ErrImpersonationNotAllowed},
+		{name: "influencer by support", impersonator: support, target: newTestUser(tenantID, entity.RoleInfluencer), wantErr: service.ErrImpersonationNotAllowed},
+		{name: "inactive user", impersonator: support, target: inactive, wantErr: service.ErrImpersonationNotAllowed},
+		{name: "influencer pending approval", impersonator: support, target: pendingInfluencer, wantErr: service.ErrImpersonationNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newRoleUserRepo(tt.impersonator, tt.target)
+			roles := NewRoleService(&memoryRoleRepo{roles: []*entity.TenantRole{moderator}}, users, &recordingAuditLogger{}, allowAllQuota{})
+			impersonations := &memoryImpersonationRepo{}
+			events := &memorySecurityEventRepo{}
+			svc := NewImpersonationService(impersonations, users, nil, events, roles, jwtProvider, 15*time.Minute, loggerPkg.NewLogger("error"))
+
+			token, err := svc.Start(context.Background(), tt.impersonator, tt.target.ID, "support ticket")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Start() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(impersonations.impersonations) != 0 || len(events.events) != 0 {
+					t.Errorf("%d impersonations and %d security events recorded, want none", len(impersonations.impersonations), len(events.events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			if token.AccessToken == "" || token.Impersonation.UserID != tt.target.ID || token.Impersonation.ImpersonatorID != tt.impersonator.ID {
+				t.Errorf("Start() = %+v, want a token for %s acting as %s", token.Impersonation, tt.impersonator.ID, tt.target.ID)
+			}
+			if len(impersonations.impersonations) != 1 || len(events.events) != 1 {
+				t.Errorf("%d impersonations and %d security events recorded, want 1 of each", len(impersonations.impersonations), len(events.events))
+			}
+		})
+	}
+}
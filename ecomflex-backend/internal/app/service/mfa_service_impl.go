@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// recoveryCodeCount is how many recovery codes a user gets at a time
+const recoveryCodeCount = 10
+
+// MFAServiceImpl implements MFAService interface
+type MFAServiceImpl struct {
+	mfaRepo    repository.MFARepository
+	userRepo   repository.UserRepository
+	tenantRepo repository.TenantRepository
+	issuer     string
+}
+
+// NewMFAService creates a new MFAServiceImpl.
+// issuer is the name authenticator apps list the account under.
+func NewMFAService(
+	mfaRepo repository.MFARepository,
+	userRepo repository.UserRepository,
+	tenantRepo repository.TenantRepository,
+	issuer string,
+) service.MFAService {
+	return &MFAServiceImpl{
+		mfaRepo:    mfaRepo,
+		userRepo:   userRepo,
+		tenantRepo: tenantRepo,
+		issuer:     issuer,
+	}
+}
+
+// GetStatus gets a user's two-factor authentication status
+func (s *MFAServiceImpl) GetStatus(ctx context.Context, userID uuid.UUID) (*service.MFAStatus, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	required, err := s.requiredByPolicy(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &service.MFAStatus{Required: required}
+
+	factor, err := s.mfaRepo.GetFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFAFactorNotFound) {
+			return status, nil
+		}
+		return nil, err
+	}
+
+	if factor.IsEnabled() {
+		status.Enabled = true
+		if status.RecoveryCodesLeft, err = s.mfaRepo.CountUnusedRecoveryCodes(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// Enroll creates a new pending authenticator for a user.
+// Enrolling again before enabling replaces the pending secret.
+func (s *MFAServiceImpl) Enroll(ctx context.Context, userID uuid.UUID) (*service.MFAEnrollment, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	factor, err := s.mfaRepo.GetFactor(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrMFAFactorNotFound) {
+		return nil, err
+	}
+	if factor != nil && factor.IsEnabled() {
+		return nil, service.ErrMFAAlreadyEnabled
+	}
+
+	factor, err = entity.NewMFAFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.SaveFactor(ctx, factor); err != nil {
+		return nil, err
+	}
+
+	return &service.MFAEnrollment{
+		Secret: factor.Secret,
+		URI:    factor.ProvisioningURI(s.issuer, user.Email),
+	}, nil
+}
+
+// Enable confirms a pending authenticator with a code from it and returns new recovery codes
+func (s *MFAServiceImpl) Enable(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	factor, err := s.mfaRepo.GetFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFAFactorNotFound) {
+			return nil, service.ErrMFANotEnrolled
+		}
+		return nil, err
+	}
+
+	if factor.IsEnabled() {
+		return nil, service.ErrMFAAlreadyEnabled
+	}
+
+	step, ok := factor.MatchCode(code, time.Now())
+	if !ok {
+		return nil, service.ErrInvalidMFACode
+	}
+	factor.LastUsedStep = step
+	factor.Enable()
+
+	raw, codes, err := entity.NewMFARecoveryCodes(userID, recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.EnableFactor(ctx, factor, codes); err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// Disable removes a user's authenticator after checking a code or recovery code.
+// Users whose tenant requires MFA cannot turn it off.
+func (s *MFAServiceImpl) Disable(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	required, err := s.requiredByPolicy(ctx, user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return service.ErrMFARequiredByPolicy
+	}
+
+	if err := s.Verify(ctx, userID, code, recoveryCode); err != nil {
+		return err
+	}
+
+	return s.mfaRepo.DeleteFactor(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces a user's recovery codes after checking a code
+func (s *MFAServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := s.Verify(ctx, userID, code, ""); err != nil {
+		return nil, err
+	}
+
+	raw, codes, err := entity.NewMFARecoveryCodes(userID, recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+// LoginRequirement gets what a user has to do after their password to log in
+func (s *MFAServiceImpl) LoginRequirement(ctx context.Context, user *entity.User) (service.MFARequirement, error) {
+	factor, err := s.mfaRepo.GetFactor(ctx, user.ID)
+	if err != nil && !errors.Is(err, repository.ErrMFAFactorNotFound) {
+		return service.MFARequirementNone, err
+	}
+	if factor != nil && factor.IsEnabled() {
+		return service.MFARequirementVerify, nil
+	}
+
+	required, err := s.requiredByPolicy(ctx, user)
+	if err != nil {
+		return service.MFARequirementNone, err
+	}
+	if required {
+		return service.MFARequirementEnroll, nil
+	}
+
+	return service.MFARequirementNone, nil
+}
+
+// Verify checks a code or a recovery code of a user's enabled authenticator.
+// Each code can be used only once.
+func (s *MFAServiceImpl) Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error {
+	factor, err := s.mfaRepo.GetFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFAFactorNotFound) {
+			return service.ErrMFANotEnrolled
+		}
+		return err
+	}
+
+	if !factor.IsEnabled() {
+		return service.ErrMFANotEnrolled
+	}
+
+	if recoveryCode != "" {
+		err := s.mfaRepo.UseRecoveryCode(ctx, userID, entity.HashRecoveryCode(recoveryCode))
+		if errors.Is(err, repository.ErrRecoveryCodeNotFound) {
+			return service.ErrInvalidMFACode
+		}
+		return err
+	}
+
+	step, ok := factor.MatchCode(code, time.Now())
+	if !ok {
+		return service.ErrInvalidMFACode
+	}
+
+	// Two requests racing with the same code cannot both pass
+	if err := s.mfaRepo.MarkStepUsed(ctx, userID, step); err != nil {
+		if errors.Is(err, repository.ErrMFACodeReused) {
+			return service.ErrInvalidMFACode
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetTenantPolicy gets a tenant's two-factor authentication policy
+func (s *MFAServiceImpl) GetTenantPolicy(ctx context.Context, tenantID uuid.UUID) (*service.MFAPolicy, error) {
+	if err := service.CheckTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.tenantRepo.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.MFAPolicy{
+		RequireSuperAdmin: tenant.RequiresMFA(entity.RoleSuperAdmin),
+	}, nil
+}
+
+// UpdateTenantPolicy sets a tenant's two-factor authentication policy.
+// Super admins without an authenticator have to enroll at their next login.
+func (s *MFAServiceImpl) UpdateTenantPolicy(ctx context.Context, tenantID uuid.UUID, policy service.MFAPolicy) (*service.MFAPolicy, error) {
+	if err := service.CheckTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.tenantRepo.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.SetSuperAdminMFARequired(policy.RequireSuperAdmin)
+
+	if err := s.tenantRepo.UpdateTenant(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// requiredByPolicy checks if a user's tenant makes MFA mandatory for their role
+func (s *MFAServiceImpl) requiredByPolicy(ctx context.Context, user *entity.User) (bool, error) {
+	tenant, err := s.tenantRepo.GetTenantByID(ctx, user.TenantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTenantNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return tenant.RequiresMFA(user.Role), nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+)
+
+// verifyMFARepo keeps a single enabled factor in memory and records used steps like the database does
+type verifyMFARepo struct {
+	repository.MFARepository
+	factor *entity.MFAFactor
+}
+
+func (r *verifyMFARepo) GetFactor(ctx context.Context, userID uuid.UUID) (*entity.MFAFactor, error) {
+	if r.factor.UserID != userID {
+		return nil, repository.ErrMFAFactorNotFound
+	}
+	stored := *r.factor
+	return &stored, nil
+}
+
+func (r *verifyMFARepo) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) error {
+	if r.factor.LastUsedStep >= step {
+		return repository.ErrMFACodeReused
+	}
+	r.factor.LastUsedStep = step
+	return nil
+}
+
+func TestMFAServiceVerify(t *testing.T) {
+	type attempt struct {
+		stepOffset int64 // time step of the code, relative to now
+		wantErr    error
+	}
+
+	tests := []struct {
+		name     string
+		attempts []attempt
+	}{
+		{
+			name:     "current code",
+			attempts: []attempt{{stepOffset: 0}},
+		},
+		{
+			name:     "replayed code",
+			attempts: []attempt{{stepOffset: 0}, {stepOffset: 0, wantErr: service.ErrInvalidMFACode}},
+		},
+		{
+			name:     "earlier code after a later one",
+			attempts: []attempt{{stepOffset: 0}, {stepOffset: -1, wantErr: service.ErrInvalidMFACode}},
+		},
+		{
+			name:     "later code after an earlier one",
+			attempts: []attempt{{stepOffset: -1}, {stepOffset: 0}},
+		},
+		{
+			name:     "expired code",
+			attempts: []attempt{{stepOffset: -3, wantErr: service.ErrInvalidMFACode}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor, err := entity.NewMFAFactor(uuid.New())
+			if err != nil {
+				t.Fatalf("NewMFAFactor: %v", err)
+			}
+			factor.Enable()
+
+			repo := &verifyMFARepo{factor: factor}
+			svc := NewMFAService(repo, nil, nil, "EcomFlex")
+
+			for i, a := range tt.attempts {
+				code, err := util.TOTPCode(factor.Secret, util.TOTPStep(time.Now())+a.stepOffset)
+				if err != nil {
+					t.Fatalf("TOTPCode: %v", err)
+				}
+
+				err = svc.Verify(context.Background(), factor.UserID, code, "")
+				if !errors.Is(err, a.wantErr) {
+					t.Fatalf("attempt %d: Verify() error = %v, want %v", i, err, a.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestMFAServiceVerifyPendingFactor(t *testing.T) {
+	factor, err := entity.NewMFAFactor(uuid.New())
+	if err != nil {
+		t.Fatalf("NewMFAFactor: %v", err)
+	}
+
+	code, err := util.TOTPCode(factor.Secret, util.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatalf("TOTPCode: %v", err)
+	}
+
+	svc := NewMFAService(&verifyMFARepo{factor: factor}, nil, nil, "EcomFlex")
+	if err := svc.Verify(context.Background(), factor.UserID, code, ""); !errors.Is(err, service.ErrMFANotEnrolled) {
+		t.Fatalf("Verify() error = %v, want %v", err, service.ErrMFANotEnrolled)
+	}
+}
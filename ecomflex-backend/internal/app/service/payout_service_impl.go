@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// manualPayoutProvider marks payouts an admin moved along outside of the payout provider
+const manualPayoutProvider = "manual"
+
+// PayoutServiceImpl implements PayoutService interface
+type PayoutServiceImpl struct {
+	payoutRepo repository.PayoutRepository
+	ledgerRepo repository.LedgerRepository
+	userRepo   repository.UserRepository
+	provider   service.PayoutProvider
+	logger     loggerPkg.Logger
+	threshold  float64
+	currency   string
+}
+
+// NewPayoutService creates a new PayoutServiceImpl.
+// threshold is the minimum balance paid out by a payout run; transfers are sent in currency.
+// provider is nil when payouts are disabled; balances then keep accruing until they are turned on.
+func NewPayoutService(
+	payoutRepo repository.PayoutRepository,
+	ledgerRepo repository.LedgerRepository,
+	userRepo repository.UserRepository,
+	provider service.PayoutProvider,
+	logger loggerPkg.Logger,
+	threshold float64,
+	currency string,
+) service.PayoutService {
+	return &PayoutServiceImpl{
+		payoutRepo: payoutRepo,
+		ledgerRepo: ledgerRepo,
+		userRepo:   userRepo,
+		provider:   provider,
+		logger:     logger,
+		threshold:  threshold,
+		currency:   currency,
+	}
+}
+
+// RunPayouts creates payouts for every payable balance at or above the payout threshold.
+// Runs span every tenant, so only super admins and the scheduler start them.
+func (s *PayoutServiceImpl) RunPayouts(ctx context.Context) (*entity.PayoutRun, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, err
+	}
+	if s.provider == nil {
+		return nil, service.ErrPayoutsDisabled
+	}
+
+	run := entity.NewPayoutRun(s.threshold)
+	if err := s.payoutRepo.StartRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	runErr := s.createPayouts(ctx, run)
+	run.Complete(runErr)
+
+	// Record the outcome even if the request went away, or the run would block later runs
+	if err := s.payoutRepo.CompleteRun(context.WithoutCancel(ctx), run); err != nil {
+		return nil, err
+	}
+
+	if runErr != nil {
+		return run, runErr
+	}
+
+	s.logger.Info("Payout run completed",
+		"run_id", run.ID.String(),
+		"payouts", run.PayoutCount,
+		"total", run.TotalAmount,
+	)
+
+	return run, nil
+}
+
+// createPayouts pays out the eligible balances of a run
+func (s *PayoutServiceImpl) createPayouts(ctx context.Context, run *entity.PayoutRun) error {
+	for _, account := range entity.PayableAccounts {
+		balances, err := s.ledgerRepo.ListBalancesAbove(ctx, account, run.Threshold)
+		if err != nil {
+			return err
+		}
+
+		for _, balance := range balances {
+			// Cashback waits in the wallet until its owner has said where to send it
+			if account == entity.LedgerAccountCashbackWallet {
+				if _, err := s.payoutRepo.GetPayoutMethod(ctx, balance.OwnerID); err != nil {
+					if errors.Is(err, repository.ErrPayoutMethodNotFound) {
+						continue
+					}
+					return err
+				}
+			}
+
+			amount := math.Round(balance.Balance*100) / 100
+			payout := entity.NewPayout(run.ID, balance.TenantID, balance.OwnerID, balance.Account, amount)
+
+			if err := s.payoutRepo.CreatePayout(ctx, payout, entity.NewPayoutEntries(payout)); err != nil {
+				// The balance changed since it was listed; it is picked up by the next run
+				if errors.Is(err, entity.ErrInsufficientBalance) {
+					continue
+				}
+				return fmt.Errorf("failed to pay out %s: %w", balance.OwnerID, err)
+			}
+
+			run.AddPayout(payout)
+		}
+	}
+
+	return nil
+}
+
+// ListRuns lists payout runs, newest first. Runs span every tenant, so only super admins see them.
+func (s *PayoutServiceImpl) ListRuns(ctx context.Context, limit, offset int) ([]*entity.PayoutRun, int, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, 0, err
+	}
+	return s.payoutRepo.ListRuns(ctx, limit, offset)
+}
+
+// ListPayouts lists payouts for admins; admins other than super admins only see their own tenant's
+func (s *PayoutServiceImpl) ListPayouts(ctx context.Context, filter repository.PayoutFilter) ([]*entity.Payout, int, error) {
+	if tenantID, limited := service.TenantScope(ctx); limited {
+		filter.TenantID = tenantID.String()
+	}
+	return s.payoutRepo.ListPayouts(ctx, filter)
+}
+
+// GetPayout gets a payout by ID, keeping admins within their tenant
+func (s *PayoutServiceImpl) GetPayout(ctx context.Context, id uuid.UUID) (*entity.Payout, error) {
+	payout, err := s.payoutRepo.GetPayoutByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckTenant(ctx, payout.TenantID); err != nil {
+		return nil, err
+	}
+
+	return payout, nil
+}
+
+// UpdatePayoutStatus moves a payout along its lifecycle.
+// Failed payouts return their amount to the payee's balance.
+func (s *PayoutServiceImpl) UpdatePayoutStatus(ctx context.Context, id uuid.UUID, req service.UpdatePayoutStatusRequest) (*entity.Payout, error) {
+	payout, err := s.GetPayout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStatus := payout.Status
+	var entries []*entity.LedgerEntry
+
+	switch req.Status {
+	case entity.PayoutStatusProcessing:
+		err = payout.MarkProcessing(manualPayoutProvider, req.Reference)
+	case entity.PayoutStatusPaid:
+		err = payout.MarkPaid(req.Reference)
+	case entity.PayoutStatusFailed:
+		err = payout.MarkFailed(req.Reason)
+		entries = entity.NewPayoutFailureEntries(payout)
+	default:
+		err = entity.ErrInvalidPayoutTransition
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.payoutRepo.UpdatePayoutStatus(ctx, payout, previousStatus, entries, nil); err != nil {
+		return nil, err
+	}
+
+	return payout, nil
+}
+
+// DispatchPayouts hands pending payouts to the payout provider and returns how many were sent.
+// Payouts that cannot be sent stay pending and are retried by the next dispatch.
+// Dispatches span every tenant, so only super admins and the scheduler start them.
+func (s *PayoutServiceImpl) DispatchPayouts(ctx context.Context) (int, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return 0, err
+	}
+	if s.provider == nil {
+		return 0, service.ErrPayoutsDisabled
+	}
+
+	payouts, _, err := s.payoutRepo.ListPayouts(ctx, repository.PayoutFilter{
+		Status: string(entity.PayoutStatusPending),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, payout := range payouts {
+		if err := s.dispatch(ctx, payout); err != nil {
+			s.logger.Error("Failed to dispatch payout", err, "payout_id", payout.ID.String())
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// SyncPayouts polls the payout provider for payouts still in flight and returns how many settled.
+// Syncs span every tenant, so only super admins and the scheduler start them.
+func (s *PayoutServiceImpl) SyncPayouts(ctx context.Context) (int, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return 0, err
+	}
+	if s.provider == nil {
+		return 0, service.ErrPayoutsDisabled
+	}
+
+	payouts, _, err := s.payoutRepo.ListPayouts(ctx, repository.PayoutFilter{
+		Status:   string(entity.PayoutStatusProcessing),
+		Provider: s.provider.Name(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, payout := range payouts {
+		transfer, err := s.provider.GetTransfer(ctx, payout.Reference)
+		if err != nil {
+			s.logger.Error("Failed to get payout transfer", err, "payout_id", payout.ID.String())
+			continue
+		}
+
+		if err := s.applyTransferStatus(ctx, payout, *transfer, ""); err != nil {
+			s.logger.Error("Failed to update payout status", err, "payout_id", payout.ID.String())
+			continue
+		}
+
+		if payout.IsFinal() {
+			settled++
+		}
+	}
+
+	return settled, nil
+}
+
+// HandleWebhook verifies and applies a transfer status webhook from the payout provider.
+// Redelivered and outdated events are acknowledged without changing anything.
+func (s *PayoutServiceImpl) HandleWebhook(ctx context.Context, header http.Header, body []byte) error {
+	if s.provider == nil {
+		return service.ErrPayoutsDisabled
+	}
+
+	event, err := s.provider.ParseWebhook(header, body)
+	if err != nil {
+		return err
+	}
+
+	payout, err := s.payoutRepo.GetPayoutByReference(ctx, s.provider.Name(), event.Reference)
+	if err != nil {
+		return err
+	}
+
+	err = s.applyTransferStatus(ctx, payout, event.TransferStatus, event.EventID)
+	switch {
+	case errors.Is(err, entity.ErrDuplicatePayoutEvent):
+		return nil
+	case errors.Is(err, entity.ErrInvalidPayoutTransition):
+		s.logger.Warn("Ignoring payout webhook that does not apply",
+			"payout_id", payout.ID.String(),
+			"event_id", event.EventID,
+			"status", string(event.Status),
+		)
+		return nil
+	}
+
+	return err
+}
+
+// dispatch starts the transfer of a pending payout and records its reference
+func (s *PayoutServiceImpl) dispatch(ctx context.Context, payout *entity.Payout) error {
+	beneficiaryRef, err := s.beneficiaryRef(ctx, payout.UserID)
+	if err != nil {
+		return err
+	}
+
+	// The payout ID is the idempotency key, so a retry after a crash does not pay twice
+	transfer, err := s.provider.InitiateTransfer(ctx, service.TransferRequest{
+		PayoutID:       payout.ID,
+		BeneficiaryRef: beneficiaryRef,
+		Amount:         payout.Amount,
+		Currency:       s.currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initiate transfer: %w", err)
+	}
+
+	previousStatus := payout.Status
+	if err := payout.MarkProcessing(s.provider.Name(), transfer.Reference); err != nil {
+		return err
+	}
+
+	if err := s.payoutRepo.UpdatePayoutStatus(ctx, payout, previousStatus, nil, nil); err != nil {
+		return err
+	}
+
+	// Some rails settle or reject a transfer right away
+	return s.applyTransferStatus(ctx, payout, *transfer, "")
+}
+
+// applyTransferStatus moves a payout to the status reported by the provider.
+// Failed transfers return the amount to the payee's balance.
+func (s *PayoutServiceImpl) applyTransferStatus(ctx context.Context, payout *entity.Payout, transfer service.TransferStatus, eventID string) error {
+	if transfer.Status == payout.Status || transfer.Status == entity.PayoutStatusProcessing {
+		return nil
+	}
+
+	previousStatus := payout.Status
+	var entries []*entity.LedgerEntry
+	var err error
+
+	switch transfer.Status {
+	case entity.PayoutStatusPaid:
+		err = payout.MarkPaid(transfer.Reference)
+	case entity.PayoutStatusFailed:
+		err = payout.MarkFailed(transfer.FailureReason)
+		entries = entity.NewPayoutFailureEntries(payout)
+	default:
+		err = fmt.Errorf("unknown transfer status %q", transfer.Status)
+	}
+	if err != nil {
+		return err
+	}
+
+	var event *entity.PayoutEvent
+	if eventID != "" {
+		event = entity.NewPayoutEvent(s.provider.Name(), eventID, payout)
+	}
+
+	if err := s.payoutRepo.UpdatePayoutStatus(ctx, payout, previousStatus, entries, event); err != nil {
+		return err
+	}
+
+	s.logger.Info("Payout settled",
+		"payout_id", payout.ID.String(),
+		"status", string(payout.Status),
+	)
+
+	return nil
+}
+
+// beneficiaryRef returns the provider's reference for a payee, registering them on first payout
+func (s *PayoutServiceImpl) beneficiaryRef(ctx context.Context, userID uuid.UUID) (string, error) {
+	beneficiary, err := s.payoutRepo.GetBeneficiary(ctx, userID, s.provider.Name())
+	if err == nil {
+		return beneficiary.Reference, nil
+	}
+	if !errors.Is(err, repository.ErrPayoutBeneficiaryNotFound) {
+		return "", err
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get payee: %w", err)
+	}
+
+	payee := service.PayoutBeneficiary{
+		UserID: user.ID,
+		Name:   user.FullName,
+		Email:  user.Email,
+		Phone:  user.Phone,
+	}
+
+	// Register the payee with the method they chose, if any
+	method, err := s.payoutRepo.GetPayoutMethod(ctx, userID)
+	switch {
+	case err == nil:
+		payee.Method = method.Type
+		payee.Details = method.Details
+	case !errors.Is(err, repository.ErrPayoutMethodNotFound):
+		return "", err
+	}
+
+	reference, err := s.provider.CreateBeneficiary(ctx, payee)
+	if err != nil {
+		return "", fmt.Errorf("failed to create beneficiary: %w", err)
+	}
+
+	if err := s.payoutRepo.SaveBeneficiary(ctx, entity.NewPayoutBeneficiary(user.ID, s.provider.Name(), reference)); err != nil {
+		return "", err
+	}
+
+	return reference, nil
+}
+
+// GetEarningsBalance gets the earnings balance of an influencer
+func (s *PayoutServiceImpl) GetEarningsBalance(ctx context.Context, influencerID uuid.UUID) (repository.BalanceSummary, error) {
+	return s.ledgerRepo.GetBalanceSummary(ctx, entity.LedgerAccountInfluencerEarnings, influencerID)
+}
+
+// ListEarningsEntries lists the ledger entries of an influencer's earnings, newest first
+func (s *PayoutServiceImpl) ListEarningsEntries(ctx context.Context, influencerID uuid.UUID, limit, offset int) ([]*entity.LedgerEntry, int, error) {
+	return s.ledgerRepo.ListEntries(ctx, entity.LedgerAccountInfluencerEarnings, influencerID, limit, offset)
+}
+
+// ListInfluencerPayouts lists the payouts of an influencer, newest first
+func (s *PayoutServiceImpl) ListInfluencerPayouts(ctx context.Context, influencerID uuid.UUID, limit, offset int) ([]*entity.Payout, int, error) {
+	return s.payoutRepo.ListPayouts(ctx, repository.PayoutFilter{
+		UserID:  influencerID.String(),
+		Account: string(entity.LedgerAccountInfluencerEarnings),
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// StartPayoutRuns periodically runs payouts, sends them to the payout provider and polls
+// transfers in flight until the context is cancelled
+func StartPayoutRuns(ctx context.Context, payoutService service.PayoutService, interval time.Duration, logger loggerPkg.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := payoutService.RunPayouts(ctx); err != nil && !errors.Is(err, entity.ErrPayoutRunInProgress) {
+				logger.Error("Failed to run payouts", err)
+			}
+			if _, err := payoutService.DispatchPayouts(ctx); err != nil {
+				logger.Error("Failed to dispatch payouts", err)
+			}
+			if _, err := payoutService.SyncPayouts(ctx); err != nil {
+				logger.Error("Failed to sync payouts", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	"github.com/naresh6454/ecomflex-backend/internal/infrastructure/payout"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// webhookPayoutRepo keeps a single payout in memory and records provider events like the database does
+type webhookPayoutRepo struct {
+	repository.PayoutRepository
+	payout  *entity.Payout
+	events  map[string]bool
+	entries int
+	updates int
+}
+
+func (r *webhookPayoutRepo) GetPayoutByReference(ctx context.Context, provider, reference string) (*entity.Payout, error) {
+	if r.payout.Provider != provider || r.payout.Reference != reference {
+		return nil, repository.ErrPayoutNotFound
+	}
+	stored := *r.payout
+	return &stored, nil
+}
+
+func (r *webhookPayoutRepo) UpdatePayoutStatus(ctx context.Context, p *entity.Payout, previousStatus entity.PayoutStatus, entries []*entity.LedgerEntry, event *entity.PayoutEvent) error {
+	if event != nil {
+		if r.events[event.EventID] {
+			return entity.ErrDuplicatePayoutEvent
+		}
+		r.events[event.EventID] = true
+	}
+	if r.payout.Status != previousStatus {
+		return entity.ErrInvalidPayoutTransition
+	}
+
+	stored := *p
+	r.payout = &stored
+	r.entries += len(entries)
+	r.updates++
+	return nil
+}
+
+func TestPayoutServiceHandleWebhook(t *testing.T) {
+	type delivery struct {
+		status    entity.PayoutStatus
+		redeliver bool // resend the previous webhook unchanged
+	}
+
+	tests := []struct {
+		name         string
+		deliveries   []delivery
+		wantStatus   entity.PayoutStatus
+		wantUpdates  int
+		wantReturned bool // the amount goes back to the payee's balance
+	}{
+		{
+			name:        "paid once",
+			deliveries:  []delivery{{status: entity.PayoutStatusPaid}},
+			wantStatus:  entity.PayoutStatusPaid,
+			wantUpdates: 1,
+		},
+		{
+			name:        "redelivered paid event",
+			deliveries:  []delivery{{status: entity.PayoutStatusPaid}, {redeliver: true}, {redeliver: true}},
+			wantStatus:  entity.PayoutStatusPaid,
+			wantUpdates: 1,
+		},
+		{
+			name:         "redelivered failed event returns the amount once",
+			deliveries:   []delivery{{status: entity.PayoutStatusFailed}, {redeliver: true}},
+			wantStatus:   entity.PayoutStatusFailed,
+			wantUpdates:  1,
+			wantReturned: true,
+		},
+		{
+			name:        "failure reported after the payout was paid",
+			deliveries:  []delivery{{status: entity.PayoutStatusPaid}, {status: entity.PayoutStatusFailed}},
+			wantStatus:  entity.PayoutStatusPaid,
+			wantUpdates: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			provider, err := payout.NewFakeProvider("", "test-webhook-secret")
+			if err != nil {
+				t.Fatalf("NewFakeProvider: %v", err)
+			}
+
+			beneficiaryRef, err := provider.CreateBeneficiary(ctx, service.PayoutBeneficiary{UserID: uuid.New(), Email: "influencer@example.com"})
+			if err != nil {
+				t.Fatalf("CreateBeneficiary: %v", err)
+			}
+
+			p := entity.NewPayout(uuid.New(), uuid.New(), uuid.New(), entity.LedgerAccountInfluencerEarnings, 75)
+			transfer, err := provider.InitiateTransfer(ctx, service.TransferRequest{PayoutID: p.ID, BeneficiaryRef: beneficiaryRef, Amount: p.Amount, Currency: "USD"})
+			if err != nil {
+				t.Fatalf("InitiateTransfer: %v", err)
+			}
+			if err := p.MarkProcessing(provider.Name(), transfer.Reference); err != nil {
+				t.Fatalf("MarkProcessing: %v", err)
+			}
+
+			repo := &webhookPayoutRepo{payout: p, events: map[string]bool{}}
+			svc := NewPayoutService(repo, nil, nil, provider, loggerPkg.NewLogger("error"), 50, "USD")
+
+			var body []byte
+			var signature string
+			for i, d := range tt.deliveries {
+				if !d.redeliver {
+					body, signature, err = provider.Settle(transfer.Reference, d.status, "")
+					if err != nil {
+						t.Fatalf("Settle: %v", err)
+					}
+				}
+
+				header := http.Header{}
+				header.Set(payout.SignatureHeader, signature)
+				if err := svc.HandleWebhook(ctx, header, body); err != nil {
+					t.Fatalf("delivery %d: HandleWebhook() error = %v", i, err)
+				}
+			}
+
+			if repo.payout.Status != tt.wantStatus {
+				t.Errorf("payout status = %s, want %s", repo.payout.Status, tt.wantStatus)
+			}
+			if repo.updates != tt.wantUpdates {
+				t.Errorf("payout updates = %d, want %d", repo.updates, tt.wantUpdates)
+			}
+			wantEntries := 0
+			if tt.wantReturned {
+				wantEntries = len(entity.NewPayoutFailureEntries(p))
+			}
+			if repo.entries != wantEntries {
+				t.Errorf("ledger entries = %d, want %d", repo.entries, wantEntries)
+			}
+		})
+	}
+}
+
+func TestPayoutServiceHandleWebhookRejectsBadSignature(t *testing.T) {
+	provider, err := payout.NewFakeProvider("", "test-webhook-secret")
+	if err != nil {
+		t.Fatalf("NewFakeProvider: %v", err)
+	}
+
+	repo := &webhookPayoutRepo{payout: &entity.Payout{}, events: map[string]bool{}}
+	svc := NewPayoutService(repo, nil, nil, provider, loggerPkg.NewLogger("error"), 50, "USD")
+
+	header := http.Header{}
+	header.Set(payout.SignatureHeader, "00")
+	err = svc.HandleWebhook(context.Background(), header, []byte(`{"event_id":"evt_1","reference":"tr_1","status":"paid"}`))
+	if !errors.Is(err, service.ErrInvalidWebhookSignature) {
+		t.Fatalf("HandleWebhook() error = %v, want %v", err, service.ErrInvalidWebhookSignature)
+	}
+	if repo.updates != 0 {
+		t.Errorf("payout updates = %d, want 0", repo.updates)
+	}
+}
+
+func TestPayoutServiceDisabled(t *testing.T) {
+	repo := &webhookPayoutRepo{payout: &entity.Payout{}, events: map[string]bool{}}
+	svc := NewPayoutService(repo, nil, nil, nil, loggerPkg.NewLogger("error"), 50, "USD")
+	ctx := context.Background()
+
+	if _, err := svc.RunPayouts(ctx); !errors.Is(err, service.ErrPayoutsDisabled) {
+		t.Errorf("RunPayouts() error = %v, want %v", err, service.ErrPayoutsDisabled)
+	}
+	if _, err := svc.DispatchPayouts(ctx); !errors.Is(err, service.ErrPayoutsDisabled) {
+		t.Errorf("DispatchPayouts() error = %v, want %v", err, service.ErrPayoutsDisabled)
+	}
+	if _, err := svc.SyncPayouts(ctx); !errors.Is(err, service.ErrPayoutsDisabled) {
+		t.Errorf("SyncPayouts() error = %v, want %v", err, service.ErrPayoutsDisabled)
+	}
+	if err := svc.HandleWebhook(ctx, http.Header{}, []byte(`{}`)); !errors.Is(err, service.ErrPayoutsDisabled) {
+		t.Errorf("HandleWebhook() error = %v, want %v", err, service.ErrPayoutsDisabled)
+	}
+}
+
+// runLedgerRepo lists fixed balances like the ledger does
+type runLedgerRepo struct {
+	repository.LedgerRepository
+	balances []repository.AccountBalance
+}
+
+func (r *runLedgerRepo) ListBalancesAbove(ctx context.Context, account entity.LedgerAccount, threshold float64) ([]repository.AccountBalance, error) {
+	var balances []repository.AccountBalance
+	for _, balance := range r.balances {
+		if balance.Account == account && balance.Balance >= threshold {
+			balances = append(balances, balance)
+		}
+	}
+	return balances, nil
+}
+
+// runPayoutRepo records the payouts and runs a payout run creates
+type runPayoutRepo struct {
+	repository.PayoutRepository
+	methods   map[uuid.UUID]bool
+	spent     map[uuid.UUID]bool // balances paid out since they were listed
+	failFor   uuid.UUID
+	payouts   []*entity.Payout
+	entries   []*entity.LedgerEntry
+	completed *entity.PayoutRun
+}
+
+func (r *runPayoutRepo) StartRun(ctx context.Context, run *entity.PayoutRun) error {
+	return nil
+}
+
+func (r *runPayoutRepo) CompleteRun(ctx context.Context, run *entity.PayoutRun) error {
+	stored := *run
+	r.completed = &stored
+	return nil
+}
+
+func (r *runPayoutRepo) GetPayoutMethod(ctx context.Context, userID uuid.UUID) (*entity.PayoutMethod, error) {
+	if !r.methods[userID] {
+		return nil, repository.ErrPayoutMethodNotFound
+	}
+	return &entity.PayoutMethod{UserID: userID, Type: entity.PayoutMethodBankTransfer}, nil
+}
+
+func (r *runPayoutRepo) CreatePayout(ctx context.Context, p *entity.Payout, entries []*entity.LedgerEntry) error {
+	if p.UserID == r.failFor {
+		return errors.New("connection reset")
+	}
+	if r.spent[p.UserID] {
+		return entity.ErrInsufficientBalance
+	}
+	r.payouts = append(r.payouts, p)
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func TestPayoutServiceRunPayouts(t *testing.T) {
+	tenantID := uuid.New()
+	influencer := uuid.New()
+	smallInfluencer := uuid.New()
+	spentInfluencer := uuid.New()
+	shopper := uuid.New()
+	shopperWithoutMethod := uuid.New()
+
+	balances := []repository.AccountBalance{
+		{TenantID: tenantID, OwnerID: influencer, Account: entity.LedgerAccountInfluencerEarnings, Balance: 75.555},
+		{TenantID: tenantID, OwnerID: smallInfluencer, Account: entity.LedgerAccountInfluencerEarnings, Balance: 49.99},
+		{TenantID: tenantID, OwnerID: spentInfluencer, Account: entity.LedgerAccountInfluencerEarnings, Balance: 80},
+		{TenantID: tenantID, OwnerID: shopper, Account: entity.LedgerAccountCashbackWallet, Balance: 50},
+		{TenantID: tenantID, OwnerID: shopperWithoutMethod, Account: entity.LedgerAccountCashbackWallet, Balance: 120},
+	}
+
+	provider, err := payout.NewFakeProvider("", "test-webhook-secret")
+	if err != nil {
+		t.Fatalf("NewFakeProvider: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		failFor     uuid.UUID
+		wantErr     error
+		wantAnyErr  bool
+		wantPayouts map[uuid.UUID]float64
+		wantStatus  entity.PayoutRunStatus
+	}{
+		{
+			name:        "scheduler",
+			ctx:         context.Background(),
+			wantPayouts: map[uuid.UUID]float64{influencer: 75.56, shopper: 50},
+			wantStatus:  entity.PayoutRunStatusCompleted,
+		},
+		{
+			name:        "super admin",
+			ctx:         service.WithActor(context.Background(), service.Actor{UserID: uuid.New(), Role: entity.RoleSuperAdmin}),
+			wantPayouts: map[uuid.UUID]float64{influencer: 75.56, shopper: 50},
+			wantStatus:  entity.PayoutRunStatusCompleted,
+		},
+		{
+			name:    "tenant admin",
+			ctx:     service.WithActor(context.Background(), service.Actor{UserID: uuid.New(), TenantID: tenantID, Role: "payout_manager"}),
+			wantErr: service.ErrOtherTenant,
+		},
+		{
+			name:        "failed payout",
+			ctx:         context.Background(),
+			failFor:     shopper,
+			wantAnyErr:  true,
+			wantPayouts: map[uuid.UUID]float64{influencer: 75.56},
+			wantStatus:  entity.PayoutRunStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := &runLedgerRepo{balances: balances}
+			payouts := &runPayoutRepo{
+				methods: map[uuid.UUID]bool{shopper: true},
+				spent:   map[uuid.UUID]bool{spentInfluencer: true},
+				failFor: tt.failFor,
+			}
+			svc := NewPayoutService(payouts, ledger, nil, provider, loggerPkg.NewLogger("error"), 50, "USD")
+
+			run, err := svc.RunPayouts(tt.ctx)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RunPayouts() error = %v, want %v", err, tt.wantErr)
+				}
+				if payouts.completed != nil || len(payouts.payouts) != 0 {
+					t.Errorf("run recorded with %d payouts, want no run", len(payouts.payouts))
+				}
+				return
+			}
+			if tt.wantAnyErr != (err != nil) {
+				t.Fatalf("RunPayouts() error = %v, want error %t", err, tt.wantAnyErr)
+			}
+			if payouts.completed == nil || payouts.completed.Status != tt.wantStatus {
+				t.Fatalf("recorded run = %+v, want status %s", payouts.completed, tt.wantStatus)
+			}
+
+			got := map[uuid.UUID]float64{}
+			var total float64
+			for _, p := range payouts.payouts {
+				got[p.UserID] = p.Amount
+				total += p.Amount
+			}
+			if len(got) != len(tt.wantPayouts) {
+				t.Errorf("payouts = %v, want %v", got, tt.wantPayouts)
+			}
+			for owner, amount := range tt.wantPayouts {
+				if got[owner] != amount {
+					t.Errorf("payout to %s = %v, want %v", owner, got[owner], amount)
+				}
+			}
+			if run.PayoutCount != len(tt.wantPayouts) || run.TotalAmount != total {
+				t.Errorf("run = %d payouts totalling %v, want %d totalling %v", run.PayoutCount, run.TotalAmount, len(tt.wantPayouts), total)
+			}
+			if len(payouts.entries) != 2*len(payouts.payouts) {
+				t.Errorf("%d ledger entries for %d payouts, want a debit and a credit each", len(payouts.entries), len(payouts.payouts))
+			}
+		})
+	}
+}
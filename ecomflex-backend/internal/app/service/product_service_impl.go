@@ -15,12 +15,18 @@ import (
 
 type productServiceImpl struct {
 	productRepository repository.ProductRepository
+	tenantRepository  repository.TenantRepository
+	auditLogger       service.AuditLogger
+	quotaService      service.QuotaService
 	// Could add S3Client or other file storage service here
 }
 
-func NewProductService(productRepo repository.ProductRepository) service.ProductService {
+func NewProductService(productRepo repository.ProductRepository, tenantRepo repository.TenantRepository, auditLogger service.AuditLogger, quotaService service.QuotaService) service.ProductService {
 	return &productServiceImpl{
 		productRepository: productRepo,
+		tenantRepository:  tenantRepo,
+		auditLogger:       auditLogger,
+		quotaService:      quotaService,
 	}
 }
 
@@ -31,7 +37,23 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, product *entity.
 	product.UpdatedAt = now
 	product.CurrentBookings = 0
 
-	return s.productRepository.Create(ctx, product)
+	if err := s.checkMarketplace(ctx, product); err != nil {
+		return err
+	}
+
+	// Active products count against the tenant's plan
+	if product.IsActive {
+		if err := s.quotaService.CheckQuota(ctx, product.TenantID, entity.QuotaActiveProducts, 1); err != nil {
+			return err
+		}
+	}
+
+	if err := s.productRepository.Create(ctx, product); err != nil {
+		return err
+	}
+
+	s.audit(ctx, entity.AuditActionProductCreated, nil, product)
+	return nil
 }
 
 func (s *productServiceImpl) GetProductByID(ctx context.Context, id string) (*entity.Product, error) {
@@ -53,12 +75,13 @@ func (s *productServiceImpl) uuidToString(id interface{}) string {
 
 func (s *productServiceImpl) UpdateProduct(ctx context.Context, product *entity.Product) error {
 	// Verify the product exists
-	existingProduct, err := s.productRepository.FindByID(ctx, product.ID.String())
+	existingProduct, err := s.ownedProduct(ctx, product.ID.String())
 	if err != nil {
 		return err
 	}
 
-	// Update timestamps and preserve some fields
+	// Update timestamps and preserve some fields; products stay with the tenant that created them
+	product.TenantID = existingProduct.TenantID
 	product.CreatedAt = existingProduct.CreatedAt
 	product.UpdatedAt = time.Now()
 	product.CurrentBookings = existingProduct.CurrentBookings
@@ -68,16 +91,39 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, product *entity.
 		product.ImageURL = existingProduct.ImageURL
 	}
 
-	return s.productRepository.Update(ctx, product)
+	// Products listed before the tenant narrowed its marketplaces keep their link
+	if product.ProductLink != existingProduct.ProductLink {
+		if err := s.checkMarketplace(ctx, product); err != nil {
+			return err
+		}
+	}
+
+	if product.IsActive && !existingProduct.IsActive {
+		if err := s.quotaService.CheckQuota(ctx, existingProduct.TenantID, entity.QuotaActiveProducts, 1); err != nil {
+			return err
+		}
+	}
+
+	if err := s.productRepository.Update(ctx, product); err != nil {
+		return err
+	}
+
+	s.audit(ctx, entity.AuditActionProductUpdated, existingProduct, product)
+	return nil
 }
 
 func (s *productServiceImpl) DeleteProduct(ctx context.Context, id string) error {
 	// Verify the product exists
-	_, err := s.productRepository.FindByID(ctx, id)
+	existingProduct, err := s.ownedProduct(ctx, id)
 	if err != nil {
 		return err
 	}
-	return s.productRepository.Delete(ctx, id)
+	if err := s.productRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, entity.AuditActionProductDeleted, existingProduct, nil)
+	return nil
 }
 
 func (s *productServiceImpl) GetProducts(ctx context.Context, filter entity.ProductFilter) ([]*entity.Product, int64, error) {
@@ -86,11 +132,68 @@ func (s *productServiceImpl) GetProducts(ctx context.Context, filter entity.Prod
 
 func (s *productServiceImpl) ToggleProductStatus(ctx context.Context, id string, isActive bool) error {
 	// Verify the product exists
-	_, err := s.productRepository.FindByID(ctx, id)
+	existingProduct, err := s.ownedProduct(ctx, id)
 	if err != nil {
 		return err
 	}
-	return s.productRepository.UpdateStatus(ctx, id, isActive)
+	if isActive && !existingProduct.IsActive {
+		if err := s.quotaService.CheckQuota(ctx, existingProduct.TenantID, entity.QuotaActiveProducts, 1); err != nil {
+			return err
+		}
+	}
+	if err := s.productRepository.UpdateStatus(ctx, id, isActive); err != nil {
+		return err
+	}
+
+	updatedProduct := *existingProduct
+	updatedProduct.IsActive = isActive
+	s.audit(ctx, entity.AuditActionProductStatusChanged, existingProduct, &updatedProduct)
+	return nil
+}
+
+// ownedProduct loads a product an admin changes, keeping admins other than super admins to their own tenant's products
+func (s *productServiceImpl) ownedProduct(ctx context.Context, id string) (*entity.Product, error) {
+	product, err := s.productRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckTenant(ctx, product.TenantID); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// checkMarketplace checks that a product links to one of its tenant's allowed marketplaces
+func (s *productServiceImpl) checkMarketplace(ctx context.Context, product *entity.Product) error {
+	tenant, err := s.tenantRepository.GetTenantByID(ctx, product.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	if !tenant.Settings.AllowsProductLink(product.ProductLink) {
+		return entity.ErrMarketplaceNotAllowed
+	}
+
+	return nil
+}
+
+// audit records a change to a product in its tenant's audit log; before or after is nil when the product did not exist
+func (s *productServiceImpl) audit(ctx context.Context, action entity.AuditAction, before, after *entity.Product) {
+	product := after
+	if product == nil {
+		product = before
+	}
+
+	s.auditLogger.Record(ctx, service.AuditEntry{
+		TenantID:   product.TenantID,
+		Action:     action,
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   product.ID,
+		Before:     before,
+		After:      after,
+	})
 }
 
 func (s *productServiceImpl) GetTrendingProducts(ctx context.Context, limit int) ([]*entity.Product, error) {
@@ -105,7 +208,7 @@ func (s *productServiceImpl) GetProductCategories(ctx context.Context) ([]string
 
 func (s *productServiceImpl) UploadProductImage(ctx context.Context, id string, file *multipart.FileHeader) (string, error) {
 	// Verify the product exists
-	_, err := s.productRepository.FindByID(ctx, id)
+	_, err := s.ownedProduct(ctx, id)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// ProofServiceImpl implements ProofService interface
+type ProofServiceImpl struct {
+	proofRepo      repository.ProofRepository
+	bookingService service.BookingService
+	logger         loggerPkg.Logger
+	claimTTL       time.Duration
+}
+
+// NewProofService creates a new ProofServiceImpl.
+// claimTTL is how long a reviewer's claim keeps other reviewers away from a proof.
+func NewProofService(
+	proofRepo repository.ProofRepository,
+	bookingService service.BookingService,
+	logger loggerPkg.Logger,
+	claimTTL time.Duration,
+) service.ProofService {
+	return &ProofServiceImpl{
+		proofRepo:      proofRepo,
+		bookingService: bookingService,
+		logger:         logger,
+		claimTTL:       claimTTL,
+	}
+}
+
+// ListProofQueue lists proofs awaiting or past moderation, oldest first.
+// Admins other than super admins only see their own tenant's proofs.
+func (s *ProofServiceImpl) ListProofQueue(ctx context.Context, filter repository.ProofFilter) ([]*entity.Proof, int, error) {
+	if tenantID, limited := service.TenantScope(ctx); limited {
+		filter.TenantID = tenantID.String()
+	}
+	return s.proofRepo.ListProofs(ctx, filter)
+}
+
+// GetProof gets a proof by ID, keeping admins within their tenant
+func (s *ProofServiceImpl) GetProof(ctx context.Context, id uuid.UUID) (*entity.Proof, error) {
+	proof, err := s.proofRepo.GetProofByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckTenant(ctx, proof.TenantID); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// GetProofHistory gets the moderation history of a proof
+func (s *ProofServiceImpl) GetProofHistory(ctx context.Context, id uuid.UUID) ([]*entity.ProofDecision, error) {
+	// Make sure the proof exists so unknown IDs are reported as such
+	if _, err := s.GetProof(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.proofRepo.GetProofDecisions(ctx, id)
+}
+
+// ClaimProof assigns a proof to a reviewer so others do not review it concurrently
+func (s *ProofServiceImpl) ClaimProof(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Proof, error) {
+	proof, err := s.GetProof(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := proof.Claim(reviewerID); err != nil {
+		return nil, err
+	}
+
+	if err := s.proofRepo.ClaimProof(ctx, proof, s.staleClaimCutoff()); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// ApproveProof approves a proof and its booking
+func (s *ProofServiceImpl) ApproveProof(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Proof, error) {
+	proof, err := s.reviewableProof(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The booking service records the decision on the proof
+	if _, err := s.bookingService.ApproveBooking(ctx, proof.BookingID, reviewerID); err != nil {
+		return nil, err
+	}
+
+	return s.proofRepo.GetProofByID(ctx, id)
+}
+
+// RejectProof rejects a proof and its booking with a reason
+func (s *ProofServiceImpl) RejectProof(ctx context.Context, id, reviewerID uuid.UUID, req service.RejectProofRequest) (*entity.Proof, error) {
+	proof, err := s.reviewableProof(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rejectReq := service.RejectBookingRequest{Reason: req.Reason}
+	if _, err := s.bookingService.RejectBooking(ctx, proof.BookingID, reviewerID, rejectReq); err != nil {
+		return nil, err
+	}
+
+	return s.proofRepo.GetProofByID(ctx, id)
+}
+
+// reviewableProof loads a proof that the reviewer is allowed to decide on
+func (s *ProofServiceImpl) reviewableProof(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Proof, error) {
+	proof, err := s.GetProof(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if proof.IsReviewed() {
+		return nil, entity.ErrProofAlreadyReviewed
+	}
+
+	if proof.IsClaimedByOther(reviewerID, s.staleClaimCutoff()) {
+		return nil, entity.ErrProofAlreadyClaimed
+	}
+
+	return proof, nil
+}
+
+// staleClaimCutoff returns the time before which claims are considered abandoned
+func (s *ProofServiceImpl) staleClaimCutoff() time.Time {
+	return time.Now().Add(-s.claimTTL)
+}
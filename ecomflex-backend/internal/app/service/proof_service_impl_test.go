@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// memoryProofRepo keeps proofs in memory
+type memoryProofRepo struct {
+	repository.ProofRepository
+	proofs map[uuid.UUID]*entity.Proof
+	claims int
+}
+
+func (r *memoryProofRepo) GetProofByID(ctx context.Context, id uuid.UUID) (*entity.Proof, error) {
+	proof, ok := r.proofs[id]
+	if !ok {
+		return nil, repository.ErrProofNotFound
+	}
+	stored := *proof
+	return &stored, nil
+}
+
+func (r *memoryProofRepo) ClaimProof(ctx context.Context, proof *entity.Proof, staleBefore time.Time) error {
+	stored := *proof
+	r.proofs[proof.ID] = &stored
+	r.claims++
+	return nil
+}
+
+// decidingBookingService records the bookings it is asked to decide on
+type decidingBookingService struct {
+	service.BookingService
+	approved []uuid.UUID
+	rejected []uuid.UUID
+}
+
+func (s *decidingBookingService) ApproveBooking(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Booking, error) {
+	s.approved = append(s.approved, id)
+	return nil, nil
+}
+
+func (s *decidingBookingService) RejectBooking(ctx context.Context, id, reviewerID uuid.UUID, req service.RejectBookingRequest) (*entity.Booking, error) {
+	s.rejected = append(s.rejected, id)
+	return nil, nil
+}
+
+func TestProofServiceApproveProof(t *testing.T) {
+	reviewerID := uuid.New()
+	otherID := uuid.New()
+	freshClaim := time.Now().Add(-time.Minute)
+	staleClaim := time.Now().Add(-2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		status    entity.ProofStatus
+		claimedBy *uuid.UUID
+		claimedAt *time.Time
+		wantErr   error
+	}{
+		{name: "unclaimed proof", status: entity.ProofStatusSubmitted},
+		{name: "proof the reviewer claimed", status: entity.ProofStatusInReview, claimedBy: &reviewerID, claimedAt: &freshClaim},
+		{name: "stale claim by another reviewer", status: entity.ProofStatusInReview, claimedBy: &otherID, claimedAt: &staleClaim},
+		{name: "claimed by another reviewer", status: entity.ProofStatusInReview, claimedBy: &otherID, claimedAt: &freshClaim, wantErr: entity.ErrProofAlreadyClaimed},
+		{name: "already approved", status: entity.ProofStatusApproved, wantErr: entity.ErrProofAlreadyReviewed},
+		{name: "withdrawn", status: entity.ProofStatusWithdrawn, wantErr: entity.ErrProofAlreadyReviewed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := entity.NewBooking(uuid.New(), uuid.New(), uuid.New(), "")
+			proof := entity.NewProof(booking, "", "https://proofs/1.png", "", nil)
+			proof.Status = tt.status
+			proof.ClaimedBy = tt.claimedBy
+			proof.ClaimedAt = tt.claimedAt
+
+			proofs := &memoryProofRepo{proofs: map[uuid.UUID]*entity.Proof{proof.ID: proof}}
+			bookings := &decidingBookingService{}
+			svc := NewProofService(proofs, bookings, loggerPkg.NewLogger("error"), time.Hour)
+
+			_, err := svc.ApproveProof(context.Background(), proof.ID, reviewerID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ApproveProof() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(bookings.approved) != 0 {
+					t.Errorf("approved %d bookings, want none", len(bookings.approved))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApproveProof() error = %v", err)
+			}
+			if len(bookings.approved) != 1 || bookings.approved[0] != booking.ID {
+				t.Errorf("approved bookings %v, want %s", bookings.approved, booking.ID)
+			}
+		})
+	}
+}
+
+func TestProofServiceOtherTenant(t *testing.T) {
+	booking := entity.NewBooking(uuid.New(), uuid.New(), uuid.New(), "")
+	proof := entity.NewProof(booking, "", "https://proofs/1.png", "", nil)
+	proofs := &memoryProofRepo{proofs: map[uuid.UUID]*entity.Proof{proof.ID: proof}}
+	bookings := &decidingBookingService{}
+	svc := NewProofService(proofs, bookings, loggerPkg.NewLogger("error"), time.Hour)
+
+	reviewerID := uuid.New()
+	ctx := service.WithActor(context.Background(), service.Actor{
+		UserID:   reviewerID,
+		TenantID: uuid.New(),
+		Role:     entity.RoleSupport,
+	})
+
+	if _, err := svc.ClaimProof(ctx, proof.ID, reviewerID); !errors.Is(err, service.ErrOtherTenant) {
+		t.Errorf("ClaimProof() error = %v, want %v", err, service.ErrOtherTenant)
+	}
+	if _, err := svc.RejectProof(ctx, proof.ID, reviewerID, service.RejectProofRequest{Reason: "blurry"}); !errors.Is(err, service.ErrOtherTenant) {
+		t.Errorf("RejectProof() error = %v, want %v", err, service.ErrOtherTenant)
+	}
+	if proofs.claims != 0 || len(bookings.rejected) != 0 {
+		t.Errorf("%d claims and %d rejections, want none", proofs.claims, len(bookings.rejected))
+	}
+}
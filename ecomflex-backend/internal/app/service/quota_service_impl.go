@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// QuotaServiceImpl implements QuotaService interface
+type QuotaServiceImpl struct {
+	planRepo   repository.PlanRepository
+	tenantRepo repository.TenantRepository
+}
+
+// NewQuotaService creates a new QuotaServiceImpl
+func NewQuotaService(planRepo repository.PlanRepository, tenantRepo repository.TenantRepository) service.QuotaService {
+	return &QuotaServiceImpl{
+		planRepo:   planRepo,
+		tenantRepo: tenantRepo,
+	}
+}
+
+// ListPlans lists the plans tenants can be on, cheapest first
+func (s *QuotaServiceImpl) ListPlans(ctx context.Context) ([]*entity.Plan, error) {
+	return s.planRepo.ListPlans(ctx)
+}
+
+// GetUsage reports a tenant's usage of the limits of its plan
+func (s *QuotaServiceImpl) GetUsage(ctx context.Context, tenantID uuid.UUID) (*service.UsageReport, error) {
+	if err := service.CheckTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.tenantPlan(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	periodStart := entity.UsagePeriodStart(time.Now())
+	usage, err := s.planRepo.GetTenantUsage(ctx, tenantID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &service.UsageReport{
+		TenantID:    tenantID,
+		Plan:        plan,
+		PeriodStart: periodStart,
+		Resources:   make([]service.ResourceUsage, 0, len(entity.QuotaResources)),
+	}
+	for _, resource := range entity.QuotaResources {
+		resourceUsage := service.ResourceUsage{Resource: resource, Used: usage.Of(resource)}
+		if limit, ok := plan.Limit(resource); ok {
+			resourceUsage.Limit = &limit
+		}
+		report.Resources = append(report.Resources, resourceUsage)
+	}
+
+	return report, nil
+}
+
+// CheckQuota checks that a tenant's plan allows using amount more of a resource.
+// Concurrent requests can each pass the check, so a tenant may end up slightly over a limit.
+func (s *QuotaServiceImpl) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource entity.QuotaResource, amount int64) error {
+	plan, err := s.tenantPlan(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	limit, ok := plan.Limit(resource)
+	if !ok {
+		return nil
+	}
+
+	usage, err := s.planRepo.GetTenantUsage(ctx, tenantID, entity.UsagePeriodStart(time.Now()))
+	if err != nil {
+		return err
+	}
+
+	if used := usage.Of(resource); used+amount > limit {
+		return &service.QuotaExceededError{
+			PlanID:    plan.ID,
+			Resource:  resource,
+			Limit:     limit,
+			Used:      used,
+			Requested: amount,
+		}
+	}
+
+	return nil
+}
+
+// RequireFeature checks that a tenant's plan includes a feature
+func (s *QuotaServiceImpl) RequireFeature(ctx context.Context, tenantID uuid.UUID, feature entity.PlanFeature) error {
+	plan, err := s.tenantPlan(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if !plan.HasFeature(feature) {
+		return &service.FeatureUnavailableError{PlanID: plan.ID, Feature: feature}
+	}
+
+	return nil
+}
+
+// RecordStoredFile counts an uploaded file against its tenant's storage
+func (s *QuotaServiceImpl) RecordStoredFile(ctx context.Context, file *entity.StoredFile) error {
+	return s.planRepo.CreateStoredFile(ctx, file)
+}
+
+// tenantPlan gets the plan a tenant is on
+func (s *QuotaServiceImpl) tenantPlan(ctx context.Context, tenantID uuid.UUID) (*entity.Plan, error) {
+	tenant, err := s.tenantRepo.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.planRepo.GetPlan(ctx, tenant.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan of tenant %s: %w", tenantID, err)
+	}
+
+	return plan, nil
+}
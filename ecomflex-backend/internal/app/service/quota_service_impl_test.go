@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// usagePlanRepo reports the same usage for every tenant
+type usagePlanRepo struct {
+	memoryPlanRepo
+	usage entity.TenantUsage
+}
+
+func (r *usagePlanRepo) GetTenantUsage(ctx context.Context, tenantID uuid.UUID, periodStart time.Time) (*entity.TenantUsage, error) {
+	usage := r.usage
+	return &usage, nil
+}
+
+func TestQuotaServiceCheckQuota(t *testing.T) {
+	limit := int64(10)
+	plans := memoryPlanRepo{plans: map[string]*entity.Plan{
+		"free": {ID: "free", MaxActiveProducts: &limit, MaxMonthlyBookings: &limit},
+		"pro":  {ID: "pro"},
+	}}
+
+	tests := []struct {
+		name     string
+		planID   string
+		resource entity.QuotaResource
+		amount   int64
+		wantErr  bool
+	}{
+		{name: "under the limit", planID: "free", resource: entity.QuotaActiveProducts, amount: 1},
+		{name: "up to the limit", planID: "free", resource: entity.QuotaActiveProducts, amount: 2},
+		{name: "past the limit", planID: "free", resource: entity.QuotaActiveProducts, amount: 3, wantErr: true},
+		{name: "already at the limit", planID: "free", resource: entity.QuotaMonthlyBookings, amount: 1, wantErr: true},
+		{name: "resource the plan does not limit", planID: "free", resource: entity.QuotaStorageBytes, amount: 1 << 40},
+		{name: "unlimited plan", planID: "pro", resource: entity.QuotaMonthlyBookings, amount: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := entity.NewTenant("Acme", "acme.ecomflex.com", tt.planID)
+			svc := NewQuotaService(
+				&usagePlanRepo{memoryPlanRepo: plans, usage: entity.TenantUsage{ActiveProducts: 8, MonthlyBookings: 10}},
+				newMemoryTenantRepo(tenant),
+			)
+
+			err := svc.CheckQuota(context.Background(), tenant.ID, tt.resource, tt.amount)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("CheckQuota() error = %v", err)
+				}
+				return
+			}
+
+			var exceeded *service.QuotaExceededError
+			if !errors.As(err, &exceeded) {
+				t.Fatalf("CheckQuota() error = %v, want a QuotaExceededError", err)
+			}
+			if exceeded.PlanID != tt.planID || exceeded.Resource != tt.resource || exceeded.Limit != limit || exceeded.Requested != tt.amount {
+				t.Errorf("CheckQuota() error = %+v", exceeded)
+			}
+		})
+	}
+}
+
+func TestQuotaServiceRequireFeature(t *testing.T) {
+	plans := &memoryPlanRepo{plans: map[string]*entity.Plan{
+		"free": {ID: "free"},
+		"pro":  {ID: "pro", Features: []entity.PlanFeature{entity.FeatureCustomRoles}},
+	}}
+
+	tests := []struct {
+		name    string
+		planID  string
+		feature entity.PlanFeature
+		wantErr bool
+	}{
+		{name: "plan includes the feature", planID: "pro", feature: entity.FeatureCustomRoles},
+		{name: "plan lacks the feature", planID: "pro", feature: entity.FeatureCustomDomain, wantErr: true},
+		{name: "plan without features", planID: "free", feature: entity.FeatureCustomRoles, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := entity.NewTenant("Acme", "acme.ecomflex.com", tt.planID)
+			svc := NewQuotaService(plans, newMemoryTenantRepo(tenant))
+
+			err := svc.RequireFeature(context.Background(), tenant.ID, tt.feature)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("RequireFeature() error = %v", err)
+				}
+				return
+			}
+
+			var unavailable *service.FeatureUnavailableError
+			if !errors.As(err, &unavailable) || unavailable.PlanID != tt.planID || unavailable.Feature != tt.feature {
+				t.Fatalf("RequireFeature() error = %v, want the %s plan to lack %s", err, tt.planID, tt.feature)
+			}
+		})
+	}
+}
+
+func TestQuotaServiceGetUsageOtherTenant(t *testing.T) {
+	tenant := entity.NewTenant("Acme", "acme.ecomflex.com", "free")
+	svc := NewQuotaService(&usagePlanRepo{memoryPlanRepo: memoryPlanRepo{plans: map[string]*entity.Plan{"free": {ID: "free"}}}}, newMemoryTenantRepo(tenant))
+
+	ctx := service.WithActor(context.Background(), service.Actor{
+		UserID:   uuid.New(),
+		TenantID: uuid.New(),
+		Role:     entity.Role("tenant_owner"),
+	})
+	if _, err := svc.GetUsage(ctx, tenant.ID); !errors.Is(err, service.ErrOtherTenant) {
+		t.Fatalf("GetUsage() error = %v, want %v", err, service.ErrOtherTenant)
+	}
+}
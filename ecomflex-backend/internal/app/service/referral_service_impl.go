@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// ErrReferralCodeNotFound is returned when a referral code does not belong to an active influencer
+var ErrReferralCodeNotFound = errors.New("referral code not found")
+
+// maxUserAgentLength bounds the user agent stored for a click, in characters
+const maxUserAgentLength = 512
+
+// ReferralServiceImpl implements ReferralService interface
+type ReferralServiceImpl struct {
+	referralRepo      repository.ReferralRepository
+	userRepo          repository.UserRepository
+	productRepo       repository.ProductRepository
+	tenantRepo        repository.TenantRepository
+	commissionService service.CommissionService
+	logger            loggerPkg.Logger
+	ipHashSalt        string
+}
+
+// NewReferralService creates a new ReferralServiceImpl.
+// How long after a click registrations and bookings are still attributed to it is set per tenant.
+func NewReferralService(
+	referralRepo repository.ReferralRepository,
+	userRepo repository.UserRepository,
+	productRepo repository.ProductRepository,
+	tenantRepo repository.TenantRepository,
+	commissionService service.CommissionService,
+	logger loggerPkg.Logger,
+	ipHashSalt string,
+) service.ReferralService {
+	return &ReferralServiceImpl{
+		referralRepo:      referralRepo,
+		userRepo:          userRepo,
+		productRepo:       productRepo,
+		tenantRepo:        tenantRepo,
+		commissionService: commissionService,
+		logger:            logger,
+		ipHashSalt:        ipHashSalt,
+	}
+}
+
+// TrackClick records a click on a referral link; the click ID is the attribution token
+func (s *ReferralServiceImpl) TrackClick(ctx context.Context, req service.TrackClickRequest) (*entity.ReferralClick, error) {
+	influencer, err := s.influencerByCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only keep the landing product if it exists
+	var productID *uuid.UUID
+	if req.ProductID != "" {
+		if product, err := s.productRepo.FindByID(ctx, req.ProductID); err == nil {
+			productID = &product.ID
+		}
+	}
+
+	userAgent := util.TruncateRunes(req.UserAgent, maxUserAgentLength)
+	click := entity.NewReferralClick(influencer, productID, s.hashIP(req.IPAddress), userAgent)
+	if err := s.referralRepo.RecordClick(ctx, click); err != nil {
+		return nil, err
+	}
+
+	return click, nil
+}
+
+// AttributeRegistration creates a referral for a new user from an attribution token
+func (s *ReferralServiceImpl) AttributeRegistration(ctx context.Context, token string, user *entity.User) (*entity.Referral, error) {
+	click, err := s.clickFromToken(ctx, token)
+	if err != nil || click == nil {
+		return nil, err
+	}
+
+	// Influencers cannot refer themselves
+	if click.InfluencerID == user.ID {
+		return nil, nil
+	}
+
+	referral := entity.NewReferral(click.TenantID, click.InfluencerID, user.ID, click.ProductID, entity.ReferralSourceRegistration, 0)
+	referral.ClickID = &click.ID
+
+	return s.createReferral(ctx, referral)
+}
+
+// AttributeBooking creates a referral for a booking from an attribution token, the booking's
+// referral code or the user's signup referral
+func (s *ReferralServiceImpl) AttributeBooking(ctx context.Context, token string, booking *entity.Booking) (*entity.Referral, error) {
+	var tenantID, influencerID uuid.UUID
+	var clickID *uuid.UUID
+
+	click, err := s.clickFromToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case click != nil:
+		tenantID, influencerID, clickID = click.TenantID, click.InfluencerID, &click.ID
+	case booking.ReferralCode != "":
+		influencer, err := s.influencerByCode(ctx, booking.ReferralCode)
+		if err != nil {
+			if errors.Is(err, ErrReferralCodeNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		tenantID, influencerID = influencer.TenantID, influencer.ID
+	default:
+		// Fall back to the influencer who brought the user in, while still within the booking tenant's window
+		window, err := s.attributionWindow(ctx, booking.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		signup, err := s.referralRepo.GetRegistrationReferral(ctx, booking.UserID, time.Now().Add(-window))
+		if err != nil {
+			if errors.Is(err, repository.ErrReferralNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		tenantID, influencerID, clickID = signup.TenantID, signup.InfluencerID, signup.ClickID
+	}
+
+	// Influencers cannot refer themselves
+	if influencerID == booking.UserID {
+		return nil, nil
+	}
+
+	productID := booking.ProductID
+	bookingID := booking.ID
+	referral := entity.NewReferral(tenantID, influencerID, booking.UserID, &productID, entity.ReferralSourceBooking, 0)
+	referral.BookingID = &bookingID
+	referral.ClickID = clickID
+
+	return s.createReferral(ctx, referral)
+}
+
+// ListReferrals lists referrals for admins; admins other than super admins only see their own tenant's
+func (s *ReferralServiceImpl) ListReferrals(ctx context.Context, filter repository.ReferralFilter) ([]*entity.Referral, int, error) {
+	if tenantID, limited := service.TenantScope(ctx); limited {
+		filter.TenantID = tenantID.String()
+	}
+	return s.referralRepo.ListReferrals(ctx, filter)
+}
+
+// ApproveReferral approves a pending referral and computes its earnings from the commission rules
+func (s *ReferralServiceImpl) ApproveReferral(ctx context.Context, id uuid.UUID) (*entity.Referral, error) {
+	referral, err := s.referralRepo.GetReferralByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckTenant(ctx, referral.TenantID); err != nil {
+		return nil, err
+	}
+
+	return s.approve(ctx, referral)
+}
+
+// RejectReferral rejects a referral, reversing its earnings if it was already approved
+func (s *ReferralServiceImpl) RejectReferral(ctx context.Context, id uuid.UUID) (*entity.Referral, error) {
+	referral, err := s.referralRepo.GetReferralByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckTenant(ctx, referral.TenantID); err != nil {
+		return nil, err
+	}
+
+	return s.reject(ctx, referral)
+}
+
+// ApproveBookingReferral approves the referral of an approved booking, if there is one
+func (s *ReferralServiceImpl) ApproveBookingReferral(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error) {
+	referral, err := s.referralRepo.GetReferralByBooking(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReferralNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s.approve(ctx, referral)
+}
+
+// RejectBookingReferral rejects the referral of a rejected or cancelled booking, if there is one
+func (s *ReferralServiceImpl) RejectBookingReferral(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error) {
+	referral, err := s.referralRepo.GetReferralByBooking(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReferralNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s.reject(ctx, referral)
+}
+
+// approve computes the commission of a pending referral and approves it
+func (s *ReferralServiceImpl) approve(ctx context.Context, referral *entity.Referral) (*entity.Referral, error) {
+	if !referral.IsPending() {
+		return nil, entity.ErrReferralNotPending
+	}
+
+	snapshot, err := s.commissionService.CalculateCommission(ctx, referral)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate commission: %w", err)
+	}
+
+	referral.ApplyCommission(snapshot)
+	referral.Approve()
+
+	// The earnings are credited to the influencer in the same transaction as the approval
+	if err := s.referralRepo.UpdateReferralDecision(ctx, referral, "pending", entity.NewCommissionEntries(referral)); err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
+
+// reject rejects a pending or approved referral, reversing the earnings of an approved one
+func (s *ReferralServiceImpl) reject(ctx context.Context, referral *entity.Referral) (*entity.Referral, error) {
+	if !referral.CanReject() {
+		return nil, entity.ErrReferralAlreadyRejected
+	}
+
+	previousStatus := referral.Status
+	var entries []*entity.LedgerEntry
+	if referral.IsApproved() {
+		entries = entity.NewReversalEntries(referral)
+	}
+
+	referral.Reject()
+
+	if err := s.referralRepo.UpdateReferralDecision(ctx, referral, previousStatus, entries); err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
+
+// createReferral stores a referral, returning nil if it was already attributed
+func (s *ReferralServiceImpl) createReferral(ctx context.Context, referral *entity.Referral) (*entity.Referral, error) {
+	created, err := s.referralRepo.CreateReferral(ctx, referral)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		return nil, nil
+	}
+
+	s.logger.Info("Referral attributed",
+		"referral_id", referral.ID.String(),
+		"influencer_id", referral.InfluencerID.String(),
+		"source", string(referral.Source),
+	)
+
+	return referral, nil
+}
+
+// clickFromToken loads the click behind an attribution token if it is still within its tenant's window.
+// Unknown, malformed and expired tokens yield no click.
+func (s *ReferralServiceImpl) clickFromToken(ctx context.Context, token string) (*entity.ReferralClick, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	clickID, err := uuid.Parse(token)
+	if err != nil {
+		return nil, nil
+	}
+
+	click, err := s.referralRepo.GetClickByID(ctx, clickID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReferralClickNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	window, err := s.attributionWindow(ctx, click.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !click.IsWithin(window) {
+		return nil, nil
+	}
+
+	return click, nil
+}
+
+// attributionWindow gets how long after a click a tenant still attributes registrations and bookings to it
+func (s *ReferralServiceImpl) attributionWindow(ctx context.Context, tenantID uuid.UUID) (time.Duration, error) {
+	tenant, err := s.tenantRepo.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	return tenant.Settings.AttributionWindow(), nil
+}
+
+// influencerByCode finds the active influencer owning a referral code
+func (s *ReferralServiceImpl) influencerByCode(ctx context.Context, code string) (*entity.User, error) {
+	if code == "" {
+		return nil, ErrReferralCodeNotFound
+	}
+
+	influencer, err := s.userRepo.GetUserByReferralCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrReferralCodeNotFound
+		}
+		return nil, fmt.Errorf("failed to get influencer: %w", err)
+	}
+
+	if influencer.Role != entity.RoleInfluencer {
+		return nil, ErrReferralCodeNotFound
+	}
+
+	return influencer, nil
+}
+
+// hashIP hashes an IP address so clicks can be grouped without storing the address
+func (s *ReferralServiceImpl) hashIP(ip string) string {
+	mac := hmac.New(sha256.New, []byte(s.ipHashSalt))
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
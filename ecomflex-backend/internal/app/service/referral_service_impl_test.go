@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// referralCodeUserRepo finds users by referral code
+type referralCodeUserRepo struct {
+	repository.UserRepository
+	users map[string]*entity.User
+	err   error
+}
+
+func (r *referralCodeUserRepo) GetUserByReferralCode(ctx context.Context, referralCode string) (*entity.User, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	user, ok := r.users[referralCode]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// clickReferralRepo keeps the clicks it records
+type clickReferralRepo struct {
+	repository.ReferralRepository
+	clicks []*entity.ReferralClick
+}
+
+func (r *clickReferralRepo) RecordClick(ctx context.Context, click *entity.ReferralClick) error {
+	r.clicks = append(r.clicks, click)
+	return nil
+}
+
+func TestReferralServiceTrackClick(t *testing.T) {
+	influencer := entity.NewUser(uuid.New(), "influencer@example.com", "hash", "Influencer", entity.RoleInfluencer, "")
+	influencer.ReferralCode = "INFLU1234"
+	customer := entity.NewUser(influencer.TenantID, "public@example.com", "hash", "Public", entity.RolePublic, "")
+	customer.ReferralCode = "CUSTO1234"
+
+	tests := []struct {
+		name          string
+		code          string
+		userAgent     string
+		lookupErr     error
+		wantErr       error
+		wantOtherErr  bool
+		wantUserAgent string
+	}{
+		{name: "influencer code", code: influencer.ReferralCode, userAgent: "Mozilla/5.0", wantUserAgent: "Mozilla/5.0"},
+		{
+			name:          "long multi-byte user agent",
+			code:          influencer.ReferralCode,
+			userAgent:     strings.Repeat("é", maxUserAgentLength+10),
+			wantUserAgent: strings.Repeat("é", maxUserAgentLength),
+		},
+		{name: "unknown code", code: "NOPE00000", wantErr: ErrReferralCodeNotFound},
+		{name: "empty code", code: "", wantErr: ErrReferralCodeNotFound},
+		{name: "code of a non-influencer", code: customer.ReferralCode, wantErr: ErrReferralCodeNotFound},
+		{name: "lookup failure", code: influencer.ReferralCode, lookupErr: errors.New("connection refused"), wantOtherErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &referralCodeUserRepo{
+				users: map[string]*entity.User{influencer.ReferralCode: influencer, customer.ReferralCode: customer},
+				err:   tt.lookupErr,
+			}
+			referrals := &clickReferralRepo{}
+			svc := NewReferralService(referrals, users, nil, nil, nil, nil, "salt")
+
+			click, err := svc.TrackClick(context.Background(), service.TrackClickRequest{
+				Code:      tt.code,
+				IPAddress: "203.0.113.7",
+				UserAgent: tt.userAgent,
+			})
+			if tt.wantErr != nil || tt.wantOtherErr {
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("TrackClick() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantOtherErr && (err == nil || errors.Is(err, ErrReferralCodeNotFound)) {
+					t.Fatalf("TrackClick() error = %v, want a lookup error", err)
+				}
+				if len(referrals.clicks) != 0 {
+					t.Errorf("recorded %d clicks, want none", len(referrals.clicks))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TrackClick() error = %v", err)
+			}
+			if click.UserAgent != tt.wantUserAgent {
+				t.Errorf("user agent = %q, want %q", click.UserAgent, tt.wantUserAgent)
+			}
+			if !utf8.ValidString(click.UserAgent) {
+				t.Errorf("user agent %q is not valid UTF-8", click.UserAgent)
+			}
+			if click.IPHash == "" || click.IPHash == "203.0.113.7" {
+				t.Errorf("ip hash = %q, want a hash of the address", click.IPHash)
+			}
+			if len(referrals.clicks) != 1 {
+				t.Errorf("recorded %d clicks, want 1", len(referrals.clicks))
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// RoleServiceImpl implements RoleService interface
+type RoleServiceImpl struct {
+	roleRepo     repository.TenantRoleRepository
+	userRepo     repository.UserRepository
+	auditLogger  service.AuditLogger
+	quotaService service.QuotaService
+}
+
+// NewRoleService creates a new RoleServiceImpl
+func NewRoleService(roleRepo repository.TenantRoleRepository, userRepo repository.UserRepository, auditLogger service.AuditLogger, quotaService service.QuotaService) service.RoleService {
+	return &RoleServiceImpl{
+		roleRepo:     roleRepo,
+		userRepo:     userRepo,
+		auditLogger:  auditLogger,
+		quotaService: quotaService,
+	}
+}
+
+// GetPermissions gets the permissions a user has through their role.
+// Users whose custom role was removed have no permissions.
+func (s *RoleServiceImpl) GetPermissions(ctx context.Context, user *entity.User) (entity.PermissionSet, error) {
+	if user.Role.IsBuiltIn() {
+		return entity.NewPermissionSet(user.Role.Permissions()...), nil
+	}
+
+	role, err := s.roleRepo.GetRoleByName(ctx, user.TenantID, user.Role)
+	if err != nil {
+		if errors.Is(err, repository.ErrTenantRoleNotFound) {
+			return entity.NewPermissionSet(), nil
+		}
+		return nil, err
+	}
+
+	return role.PermissionSet(), nil
+}
+
+// ListRoles lists the built-in roles and a tenant's custom roles
+func (s *RoleServiceImpl) ListRoles(ctx context.Context, actor *entity.User, tenantID uuid.UUID) (*service.RoleList, error) {
+	if !actor.CanActInTenant(tenantID) {
+		return nil, service.ErrOtherTenant
+	}
+
+	custom, err := s.roleRepo.ListRoles(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &service.RoleList{
+		Custom:      custom,
+		Permissions: entity.CustomRolePermissions(),
+	}
+	for _, role := range entity.BuiltInRoles() {
+		list.BuiltIn = append(list.BuiltIn, service.BuiltInRole{
+			Name:        role,
+			Permissions: role.Permissions(),
+		})
+	}
+
+	return list, nil
+}
+
+// CreateRole creates a custom role for a tenant
+func (s *RoleServiceImpl) CreateRole(ctx context.Context, actor *entity.User, tenantID uuid.UUID, req service.RoleRequest) (*entity.TenantRole, error) {
+	if !actor.CanActInTenant(tenantID) {
+		return nil, service.ErrOtherTenant
+	}
+
+	// Existing custom roles keep working when a tenant moves to a plan without them
+	if err := s.quotaService.RequireFeature(ctx, tenantID, entity.FeatureCustomRoles); err != nil {
+		return nil, err
+	}
+
+	role, err := entity.NewTenantRole(tenantID, entity.Role(req.Name), req.Description, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCanGrant(ctx, actor, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.CreateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.auditRole(ctx, entity.AuditActionRoleCreated, nil, role)
+	return role, nil
+}
+
+// UpdateRole replaces the description and permissions of a tenant's custom role
+func (s *RoleServiceImpl) UpdateRole(ctx context.Context, actor *entity.User, tenantID, roleID uuid.UUID, req service.RoleRequest) (*entity.TenantRole, error) {
+	if !actor.CanActInTenant(tenantID) {
+		return nil, service.ErrOtherTenant
+	}
+
+	role, err := s.roleRepo.GetRoleByID(ctx, tenantID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *role
+	if err := role.Update(req.Description, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCanGrant(ctx, actor, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.UpdateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.auditRole(ctx, entity.AuditActionRoleUpdated, &before, role)
+	return role, nil
+}
+
+// DeleteRole deletes a tenant's custom role that no user has
+func (s *RoleServiceImpl) DeleteRole(ctx context.Context, actor *entity.User, tenantID, roleID uuid.UUID) error {
+	if !actor.CanActInTenant(tenantID) {
+		return service.ErrOtherTenant
+	}
+
+	role, err := s.roleRepo.GetRoleByID(ctx, tenantID, roleID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.userRepo.CountUsersWithRole(ctx, tenantID, role.Name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return service.ErrRoleInUse
+	}
+
+	if err := s.roleRepo.DeleteRole(ctx, tenantID, roleID); err != nil {
+		return err
+	}
+
+	s.auditRole(ctx, entity.AuditActionRoleDeleted, role, nil)
+	return nil
+}
+
+// AssignRole gives a user a built-in role or a custom role of their tenant.
+// The acting user must hold the permissions of both the old and the new role.
+func (s *RoleServiceImpl) AssignRole(ctx context.Context, actor *entity.User, userID uuid.UUID, role entity.Role) (*entity.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !actor.CanActInTenant(user.TenantID) {
+		return nil, service.ErrOtherTenant
+	}
+
+	permissions := role.Permissions()
+	if !role.IsBuiltIn() {
+		custom, err := s.roleRepo.GetRoleByName(ctx, user.TenantID, role)
+		if err != nil {
+			if errors.Is(err, repository.ErrTenantRoleNotFound) {
+				return nil, service.ErrUnknownRole
+			}
+			return nil, err
+		}
+		permissions = custom.Permissions
+	}
+
+	if err := s.checkCanGrant(ctx, actor, permissions); err != nil {
+		return nil, err
+	}
+
+	// Users cannot take roles away from someone with permissions they lack either
+	current, err := s.GetPermissions(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCanGrant(ctx, actor, current.List()); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, user.ID, role); err != nil {
+		return nil, err
+	}
+	before := *user
+	user.Role = role
+
+	s.auditLogger.Record(ctx, service.AuditEntry{
+		TenantID:   user.TenantID,
+		Action:     entity.AuditActionUserRoleChanged,
+		EntityType: entity.AuditEntityUser,
+		EntityID:   user.ID,
+		Before:     &before,
+		After:      user,
+	})
+
+	return user, nil
+}
+
+// auditRole records a change to a custom role in its tenant's audit log; before or after is nil when the role did not exist
+func (s *RoleServiceImpl) auditRole(ctx context.Context, action entity.AuditAction, before, after *entity.TenantRole) {
+	role := after
+	if role == nil {
+		role = before
+	}
+
+	s.auditLogger.Record(ctx, service.AuditEntry{
+		TenantID:   role.TenantID,
+		Action:     action,
+		EntityType: entity.AuditEntityRole,
+		EntityID:   role.ID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// checkCanGrant checks that the acting user holds every permission they are granting
+func (s *RoleServiceImpl) checkCanGrant(ctx context.Context, actor *entity.User, permissions []entity.Permission) error {
+	held, err := s.GetPermissions(ctx, actor)
+	if err != nil {
+		return err
+	}
+
+	if !held.Has(permissions...) {
+		return service.ErrPermissionEscalation
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// memoryRoleRepo keeps custom roles in memory
+type memoryRoleRepo struct {
+	repository.TenantRoleRepository
+	roles []*entity.TenantRole
+}
+
+func (r *memoryRoleRepo) GetRoleByName(ctx context.Context, tenantID uuid.UUID, name entity.Role) (*entity.TenantRole, error) {
+	for _, role := range r.roles {
+		if role.TenantID == tenantID && role.Name == name {
+			return role, nil
+		}
+	}
+	return nil, repository.ErrTenantRoleNotFound
+}
+
+func (r *memoryRoleRepo) CreateRole(ctx context.Context, role *entity.TenantRole) error {
+	r.roles = append(r.roles, role)
+	return nil
+}
+
+// roleUserRepo keeps users in memory and records role changes
+type roleUserRepo struct {
+	repository.UserRepository
+	users map[uuid.UUID]*entity.User
+}
+
+func newRoleUserRepo(users ...*entity.User) *roleUserRepo {
+	repo := &roleUserRepo{users: map[uuid.UUID]*entity.User{}}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+func (r *roleUserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	stored := *user
+	return &stored, nil
+}
+
+func (r *roleUserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role entity.Role) error {
+	r.users[id].Role = role
+	return nil
+}
+
+// allowAllQuota lets every tenant use every feature without limits
+type allowAllQuota struct {
+	service.QuotaService
+}
+
+func (allowAllQuota) RequireFeature(ctx context.Context, tenantID uuid.UUID, feature entity.PlanFeature) error {
+	return nil
+}
+
+func (allowAllQuota) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource entity.QuotaResource, amount int64) error {
+	return nil
+}
+
+func newTestUser(tenantID uuid.UUID, role entity.Role) *entity.User {
+	return entity.NewUser(tenantID, uuid.NewString()+"@example.com", "hash", "Test", role, "")
+}
+
+func mustTenantRole(t *testing.T, tenantID uuid.UUID, name entity.Role, permissions ...entity.Permission) *entity.TenantRole {
+	t.Helper()
+	role, err := entity.NewTenantRole(tenantID, name, "", permissions)
+	if err != nil {
+		t.Fatalf("NewTenantRole: %v", err)
+	}
+	return role
+}
+
+func TestRoleServiceGetPermissions(t *testing.T) {
+	tenantID := uuid.New()
+	moderator := mustTenantRole(t, tenantID, "moderator", entity.PermissionProofsRead, entity.PermissionProofsReview)
+	roles := &memoryRoleRepo{roles: []*entity.TenantRole{moderator}}
+	svc := NewRoleService(roles, nil, &recordingAuditLogger{}, allowAllQuota{})
+
+	tests := []struct {
+		name    string
+		user    *entity.User
+		want    []entity.Permission
+		notWant []entity.Permission
+	}{
+		{name: "built-in role", user: newTestUser(tenantID, entity.RoleSupport), want: entity.RoleSupport.Permissions(), notWant: []entity.Permission{entity.PermissionRolesManage}},
+		{name: "custom role", user: newTestUser(tenantID, "moderator"), want: moderator.Permissions, notWant: []entity.Permission{entity.PermissionBookingsRead}},
+		{name: "custom role of another tenant", user: newTestUser(uuid.New(), "moderator"), notWant: moderator.Permissions},
+		{name: "removed custom role", user: newTestUser(tenantID, "editor"), notWant: []entity.Permission{entity.PermissionProofsRead}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissions, err := svc.GetPermissions(context.Background(), tt.user)
+			if err != nil {
+				t.Fatalf("GetPermissions() error = %v", err)
+			}
+			if !permissions.Has(tt.want...) {
+				t.Errorf("GetPermissions() = %v, want %v", permissions.List(), tt.want)
+			}
+			for _, permission := range tt.notWant {
+				if permissions.Has(permission) {
+					t.Errorf("GetPermissions() = %v, want no %s", permissions.List(), permission)
+				}
+			}
+		})
+	}
+}
+
+func TestRoleServiceAssignRole(t *testing.T) {
+	tenantID := uuid.New()
+	otherTenantID := uuid.New()
+
+	roleManager := mustTenantRole(t, tenantID, "role_manager", entity.PermissionRolesManage, entity.PermissionProofsRead)
+	moderator := mustTenantRole(t, tenantID, "moderator", entity.PermissionProofsRead)
+	reviewer := mustTenantRole(t, tenantID, "reviewer", entity.PermissionProofsRead, entity.PermissionProofsReview)
+	otherModerator := mustTenantRole(t, otherTenantID, "moderator", entity.PermissionProofsRead)
+
+	tests := []struct {
+		name    string
+		actor   *entity.User
+		target  *entity.User
+		role    entity.Role
+		wantErr error
+	}{
+		{name: "grant held permissions", actor: newTestUser(tenantID, roleManager.Name), target: newTestUser(tenantID, entity.RolePublic), role: moderator.Name},
+		{name: "grant unheld permissions", actor: newTestUser(tenantID, roleManager.Name), target: newTestUser(tenantID, entity.RolePublic), role: reviewer.Name, wantErr: service.ErrPermissionEscalation},
+		{name: "grant a built-in role with unheld permissions", actor: newTestUser(tenantID, roleManager.Name), target: newTestUser(tenantID, entity.RolePublic), role: entity.RoleSupport, wantErr: service.ErrPermissionEscalation},
+		{name: "demote a user with unheld permissions", actor: newTestUser(tenantID, roleManager.Name), target: newTestUser(tenantID, reviewer.Name), role: entity.RolePublic, wantErr: service.ErrPermissionEscalation},
+		{name: "grant super admin", actor: newTestUser(tenantID, roleManager.Name), target: newTestUser(tenantID, entity.RolePublic), role: entity.RoleSuperAdmin, wantErr: service.ErrPermissionEscalation},
+		{name: "user of another tenant", actor: newTestUser(tenantID, roleManager.Name), target: newTestUser(otherTenantID, entity.RolePublic), role: otherModerator.Name, wantErr: service.ErrOtherTenant},
+		{name: "unknown role", actor: newTestUser(tenantID, roleManager.Name), target: newTestUser(tenantID, entity.RolePublic), role: "editor", wantErr: service.ErrUnknownRole},
+		{name: "super admin in another tenant", actor: newTestUser(uuid.New(), entity.RoleSuperAdmin), target: newTestUser(tenantID, entity.RolePublic), role: reviewer.Name},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := &memoryRoleRepo{roles: []*entity.TenantRole{roleManager, moderator, reviewer, otherModerator}}
+			users := newRoleUserRepo(tt.actor, tt.target)
+			auditLogger := &recordingAuditLogger{}
+			svc := NewRoleService(roles, users, auditLogger, allowAllQuota{})
+
+			previousRole := tt.target.Role
+			user, err := svc.AssignRole(context.Background(), tt.actor, tt.target.ID, tt.role)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("AssignRole() error = %v, want %v", err, tt.wantErr)
+				}
+				if users.users[tt.target.ID].Role != previousRole || len(auditLogger.entries) != 0 {
+					t.Errorf("role = %s with %d audit entries, want it unchanged", users.users[tt.target.ID].Role, len(auditLogger.entries))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AssignRole() error = %v", err)
+			}
+			if user.Role != tt.role || users.users[tt.target.ID].Role != tt.role {
+				t.Errorf("role = %s, want %s", users.users[tt.target.ID].Role, tt.role)
+			}
+			if len(auditLogger.entries) != 1 {
+				t.Errorf("%d audit entries, want 1", len(auditLogger.entries))
+			}
+		})
+	}
+}
+
+func TestRoleServiceCreateRole(t *testing.T) {
+	tenantID := uuid.New()
+	roleManager := mustTenantRole(t, tenantID, "role_manager", entity.PermissionRolesManage, entity.PermissionProofsRead)
+
+	tests := []struct {
+		name        string
+		actor       *entity.User
+		tenantID    uuid.UUID
+		permissions []entity.Permission
+		wantErr     error
+	}{
+		{name: "held permissions", actor: newTestUser(tenantID, roleManager.Name), tenantID: tenantID, permissions: []entity.Permission{entity.PermissionProofsRead}},
+		{name: "unheld permissions", actor: newTestUser(tenantID, roleManager.Name), tenantID: tenantID, permissions: []entity.Permission{entity.PermissionPayoutsRun}, wantErr: service.ErrPermissionEscalation},
+		{name: "another tenant", actor: newTestUser(tenantID, roleManager.Name), tenantID: uuid.New(), permissions: []entity.Permission{entity.PermissionProofsRead}, wantErr: service.ErrOtherTenant},
+		{name: "tenant management", actor: newTestUser(uuid.New(), entity.RoleSuperAdmin), tenantID: tenantID, permissions: []entity.Permission{entity.PermissionTenantsManage}, wantErr: entity.ErrInvalidTenantRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := &memoryRoleRepo{roles: []*entity.TenantRole{roleManager}}
+			svc := NewRoleService(roles, nil, &recordingAuditLogger{}, allowAllQuota{})
+
+			_, err := svc.CreateRole(context.Background(), tt.actor, tt.tenantID, service.RoleRequest{Name: "moderator", Permissions: tt.permissions})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateRole() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(roles.roles) != 1 {
+					t.Errorf("%d roles stored, want the new role left out", len(roles.roles))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateRole() error = %v", err)
+			}
+			if len(roles.roles) != 2 {
+				t.Errorf("%d roles stored, want 2", len(roles.roles))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// SessionServiceImpl implements SessionService interface
+type SessionServiceImpl struct {
+	authRepo repository.AuthRepository
+	userRepo repository.UserRepository
+}
+
+// NewSessionService creates a new SessionServiceImpl
+func NewSessionService(authRepo repository.AuthRepository, userRepo repository.UserRepository) service.SessionService {
+	return &SessionServiceImpl{
+		authRepo: authRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ListSessions lists the sessions of a user, flagging the one making the request
+func (s *SessionServiceImpl) ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*service.SessionResponse, error) {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.authRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*service.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = &service.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID.String() == currentSessionID,
+		}
+	}
+
+	return responses, nil
+}
+
+// RevokeSession signs a user out of one of their sessions.
+// Access tokens of the session stop working right away, not only once they expire.
+func (s *SessionServiceImpl) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.authRepo.DeleteSession(ctx, userID, sessionID)
+}
+
+// RevokeAllSessions signs a user out everywhere
+func (s *SessionServiceImpl) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.authRepo.DeleteAllSessions(ctx, userID)
+}
+
+// checkUser keeps admins other than super admins to the sessions of their own tenant's users
+func (s *SessionServiceImpl) checkUser(ctx context.Context, userID uuid.UUID) error {
+	actor, ok := service.ActorFromContext(ctx)
+	if ok && actor.UserID == userID {
+		return nil
+	}
+	if _, limited := service.TenantScope(ctx); !limited {
+		return nil
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return service.CheckTenant(ctx, user.TenantID)
+}
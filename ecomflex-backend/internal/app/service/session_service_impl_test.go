@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// sessionAuthRepo keeps sessions in memory
+type sessionAuthRepo struct {
+	repository.AuthRepository
+	sessions map[uuid.UUID]*entity.Session
+}
+
+func (r *sessionAuthRepo) ListSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
+	sessions := []*entity.Session{}
+	for _, session := range r.sessions {
+		if session.UserID == userID {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *sessionAuthRepo) DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, ok := r.sessions[sessionID]
+	if !ok || session.UserID != userID {
+		return repository.ErrSessionNotFound
+	}
+	delete(r.sessions, sessionID)
+	return nil
+}
+
+func (r *sessionAuthRepo) DeleteAllSessions(ctx context.Context, userID uuid.UUID) error {
+	for id, session := range r.sessions {
+		if session.UserID == userID {
+			delete(r.sessions, id)
+		}
+	}
+	return nil
+}
+
+// newTestSession signs a user in from a device
+func newTestSession(repo *sessionAuthRepo, user *entity.User, userAgent string) *entity.Session {
+	token := entity.NewRefreshToken(uuid.NewString(), user.ID, time.Now().Add(time.Hour))
+	session := entity.NewSession(token, userAgent, "203.0.113.7")
+	repo.sessions[session.ID] = session
+	return session
+}
+
+func TestSessionServiceListSessions(t *testing.T) {
+	user := newTestUser(uuid.New(), entity.RoleInfluencer)
+	sessions := &sessionAuthRepo{sessions: map[uuid.UUID]*entity.Session{}}
+	current := newTestSession(sessions, user, "laptop")
+	newTestSession(sessions, user, "phone")
+	newTestSession(sessions, newTestUser(user.TenantID, entity.RoleInfluencer), "someone else")
+
+	svc := NewSessionService(sessions, newRoleUserRepo(user))
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: user.ID, TenantID: user.TenantID, Role: user.Role})
+
+	listed, err := svc.ListSessions(ctx, user.ID, current.ID.String())
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("listed %d sessions, want the user's 2", len(listed))
+	}
+	for _, session := range listed {
+		if session.Current != (session.ID == current.ID) {
+			t.Errorf("session %s (%s) current = %t", session.ID, session.UserAgent, session.Current)
+		}
+	}
+}
+
+func TestSessionServiceRevokeSession(t *testing.T) {
+	tenantID := uuid.New()
+	user := newTestUser(tenantID, entity.RoleInfluencer)
+	otherTenantUser := newTestUser(uuid.New(), entity.RoleInfluencer)
+
+	tests := []struct {
+		name    string
+		actor   service.Actor
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{name: "own session", actor: service.Actor{UserID: user.ID, TenantID: tenantID, Role: user.Role}, userID: user.ID},
+		{name: "admin of the user's tenant", actor: service.Actor{UserID: uuid.New(), TenantID: tenantID, Role: entity.RoleSupport}, userID: user.ID},
+		{name: "super admin", actor: service.Actor{UserID: uuid.New(), Role: entity.RoleSuperAdmin}, userID: otherTenantUser.ID},
+		{name: "admin of another tenant", actor: service.Actor{UserID: uuid.New(), TenantID: tenantID, Role: entity.RoleSupport}, userID: otherTenantUser.ID, wantErr: service.ErrOtherTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := &sessionAuthRepo{sessions: map[uuid.UUID]*entity.Session{}}
+			svc := NewSessionService(sessions, newRoleUserRepo(user, otherTenantUser))
+			target := user
+			if tt.userID == otherTenantUser.ID {
+				target = otherTenantUser
+			}
+			session := newTestSession(sessions, target, "laptop")
+			other := newTestSession(sessions, target, "phone")
+			ctx := service.WithActor(context.Background(), tt.actor)
+
+			err := svc.RevokeSession(ctx, tt.userID, session.ID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RevokeSession() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(sessions.sessions) != 2 {
+					t.Fatalf("%d sessions left, want both kept", len(sessions.sessions))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RevokeSession() error = %v", err)
+			}
+			if _, ok := sessions.sessions[session.ID]; ok {
+				t.Fatal("session was not revoked")
+			}
+			if _, ok := sessions.sessions[other.ID]; !ok {
+				t.Fatal("another session of the user was revoked")
+			}
+
+			if err := svc.RevokeAllSessions(ctx, tt.userID); err != nil {
+				t.Fatalf("RevokeAllSessions() error = %v", err)
+			}
+			if len(sessions.sessions) != 0 {
+				t.Fatalf("%d sessions left, want none", len(sessions.sessions))
+			}
+		})
+	}
+}
+
+func TestSessionServiceRevokeSessionOfAnotherUser(t *testing.T) {
+	user := newTestUser(uuid.New(), entity.RoleInfluencer)
+	other := newTestUser(user.TenantID, entity.RoleInfluencer)
+	sessions := &sessionAuthRepo{sessions: map[uuid.UUID]*entity.Session{}}
+	session := newTestSession(sessions, other, "laptop")
+
+	svc := NewSessionService(sessions, newRoleUserRepo(user, other))
+	ctx := service.WithActor(context.Background(), service.Actor{UserID: user.ID, TenantID: user.TenantID, Role: user.Role})
+
+	// Users name their own ID, so another user's session is simply not theirs
+	if err := svc.RevokeSession(ctx, user.ID, session.ID); !errors.Is(err, repository.ErrSessionNotFound) {
+		t.Fatalf("RevokeSession() error = %v, want %v", err, repository.ErrSessionNotFound)
+	}
+	if _, ok := sessions.sessions[session.ID]; !ok {
+		t.Fatal("another user's session was revoked")
+	}
+}
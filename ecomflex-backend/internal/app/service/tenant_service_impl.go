@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+const (
+	// defaultPlanID is the plan tenants start on unless another one is picked
+	defaultPlanID = "free"
+
+	// maxDomainAttempts is how many generated subdomains are tried before giving up
+	maxDomainAttempts = 5
+)
+
+// defaultTenantID is the tenant the platform itself runs in
+var defaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+// TenantServiceImpl implements TenantService interface
+type TenantServiceImpl struct {
+	tenantRepo    repository.TenantRepository
+	planRepo      repository.PlanRepository
+	quotaService  service.QuotaService
+	auditLogger   service.AuditLogger
+	baseDomain    string
+	platformHosts map[string]bool
+}
+
+// NewTenantService creates a new TenantServiceImpl.
+// Generated domains are subdomains of the configured base domain; platform hosts cannot be given to tenants.
+func NewTenantService(
+	tenantRepo repository.TenantRepository,
+	planRepo repository.PlanRepository,
+	quotaService service.QuotaService,
+	auditLogger service.AuditLogger,
+	cfg config.TenancyConfig,
+) service.TenantService {
+	platformHosts := map[string]bool{strings.ToLower(cfg.BaseDomain): true}
+	for _, host := range cfg.PlatformHosts {
+		platformHosts[strings.ToLower(host)] = true
+	}
+
+	return &TenantServiceImpl{
+		tenantRepo:    tenantRepo,
+		planRepo:      planRepo,
+		quotaService:  quotaService,
+		auditLogger:   auditLogger,
+		baseDomain:    strings.ToLower(cfg.BaseDomain),
+		platformHosts: platformHosts,
+	}
+}
+
+// CreateTenant creates a tenant, picking a free subdomain when no domain is given
+func (s *TenantServiceImpl) CreateTenant(ctx context.Context, req service.CreateTenantRequest) (*entity.Tenant, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, err
+	}
+
+	planID := req.PlanID
+	if planID == "" {
+		planID = defaultPlanID
+	}
+	if err := s.checkPlan(ctx, planID); err != nil {
+		return nil, err
+	}
+
+	var tenant *entity.Tenant
+	if req.Domain != "" {
+		domain, err := s.checkDomain(req.Domain)
+		if err != nil {
+			return nil, err
+		}
+
+		tenant = entity.NewTenant(req.Name, domain, planID)
+		if err := s.tenantRepo.CreateTenant(ctx, tenant); err != nil {
+			return nil, err
+		}
+	} else {
+		subdomain := req.Subdomain
+		if subdomain == "" {
+			subdomain = req.Name
+		}
+
+		var err error
+		if tenant, err = s.createWithGeneratedDomain(ctx, req.Name, subdomain, planID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.audit(ctx, entity.AuditActionTenantCreated, nil, tenant)
+	return tenant, nil
+}
+
+// createWithGeneratedDomain creates a tenant on a subdomain of the platform.
+// Names are not unique, so a random suffix is added when the plain subdomain is taken.
+func (s *TenantServiceImpl) createWithGeneratedDomain(ctx context.Context, name, subdomain, planID string) (*entity.Tenant, error) {
+	slug := entity.TenantDomainSlug(subdomain)
+
+	for attempt := 0; attempt < maxDomainAttempts; attempt++ {
+		label := slug
+		if attempt > 0 {
+			suffix, err := randomDomainSuffix()
+			if err != nil {
+				return nil, err
+			}
+			label = slug + "-" + suffix
+		}
+
+		tenant := entity.NewTenant(name, label+"."+s.baseDomain, planID)
+		if s.platformHosts[tenant.Domain] {
+			continue
+		}
+
+		err := s.tenantRepo.CreateTenant(ctx, tenant)
+		if err == nil {
+			return tenant, nil
+		}
+		if !errors.Is(err, repository.ErrTenantDomainTaken) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find a free domain for %q: %w", name, repository.ErrTenantDomainTaken)
+}
+
+// GetTenant gets a tenant by ID; admins other than super admins only reach their own
+func (s *TenantServiceImpl) GetTenant(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	if err := service.CheckTenant(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.tenantRepo.GetTenantByID(ctx, id)
+}
+
+// ListTenants lists tenants for super admins, newest first
+func (s *TenantServiceImpl) ListTenants(ctx context.Context, filter repository.TenantFilter) ([]*entity.Tenant, int, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	return s.tenantRepo.ListTenants(ctx, filter)
+}
+
+// UpdateTenant replaces the name, domain and plan of a tenant.
+// Tenants cannot change their own plan or domain, so only super admins update them.
+func (s *TenantServiceImpl) UpdateTenant(ctx context.Context, id uuid.UUID, req service.UpdateTenantRequest) (*entity.Tenant, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, err
+	}
+
+	domain, err := s.checkDomain(req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPlan(ctx, req.PlanID); err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, id, entity.AuditActionTenantUpdated, func(tenant *entity.Tenant) error {
+		tenant.Update(req.Name, domain, req.PlanID)
+		return nil
+	})
+}
+
+// SuspendTenant deactivates a tenant; its users cannot sign in until it is activated again
+func (s *TenantServiceImpl) SuspendTenant(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, err
+	}
+	if id == defaultTenantID {
+		return nil, service.ErrDefaultTenant
+	}
+
+	return s.change(ctx, id, entity.AuditActionTenantSuspended, func(tenant *entity.Tenant) error {
+		tenant.Deactivate()
+		return nil
+	})
+}
+
+// ActivateTenant lifts the suspension of a tenant
+func (s *TenantServiceImpl) ActivateTenant(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, id, entity.AuditActionTenantActivated, func(tenant *entity.Tenant) error {
+		tenant.Activate()
+		return nil
+	})
+}
+
+// VerifyDomain records that a tenant proved it owns its custom domain, so the domain starts serving it
+func (s *TenantServiceImpl) VerifyDomain(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.quotaService.RequireFeature(ctx, id, entity.FeatureCustomDomain); err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, id, entity.AuditActionTenantDomainVerified, func(tenant *entity.Tenant) error {
+		tenant.VerifyDomain()
+		return nil
+	})
+}
+
+// DeleteTenant deletes a tenant together with its users and deactivates its products
+func (s *TenantServiceImpl) DeleteTenant(ctx context.Context, id uuid.UUID) error {
+	if err := service.RequirePlatformScope(ctx); err != nil {
+		return err
+	}
+	if id == defaultTenantID {
+		return service.ErrDefaultTenant
+	}
+
+	tenant, err := s.GetTenant(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tenantRepo.DeleteTenant(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, entity.AuditActionTenantDeleted, tenant, nil)
+	return nil
+}
+
+// GetSettings gets the settings of a tenant, with defaults for the ones it never set
+func (s *TenantServiceImpl) GetSettings(ctx context.Context, id uuid.UUID) (*entity.TenantSettings, error) {
+	tenant, err := s.GetTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Settings, nil
+}
+
+// UpdateSettings applies a partial settings document to a tenant's settings
+func (s *TenantServiceImpl) UpdateSettings(ctx context.Context, id uuid.UUID, patch []byte) (*entity.TenantSettings, error) {
+	tenant, err := s.change(ctx, id, entity.AuditActionTenantSettingsUpdated, func(tenant *entity.Tenant) error {
+		return tenant.UpdateSettings(patch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Settings, nil
+}
+
+// change loads a tenant, applies a change, persists it and records it in the audit log
+func (s *TenantServiceImpl) change(ctx context.Context, id uuid.UUID, action entity.AuditAction, apply func(*entity.Tenant) error) (*entity.Tenant, error) {
+	tenant, err := s.GetTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *tenant
+	if err := apply(tenant); err != nil {
+		return nil, err
+	}
+
+	if err := s.tenantRepo.UpdateTenant(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, action, &before, tenant)
+	return tenant, nil
+}
+
+// checkPlan checks that a plan tenants are put on exists
+func (s *TenantServiceImpl) checkPlan(ctx context.Context, planID string) error {
+	_, err := s.planRepo.GetPlan(ctx, planID)
+	return err
+}
+
+// checkDomain normalizes a domain given to a tenant and keeps the platform's own hosts out of reach
+func (s *TenantServiceImpl) checkDomain(domain string) (string, error) {
+	domain, err := entity.NormalizeTenantDomain(domain)
+	if err != nil {
+		return "", err
+	}
+
+	if s.platformHosts[domain] {
+		return "", service.ErrPlatformDomain
+	}
+
+	return domain, nil
+}
+
+// audit records a change to a tenant in its own audit log; before or after is nil when the tenant did not exist
+func (s *TenantServiceImpl) audit(ctx context.Context, action entity.AuditAction, before, after *entity.Tenant) {
+	tenant := after
+	if tenant == nil {
+		tenant = before
+	}
+
+	s.auditLogger.Record(ctx, service.AuditEntry{
+		TenantID:   tenant.ID,
+		Action:     action,
+		EntityType: entity.AuditEntityTenant,
+		EntityID:   tenant.ID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// randomDomainSuffix returns a short random suffix that tells apart tenants with the same name
+func randomDomainSuffix() (string, error) {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate domain suffix: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
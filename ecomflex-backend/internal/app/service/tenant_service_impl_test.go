@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// memoryTenantRepo keeps tenants in memory
+type memoryTenantRepo struct {
+	repository.TenantRepository
+	tenants map[uuid.UUID]*entity.Tenant
+	writes  int
+}
+
+func newMemoryTenantRepo(tenants ...*entity.Tenant) *memoryTenantRepo {
+	repo := &memoryTenantRepo{tenants: map[uuid.UUID]*entity.Tenant{}}
+	for _, tenant := range tenants {
+		repo.tenants[tenant.ID] = tenant
+	}
+	return repo
+}
+
+func (r *memoryTenantRepo) GetTenantByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	tenant, ok := r.tenants[id]
+	if !ok {
+		return nil, repository.ErrTenantNotFound
+	}
+	stored := *tenant
+	return &stored, nil
+}
+
+func (r *memoryTenantRepo) UpdateTenant(ctx context.Context, tenant *entity.Tenant) error {
+	stored := *tenant
+	r.tenants[tenant.ID] = &stored
+	r.writes++
+	return nil
+}
+
+func (r *memoryTenantRepo) DeleteTenant(ctx context.Context, id uuid.UUID) error {
+	delete(r.tenants, id)
+	r.writes++
+	return nil
+}
+
+// memoryPlanRepo knows a fixed set of plans
+type memoryPlanRepo struct {
+	repository.PlanRepository
+	plans map[string]*entity.Plan
+}
+
+func (r *memoryPlanRepo) GetPlan(ctx context.Context, id string) (*entity.Plan, error) {
+	plan, ok := r.plans[id]
+	if !ok {
+		return nil, repository.ErrPlanNotFound
+	}
+	return plan, nil
+}
+
+// recordingAuditLogger keeps the entries services record
+type recordingAuditLogger struct {
+	service.AuditLogger
+	entries []service.AuditEntry
+}
+
+func (l *recordingAuditLogger) Record(ctx context.Context, entry service.AuditEntry) {
+	l.entries = append(l.entries, entry)
+}
+
+func TestTenantServiceMutationsRequirePlatformScope(t *testing.T) {
+	tenant := entity.NewTenant("Acme", "acme.ecomflex.com", "free")
+
+	// A tenant admin whose custom role was given tenants:manage before it was reserved for super admins
+	tenantAdmin := service.WithActor(context.Background(), service.Actor{
+		UserID:   uuid.New(),
+		TenantID: tenant.ID,
+		Role:     entity.Role("tenant_owner"),
+	})
+	superAdmin := service.WithActor(context.Background(), service.Actor{
+		UserID: uuid.New(),
+		Role:   entity.RoleSuperAdmin,
+	})
+
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, svc service.TenantService) error
+	}{
+		{
+			name: "upgrade own plan",
+			change: func(ctx context.Context, svc service.TenantService) error {
+				_, err := svc.UpdateTenant(ctx, tenant.ID, service.UpdateTenantRequest{Name: "Acme", Domain: "acme.ecomflex.com", PlanID: "pro"})
+				return err
+			},
+		},
+		{
+			name: "suspend own tenant",
+			change: func(ctx context.Context, svc service.TenantService) error {
+				_, err := svc.SuspendTenant(ctx, tenant.ID)
+				return err
+			},
+		},
+		{
+			name: "reactivate own tenant",
+			change: func(ctx context.Context, svc service.TenantService) error {
+				_, err := svc.ActivateTenant(ctx, tenant.ID)
+				return err
+			},
+		},
+		{
+			name: "verify own domain",
+			change: func(ctx context.Context, svc service.TenantService) error {
+				_, err := svc.VerifyDomain(ctx, tenant.ID)
+				return err
+			},
+		},
+		{
+			name: "delete own tenant",
+			change: func(ctx context.Context, svc service.TenantService) error {
+				return svc.DeleteTenant(ctx, tenant.ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plans := &memoryPlanRepo{plans: map[string]*entity.Plan{"free": {ID: "free"}, "pro": {ID: "pro"}}}
+
+			stored := *tenant
+			tenants := newMemoryTenantRepo(&stored)
+			auditLogger := &recordingAuditLogger{}
+			svc := NewTenantService(tenants, plans, nil, auditLogger, config.TenancyConfig{BaseDomain: "ecomflex.com"})
+
+			if err := tt.change(tenantAdmin, svc); !errors.Is(err, service.ErrOtherTenant) {
+				t.Fatalf("tenant admin: error = %v, want %v", err, service.ErrOtherTenant)
+			}
+			if tenants.writes != 0 || len(auditLogger.entries) != 0 {
+				t.Fatalf("tenant admin: %d writes and %d audit entries, want none", tenants.writes, len(auditLogger.entries))
+			}
+
+			// Verifying a domain also needs the custom domain feature, which the quota tests cover
+			if tt.name == "verify own domain" {
+				return
+			}
+			if err := tt.change(superAdmin, svc); err != nil {
+				t.Fatalf("super admin: error = %v", err)
+			}
+			if tenants.writes != 1 || len(auditLogger.entries) != 1 {
+				t.Errorf("super admin: %d writes and %d audit entries, want 1 of each", tenants.writes, len(auditLogger.entries))
+			}
+		})
+	}
+}
+
+func TestTenantServiceUpdateSettingsOfOwnTenant(t *testing.T) {
+	tenant := entity.NewTenant("Acme", "acme.ecomflex.com", "free")
+	other := entity.NewTenant("Other", "other.ecomflex.com", "free")
+
+	tenantAdmin := service.WithActor(context.Background(), service.Actor{
+		UserID:   uuid.New(),
+		TenantID: tenant.ID,
+		Role:     entity.Role("tenant_owner"),
+	})
+
+	tenants := newMemoryTenantRepo(tenant, other)
+	svc := NewTenantService(tenants, nil, nil, &recordingAuditLogger{}, config.TenancyConfig{BaseDomain: "ecomflex.com"})
+
+	patch := []byte(`{"support_email": "help@acme.test"}`)
+	if _, err := svc.UpdateSettings(tenantAdmin, tenant.ID, patch); err != nil {
+		t.Fatalf("UpdateSettings(own tenant) error = %v", err)
+	}
+	if _, err := svc.UpdateSettings(tenantAdmin, other.ID, patch); !errors.Is(err, service.ErrOtherTenant) {
+		t.Fatalf("UpdateSettings(other tenant) error = %v, want %v", err, service.ErrOtherTenant)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,27 +16,43 @@ import (
 
 // UserServiceImpl implements UserService interface
 type UserServiceImpl struct {
-	userRepo repository.UserRepository
-	logger   loggerPkg.Logger
+	userRepo     repository.UserRepository
+	mailer       service.Mailer
+	auditLogger  service.AuditLogger
+	quotaService service.QuotaService
+	logger       loggerPkg.Logger
 }
 
 // NewUserService creates a new UserServiceImpl
-func NewUserService(userRepo repository.UserRepository, logger loggerPkg.Logger) service.UserService {
+func NewUserService(userRepo repository.UserRepository, mailer service.Mailer, auditLogger service.AuditLogger, quotaService service.QuotaService, logger loggerPkg.Logger) service.UserService {
 	return &UserServiceImpl{
-		userRepo: userRepo,
-		logger:   logger,
+		userRepo:     userRepo,
+		mailer:       mailer,
+		auditLogger:  auditLogger,
+		quotaService: quotaService,
+		logger:       logger,
 	}
 }
 
 // GetUserByID gets a user by ID
 func (s *UserServiceImpl) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	return s.userRepo.GetUserByID(ctx, id)
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admins other than super admins only reach users of their own tenant
+	if err := service.CheckTenant(ctx, user.TenantID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
 
 // UpdateUser updates a user's information
 func (s *UserServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, req service.UserUpdateRequest) (*entity.User, error) {
 	// Get existing user
-	user, err := s.userRepo.GetUserByID(ctx, id)
+	user, err := s.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -71,7 +86,7 @@ func (s *UserServiceImpl) UpdateUser(ctx context.Context, id uuid.UUID, req serv
 // UpdateInfluencerStatus updates an influencer's approval status
 func (s *UserServiceImpl) UpdateInfluencerStatus(ctx context.Context, id uuid.UUID, req service.InfluencerStatusUpdateRequest) error {
 	// Get existing user
-	user, err := s.userRepo.GetUserByID(ctx, id)
+	user, err := s.GetUserByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
@@ -82,12 +97,18 @@ func (s *UserServiceImpl) UpdateInfluencerStatus(ctx context.Context, id uuid.UU
 	}
 
 	// Update status based on request
+	before := *user
 	switch req.Status {
 	case "active":
+		// Approved influencers count against the tenant's plan
+		if !user.IsActive() {
+			if err := s.quotaService.CheckQuota(ctx, user.TenantID, entity.QuotaInfluencers, 1); err != nil {
+				return err
+			}
+		}
 		user.SetActive()
 	case "rejected":
 		user.SetRejected()
-		// TODO: Send rejection email with reason
 	default:
 		return errors.New("invalid status")
 	}
@@ -98,9 +119,42 @@ func (s *UserServiceImpl) UpdateInfluencerStatus(ctx context.Context, id uuid.UU
 		return fmt.Errorf("failed to update influencer status: %w", err)
 	}
 
+	if user.Status != before.Status {
+		s.auditLogger.Record(ctx, service.AuditEntry{
+			TenantID:   user.TenantID,
+			Action:     entity.AuditActionInfluencerStatusChanged,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   user.ID,
+			Before:     &before,
+			After:      user,
+		})
+		s.notifyInfluencerStatus(ctx, user, req.Reason)
+	}
+
 	return nil
 }
 
+// notifyInfluencerStatus emails an influencer whether their account was approved.
+// The status has already been saved, so failures are logged rather than returned.
+func (s *UserServiceImpl) notifyInfluencerStatus(ctx context.Context, user *entity.User, reason string) {
+	msg := service.EmailMessage{
+		TenantID: user.TenantID,
+		To:       user.Email,
+		Template: entity.EmailTemplateInfluencerApproved,
+		Data: map[string]interface{}{
+			"Name": user.FullName,
+		},
+	}
+	if user.Status == "rejected" {
+		msg.Template = entity.EmailTemplateInfluencerRejected
+		msg.Data["Reason"] = reason
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.Error("Failed to send influencer status email", err, "user_id", user.ID.String())
+	}
+}
+
 // GetInfluencersByStatus gets influencers by status with filters
 func (s *UserServiceImpl) GetInfluencersByStatus(ctx context.Context, status, search, fromDate string, limit, offset int) ([]*entity.User, int, error) {
 	// If status is empty or "all", set it to empty to get all influencers
@@ -109,13 +163,22 @@ func (s *UserServiceImpl) GetInfluencersByStatus(ctx context.Context, status, se
 	}
 
 	// Get influencers from repository
-	return s.userRepo.GetInfluencersByStatus(ctx, status, limit, offset)
+	return s.userRepo.GetInfluencersByStatus(ctx, scopedTenantID(ctx), status, limit, offset)
 }
 
 // GetPendingInfluencers gets all pending influencers with pagination
 func (s *UserServiceImpl) GetPendingInfluencers(ctx context.Context, limit, offset int) ([]*entity.User, int, error) {
 	// Use "pending_approval" as the status to get pending influencers
-	return s.userRepo.GetInfluencersByStatus(ctx, "pending_approval", limit, offset)
+	return s.userRepo.GetInfluencersByStatus(ctx, scopedTenantID(ctx), "pending_approval", limit, offset)
+}
+
+// scopedTenantID returns the tenant admins other than super admins are limited to, or nil for every tenant
+func scopedTenantID(ctx context.Context) *uuid.UUID {
+	tenantID, limited := service.TenantScope(ctx)
+	if !limited {
+		return nil
+	}
+	return &tenantID
 }
 
 // IsReferralCodeValid checks if a referral code is valid
@@ -123,7 +186,7 @@ func (s *UserServiceImpl) IsReferralCodeValid(ctx context.Context, referralCode
 	// Get user by referral code
 	user, err := s.userRepo.GetUserByReferralCode(ctx, referralCode)
 	if err != nil {
-		if strings.Contains(err.Error(), "user not found") {
+		if errors.Is(err, repository.ErrUserNotFound) {
 			return false, nil
 		}
 		return false, fmt.Errorf("failed to check referral code: %w", err)
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// WalletServiceImpl implements WalletService interface
+type WalletServiceImpl struct {
+	bookingRepo repository.BookingRepository
+	ledgerRepo  repository.LedgerRepository
+	payoutRepo  repository.PayoutRepository
+	currency    string
+}
+
+// NewWalletService creates a new WalletServiceImpl.
+// currency is the currency wallet amounts are paid out in.
+func NewWalletService(
+	bookingRepo repository.BookingRepository,
+	ledgerRepo repository.LedgerRepository,
+	payoutRepo repository.PayoutRepository,
+	currency string,
+) service.WalletService {
+	return &WalletServiceImpl{
+		bookingRepo: bookingRepo,
+		ledgerRepo:  ledgerRepo,
+		payoutRepo:  payoutRepo,
+		currency:    currency,
+	}
+}
+
+// GetWallet gets the cashback wallet of a user.
+// Released cashback comes from the ledger; pending cashback is still on the bookings.
+func (s *WalletServiceImpl) GetWallet(ctx context.Context, userID uuid.UUID) (*service.Wallet, error) {
+	balance, err := s.ledgerRepo.GetBalanceSummary(ctx, entity.LedgerAccountCashbackWallet, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.bookingRepo.GetPendingCashback(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.Wallet{
+		Available:   balance.Available,
+		Pending:     pending,
+		TotalEarned: balance.TotalEarned,
+		PaidOut:     balance.PaidOut,
+		Currency:    s.currency,
+	}, nil
+}
+
+// ListTransactions lists the ledger entries of a user's wallet, newest first
+func (s *WalletServiceImpl) ListTransactions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.LedgerEntry, int, error) {
+	return s.ledgerRepo.ListEntries(ctx, entity.LedgerAccountCashbackWallet, userID, limit, offset)
+}
+
+// ListPayouts lists the payouts of a user's wallet, newest first
+func (s *WalletServiceImpl) ListPayouts(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Payout, int, error) {
+	return s.payoutRepo.ListPayouts(ctx, repository.PayoutFilter{
+		UserID:  userID.String(),
+		Account: string(entity.LedgerAccountCashbackWallet),
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// GetPayoutMethod gets the payout method chosen by a user
+func (s *WalletServiceImpl) GetPayoutMethod(ctx context.Context, userID uuid.UUID) (*entity.PayoutMethod, error) {
+	return s.payoutRepo.GetPayoutMethod(ctx, userID)
+}
+
+// SavePayoutMethod chooses where a user's payouts are sent
+func (s *WalletServiceImpl) SavePayoutMethod(ctx context.Context, userID uuid.UUID, req service.SavePayoutMethodRequest) (*entity.PayoutMethod, error) {
+	method, err := entity.NewPayoutMethod(userID, req.Type, req.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the original creation time when the method is replaced
+	if existing, err := s.payoutRepo.GetPayoutMethod(ctx, userID); err == nil {
+		method.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.payoutRepo.SavePayoutMethod(ctx, method); err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountTokenPurpose identifies what a single-use account token may be used for
+type AccountTokenPurpose string
+
+// Account token purposes
+const (
+	AccountTokenEmailVerification AccountTokenPurpose = "email_verification"
+	AccountTokenPasswordReset     AccountTokenPurpose = "password_reset"
+	AccountTokenAccountUnlock     AccountTokenPurpose = "account_unlock"
+)
+
+// AccountToken is a single-use token emailed to a user to prove they own their address.
+// Only the hash of the token is stored; the raw token only exists in the email.
+type AccountToken struct {
+	Purpose   AccountTokenPurpose `json:"purpose"`
+	Hash      string              `json:"hash"`
+	UserID    uuid.UUID           `json:"user_id"`
+	Email     string              `json:"email"`
+	ExpiresAt time.Time           `json:"expires_at"`
+}
+
+// NewAccountToken creates a token for a user and returns it along with the raw value to send
+func NewAccountToken(purpose AccountTokenPurpose, user *User, ttl time.Duration) (string, *AccountToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate account token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	return token, &AccountToken{
+		Purpose:   purpose,
+		Hash:      HashAccountToken(token),
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// HashAccountToken hashes a raw account token for lookup
+func HashAccountToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
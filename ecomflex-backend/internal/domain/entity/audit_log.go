@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+)
+
+// AuditAction identifies a change recorded in the audit log
+type AuditAction string
+
+// Audit actions
+const (
+	AuditActionProductCreated          AuditAction = "product.created"
+	AuditActionProductUpdated          AuditAction = "product.updated"
+	AuditActionProductDeleted          AuditAction = "product.deleted"
+	AuditActionProductStatusChanged    AuditAction = "product.status_changed"
+	AuditActionInfluencerStatusChanged AuditAction = "influencer.status_changed"
+	AuditActionBookingApproved         AuditAction = "booking.approved"
+	AuditActionBookingRejected         AuditAction = "booking.rejected"
+	AuditActionBookingCashbackPaid     AuditAction = "booking.cashback_paid"
+	AuditActionRoleCreated             AuditAction = "role.created"
+	AuditActionRoleUpdated             AuditAction = "role.updated"
+	AuditActionRoleDeleted             AuditAction = "role.deleted"
+	AuditActionUserRoleChanged         AuditAction = "user.role_changed"
+	AuditActionLogin                   AuditAction = "auth.login"
+	AuditActionTenantCreated           AuditAction = "tenant.created"
+	AuditActionTenantUpdated           AuditAction = "tenant.updated"
+	AuditActionTenantSuspended         AuditAction = "tenant.suspended"
+	AuditActionTenantActivated         AuditAction = "tenant.activated"
+	AuditActionTenantDomainVerified    AuditAction = "tenant.domain_verified"
+	AuditActionTenantDeleted           AuditAction = "tenant.deleted"
+	AuditActionTenantSettingsUpdated   AuditAction = "tenant.settings_updated"
+)
+
+// Audited entity types
+const (
+	AuditEntityProduct = "product"
+	AuditEntityUser    = "user"
+	AuditEntityBooking = "booking"
+	AuditEntityRole    = "role"
+	AuditEntityTenant  = "tenant"
+)
+
+// maxAuditUserAgentLength is the size of the user agent column, in characters
+const maxAuditUserAgentLength = 255
+
+// AuditSnapshot is the JSON state of an entity before or after a change
+type AuditSnapshot json.RawMessage
+
+// NewAuditSnapshot captures the state of an entity; nil, including a nil pointer, captures nothing
+func NewAuditSnapshot(v interface{}) (AuditSnapshot, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture audit snapshot: %w", err)
+	}
+	return AuditSnapshot(data), nil
+}
+
+// MarshalJSON implements json.Marshaler so snapshots are embedded as JSON
+func (s AuditSnapshot) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+// Value implements driver.Valuer so snapshots are stored as JSONB, or NULL when empty
+func (s AuditSnapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return []byte(s), nil
+}
+
+// Scan implements sql.Scanner so snapshots are read from JSONB
+func (s *AuditSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+	case []byte:
+		*s = append(AuditSnapshot(nil), v...)
+	case string:
+		*s = AuditSnapshot(v)
+	default:
+		return fmt.Errorf("unsupported type for audit snapshot: %T", src)
+	}
+	return nil
+}
+
+// AuditLog records who changed what in a tenant, with the entity's state before and after
+type AuditLog struct {
+	ID             uuid.UUID     `json:"id" db:"id"`
+	TenantID       uuid.UUID     `json:"tenant_id" db:"tenant_id"`
+	UserID         *uuid.UUID    `json:"user_id,omitempty" db:"user_id"`
+	ImpersonatorID *uuid.UUID    `json:"impersonator_id,omitempty" db:"impersonator_id"`
+	Action         AuditAction   `json:"action" db:"action"`
+	EntityType     string        `json:"entity_type" db:"entity_type"`
+	EntityID       *uuid.UUID    `json:"entity_id,omitempty" db:"entity_id"`
+	OldValue       AuditSnapshot `json:"old_value" db:"old_value"`
+	NewValue       AuditSnapshot `json:"new_value" db:"new_value"`
+	IPAddress      string        `json:"ip_address" db:"ip_address"`
+	UserAgent      string        `json:"user_agent" db:"user_agent"`
+	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
+}
+
+// NewAuditLog creates an audit log entry for a change in a tenant
+func NewAuditLog(tenantID uuid.UUID, action AuditAction, entityType string, entityID *uuid.UUID, oldValue, newValue AuditSnapshot) *AuditLog {
+	return &AuditLog{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// SetClient records the device the change was made from
+func (l *AuditLog) SetClient(ipAddress, userAgent string) {
+	l.IPAddress = ipAddress
+	l.UserAgent = util.TruncateRunes(userAgent, maxAuditUserAgentLength)
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingStatus represents the lifecycle state of a booking
+type BookingStatus string
+
+// Booking statuses
+const (
+	BookingStatusInitiated BookingStatus = "initiated"
+	BookingStatusPending   BookingStatus = "pending"
+	BookingStatusApproved  BookingStatus = "approved"
+	BookingStatusRejected  BookingStatus = "rejected"
+	BookingStatusExpired   BookingStatus = "expired"
+)
+
+// CashbackStatus represents whether the cashback for a booking has been paid
+type CashbackStatus string
+
+// Cashback statuses
+const (
+	CashbackStatusNotPaid CashbackStatus = "not_paid"
+	CashbackStatusPaid    CashbackStatus = "paid"
+)
+
+// ErrInvalidBookingTransition is returned when a booking cannot move to the requested state
+var ErrInvalidBookingTransition = errors.New("invalid booking status transition")
+
+// Booking represents a user's booking of a product campaign
+type Booking struct {
+	ID              uuid.UUID      `json:"id" db:"id"`
+	TenantID        uuid.UUID      `json:"tenantId" db:"tenant_id"`
+	UserID          uuid.UUID      `json:"userId" db:"user_id"`
+	ProductID       uuid.UUID      `json:"productId" db:"product_id"`
+	ReferralCode    string         `json:"referralCode,omitempty" db:"referral_code"`
+	Status          BookingStatus  `json:"status" db:"status"`
+	ProofURL        string         `json:"proofUrl,omitempty" db:"proof_url"`
+	RejectionReason string         `json:"rejectionReason,omitempty" db:"rejection_reason"`
+	CashbackStatus  CashbackStatus `json:"cashbackStatus" db:"cashback_status"`
+	CashbackAmount  float64        `json:"cashbackAmount" db:"cashback_amount"`
+	CreatedAt       time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time      `json:"updatedAt" db:"updated_at"`
+	DeletedAt       *time.Time     `json:"deletedAt,omitempty" db:"deleted_at"`
+}
+
+// NewBooking creates a new booking in the initiated state
+func NewBooking(tenantID, userID, productID uuid.UUID, referralCode string) *Booking {
+	now := time.Now()
+	return &Booking{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		UserID:         userID,
+		ProductID:      productID,
+		ReferralCode:   referralCode,
+		Status:         BookingStatusInitiated,
+		CashbackStatus: CashbackStatusNotPaid,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// SubmitProof attaches a proof of purchase and moves the booking to pending
+func (b *Booking) SubmitProof(proofURL string) error {
+	if b.Status != BookingStatusInitiated {
+		return ErrInvalidBookingTransition
+	}
+	b.ProofURL = proofURL
+	b.Status = BookingStatusPending
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Approve approves a pending booking
+func (b *Booking) Approve() error {
+	if b.Status != BookingStatusPending {
+		return ErrInvalidBookingTransition
+	}
+	b.Status = BookingStatusApproved
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Reject rejects a pending booking with a reason
+func (b *Booking) Reject(reason string) error {
+	if b.Status != BookingStatusPending {
+		return ErrInvalidBookingTransition
+	}
+	b.Status = BookingStatusRejected
+	b.RejectionReason = reason
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkCashbackPaid marks the cashback of an approved booking as paid into the user's wallet
+func (b *Booking) MarkCashbackPaid() error {
+	if b.Status != BookingStatusApproved || b.CashbackStatus != CashbackStatusNotPaid {
+		return ErrInvalidBookingTransition
+	}
+	b.CashbackStatus = CashbackStatusPaid
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Cancel cancels a booking that has not been reviewed yet
+func (b *Booking) Cancel() error {
+	if !b.CanCancel() {
+		return ErrInvalidBookingTransition
+	}
+	now := time.Now()
+	b.DeletedAt = &now
+	b.UpdatedAt = now
+	return nil
+}
+
+// CanCancel checks if the booking can still be cancelled by its owner
+func (b *Booking) CanCancel() bool {
+	return b.Status == BookingStatusInitiated || b.Status == BookingStatusPending
+}
+
+// IsPending checks if the booking is awaiting review
+func (b *Booking) IsPending() bool {
+	return b.Status == BookingStatusPending
+}
+
+// IsApproved checks if the booking is approved
+func (b *Booking) IsApproved() bool {
+	return b.Status == BookingStatusApproved
+}
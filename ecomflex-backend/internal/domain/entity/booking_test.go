@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBookingTransitions(t *testing.T) {
+	transitions := []struct {
+		name string
+		from []BookingStatus
+		to   BookingStatus
+		do   func(b *Booking) error
+	}{
+		{name: "submit proof", from: []BookingStatus{BookingStatusInitiated}, to: BookingStatusPending, do: func(b *Booking) error { return b.SubmitProof("https://proofs/1.png") }},
+		{name: "approve", from: []BookingStatus{BookingStatusPending}, to: BookingStatusApproved, do: (*Booking).Approve},
+		{name: "reject", from: []BookingStatus{BookingStatusPending}, to: BookingStatusRejected, do: func(b *Booking) error { return b.Reject("blurry receipt") }},
+	}
+	statuses := []BookingStatus{
+		BookingStatusInitiated,
+		BookingStatusPending,
+		BookingStatusApproved,
+		BookingStatusRejected,
+		BookingStatusExpired,
+	}
+
+	for _, tr := range transitions {
+		for _, status := range statuses {
+			allowed := false
+			for _, from := range tr.from {
+				allowed = allowed || from == status
+			}
+
+			t.Run(tr.name+" from "+string(status), func(t *testing.T) {
+				booking := NewBooking(uuid.New(), uuid.New(), uuid.New(), "")
+				booking.Status = status
+
+				err := tr.do(booking)
+				if !allowed {
+					if !errors.Is(err, ErrInvalidBookingTransition) {
+						t.Fatalf("error = %v, want %v", err, ErrInvalidBookingTransition)
+					}
+					if booking.Status != status {
+						t.Errorf("status = %s, want %s unchanged", booking.Status, status)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("error = %v", err)
+				}
+				if booking.Status != tr.to {
+					t.Errorf("status = %s, want %s", booking.Status, tr.to)
+				}
+			})
+		}
+	}
+}
+
+func TestBookingCancel(t *testing.T) {
+	tests := []struct {
+		name   string
+		status BookingStatus
+		want   bool
+	}{
+		{name: "initiated", status: BookingStatusInitiated, want: true},
+		{name: "pending", status: BookingStatusPending, want: true},
+		{name: "approved", status: BookingStatusApproved},
+		{name: "rejected", status: BookingStatusRejected},
+		{name: "expired", status: BookingStatusExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := NewBooking(uuid.New(), uuid.New(), uuid.New(), "")
+			booking.Status = tt.status
+
+			if got := booking.CanCancel(); got != tt.want {
+				t.Fatalf("CanCancel() = %t, want %t", got, tt.want)
+			}
+
+			err := booking.Cancel()
+			if !tt.want {
+				if !errors.Is(err, ErrInvalidBookingTransition) || booking.DeletedAt != nil {
+					t.Fatalf("Cancel() error = %v, deleted at %v, want the booking left alone", err, booking.DeletedAt)
+				}
+				return
+			}
+			if err != nil || booking.DeletedAt == nil {
+				t.Fatalf("Cancel() error = %v, deleted at %v, want the booking deleted", err, booking.DeletedAt)
+			}
+		})
+	}
+}
+
+func TestBookingMarkCashbackPaid(t *testing.T) {
+	booking := NewBooking(uuid.New(), uuid.New(), uuid.New(), "")
+	if err := booking.MarkCashbackPaid(); !errors.Is(err, ErrInvalidBookingTransition) {
+		t.Fatalf("paying an initiated booking: error = %v, want %v", err, ErrInvalidBookingTransition)
+	}
+
+	booking.Status = BookingStatusApproved
+	if err := booking.MarkCashbackPaid(); err != nil {
+		t.Fatalf("paying an approved booking: error = %v", err)
+	}
+	if booking.CashbackStatus != CashbackStatusPaid {
+		t.Fatalf("cashback status = %s, want %s", booking.CashbackStatus, CashbackStatusPaid)
+	}
+
+	if err := booking.MarkCashbackPaid(); !errors.Is(err, ErrInvalidBookingTransition) {
+		t.Fatalf("paying twice: error = %v, want %v", err, ErrInvalidBookingTransition)
+	}
+}
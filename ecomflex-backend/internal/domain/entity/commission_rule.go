@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommissionAmountType represents how a commission amount is applied
+type CommissionAmountType string
+
+// Commission amount types
+const (
+	CommissionAmountFlat    CommissionAmountType = "flat"
+	CommissionAmountPercent CommissionAmountType = "percent"
+)
+
+// ErrInvalidCommissionRule is returned when a commission rule is inconsistent
+var ErrInvalidCommissionRule = errors.New("invalid commission rule")
+
+// CommissionRule decides the earnings of approved referrals.
+// Rules without a tenant or product apply everywhere; tiers are rules with a higher
+// minimum number of approved referrals.
+type CommissionRule struct {
+	ID                   uuid.UUID            `json:"id" db:"id"`
+	Name                 string               `json:"name" db:"name"`
+	TenantID             *uuid.UUID           `json:"tenant_id,omitempty" db:"tenant_id"`
+	ProductID            *uuid.UUID           `json:"product_id,omitempty" db:"product_id"`
+	Source               ReferralSource       `json:"source" db:"source"`
+	AmountType           CommissionAmountType `json:"amount_type" db:"amount_type"`
+	Amount               float64              `json:"amount" db:"amount"`
+	MinApprovedReferrals int                  `json:"min_approved_referrals" db:"min_approved_referrals"`
+	StartsAt             *time.Time           `json:"starts_at,omitempty" db:"starts_at"`
+	EndsAt               *time.Time           `json:"ends_at,omitempty" db:"ends_at"`
+	IsActive             bool                 `json:"is_active" db:"is_active"`
+	CreatedAt            time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// NewCommissionRule creates a new active commission rule
+func NewCommissionRule(name string, source ReferralSource, amountType CommissionAmountType, amount float64) *CommissionRule {
+	now := time.Now()
+	return &CommissionRule{
+		ID:         uuid.New(),
+		Name:       name,
+		Source:     source,
+		AmountType: amountType,
+		Amount:     amount,
+		IsActive:   true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Validate checks that the rule can be applied
+func (r *CommissionRule) Validate() error {
+	if r.Amount < 0 {
+		return fmt.Errorf("%w: amount must not be negative", ErrInvalidCommissionRule)
+	}
+	if r.AmountType == CommissionAmountPercent && r.Amount > 100 {
+		return fmt.Errorf("%w: percentage must not exceed 100", ErrInvalidCommissionRule)
+	}
+	if r.MinApprovedReferrals < 0 {
+		return fmt.Errorf("%w: minimum approved referrals must not be negative", ErrInvalidCommissionRule)
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return fmt.Errorf("%w: end must be after start", ErrInvalidCommissionRule)
+	}
+	return nil
+}
+
+// AppliesTo checks if the rule covers a referral of the given source for an influencer
+// with approvedCount approved referrals at the given time
+func (r *CommissionRule) AppliesTo(source ReferralSource, approvedCount int, at time.Time) bool {
+	if !r.IsActive || r.Source != source || approvedCount < r.MinApprovedReferrals {
+		return false
+	}
+	if r.StartsAt != nil && at.Before(*r.StartsAt) {
+		return false
+	}
+	if r.EndsAt != nil && !at.Before(*r.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// Specificity ranks product rules above tenant rules above global rules
+func (r *CommissionRule) Specificity() int {
+	switch {
+	case r.ProductID != nil:
+		return 2
+	case r.TenantID != nil:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Outranks checks if the rule takes precedence over another applicable rule.
+// More specific scopes win, then higher tiers, then newer rules.
+func (r *CommissionRule) Outranks(other *CommissionRule) bool {
+	if r.Specificity() != other.Specificity() {
+		return r.Specificity() > other.Specificity()
+	}
+	if r.MinApprovedReferrals != other.MinApprovedReferrals {
+		return r.MinApprovedReferrals > other.MinApprovedReferrals
+	}
+	return r.CreatedAt.After(other.CreatedAt)
+}
+
+// Compute returns the commission for a base amount, rounded to cents
+func (r *CommissionRule) Compute(baseAmount float64) float64 {
+	amount := r.Amount
+	if r.AmountType == CommissionAmountPercent {
+		amount = baseAmount * r.Amount / 100
+	}
+	return math.Round(amount*100) / 100
+}
+
+// CommissionSnapshot records the rule applied to a referral at approval time
+type CommissionSnapshot struct {
+	RuleID               uuid.UUID            `json:"rule_id"`
+	RuleName             string               `json:"rule_name"`
+	AmountType           CommissionAmountType `json:"amount_type"`
+	Amount               float64              `json:"amount"`
+	MinApprovedReferrals int                  `json:"min_approved_referrals"`
+	TenantID             *uuid.UUID           `json:"tenant_id,omitempty"`
+	ProductID            *uuid.UUID           `json:"product_id,omitempty"`
+	BaseAmount           float64              `json:"base_amount"`
+	ApprovedCount        int                  `json:"approved_count"`
+	Earnings             float64              `json:"earnings"`
+	ComputedAt           time.Time            `json:"computed_at"`
+}
+
+// NewCommissionSnapshot applies a rule to a base amount and records the result
+func NewCommissionSnapshot(rule *CommissionRule, baseAmount float64, approvedCount int) *CommissionSnapshot {
+	return &CommissionSnapshot{
+		RuleID:               rule.ID,
+		RuleName:             rule.Name,
+		AmountType:           rule.AmountType,
+		Amount:               rule.Amount,
+		MinApprovedReferrals: rule.MinApprovedReferrals,
+		TenantID:             rule.TenantID,
+		ProductID:            rule.ProductID,
+		BaseAmount:           baseAmount,
+		ApprovedCount:        approvedCount,
+		Earnings:             rule.Compute(baseAmount),
+		ComputedAt:           time.Now(),
+	}
+}
+
+// Value implements driver.Valuer so the snapshot is stored as JSONB
+func (s CommissionSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner so the snapshot is read from JSONB
+func (s *CommissionSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported type for commission snapshot: %T", src)
+	}
+}
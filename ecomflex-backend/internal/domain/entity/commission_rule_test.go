@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCommissionRuleValidate(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		change  func(rule *CommissionRule)
+		wantErr bool
+	}{
+		{name: "flat amount", change: func(rule *CommissionRule) {}},
+		{name: "full percentage", change: func(rule *CommissionRule) { rule.AmountType, rule.Amount = CommissionAmountPercent, 100 }},
+		{name: "time window", change: func(rule *CommissionRule) { rule.StartsAt, rule.EndsAt = &start, &end }},
+		{name: "negative amount", change: func(rule *CommissionRule) { rule.Amount = -1 }, wantErr: true},
+		{name: "percentage over 100", change: func(rule *CommissionRule) { rule.AmountType, rule.Amount = CommissionAmountPercent, 100.5 }, wantErr: true},
+		{name: "negative tier", change: func(rule *CommissionRule) { rule.MinApprovedReferrals = -1 }, wantErr: true},
+		{name: "end before start", change: func(rule *CommissionRule) { rule.StartsAt, rule.EndsAt = &end, &start }, wantErr: true},
+		{name: "empty window", change: func(rule *CommissionRule) { rule.StartsAt, rule.EndsAt = &start, &start }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewCommissionRule("Test", ReferralSourceBooking, CommissionAmountFlat, 5)
+			tt.change(rule)
+
+			err := rule.Validate()
+			if tt.wantErr != errors.Is(err, ErrInvalidCommissionRule) {
+				t.Errorf("Validate() error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommissionRuleAppliesTo(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		change        func(rule *CommissionRule)
+		source        ReferralSource
+		approvedCount int
+		want          bool
+	}{
+		{name: "matching source", change: func(rule *CommissionRule) {}, source: ReferralSourceBooking, want: true},
+		{name: "other source", change: func(rule *CommissionRule) {}, source: ReferralSourceRegistration},
+		{name: "inactive", change: func(rule *CommissionRule) { rule.IsActive = false }, source: ReferralSourceBooking},
+		{name: "tier reached", change: func(rule *CommissionRule) { rule.MinApprovedReferrals = 10 }, source: ReferralSourceBooking, approvedCount: 10, want: true},
+		{name: "tier not reached", change: func(rule *CommissionRule) { rule.MinApprovedReferrals = 10 }, source: ReferralSourceBooking, approvedCount: 9},
+		{name: "within window", change: func(rule *CommissionRule) { rule.StartsAt, rule.EndsAt = &before, &after }, source: ReferralSourceBooking, want: true},
+		{name: "starts at that moment", change: func(rule *CommissionRule) { rule.StartsAt = &now }, source: ReferralSourceBooking, want: true},
+		{name: "not started", change: func(rule *CommissionRule) { rule.StartsAt = &after }, source: ReferralSourceBooking},
+		{name: "ends at that moment", change: func(rule *CommissionRule) { rule.EndsAt = &now }, source: ReferralSourceBooking},
+		{name: "ended", change: func(rule *CommissionRule) { rule.EndsAt = &before }, source: ReferralSourceBooking},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewCommissionRule("Test", ReferralSourceBooking, CommissionAmountFlat, 5)
+			tt.change(rule)
+
+			if got := rule.AppliesTo(tt.source, tt.approvedCount, now); got != tt.want {
+				t.Errorf("AppliesTo(%s, %d) = %t, want %t", tt.source, tt.approvedCount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommissionRuleOutranks(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	now := time.Now()
+
+	rule := func(tenant, product bool, tier int, created time.Time) *CommissionRule {
+		r := NewCommissionRule("Test", ReferralSourceBooking, CommissionAmountFlat, 5)
+		if tenant {
+			r.TenantID = &tenantID
+		}
+		if product {
+			r.ProductID = &productID
+		}
+		r.MinApprovedReferrals = tier
+		r.CreatedAt = created
+		return r
+	}
+
+	tests := []struct {
+		name  string
+		rule  *CommissionRule
+		other *CommissionRule
+		want  bool
+	}{
+		{name: "product over tenant", rule: rule(true, true, 0, now), other: rule(true, false, 0, now), want: true},
+		{name: "tenant over global", rule: rule(true, false, 0, now), other: rule(false, false, 0, now), want: true},
+		{name: "global tier under tenant", rule: rule(false, false, 50, now), other: rule(true, false, 0, now)},
+		{name: "higher tier in the same scope", rule: rule(true, false, 10, now), other: rule(true, false, 5, now), want: true},
+		{name: "lower tier in the same scope", rule: rule(true, false, 5, now), other: rule(true, false, 10, now)},
+		{name: "newer in the same tier", rule: rule(true, false, 5, now), other: rule(true, false, 5, now.Add(-time.Hour)), want: true},
+		{name: "older in the same tier", rule: rule(true, false, 5, now.Add(-time.Hour)), other: rule(true, false, 5, now)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Outranks(tt.other); got != tt.want {
+				t.Errorf("Outranks() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommissionRuleCompute(t *testing.T) {
+	tests := []struct {
+		name       string
+		amountType CommissionAmountType
+		amount     float64
+		base       float64
+		want       float64
+	}{
+		{name: "flat", amountType: CommissionAmountFlat, amount: 7.5, base: 100, want: 7.5},
+		{name: "flat without a base", amountType: CommissionAmountFlat, amount: 7.5, want: 7.5},
+		{name: "percent", amountType: CommissionAmountPercent, amount: 10, base: 49.99, want: 5},
+		{name: "percent rounded to cents", amountType: CommissionAmountPercent, amount: 12.5, base: 19.99, want: 2.5},
+		{name: "percent without a base", amountType: CommissionAmountPercent, amount: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewCommissionRule("Test", ReferralSourceBooking, tt.amountType, tt.amount)
+			if got := rule.Compute(tt.base); got != tt.want {
+				t.Errorf("Compute(%v) = %v, want %v", tt.base, got, tt.want)
+			}
+		})
+	}
+}
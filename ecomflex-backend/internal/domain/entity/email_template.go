@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailTemplateName identifies the email a template renders
+type EmailTemplateName string
+
+// Email templates
+const (
+	EmailTemplateVerification       EmailTemplateName = "email_verification"
+	EmailTemplatePasswordReset      EmailTemplateName = "password_reset"
+	EmailTemplateAccountLocked      EmailTemplateName = "account_locked"
+	EmailTemplateInfluencerApproved EmailTemplateName = "influencer_approved"
+	EmailTemplateInfluencerRejected EmailTemplateName = "influencer_rejected"
+	EmailTemplateBookingApproved    EmailTemplateName = "booking_approved"
+	EmailTemplateBookingRejected    EmailTemplateName = "booking_rejected"
+)
+
+// EmailTemplateNames lists every email the platform sends
+var EmailTemplateNames = []EmailTemplateName{
+	EmailTemplateVerification,
+	EmailTemplatePasswordReset,
+	EmailTemplateAccountLocked,
+	EmailTemplateInfluencerApproved,
+	EmailTemplateInfluencerRejected,
+	EmailTemplateBookingApproved,
+	EmailTemplateBookingRejected,
+}
+
+// ErrInvalidEmailTemplate is returned when an email template is unknown or does not parse
+var ErrInvalidEmailTemplate = errors.New("invalid email template")
+
+// IsValid checks if the name is one of the platform's emails
+func (n EmailTemplateName) IsValid() bool {
+	for _, name := range EmailTemplateNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailTemplate is a tenant's override of one of the platform's emails.
+// Subject and TextBody are text/template sources, HTMLBody is an html/template source.
+type EmailTemplate struct {
+	TenantID  uuid.UUID         `json:"tenant_id" db:"tenant_id"`
+	Name      EmailTemplateName `json:"name" db:"name"`
+	Subject   string            `json:"subject" db:"subject"`
+	HTMLBody  string            `json:"html_body" db:"html_body"`
+	TextBody  string            `json:"text_body" db:"text_body"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// NewEmailTemplate creates an email template, checking that its sources parse
+func NewEmailTemplate(tenantID uuid.UUID, name EmailTemplateName, subject, htmlBody, textBody string) (*EmailTemplate, error) {
+	if !name.IsValid() {
+		return nil, fmt.Errorf("%w: unknown template %q", ErrInvalidEmailTemplate, name)
+	}
+
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: subject is required", ErrInvalidEmailTemplate)
+	}
+	if strings.TrimSpace(htmlBody) == "" && strings.TrimSpace(textBody) == "" {
+		return nil, fmt.Errorf("%w: an html or text body is required", ErrInvalidEmailTemplate)
+	}
+
+	now := time.Now()
+	template := &EmailTemplate{
+		TenantID:  tenantID,
+		Name:      name,
+		Subject:   subject,
+		HTMLBody:  htmlBody,
+		TextBody:  textBody,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if _, err := template.Parse(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// ParsedEmailTemplate holds the compiled parts of an email template.
+// HTML and Text are nil when the template has no body of that kind.
+type ParsedEmailTemplate struct {
+	Subject *texttemplate.Template
+	HTML    *htmltemplate.Template
+	Text    *texttemplate.Template
+}
+
+// Parse compiles the sources of the template
+func (t *EmailTemplate) Parse() (*ParsedEmailTemplate, error) {
+	parsed := &ParsedEmailTemplate{}
+
+	var err error
+	if parsed.Subject, err = texttemplate.New("subject").Option("missingkey=zero").Parse(t.Subject); err != nil {
+		return nil, fmt.Errorf("%w: subject: %v", ErrInvalidEmailTemplate, err)
+	}
+
+	if strings.TrimSpace(t.HTMLBody) != "" {
+		if parsed.HTML, err = htmltemplate.New("html").Option("missingkey=zero").Parse(t.HTMLBody); err != nil {
+			return nil, fmt.Errorf("%w: html body: %v", ErrInvalidEmailTemplate, err)
+		}
+	}
+
+	if strings.TrimSpace(t.TextBody) != "" {
+		if parsed.Text, err = texttemplate.New("text").Option("missingkey=zero").Parse(t.TextBody); err != nil {
+			return nil, fmt.Errorf("%w: text body: %v", ErrInvalidEmailTemplate, err)
+		}
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Impersonation records an admin acting as another user with a short-lived access token.
+// It ends when the admin ends it or when its token expires.
+type Impersonation struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	ImpersonatorID uuid.UUID  `json:"impersonator_id" db:"impersonator_id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	TenantID       uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	Reason         string     `json:"reason" db:"reason"`
+	TokenID        string     `json:"-" db:"token_id"`
+	IPAddress      string     `json:"ip_address" db:"ip_address"`
+	UserAgent      string     `json:"user_agent" db:"user_agent"`
+	StartedAt      time.Time  `json:"started_at" db:"started_at"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	EndedAt        *time.Time `json:"ended_at,omitempty" db:"ended_at"`
+}
+
+// NewImpersonation starts an impersonation of a user by an admin
+func NewImpersonation(impersonator, user *User, reason, ipAddress, userAgent string, ttl time.Duration) *Impersonation {
+	now := time.Now()
+	return &Impersonation{
+		ID:             uuid.New(),
+		ImpersonatorID: impersonator.ID,
+		UserID:         user.ID,
+		TenantID:       user.TenantID,
+		Reason:         reason,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		StartedAt:      now,
+		ExpiresAt:      now.Add(ttl),
+	}
+}
+
+// IsActive checks if the impersonation has neither ended nor expired at a time
+func (i *Impersonation) IsActive(at time.Time) bool {
+	return i.EndedAt == nil && at.Before(i.ExpiresAt)
+}
+
+// EndTime gets when the impersonation ended or expired, or nil while it is active
+func (i *Impersonation) EndTime(at time.Time) *time.Time {
+	if i.EndedAt != nil {
+		return i.EndedAt
+	}
+	if !at.Before(i.ExpiresAt) {
+		return &i.ExpiresAt
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LedgerAccount identifies an account in the earnings ledger
+type LedgerAccount string
+
+// Ledger accounts
+const (
+	// LedgerAccountInfluencerEarnings holds what the platform owes an influencer
+	LedgerAccountInfluencerEarnings LedgerAccount = "influencer_earnings"
+
+	// LedgerAccountCommissionExpense is the platform side of commissions
+	LedgerAccountCommissionExpense LedgerAccount = "commission_expense"
+
+	// LedgerAccountPayoutsClearing holds money sent out in payouts
+	LedgerAccountPayoutsClearing LedgerAccount = "payouts_clearing"
+
+	// LedgerAccountCashbackWallet holds the cashback released to a user
+	LedgerAccountCashbackWallet LedgerAccount = "cashback_wallet"
+
+	// LedgerAccountCashbackExpense is the platform side of cashback
+	LedgerAccountCashbackExpense LedgerAccount = "cashback_expense"
+)
+
+// PayableAccounts are the user-owned accounts whose balances are paid out
+var PayableAccounts = []LedgerAccount{LedgerAccountInfluencerEarnings, LedgerAccountCashbackWallet}
+
+// LedgerDirection is the side of a ledger entry
+type LedgerDirection string
+
+// Ledger directions
+const (
+	LedgerDebit  LedgerDirection = "debit"
+	LedgerCredit LedgerDirection = "credit"
+)
+
+// LedgerEntryType represents the business event behind a ledger transaction
+type LedgerEntryType string
+
+// Ledger entry types
+const (
+	LedgerEntryCommission    LedgerEntryType = "commission"
+	LedgerEntryCashback      LedgerEntryType = "cashback"
+	LedgerEntryReversal      LedgerEntryType = "reversal"
+	LedgerEntryPayout        LedgerEntryType = "payout"
+	LedgerEntryPayoutFailure LedgerEntryType = "payout_failure"
+)
+
+// LedgerEntry represents one side of a balanced ledger transaction.
+// Entries are append-only; corrections are posted as new transactions.
+type LedgerEntry struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	TransactionID uuid.UUID       `json:"transaction_id" db:"transaction_id"`
+	TenantID      uuid.UUID       `json:"tenant_id" db:"tenant_id"`
+	Account       LedgerAccount   `json:"account" db:"account"`
+	OwnerID       *uuid.UUID      `json:"owner_id,omitempty" db:"owner_id"`
+	Direction     LedgerDirection `json:"direction" db:"direction"`
+	Amount        float64         `json:"amount" db:"amount"`
+	EntryType     LedgerEntryType `json:"entry_type" db:"entry_type"`
+	ReferralID    *uuid.UUID      `json:"referral_id,omitempty" db:"referral_id"`
+	BookingID     *uuid.UUID      `json:"booking_id,omitempty" db:"booking_id"`
+	PayoutID      *uuid.UUID      `json:"payout_id,omitempty" db:"payout_id"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ledgerPosting is an account touched by a transaction
+type ledgerPosting struct {
+	account LedgerAccount
+	ownerID *uuid.UUID
+}
+
+// newLedgerTransaction creates the debit and credit entries of a transaction.
+// It returns nil for non-positive amounts since there is nothing to post.
+func newLedgerTransaction(tenantID uuid.UUID, entryType LedgerEntryType, amount float64, debit, credit ledgerPosting) []*LedgerEntry {
+	if amount <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	transactionID := uuid.New()
+	entry := func(posting ledgerPosting, direction LedgerDirection) *LedgerEntry {
+		return &LedgerEntry{
+			ID:            uuid.New(),
+			TransactionID: transactionID,
+			TenantID:      tenantID,
+			Account:       posting.account,
+			OwnerID:       posting.ownerID,
+			Direction:     direction,
+			Amount:        amount,
+			EntryType:     entryType,
+			CreatedAt:     now,
+		}
+	}
+
+	return []*LedgerEntry{entry(debit, LedgerDebit), entry(credit, LedgerCredit)}
+}
+
+// NewCommissionEntries credits an influencer with the earnings of an approved referral
+func NewCommissionEntries(referral *Referral) []*LedgerEntry {
+	influencerID := referral.InfluencerID
+	entries := newLedgerTransaction(referral.TenantID, LedgerEntryCommission, referral.Earnings,
+		ledgerPosting{account: LedgerAccountCommissionExpense},
+		ledgerPosting{account: LedgerAccountInfluencerEarnings, ownerID: &influencerID},
+	)
+	return withReferral(entries, referral.ID)
+}
+
+// NewReversalEntries takes back the earnings of an approved referral that was rejected afterwards
+func NewReversalEntries(referral *Referral) []*LedgerEntry {
+	influencerID := referral.InfluencerID
+	entries := newLedgerTransaction(referral.TenantID, LedgerEntryReversal, referral.Earnings,
+		ledgerPosting{account: LedgerAccountInfluencerEarnings, ownerID: &influencerID},
+		ledgerPosting{account: LedgerAccountCommissionExpense},
+	)
+	return withReferral(entries, referral.ID)
+}
+
+// NewCashbackEntries credits a user's wallet with the cashback of a booking
+func NewCashbackEntries(booking *Booking) []*LedgerEntry {
+	userID := booking.UserID
+	entries := newLedgerTransaction(booking.TenantID, LedgerEntryCashback, booking.CashbackAmount,
+		ledgerPosting{account: LedgerAccountCashbackExpense},
+		ledgerPosting{account: LedgerAccountCashbackWallet, ownerID: &userID},
+	)
+	return withBooking(entries, booking.ID)
+}
+
+// NewPayoutEntries debits the paid amount from the payee's account
+func NewPayoutEntries(payout *Payout) []*LedgerEntry {
+	userID := payout.UserID
+	entries := newLedgerTransaction(payout.TenantID, LedgerEntryPayout, payout.Amount,
+		ledgerPosting{account: payout.Account, ownerID: &userID},
+		ledgerPosting{account: LedgerAccountPayoutsClearing},
+	)
+	return withPayout(entries, payout.ID)
+}
+
+// NewPayoutFailureEntries returns the amount of a failed payout to the payee's account
+func NewPayoutFailureEntries(payout *Payout) []*LedgerEntry {
+	userID := payout.UserID
+	entries := newLedgerTransaction(payout.TenantID, LedgerEntryPayoutFailure, payout.Amount,
+		ledgerPosting{account: LedgerAccountPayoutsClearing},
+		ledgerPosting{account: payout.Account, ownerID: &userID},
+	)
+	return withPayout(entries, payout.ID)
+}
+
+// withReferral links entries to the referral they were posted for
+func withReferral(entries []*LedgerEntry, referralID uuid.UUID) []*LedgerEntry {
+	for _, entry := range entries {
+		entry.ReferralID = &referralID
+	}
+	return entries
+}
+
+// withBooking links entries to the booking they were posted for
+func withBooking(entries []*LedgerEntry, bookingID uuid.UUID) []*LedgerEntry {
+	for _, entry := range entries {
+		entry.BookingID = &bookingID
+	}
+	return entries
+}
+
+// withPayout links entries to the payout they were posted for
+func withPayout(entries []*LedgerEntry, payoutID uuid.UUID) []*LedgerEntry {
+	for _, entry := range entries {
+		entry.PayoutID = &payoutID
+	}
+	return entries
+}
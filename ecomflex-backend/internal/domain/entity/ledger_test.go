@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLedgerEntriesBalance(t *testing.T) {
+	tenantID := uuid.New()
+	influencerID := uuid.New()
+	userID := uuid.New()
+
+	referral := NewReferral(tenantID, influencerID, uuid.New(), nil, ReferralSourceBooking, 12.5)
+	booking := NewBooking(tenantID, userID, uuid.New(), "")
+	booking.CashbackAmount = 19.99
+	earningsPayout := NewPayout(uuid.New(), tenantID, influencerID, LedgerAccountInfluencerEarnings, 60)
+	cashbackPayout := NewPayout(uuid.New(), tenantID, userID, LedgerAccountCashbackWallet, 19.99)
+
+	tests := []struct {
+		name      string
+		entries   []*LedgerEntry
+		entryType LedgerEntryType
+		amount    float64
+		debit     LedgerAccount
+		credit    LedgerAccount
+		owner     uuid.UUID
+		ownerSide LedgerDirection
+		link      func(entry *LedgerEntry) *uuid.UUID
+		linkID    uuid.UUID
+	}{
+		{
+			name: "commission", entries: NewCommissionEntries(referral), entryType: LedgerEntryCommission, amount: 12.5,
+			debit: LedgerAccountCommissionExpense, credit: LedgerAccountInfluencerEarnings, owner: influencerID, ownerSide: LedgerCredit,
+			link: func(entry *LedgerEntry) *uuid.UUID { return entry.ReferralID }, linkID: referral.ID,
+		},
+		{
+			name: "reversal", entries: NewReversalEntries(referral), entryType: LedgerEntryReversal, amount: 12.5,
+			debit: LedgerAccountInfluencerEarnings, credit: LedgerAccountCommissionExpense, owner: influencerID, ownerSide: LedgerDebit,
+			link: func(entry *LedgerEntry) *uuid.UUID { return entry.ReferralID }, linkID: referral.ID,
+		},
+		{
+			name: "cashback", entries: NewCashbackEntries(booking), entryType: LedgerEntryCashback, amount: 19.99,
+			debit: LedgerAccountCashbackExpense, credit: LedgerAccountCashbackWallet, owner: userID, ownerSide: LedgerCredit,
+			link: func(entry *LedgerEntry) *uuid.UUID { return entry.BookingID }, linkID: booking.ID,
+		},
+		{
+			name: "earnings payout", entries: NewPayoutEntries(earningsPayout), entryType: LedgerEntryPayout, amount: 60,
+			debit: LedgerAccountInfluencerEarnings, credit: LedgerAccountPayoutsClearing, owner: influencerID, ownerSide: LedgerDebit,
+			link: func(entry *LedgerEntry) *uuid.UUID { return entry.PayoutID }, linkID: earningsPayout.ID,
+		},
+		{
+			name: "cashback payout", entries: NewPayoutEntries(cashbackPayout), entryType: LedgerEntryPayout, amount: 19.99,
+			debit: LedgerAccountCashbackWallet, credit: LedgerAccountPayoutsClearing, owner: userID, ownerSide: LedgerDebit,
+			link: func(entry *LedgerEntry) *uuid.UUID { return entry.PayoutID }, linkID: cashbackPayout.ID,
+		},
+		{
+			name: "failed payout", entries: NewPayoutFailureEntries(earningsPayout), entryType: LedgerEntryPayoutFailure, amount: 60,
+			debit: LedgerAccountPayoutsClearing, credit: LedgerAccountInfluencerEarnings, owner: influencerID, ownerSide: LedgerCredit,
+			link: func(entry *LedgerEntry) *uuid.UUID { return entry.PayoutID }, linkID: earningsPayout.ID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.entries) != 2 {
+				t.Fatalf("got %d entries, want a debit and a credit", len(tt.entries))
+			}
+			debit, credit := tt.entries[0], tt.entries[1]
+
+			if debit.Direction != LedgerDebit || credit.Direction != LedgerCredit {
+				t.Fatalf("directions = %s, %s, want debit, credit", debit.Direction, credit.Direction)
+			}
+			if debit.Account != tt.debit || credit.Account != tt.credit {
+				t.Errorf("accounts = %s -> %s, want %s -> %s", debit.Account, credit.Account, tt.debit, tt.credit)
+			}
+			if debit.TransactionID != credit.TransactionID {
+				t.Errorf("entries belong to transactions %s and %s, want one", debit.TransactionID, credit.TransactionID)
+			}
+
+			for _, entry := range tt.entries {
+				if entry.Amount != tt.amount || entry.EntryType != tt.entryType || entry.TenantID != tenantID {
+					t.Errorf("entry = %s %v in %s, want %s %v in %s", entry.EntryType, entry.Amount, entry.TenantID, tt.entryType, tt.amount, tenantID)
+				}
+				if link := tt.link(entry); link == nil || *link != tt.linkID {
+					t.Errorf("%s entry links to %v, want %s", entry.Direction, link, tt.linkID)
+				}
+
+				// Only the user's side of the transaction has an owner
+				if entry.Direction == tt.ownerSide {
+					if entry.OwnerID == nil || *entry.OwnerID != tt.owner {
+						t.Errorf("%s entry owner = %v, want %s", entry.Direction, entry.OwnerID, tt.owner)
+					}
+				} else if entry.OwnerID != nil {
+					t.Errorf("%s entry owner = %s, want none", entry.Direction, entry.OwnerID)
+				}
+			}
+		})
+	}
+}
+
+func TestLedgerEntriesOfNothing(t *testing.T) {
+	tenantID := uuid.New()
+
+	booking := NewBooking(tenantID, uuid.New(), uuid.New(), "")
+	unpaidReferral := NewReferral(tenantID, uuid.New(), uuid.New(), nil, ReferralSourceRegistration, 0)
+
+	if entries := NewCashbackEntries(booking); entries != nil {
+		t.Errorf("NewCashbackEntries() without cashback = %d entries, want none", len(entries))
+	}
+	if entries := NewCommissionEntries(unpaidReferral); entries != nil {
+		t.Errorf("NewCommissionEntries() without earnings = %d entries, want none", len(entries))
+	}
+	if entries := NewReversalEntries(unpaidReferral); entries != nil {
+		t.Errorf("NewReversalEntries() without earnings = %d entries, want none", len(entries))
+	}
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// LoginFailures is the failed logins of an account or IP address within the throttling window
+type LoginFailures struct {
+	Count  int64
+	LastAt time.Time
+}
+
+// LoginThrottleKey identifies what failed logins are counted against
+type LoginThrottleKey string
+
+// AccountThrottleKey returns the key failed logins with an email are counted under.
+// Emails without an account are counted too, so throttling does not reveal which accounts exist.
+func AccountThrottleKey(email string) LoginThrottleKey {
+	return LoginThrottleKey("account:" + strings.ToLower(strings.TrimSpace(email)))
+}
+
+// IPThrottleKey returns the key failed logins from an IP address are counted under
+func IPThrottleKey(ip string) LoginThrottleKey {
+	return LoginThrottleKey("ip:" + ip)
+}
@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+)
+
+const (
+	// mfaCodeSkew is how many time steps a TOTP code may be off by, to allow for clock drift
+	mfaCodeSkew = 1
+
+	// recoveryCodeSize is the number of random bytes in a recovery code
+	recoveryCodeSize = 5
+)
+
+// recoveryCodeEncoding writes recovery codes in characters that are easy to type
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAFactor is a user's TOTP authenticator.
+// It is pending until the user proves their app generates matching codes;
+// only an enabled factor is asked for at login.
+type MFAFactor struct {
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	Secret       string     `json:"-" db:"secret"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty" db:"enabled_at"`
+	LastUsedStep int64      `json:"-" db:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NewMFAFactor creates a pending factor with a new secret
+func NewMFAFactor(userID uuid.UUID) (*MFAFactor, error) {
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &MFAFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsEnabled checks if the factor has been confirmed
+func (f *MFAFactor) IsEnabled() bool {
+	return f.EnabledAt != nil
+}
+
+// Enable marks the factor as confirmed
+func (f *MFAFactor) Enable() {
+	now := time.Now()
+	f.EnabledAt = &now
+	f.UpdatedAt = now
+}
+
+// MatchCode returns the time step a code is valid for.
+// Codes of steps that were already used are rejected, so a code cannot be replayed.
+func (f *MFAFactor) MatchCode(code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != util.TOTPDigits {
+		return 0, false
+	}
+
+	current := util.TOTPStep(now)
+	for step := current - mfaCodeSkew; step <= current+mfaCodeSkew; step++ {
+		if step <= f.LastUsedStep {
+			continue
+		}
+		expected, err := util.TOTPCode(f.Secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// ProvisioningURI returns the otpauth URI to show as a QR code
+func (f *MFAFactor) ProvisioningURI(issuer, account string) string {
+	return util.TOTPURI(f.Secret, issuer, account)
+}
+
+// MFARecoveryCode is a one-time code that stands in for a TOTP code when the
+// authenticator is lost. Only the hash of the code is stored.
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	CodeHash  string     `json:"-" db:"code_hash"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NewMFARecoveryCodes creates a set of recovery codes for a user and returns them
+// along with the raw codes to show the user once
+func NewMFARecoveryCodes(userID uuid.UUID, count int) ([]string, []*MFARecoveryCode, error) {
+	raw := make([]string, count)
+	codes := make([]*MFARecoveryCode, count)
+	now := time.Now()
+
+	for i := range codes {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		raw[i] = code[:4] + "-" + code[4:]
+		codes[i] = &MFARecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  HashRecoveryCode(raw[i]),
+			CreatedAt: now,
+		}
+	}
+
+	return raw, codes, nil
+}
+
+// HashRecoveryCode hashes a recovery code for lookup, ignoring case and separators
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// MFAChallenge is a login that passed the password check and awaits a second factor.
+// When the tenant requires MFA and the user has none yet, the challenge lets them enroll first.
+// Only the hash of the challenge token is stored.
+type MFAChallenge struct {
+	Hash               string    `json:"hash"`
+	UserID             uuid.UUID `json:"user_id"`
+	EnrollmentRequired bool      `json:"enrollment_required"`
+	ExpiresAt          time.Time `json:"expires_at"`
+}
+
+// NewMFAChallenge creates a challenge for a user and returns it along with the raw token
+func NewMFAChallenge(userID uuid.UUID, enrollmentRequired bool, ttl time.Duration) (string, *MFAChallenge, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate mfa challenge: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	return token, &MFAChallenge{
+		Hash:               HashMFAChallengeToken(token),
+		UserID:             userID,
+		EnrollmentRequired: enrollmentRequired,
+		ExpiresAt:          time.Now().Add(ttl),
+	}, nil
+}
+
+// HashMFAChallengeToken hashes a raw challenge token for lookup
+func HashMFAChallengeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+)
+
+// rfc6238Secret is the SHA1 secret of the RFC 6238 test vectors, base32 encoded
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestMFAFactorMatchCode(t *testing.T) {
+	// RFC 6238 gives 07081804 for this moment; authenticators show its last six digits
+	now := time.Unix(1111111109, 0)
+	current := util.TOTPStep(now)
+
+	codeAt := func(t *testing.T, step int64) string {
+		t.Helper()
+		code, err := util.TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("TOTPCode: %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name         string
+		code         func(t *testing.T) string
+		lastUsedStep int64
+		wantStep     int64
+		wantOK       bool
+	}{
+		{name: "RFC 6238 test vector", code: func(*testing.T) string { return "081804" }, wantStep: current, wantOK: true},
+		{name: "code of the previous step", code: func(t *testing.T) string { return codeAt(t, current-1) }, wantStep: current - 1, wantOK: true},
+		{name: "code of the next step", code: func(t *testing.T) string { return codeAt(t, current+1) }, wantStep: current + 1, wantOK: true},
+		{name: "code outside the allowed drift", code: func(t *testing.T) string { return codeAt(t, current-2) }},
+		{name: "surrounding spaces", code: func(*testing.T) string { return " 081804 " }, wantStep: current, wantOK: true},
+		{name: "wrong code", code: func(*testing.T) string { return "000000" }},
+		{name: "too short", code: func(*testing.T) string { return "08180" }},
+		{name: "eight digit code", code: func(*testing.T) string { return "07081804" }},
+		{name: "empty", code: func(*testing.T) string { return "" }},
+		{
+			name:         "replayed code",
+			code:         func(*testing.T) string { return "081804" },
+			lastUsedStep: current,
+		},
+		{
+			name:         "earlier code after a later one was used",
+			code:         func(t *testing.T) string { return codeAt(t, current-1) },
+			lastUsedStep: current,
+		},
+		{
+			name:         "later code after an earlier one was used",
+			code:         func(t *testing.T) string { return codeAt(t, current+1) },
+			lastUsedStep: current,
+			wantStep:     current + 1,
+			wantOK:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor := &MFAFactor{Secret: rfc6238Secret, LastUsedStep: tt.lastUsedStep}
+
+			step, ok := factor.MatchCode(tt.code(t), now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("MatchCode() = (%d, %t), want (%d, %t)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
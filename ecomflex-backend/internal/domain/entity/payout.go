@@ -0,0 +1,202 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayoutStatus represents the lifecycle state of a payout
+type PayoutStatus string
+
+// Payout statuses
+const (
+	PayoutStatusPending    PayoutStatus = "pending"
+	PayoutStatusProcessing PayoutStatus = "processing"
+	PayoutStatusPaid       PayoutStatus = "paid"
+	PayoutStatusFailed     PayoutStatus = "failed"
+)
+
+// PayoutRunStatus represents the state of a payout run
+type PayoutRunStatus string
+
+// Payout run statuses
+const (
+	PayoutRunStatusRunning   PayoutRunStatus = "running"
+	PayoutRunStatusCompleted PayoutRunStatus = "completed"
+	PayoutRunStatusFailed    PayoutRunStatus = "failed"
+)
+
+var (
+	// ErrInvalidPayoutTransition is returned when a payout cannot move to the requested status
+	ErrInvalidPayoutTransition = errors.New("invalid payout status transition")
+
+	// ErrPayoutRunInProgress is returned when a payout run is started while another one is running
+	ErrPayoutRunInProgress = errors.New("a payout run is already in progress")
+
+	// ErrInsufficientBalance is returned when a balance no longer covers a payout
+	ErrInsufficientBalance = errors.New("insufficient balance")
+
+	// ErrDuplicatePayoutEvent is returned when a provider event has already been processed
+	ErrDuplicatePayoutEvent = errors.New("payout event has already been processed")
+)
+
+// Payout represents money sent out of a ledger account to its owner
+type Payout struct {
+	ID            uuid.UUID     `json:"id" db:"id"`
+	TenantID      uuid.UUID     `json:"tenant_id" db:"tenant_id"`
+	RunID         uuid.UUID     `json:"run_id" db:"run_id"`
+	UserID        uuid.UUID     `json:"user_id" db:"user_id"`
+	Account       LedgerAccount `json:"account" db:"account"`
+	Amount        float64       `json:"amount" db:"amount"`
+	Status        PayoutStatus  `json:"status" db:"status"`
+	Provider      string        `json:"provider,omitempty" db:"provider"`
+	Reference     string        `json:"reference,omitempty" db:"reference"`
+	FailureReason string        `json:"failure_reason,omitempty" db:"failure_reason"`
+	PaidAt        *time.Time    `json:"paid_at,omitempty" db:"paid_at"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// NewPayout creates a pending payout of an account balance
+func NewPayout(runID, tenantID, userID uuid.UUID, account LedgerAccount, amount float64) *Payout {
+	now := time.Now()
+	return &Payout{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		RunID:     runID,
+		UserID:    userID,
+		Account:   account,
+		Amount:    amount,
+		Status:    PayoutStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// MarkProcessing marks a pending payout as handed to a payout provider
+func (p *Payout) MarkProcessing(provider, reference string) error {
+	if p.Status != PayoutStatusPending {
+		return ErrInvalidPayoutTransition
+	}
+	p.Status = PayoutStatusProcessing
+	p.Provider = provider
+	p.Reference = reference
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkPaid marks an open payout as paid
+func (p *Payout) MarkPaid(reference string) error {
+	if p.IsFinal() {
+		return ErrInvalidPayoutTransition
+	}
+	now := time.Now()
+	p.Status = PayoutStatusPaid
+	if reference != "" {
+		p.Reference = reference
+	}
+	p.PaidAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// MarkFailed marks an open payout as failed
+func (p *Payout) MarkFailed(reason string) error {
+	if p.IsFinal() {
+		return ErrInvalidPayoutTransition
+	}
+	p.Status = PayoutStatusFailed
+	p.FailureReason = reason
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsFinal checks if the payout has been paid or has failed
+func (p *Payout) IsFinal() bool {
+	return p.Status == PayoutStatusPaid || p.Status == PayoutStatusFailed
+}
+
+// PayoutBeneficiary links a payee to their beneficiary record at a payout provider
+type PayoutBeneficiary struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Provider  string    `json:"provider" db:"provider"`
+	Reference string    `json:"reference" db:"reference"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NewPayoutBeneficiary creates a new payout beneficiary
+func NewPayoutBeneficiary(userID uuid.UUID, provider, reference string) *PayoutBeneficiary {
+	return &PayoutBeneficiary{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Provider:  provider,
+		Reference: reference,
+		CreatedAt: time.Now(),
+	}
+}
+
+// PayoutEvent records a status update received from a payout provider.
+// The provider's event ID makes redelivered webhooks apply only once.
+type PayoutEvent struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	Provider   string       `json:"provider" db:"provider"`
+	EventID    string       `json:"event_id" db:"event_id"`
+	PayoutID   uuid.UUID    `json:"payout_id" db:"payout_id"`
+	Status     PayoutStatus `json:"status" db:"status"`
+	ReceivedAt time.Time    `json:"received_at" db:"received_at"`
+}
+
+// NewPayoutEvent creates a new payout event
+func NewPayoutEvent(provider, eventID string, payout *Payout) *PayoutEvent {
+	return &PayoutEvent{
+		ID:         uuid.New(),
+		Provider:   provider,
+		EventID:    eventID,
+		PayoutID:   payout.ID,
+		Status:     payout.Status,
+		ReceivedAt: time.Now(),
+	}
+}
+
+// PayoutRun represents a batch of payouts created from eligible balances
+type PayoutRun struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	Threshold   float64         `json:"threshold" db:"threshold"`
+	Status      PayoutRunStatus `json:"status" db:"status"`
+	PayoutCount int             `json:"payout_count" db:"payout_count"`
+	TotalAmount float64         `json:"total_amount" db:"total_amount"`
+	Error       string          `json:"error,omitempty" db:"error"`
+	StartedAt   time.Time       `json:"started_at" db:"started_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// NewPayoutRun starts a payout run for balances at or above threshold
+func NewPayoutRun(threshold float64) *PayoutRun {
+	return &PayoutRun{
+		ID:        uuid.New(),
+		Threshold: threshold,
+		Status:    PayoutRunStatusRunning,
+		StartedAt: time.Now(),
+	}
+}
+
+// AddPayout counts a payout created by the run
+func (r *PayoutRun) AddPayout(payout *Payout) {
+	r.PayoutCount++
+	r.TotalAmount = math.Round((r.TotalAmount+payout.Amount)*100) / 100
+}
+
+// Complete finishes the run, recording the error that stopped it if any
+func (r *PayoutRun) Complete(err error) {
+	now := time.Now()
+	r.Status = PayoutRunStatusCompleted
+	if err != nil {
+		r.Status = PayoutRunStatusFailed
+		r.Error = err.Error()
+	}
+	r.CompletedAt = &now
+}
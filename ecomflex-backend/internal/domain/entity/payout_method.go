@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayoutMethodType represents how a user wants to receive payouts
+type PayoutMethodType string
+
+// Payout method types
+const (
+	PayoutMethodBankTransfer PayoutMethodType = "bank_transfer"
+	PayoutMethodUPI          PayoutMethodType = "upi"
+	PayoutMethodPayPal       PayoutMethodType = "paypal"
+)
+
+// payoutMethodFields lists the details each payout method type requires
+var payoutMethodFields = map[PayoutMethodType][]string{
+	PayoutMethodBankTransfer: {"account_holder", "account_number", "routing_code"},
+	PayoutMethodUPI:          {"vpa"},
+	PayoutMethodPayPal:       {"email"},
+}
+
+// ErrInvalidPayoutMethod is returned when a payout method is missing required details
+var ErrInvalidPayoutMethod = errors.New("invalid payout method")
+
+// PayoutMethodDetails holds the account details of a payout method
+type PayoutMethodDetails map[string]string
+
+// Value implements driver.Valuer so the details are stored as JSONB
+func (d PayoutMethodDetails) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
+
+// Scan implements sql.Scanner so the details are read from JSONB
+func (d *PayoutMethodDetails) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*d = PayoutMethodDetails{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for payout method details: %T", src)
+	}
+	return json.Unmarshal(data, d)
+}
+
+// PayoutMethod represents the method a user has chosen to be paid out with
+type PayoutMethod struct {
+	UserID    uuid.UUID           `json:"user_id" db:"user_id"`
+	Type      PayoutMethodType    `json:"type" db:"type"`
+	Details   PayoutMethodDetails `json:"details" db:"details"`
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// NewPayoutMethod creates a payout method, keeping only the details its type uses
+func NewPayoutMethod(userID uuid.UUID, methodType PayoutMethodType, details map[string]string) (*PayoutMethod, error) {
+	fields, ok := payoutMethodFields[methodType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidPayoutMethod, methodType)
+	}
+
+	kept := PayoutMethodDetails{}
+	for _, field := range fields {
+		value := strings.TrimSpace(details[field])
+		if value == "" {
+			return nil, fmt.Errorf("%w: %s is required", ErrInvalidPayoutMethod, field)
+		}
+		kept[field] = value
+	}
+
+	now := time.Now()
+	return &PayoutMethod{
+		UserID:    userID,
+		Type:      methodType,
+		Details:   kept,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
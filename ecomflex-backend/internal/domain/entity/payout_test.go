@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPayoutTransitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       PayoutStatus
+		transition func(payout *Payout) error
+		wantStatus PayoutStatus
+		wantErr    bool
+	}{
+		{name: "dispatch pending", from: PayoutStatusPending, transition: func(p *Payout) error { return p.MarkProcessing("bank", "tr_1") }, wantStatus: PayoutStatusProcessing},
+		{name: "dispatch processing", from: PayoutStatusProcessing, transition: func(p *Payout) error { return p.MarkProcessing("bank", "tr_2") }, wantErr: true},
+		{name: "pay pending", from: PayoutStatusPending, transition: func(p *Payout) error { return p.MarkPaid("") }, wantStatus: PayoutStatusPaid},
+		{name: "pay processing", from: PayoutStatusProcessing, transition: func(p *Payout) error { return p.MarkPaid("tr_1") }, wantStatus: PayoutStatusPaid},
+		{name: "fail processing", from: PayoutStatusProcessing, transition: func(p *Payout) error { return p.MarkFailed("account closed") }, wantStatus: PayoutStatusFailed},
+		{name: "pay paid", from: PayoutStatusPaid, transition: func(p *Payout) error { return p.MarkPaid("") }, wantErr: true},
+		{name: "fail paid", from: PayoutStatusPaid, transition: func(p *Payout) error { return p.MarkFailed("late") }, wantErr: true},
+		{name: "pay failed", from: PayoutStatusFailed, transition: func(p *Payout) error { return p.MarkPaid("") }, wantErr: true},
+		{name: "dispatch failed", from: PayoutStatusFailed, transition: func(p *Payout) error { return p.MarkProcessing("bank", "tr_3") }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payout := NewPayout(uuid.New(), uuid.New(), uuid.New(), LedgerAccountInfluencerEarnings, 60)
+			payout.Status = tt.from
+
+			err := tt.transition(payout)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPayoutTransition) {
+					t.Fatalf("error = %v, want %v", err, ErrInvalidPayoutTransition)
+				}
+				if payout.Status != tt.from {
+					t.Errorf("status = %s, want %s unchanged", payout.Status, tt.from)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if payout.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", payout.Status, tt.wantStatus)
+			}
+			if (payout.PaidAt != nil) != (tt.wantStatus == PayoutStatusPaid) {
+				t.Errorf("paid at = %v with status %s", payout.PaidAt, payout.Status)
+			}
+		})
+	}
+}
+
+func TestPayoutRunTotals(t *testing.T) {
+	run := NewPayoutRun(50)
+	for _, amount := range []float64{50.1, 60.2, 70.3} {
+		run.AddPayout(NewPayout(run.ID, uuid.New(), uuid.New(), LedgerAccountInfluencerEarnings, amount))
+	}
+
+	if run.PayoutCount != 3 || run.TotalAmount != 180.6 {
+		t.Errorf("run = %d payouts totalling %v, want 3 totalling 180.6", run.PayoutCount, run.TotalAmount)
+	}
+
+	run.Complete(nil)
+	if run.Status != PayoutRunStatusCompleted || run.CompletedAt == nil {
+		t.Errorf("completed run status = %s at %v", run.Status, run.CompletedAt)
+	}
+
+	failed := NewPayoutRun(50)
+	failed.Complete(errors.New("ledger unavailable"))
+	if failed.Status != PayoutRunStatusFailed || failed.Error != "ledger unavailable" {
+		t.Errorf("failed run status = %s with error %q", failed.Status, failed.Error)
+	}
+}
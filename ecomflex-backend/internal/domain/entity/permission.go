@@ -0,0 +1,154 @@
+package entity
+
+import "sort"
+
+// Permission names an action that can be granted to a role
+type Permission string
+
+// Permissions
+const (
+	PermissionProductsWrite        Permission = "products:write"
+	PermissionInfluencersRead      Permission = "influencers:read"
+	PermissionInfluencersApprove   Permission = "influencers:approve"
+	PermissionInfluencerDashboard  Permission = "influencer:dashboard"
+	PermissionBookingsRead         Permission = "bookings:read"
+	PermissionBookingsReview       Permission = "bookings:review"
+	PermissionProofsRead           Permission = "proofs:read"
+	PermissionProofsReview         Permission = "proofs:review"
+	PermissionReferralsRead        Permission = "referrals:read"
+	PermissionReferralsReview      Permission = "referrals:review"
+	PermissionCommissionsManage    Permission = "commissions:manage"
+	PermissionPayoutsRead          Permission = "payouts:read"
+	PermissionPayoutsRun           Permission = "payouts:run"
+	PermissionSessionsRead         Permission = "sessions:read"
+	PermissionSessionsRevoke       Permission = "sessions:revoke"
+	PermissionUsersImpersonate     Permission = "users:impersonate"
+	PermissionEmailTemplatesManage Permission = "email_templates:manage"
+	PermissionMFAPolicyManage      Permission = "mfa_policy:manage"
+	PermissionRolesManage          Permission = "roles:manage"
+	PermissionAuditLogsRead        Permission = "audit_logs:read"
+	PermissionTenantsManage        Permission = "tenants:manage"
+	PermissionUsageRead            Permission = "usage:read"
+	PermissionSettingsManage       Permission = "settings:manage"
+)
+
+// AllPermissions lists every permission
+var AllPermissions = []Permission{
+	PermissionProductsWrite,
+	PermissionInfluencersRead,
+	PermissionInfluencersApprove,
+	PermissionInfluencerDashboard,
+	PermissionBookingsRead,
+	PermissionBookingsReview,
+	PermissionProofsRead,
+	PermissionProofsReview,
+	PermissionReferralsRead,
+	PermissionReferralsReview,
+	PermissionCommissionsManage,
+	PermissionPayoutsRead,
+	PermissionPayoutsRun,
+	PermissionSessionsRead,
+	PermissionSessionsRevoke,
+	PermissionUsersImpersonate,
+	PermissionEmailTemplatesManage,
+	PermissionMFAPolicyManage,
+	PermissionRolesManage,
+	PermissionAuditLogsRead,
+	PermissionTenantsManage,
+	PermissionUsageRead,
+	PermissionSettingsManage,
+}
+
+// platformPermissions are held only by super admins; tenants' custom roles cannot grant them
+var platformPermissions = map[Permission]bool{
+	PermissionTenantsManage: true,
+}
+
+// CustomRolePermissions lists the permissions a tenant's custom role can grant
+func CustomRolePermissions() []Permission {
+	permissions := make([]Permission, 0, len(AllPermissions))
+	for _, permission := range AllPermissions {
+		if !platformPermissions[permission] {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+// IsValid checks if the permission is known
+func (p Permission) IsValid() bool {
+	for _, permission := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// builtInRolePermissions maps the roles every tenant has to their permissions
+var builtInRolePermissions = map[Role][]Permission{
+	RoleSuperAdmin: AllPermissions,
+	RoleSupport: {
+		PermissionInfluencersRead,
+		PermissionBookingsRead,
+		PermissionProofsRead,
+		PermissionReferralsRead,
+		PermissionPayoutsRead,
+		PermissionSessionsRead,
+		PermissionSessionsRevoke,
+		PermissionUsersImpersonate,
+		PermissionAuditLogsRead,
+	},
+	RoleInfluencer: {
+		PermissionInfluencerDashboard,
+	},
+	RolePublic: {},
+}
+
+// BuiltInRoles lists the roles every tenant has
+func BuiltInRoles() []Role {
+	return []Role{RoleSuperAdmin, RoleSupport, RoleInfluencer, RolePublic}
+}
+
+// IsBuiltIn checks if the role is one every tenant has rather than a tenant's custom role
+func (r Role) IsBuiltIn() bool {
+	_, ok := builtInRolePermissions[r]
+	return ok
+}
+
+// Permissions gets the permissions of a built-in role; custom roles have none here
+func (r Role) Permissions() []Permission {
+	return builtInRolePermissions[r]
+}
+
+// PermissionSet is a set of permissions held by a user
+type PermissionSet map[Permission]struct{}
+
+// NewPermissionSet creates a set of the given permissions
+func NewPermissionSet(permissions ...Permission) PermissionSet {
+	set := make(PermissionSet, len(permissions))
+	for _, permission := range permissions {
+		set[permission] = struct{}{}
+	}
+	return set
+}
+
+// Has checks if the set holds all the given permissions
+func (s PermissionSet) Has(permissions ...Permission) bool {
+	for _, permission := range permissions {
+		if _, ok := s[permission]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// List gets the permissions in the set sorted by name
+func (s PermissionSet) List() []Permission {
+	permissions := make([]Permission, 0, len(s))
+	for permission := range s {
+		permissions = append(permissions, permission)
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i] < permissions[j] })
+	return permissions
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotaResource names something a plan limits the amount of
+type QuotaResource string
+
+// Quota resources
+const (
+	QuotaActiveProducts  QuotaResource = "active_products"
+	QuotaInfluencers     QuotaResource = "influencers"
+	QuotaMonthlyBookings QuotaResource = "monthly_bookings"
+	QuotaStorageBytes    QuotaResource = "storage_bytes"
+)
+
+// QuotaResources lists every quota resource in the order usage is reported
+var QuotaResources = []QuotaResource{
+	QuotaActiveProducts,
+	QuotaInfluencers,
+	QuotaMonthlyBookings,
+	QuotaStorageBytes,
+}
+
+// PlanFeature names a feature only some plans include
+type PlanFeature string
+
+// Plan features
+const (
+	FeatureCustomDomain   PlanFeature = "custom_domain"
+	FeatureCustomRoles    PlanFeature = "custom_roles"
+	FeatureAuditLogExport PlanFeature = "audit_log_export"
+)
+
+// Plan is a subscription plan with the limits and features of the tenants on it.
+// A nil limit means the plan does not limit that resource.
+type Plan struct {
+	ID                 string        `json:"id" db:"id"`
+	Name               string        `json:"name" db:"name"`
+	MaxActiveProducts  *int64        `json:"max_active_products" db:"max_active_products"`
+	MaxInfluencers     *int64        `json:"max_influencers" db:"max_influencers"`
+	MaxMonthlyBookings *int64        `json:"max_monthly_bookings" db:"max_monthly_bookings"`
+	MaxStorageBytes    *int64        `json:"max_storage_bytes" db:"max_storage_bytes"`
+	Features           []PlanFeature `json:"features" db:"-"`
+	SortOrder          int           `json:"sort_order" db:"sort_order"`
+	CreatedAt          time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// Limit gets the limit the plan sets on a resource; false means unlimited
+func (p *Plan) Limit(resource QuotaResource) (int64, bool) {
+	var limit *int64
+	switch resource {
+	case QuotaActiveProducts:
+		limit = p.MaxActiveProducts
+	case QuotaInfluencers:
+		limit = p.MaxInfluencers
+	case QuotaMonthlyBookings:
+		limit = p.MaxMonthlyBookings
+	case QuotaStorageBytes:
+		limit = p.MaxStorageBytes
+	}
+
+	if limit == nil {
+		return 0, false
+	}
+	return *limit, true
+}
+
+// HasFeature checks if the plan includes a feature
+func (p *Plan) HasFeature(feature PlanFeature) bool {
+	for _, f := range p.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantUsage is how much of each quota resource a tenant uses
+type TenantUsage struct {
+	ActiveProducts  int64 `db:"active_products"`
+	Influencers     int64 `db:"influencers"`
+	MonthlyBookings int64 `db:"monthly_bookings"`
+	StorageBytes    int64 `db:"storage_bytes"`
+}
+
+// Of gets the usage of a resource
+func (u *TenantUsage) Of(resource QuotaResource) int64 {
+	switch resource {
+	case QuotaActiveProducts:
+		return u.ActiveProducts
+	case QuotaInfluencers:
+		return u.Influencers
+	case QuotaMonthlyBookings:
+		return u.MonthlyBookings
+	case QuotaStorageBytes:
+		return u.StorageBytes
+	default:
+		return 0
+	}
+}
+
+// UsagePeriodStart gets the start of the calendar month, in UTC, that monthly quotas count from
+func UsagePeriodStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// StoredFile is an uploaded file counted against its tenant's storage
+type StoredFile struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Key       string     `json:"key" db:"key"`
+	SizeBytes int64      `json:"size_bytes" db:"size_bytes"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NewStoredFile creates a new stored file
+func NewStoredFile(tenantID uuid.UUID, userID *uuid.UUID, key string, sizeBytes int64) *StoredFile {
+	return &StoredFile{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		UserID:    userID,
+		Key:       key,
+		SizeBytes: sizeBytes,
+		CreatedAt: time.Now(),
+	}
+}
@@ -14,15 +14,25 @@ type Product struct {
 	Founder          string    `json:"founder" gorm:"not null"`
 	ProductLink      string    `json:"productLink" gorm:"not null"`
 	Details          string    `json:"details" gorm:"type:text"`
+	Price            float64   `json:"price" gorm:"type:decimal(10,2);not null;default:0"`
 	RequiredBookings int       `json:"requiredBookings" gorm:"not null;default:0"`
 	CurrentBookings  int       `json:"currentBookings" gorm:"not null;default:0"`
 	ImageURL         string    `json:"image" gorm:"column:image_url"`
 	IsActive         bool      `json:"isActive" gorm:"not null;default:true"`
+	AutoClosed       bool      `json:"autoClosed" gorm:"not null;default:false"`
 	TenantID         uuid.UUID `json:"tenantId" gorm:"type:uuid;not null"`
 	CreatedAt        time.Time `json:"createdAt" gorm:"not null"`
 	UpdatedAt        time.Time `json:"updatedAt" gorm:"not null"`
 }
 
+// AvailableSlots returns the number of booking slots still open on the product
+func (p *Product) AvailableSlots() int {
+	if p.CurrentBookings >= p.RequiredBookings {
+		return 0
+	}
+	return p.RequiredBookings - p.CurrentBookings
+}
+
 type ProductFilter struct {
 	Category string
 	Search   string
@@ -0,0 +1,27 @@
+package entity
+
+import "testing"
+
+func TestProductAvailableSlots(t *testing.T) {
+	tests := []struct {
+		name     string
+		required int
+		current  int
+		want     int
+	}{
+		{name: "no bookings", required: 5, want: 5},
+		{name: "partly booked", required: 5, current: 3, want: 2},
+		{name: "full", required: 5, current: 5},
+		{name: "oversold", required: 5, current: 7},
+		{name: "no slots", required: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &Product{RequiredBookings: tt.required, CurrentBookings: tt.current}
+			if got := product.AvailableSlots(); got != tt.want {
+				t.Fatalf("AvailableSlots() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProofStatus represents the moderation state of a proof of purchase
+type ProofStatus string
+
+// Proof statuses
+const (
+	ProofStatusSubmitted ProofStatus = "submitted"
+	ProofStatusInReview  ProofStatus = "in_review"
+	ProofStatusApproved  ProofStatus = "approved"
+	ProofStatusRejected  ProofStatus = "rejected"
+	// ProofStatusWithdrawn is the status of a proof whose booking was cancelled before review
+	ProofStatusWithdrawn ProofStatus = "withdrawn"
+)
+
+// ProofAction represents a moderation action taken on a proof
+type ProofAction string
+
+// Proof actions
+const (
+	ProofActionClaimed  ProofAction = "claimed"
+	ProofActionApproved ProofAction = "approved"
+	ProofActionRejected ProofAction = "rejected"
+)
+
+var (
+	// ErrProofAlreadyClaimed is returned when another reviewer holds the claim on a proof
+	ErrProofAlreadyClaimed = errors.New("proof is claimed by another reviewer")
+
+	// ErrProofAlreadyReviewed is returned when a decision has already been made on a proof
+	ErrProofAlreadyReviewed = errors.New("proof has already been reviewed")
+)
+
+// ProofFields holds the free-form fields submitted alongside a proof
+type ProofFields map[string]string
+
+// Value implements driver.Valuer so the fields are stored as JSONB
+func (f ProofFields) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements sql.Scanner so the fields are read from JSONB
+func (f *ProofFields) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*f = ProofFields{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for proof fields: %T", src)
+	}
+	return json.Unmarshal(data, f)
+}
+
+// Proof represents a proof of purchase submitted for a booking
+type Proof struct {
+	ID              uuid.UUID   `json:"id" db:"id"`
+	TenantID        uuid.UUID   `json:"tenantId" db:"tenant_id"`
+	BookingID       uuid.UUID   `json:"bookingId" db:"booking_id"`
+	ProductID       uuid.UUID   `json:"productId" db:"product_id"`
+	UserID          uuid.UUID   `json:"userId" db:"user_id"`
+	StorageKey      string      `json:"storageKey" db:"storage_key"`
+	FileURL         string      `json:"fileUrl" db:"file_url"`
+	OrderID         string      `json:"orderId,omitempty" db:"order_id"`
+	SubmittedFields ProofFields `json:"submittedFields,omitempty" db:"submitted_fields"`
+	Status          ProofStatus `json:"status" db:"status"`
+	ClaimedBy       *uuid.UUID  `json:"claimedBy,omitempty" db:"claimed_by"`
+	ClaimedAt       *time.Time  `json:"claimedAt,omitempty" db:"claimed_at"`
+	ReviewedBy      *uuid.UUID  `json:"reviewedBy,omitempty" db:"reviewed_by"`
+	ReviewedAt      *time.Time  `json:"reviewedAt,omitempty" db:"reviewed_at"`
+	RejectionReason string      `json:"rejectionReason,omitempty" db:"rejection_reason"`
+	CreatedAt       time.Time   `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time   `json:"updatedAt" db:"updated_at"`
+}
+
+// NewProof creates a new proof for a booking
+func NewProof(booking *Booking, storageKey, fileURL, orderID string, fields ProofFields) *Proof {
+	now := time.Now()
+	if fields == nil {
+		fields = ProofFields{}
+	}
+	return &Proof{
+		ID:              uuid.New(),
+		TenantID:        booking.TenantID,
+		BookingID:       booking.ID,
+		ProductID:       booking.ProductID,
+		UserID:          booking.UserID,
+		StorageKey:      storageKey,
+		FileURL:         fileURL,
+		OrderID:         orderID,
+		SubmittedFields: fields,
+		Status:          ProofStatusSubmitted,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// IsReviewed checks if the proof left the moderation queue, either decided or withdrawn
+func (p *Proof) IsReviewed() bool {
+	return p.Status == ProofStatusApproved || p.Status == ProofStatusRejected || p.Status == ProofStatusWithdrawn
+}
+
+// IsClaimedByOther checks if another reviewer holds a claim made after staleBefore
+func (p *Proof) IsClaimedByOther(reviewerID uuid.UUID, staleBefore time.Time) bool {
+	if p.ClaimedBy == nil || *p.ClaimedBy == reviewerID {
+		return false
+	}
+	return p.ClaimedAt != nil && p.ClaimedAt.After(staleBefore)
+}
+
+// Claim assigns the proof to a reviewer
+func (p *Proof) Claim(reviewerID uuid.UUID) error {
+	if p.IsReviewed() {
+		return ErrProofAlreadyReviewed
+	}
+	now := time.Now()
+	p.Status = ProofStatusInReview
+	p.ClaimedBy = &reviewerID
+	p.ClaimedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
+// Approve records an approval decision
+func (p *Proof) Approve(reviewerID uuid.UUID) error {
+	return p.decide(reviewerID, ProofStatusApproved, "")
+}
+
+// Reject records a rejection decision with a reason
+func (p *Proof) Reject(reviewerID uuid.UUID, reason string) error {
+	return p.decide(reviewerID, ProofStatusRejected, reason)
+}
+
+func (p *Proof) decide(reviewerID uuid.UUID, status ProofStatus, reason string) error {
+	if p.IsReviewed() {
+		return ErrProofAlreadyReviewed
+	}
+	now := time.Now()
+	p.Status = status
+	p.ReviewedBy = &reviewerID
+	p.ReviewedAt = &now
+	p.RejectionReason = reason
+	p.UpdatedAt = now
+	return nil
+}
+
+// ProofDecision is an entry in the moderation history of a proof
+type ProofDecision struct {
+	ID        uuid.UUID   `json:"id" db:"id"`
+	ProofID   uuid.UUID   `json:"proofId" db:"proof_id"`
+	BookingID uuid.UUID   `json:"bookingId" db:"booking_id"`
+	ActorID   uuid.UUID   `json:"actorId" db:"actor_id"`
+	Action    ProofAction `json:"action" db:"action"`
+	Reason    string      `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time   `json:"createdAt" db:"created_at"`
+}
+
+// NewProofDecision creates a new history entry for a proof
+func NewProofDecision(proof *Proof, actorID uuid.UUID, action ProofAction, reason string) *ProofDecision {
+	return &ProofDecision{
+		ID:        uuid.New(),
+		ProofID:   proof.ID,
+		BookingID: proof.BookingID,
+		ActorID:   actorID,
+		Action:    action,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestProofReviewedProofsStayClosed(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ProofStatus
+		want   bool
+	}{
+		{name: "submitted", status: ProofStatusSubmitted},
+		{name: "in review", status: ProofStatusInReview},
+		{name: "approved", status: ProofStatusApproved, want: true},
+		{name: "rejected", status: ProofStatusRejected, want: true},
+		{name: "withdrawn", status: ProofStatusWithdrawn, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := &Proof{Status: tt.status}
+			if got := proof.IsReviewed(); got != tt.want {
+				t.Fatalf("IsReviewed() = %t, want %t", got, tt.want)
+			}
+			if !tt.want {
+				return
+			}
+
+			reviewerID := uuid.New()
+			if err := proof.Claim(reviewerID); !errors.Is(err, ErrProofAlreadyReviewed) {
+				t.Errorf("Claim() error = %v, want %v", err, ErrProofAlreadyReviewed)
+			}
+			if err := proof.Approve(reviewerID); !errors.Is(err, ErrProofAlreadyReviewed) {
+				t.Errorf("Approve() error = %v, want %v", err, ErrProofAlreadyReviewed)
+			}
+			if proof.Status != tt.status {
+				t.Errorf("status = %s, want %s unchanged", proof.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestProofIsClaimedByOther(t *testing.T) {
+	reviewerID := uuid.New()
+	otherID := uuid.New()
+	now := time.Now()
+	fresh := now.Add(-time.Minute)
+	stale := now.Add(-time.Hour)
+	staleBefore := now.Add(-30 * time.Minute)
+
+	tests := []struct {
+		name      string
+		claimedBy *uuid.UUID
+		claimedAt *time.Time
+		want      bool
+	}{
+		{name: "unclaimed"},
+		{name: "claimed by the reviewer", claimedBy: &reviewerID, claimedAt: &fresh},
+		{name: "claimed by another reviewer", claimedBy: &otherID, claimedAt: &fresh, want: true},
+		{name: "stale claim by another reviewer", claimedBy: &otherID, claimedAt: &stale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := &Proof{Status: ProofStatusInReview, ClaimedBy: tt.claimedBy, ClaimedAt: tt.claimedAt}
+			if got := proof.IsClaimedByOther(reviewerID, staleBefore); got != tt.want {
+				t.Fatalf("IsClaimedByOther() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProofDecisions(t *testing.T) {
+	booking := NewBooking(uuid.New(), uuid.New(), uuid.New(), "")
+	reviewerID := uuid.New()
+
+	proof := NewProof(booking, "", "https://proofs/1.png", "111-2222222-3333333", nil)
+	if err := proof.Claim(reviewerID); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if proof.Status != ProofStatusInReview || proof.ClaimedBy == nil || *proof.ClaimedBy != reviewerID {
+		t.Fatalf("claimed proof: status = %s, claimed by %v", proof.Status, proof.ClaimedBy)
+	}
+
+	if err := proof.Reject(reviewerID, "order ID does not match"); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if proof.Status != ProofStatusRejected || proof.ReviewedBy == nil || *proof.ReviewedBy != reviewerID || proof.RejectionReason != "order ID does not match" {
+		t.Fatalf("rejected proof: status = %s, reviewed by %v, reason %q", proof.Status, proof.ReviewedBy, proof.RejectionReason)
+	}
+
+	if err := proof.Reject(reviewerID, "again"); !errors.Is(err, ErrProofAlreadyReviewed) {
+		t.Fatalf("rejecting twice: error = %v, want %v", err, ErrProofAlreadyReviewed)
+	}
+	if proof.RejectionReason != "order ID does not match" {
+		t.Fatalf("rejection reason = %q, want the first decision kept", proof.RejectionReason)
+	}
+}
@@ -1,27 +1,53 @@
 package entity
 
 import (
+	"errors"
 	"time"
 	
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrReferralNotPending is returned when a decided referral is approved again
+	ErrReferralNotPending = errors.New("referral is not pending")
+
+	// ErrReferralAlreadyRejected is returned when a rejected referral is rejected again
+	ErrReferralAlreadyRejected = errors.New("referral has already been rejected")
+)
+
+// ReferralSource represents what a referral was attributed to
+type ReferralSource string
+
+// Referral sources
+const (
+	ReferralSourceRegistration ReferralSource = "registration"
+	ReferralSourceBooking      ReferralSource = "booking"
+)
+
 // Referral represents a referral made by an influencer
 type Referral struct {
-	ID           uuid.UUID  `json:"id" db:"id"`
-	TenantID     uuid.UUID  `json:"tenant_id" db:"tenant_id"`
-	InfluencerID uuid.UUID  `json:"influencer_id" db:"influencer_id"`
-	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
-	ProductID    uuid.UUID  `json:"product_id" db:"product_id"`
-	Status       string     `json:"status" db:"status"`
-	Earnings     float64    `json:"earnings" db:"earnings"`
-	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID           uuid.UUID      `json:"id" db:"id"`
+	TenantID     uuid.UUID      `json:"tenant_id" db:"tenant_id"`
+	InfluencerID uuid.UUID      `json:"influencer_id" db:"influencer_id"`
+	UserID       uuid.UUID      `json:"user_id" db:"user_id"`
+	ProductID    *uuid.UUID     `json:"product_id,omitempty" db:"product_id"`
+	BookingID    *uuid.UUID     `json:"booking_id,omitempty" db:"booking_id"`
+	ClickID      *uuid.UUID     `json:"click_id,omitempty" db:"click_id"`
+	Source       ReferralSource `json:"source" db:"source"`
+	Status       string         `json:"status" db:"status"`
+	Earnings     float64        `json:"earnings" db:"earnings"`
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt    *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Commission applied when the referral was approved
+	CommissionRuleID   *uuid.UUID          `json:"commission_rule_id,omitempty" db:"commission_rule_id"`
+	CommissionSnapshot *CommissionSnapshot `json:"commission_snapshot,omitempty" db:"commission_snapshot"`
 }
 
-// NewReferral creates a new referral
-func NewReferral(tenantID, influencerID, userID, productID uuid.UUID, earnings float64) *Referral {
+// NewReferral creates a new referral.
+// productID is nil for registrations that did not land on a product.
+func NewReferral(tenantID, influencerID, userID uuid.UUID, productID *uuid.UUID, source ReferralSource, earnings float64) *Referral {
 	now := time.Now()
 	return &Referral{
 		ID:           uuid.New(),
@@ -29,6 +55,7 @@ func NewReferral(tenantID, influencerID, userID, productID uuid.UUID, earnings f
 		InfluencerID: influencerID,
 		UserID:       userID,
 		ProductID:    productID,
+		Source:       source,
 		Status:       "pending",
 		Earnings:     earnings,
 		CreatedAt:    now,
@@ -42,12 +69,31 @@ func (r *Referral) Approve() {
 	r.UpdatedAt = time.Now()
 }
 
+// ApplyCommission sets the earnings from a commission snapshot.
+// A nil snapshot means no rule applied and the referral earns nothing.
+func (r *Referral) ApplyCommission(snapshot *CommissionSnapshot) {
+	r.CommissionSnapshot = snapshot
+	r.CommissionRuleID = nil
+	r.Earnings = 0
+	if snapshot != nil {
+		r.CommissionRuleID = &snapshot.RuleID
+		r.Earnings = snapshot.Earnings
+	}
+	r.UpdatedAt = time.Now()
+}
+
 // Reject rejects a referral
 func (r *Referral) Reject() {
 	r.Status = "rejected"
 	r.UpdatedAt = time.Now()
 }
 
+// CanReject checks if the referral can still be rejected.
+// Approved referrals can be rejected afterwards, which reverses their earnings.
+func (r *Referral) CanReject() bool {
+	return r.IsPending() || r.IsApproved()
+}
+
 // IsPending checks if the referral is pending
 func (r *Referral) IsPending() bool {
 	return r.Status == "pending"
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReferralClick represents a visit through an influencer's referral link
+type ReferralClick struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	InfluencerID uuid.UUID  `json:"influencer_id" db:"influencer_id"`
+	ReferralCode string     `json:"referral_code" db:"referral_code"`
+	ProductID    *uuid.UUID `json:"product_id,omitempty" db:"product_id"`
+	IPHash       string     `json:"-" db:"ip_hash"`
+	UserAgent    string     `json:"user_agent" db:"user_agent"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NewReferralClick creates a new click for an influencer's referral link
+func NewReferralClick(influencer *User, productID *uuid.UUID, ipHash, userAgent string) *ReferralClick {
+	return &ReferralClick{
+		ID:           uuid.New(),
+		TenantID:     influencer.TenantID,
+		InfluencerID: influencer.ID,
+		ReferralCode: influencer.ReferralCode,
+		ProductID:    productID,
+		IPHash:       ipHash,
+		UserAgent:    userAgent,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// IsWithin checks if the click happened within the attribution window
+func (c *ReferralClick) IsWithin(window time.Duration) bool {
+	return time.Since(c.CreatedAt) <= window
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is an opaque token that renews a user's access token.
+// Tokens rotate on every use; the tokens descending from one login form a family, which is
+// revoked as a whole when an already rotated token is presented again.
+// Only the hash of the token is stored.
+type RefreshToken struct {
+	Hash      string    `json:"hash"`
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewRefreshToken creates the first refresh token of a new family for a user
+func NewRefreshToken(token string, userID uuid.UUID, expiresAt time.Time) *RefreshToken {
+	return &RefreshToken{
+		Hash:      HashRefreshToken(token),
+		FamilyID:  uuid.New(),
+		UserID:    userID,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// Rotate creates the successor of a refresh token in the same family
+func (t *RefreshToken) Rotate(token string, expiresAt time.Time) *RefreshToken {
+	return &RefreshToken{
+		Hash:      HashRefreshToken(token),
+		FamilyID:  t.FamilyID,
+		UserID:    t.UserID,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// HashRefreshToken hashes a raw refresh token for lookup
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType identifies a security-relevant account event
+type SecurityEventType string
+
+// Security event types
+const (
+	SecurityEventRefreshTokenReuse    SecurityEventType = "refresh_token_reuse"
+	SecurityEventAccountLocked        SecurityEventType = "account_locked"
+	SecurityEventAccountUnlocked      SecurityEventType = "account_unlocked"
+	SecurityEventIPLocked             SecurityEventType = "ip_locked"
+	SecurityEventImpersonationStarted SecurityEventType = "impersonation_started"
+	SecurityEventImpersonationEnded   SecurityEventType = "impersonation_ended"
+)
+
+// SecurityEventDetails holds the context of a security event
+type SecurityEventDetails map[string]string
+
+// Value implements driver.Valuer so the details are stored as JSONB
+func (d SecurityEventDetails) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
+
+// Scan implements sql.Scanner so the details are read from JSONB
+func (d *SecurityEventDetails) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*d = SecurityEventDetails{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for security event details: %T", src)
+	}
+	return json.Unmarshal(data, d)
+}
+
+// SecurityEvent records a security-relevant event on a user's account.
+// UserID is nil for events that are not tied to an account, such as a locked out IP address.
+type SecurityEvent struct {
+	ID        uuid.UUID            `json:"id" db:"id"`
+	UserID    *uuid.UUID           `json:"user_id,omitempty" db:"user_id"`
+	Type      SecurityEventType    `json:"type" db:"type"`
+	Details   SecurityEventDetails `json:"details" db:"details"`
+	CreatedAt time.Time            `json:"created_at" db:"created_at"`
+}
+
+// NewSecurityEvent creates a security event for a user
+func NewSecurityEvent(userID uuid.UUID, eventType SecurityEventType, details SecurityEventDetails) *SecurityEvent {
+	return &SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    &userID,
+		Type:      eventType,
+		Details:   details,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewAnonymousSecurityEvent creates a security event that is not tied to an account
+func NewAnonymousSecurityEvent(eventType SecurityEventType, details SecurityEventDetails) *SecurityEvent {
+	return &SecurityEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		Details:   details,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a signed-in device of a user.
+// Each session owns one refresh token family; signing out a session revokes the family.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// NewSession creates the session of the first refresh token of a family
+func NewSession(token *RefreshToken, userAgent, ipAddress string) *Session {
+	return &Session{
+		ID:         token.FamilyID,
+		UserID:     token.UserID,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+	}
+}
+
+// Touch records that the session was renewed with a rotated refresh token
+func (s *Session) Touch(token *RefreshToken, userAgent, ipAddress string) {
+	s.LastUsedAt = token.CreatedAt
+	s.ExpiresAt = token.ExpiresAt
+	if userAgent != "" {
+		s.UserAgent = userAgent
+	}
+	if ipAddress != "" {
+		s.IPAddress = ipAddress
+	}
+}
@@ -1,22 +1,39 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrInvalidTenantDomain is returned when a tenant's domain is not a valid host name
+var ErrInvalidTenantDomain = errors.New("invalid tenant domain")
+
+// domainLabelPattern is the format of each dot-separated part of a tenant's domain
+var domainLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// slugSeparatorPattern matches the runs of characters a domain slug replaces with a hyphen
+var slugSeparatorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// maxDomainSlugLength keeps generated subdomains short enough to add a suffix to
+const maxDomainSlugLength = 40
+
 // Tenant represents a tenant in the multi-tenant system
 type Tenant struct {
-	ID          uuid.UUID  `json:"id" db:"id"`
-	Name        string     `json:"name" db:"name"`
-	Domain      string     `json:"domain" db:"domain"`
-	PlanID      string     `json:"plan_id" db:"plan_id"`
-	IsActive    bool       `json:"is_active" db:"is_active"`
-	Settings    map[string]interface{} `json:"settings,omitempty" db:"settings"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID               uuid.UUID      `json:"id" db:"id"`
+	Name             string         `json:"name" db:"name"`
+	Domain           string         `json:"domain" db:"domain"`
+	DomainVerifiedAt *time.Time     `json:"domain_verified_at,omitempty" db:"domain_verified_at"`
+	PlanID           string         `json:"plan_id" db:"plan_id"`
+	IsActive         bool           `json:"is_active" db:"is_active"`
+	Settings         TenantSettings `json:"settings" db:"settings"`
+	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 // NewTenant creates a new tenant
@@ -28,18 +45,63 @@ func NewTenant(name, domain, planID string) *Tenant {
 		Domain:    domain,
 		PlanID:    planID,
 		IsActive:  true,
-		Settings:  make(map[string]interface{}),
+		Settings:  DefaultTenantSettings(),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
 
-// UpdateSettings updates tenant settings
-func (t *Tenant) UpdateSettings(settings map[string]interface{}) {
-	t.Settings = settings
+// Update replaces the name, domain and plan of the tenant.
+// A new domain has to be verified again unless it is a subdomain of the platform.
+func (t *Tenant) Update(name, domain, planID string) {
+	if domain != t.Domain {
+		t.DomainVerifiedAt = nil
+	}
+	t.Name = name
+	t.Domain = domain
+	t.PlanID = planID
+	t.UpdatedAt = time.Now()
+}
+
+// UpdateSettings validates a partial settings document and applies it to the tenant's settings
+func (t *Tenant) UpdateSettings(patch []byte) error {
+	if err := t.Settings.Apply(patch); err != nil {
+		return err
+	}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// RequiresMFA checks if the tenant's policy makes MFA mandatory for a role
+func (t *Tenant) RequiresMFA(role Role) bool {
+	if role != RoleSuperAdmin {
+		return false
+	}
+	return t.Settings.RequireSuperAdminMFA
+}
+
+// SetSuperAdminMFARequired sets whether the tenant's super admins must use MFA
+func (t *Tenant) SetSuperAdminMFARequired(required bool) {
+	t.Settings.RequireSuperAdminMFA = required
 	t.UpdatedAt = time.Now()
 }
 
+// VerifyDomain records that the tenant proved it owns its custom domain
+func (t *Tenant) VerifyDomain() {
+	now := time.Now()
+	t.DomainVerifiedAt = &now
+	t.UpdatedAt = now
+}
+
+// ServesDomain checks if requests to the tenant's domain may act in the tenant.
+// Subdomains of the platform's base domain always do; custom domains only once verified.
+func (t *Tenant) ServesDomain(baseDomain string) bool {
+	if baseDomain != "" && strings.HasSuffix(t.Domain, "."+baseDomain) {
+		return true
+	}
+	return t.DomainVerifiedAt != nil
+}
+
 // Deactivate deactivates the tenant
 func (t *Tenant) Deactivate() {
 	t.IsActive = false
@@ -50,4 +112,34 @@ func (t *Tenant) Deactivate() {
 func (t *Tenant) Activate() {
 	t.IsActive = true
 	t.UpdatedAt = time.Now()
-}
\ No newline at end of file
+}
+
+// NormalizeTenantDomain lowercases a domain and checks that it is a valid host name
+func NormalizeTenantDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+
+	labels := strings.Split(domain, ".")
+	if len(domain) > 253 || len(labels) < 2 {
+		return "", fmt.Errorf("%w: %q is not a host name", ErrInvalidTenantDomain, domain)
+	}
+	for _, label := range labels {
+		if !domainLabelPattern.MatchString(label) {
+			return "", fmt.Errorf("%w: %q is not a host name", ErrInvalidTenantDomain, domain)
+		}
+	}
+
+	return domain, nil
+}
+
+// TenantDomainSlug turns a name into the subdomain label of a tenant, like "john-doe" for "John Doe"
+func TenantDomainSlug(name string) string {
+	slug := slugSeparatorPattern.ReplaceAllString(strings.ToLower(name), "-")
+	if len(slug) > maxDomainSlugLength {
+		slug = slug[:maxDomainSlugLength]
+	}
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		return "store"
+	}
+	return slug
+}
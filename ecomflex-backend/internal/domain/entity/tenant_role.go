@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidTenantRole is returned when a custom role has a bad name or unknown permissions
+var ErrInvalidTenantRole = errors.New("invalid role")
+
+// tenantRoleNamePattern is the format of custom role names, which are stored on users like built-in roles
+var tenantRoleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// TenantRole is a role a tenant defines with its own set of permissions
+type TenantRole struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	TenantID    uuid.UUID    `json:"tenant_id" db:"tenant_id"`
+	Name        Role         `json:"name" db:"name"`
+	Description string       `json:"description" db:"description"`
+	Permissions []Permission `json:"permissions" db:"-"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// NewTenantRole creates a new custom role for a tenant
+func NewTenantRole(tenantID uuid.UUID, name Role, description string, permissions []Permission) (*TenantRole, error) {
+	if !tenantRoleNamePattern.MatchString(string(name)) {
+		return nil, fmt.Errorf("%w: names use lowercase letters, digits and underscores", ErrInvalidTenantRole)
+	}
+	if name.IsBuiltIn() {
+		return nil, fmt.Errorf("%w: %q is a built-in role", ErrInvalidTenantRole, name)
+	}
+
+	now := time.Now()
+	role := &TenantRole{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		CreatedAt: now,
+	}
+
+	if err := role.Update(description, permissions); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Update replaces the description and permissions of the role
+func (r *TenantRole) Update(description string, permissions []Permission) error {
+	set := NewPermissionSet()
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return fmt.Errorf("%w: unknown permission %q", ErrInvalidTenantRole, permission)
+		}
+		if platformPermissions[permission] {
+			return fmt.Errorf("%w: %q is reserved for super admins", ErrInvalidTenantRole, permission)
+		}
+		set[permission] = struct{}{}
+	}
+
+	r.Description = description
+	r.Permissions = set.List()
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// PermissionSet gets the permissions the role grants.
+// Platform permissions saved before they were reserved for super admins are left out.
+func (r *TenantRole) PermissionSet() PermissionSet {
+	set := NewPermissionSet(r.Permissions...)
+	for permission := range platformPermissions {
+		delete(set, permission)
+	}
+	return set
+}
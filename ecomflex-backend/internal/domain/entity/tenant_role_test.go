@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewTenantRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        Role
+		permissions []Permission
+		wantErr     bool
+	}{
+		{name: "custom role", role: "moderator", permissions: []Permission{PermissionProofsRead, PermissionProofsReview}},
+		{name: "duplicate permissions", role: "moderator", permissions: []Permission{PermissionProofsRead, PermissionProofsRead}},
+		{name: "unknown permission", role: "moderator", permissions: []Permission{"proofs:delete"}, wantErr: true},
+		{name: "tenant management", role: "tenant_owner", permissions: []Permission{PermissionSettingsManage, PermissionTenantsManage}, wantErr: true},
+		{name: "built-in name", role: RoleSupport, permissions: []Permission{PermissionProofsRead}, wantErr: true},
+		{name: "invalid name", role: "Moderators!", permissions: []Permission{PermissionProofsRead}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := NewTenantRole(uuid.New(), tt.role, "", tt.permissions)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTenantRole) {
+					t.Fatalf("NewTenantRole() error = %v, want %v", err, ErrInvalidTenantRole)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewTenantRole() error = %v", err)
+			}
+			if !role.PermissionSet().Has(tt.permissions...) {
+				t.Errorf("role permissions = %v, want %v", role.Permissions, tt.permissions)
+			}
+		})
+	}
+}
+
+func TestTenantRolePermissionSetLeavesOutPlatformPermissions(t *testing.T) {
+	// Roles saved before tenant management was reserved for super admins may still list it
+	role := &TenantRole{Permissions: []Permission{PermissionSettingsManage, PermissionTenantsManage}}
+
+	set := role.PermissionSet()
+	if set.Has(PermissionTenantsManage) {
+		t.Errorf("PermissionSet() = %v, want %s left out", set.List(), PermissionTenantsManage)
+	}
+	if !set.Has(PermissionSettingsManage) {
+		t.Errorf("PermissionSet() = %v, want %s", set.List(), PermissionSettingsManage)
+	}
+
+	for _, permission := range CustomRolePermissions() {
+		if permission == PermissionTenantsManage {
+			t.Errorf("CustomRolePermissions() includes %s", PermissionTenantsManage)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package entity
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/util"
+)
+
+// TenantSettingsVersion is the version of the settings format the server writes.
+// Version 0 is the untyped map tenants had before, which only ever held require_super_admin_mfa.
+const TenantSettingsVersion = 1
+
+// ErrInvalidTenantSettings is returned when a settings change does not match the settings schema
+var ErrInvalidTenantSettings = errors.New("invalid tenant settings")
+
+// ErrMarketplaceNotAllowed is returned when a product links to a marketplace its tenant does not allow
+var ErrMarketplaceNotAllowed = errors.New("product link is not on a marketplace the tenant allows")
+
+// TenantSettingsSchemaJSON is the JSON Schema settings changes are validated against
+//
+//go:embed tenant_settings.schema.json
+var TenantSettingsSchemaJSON []byte
+
+// tenantSettingsSchema is the parsed settings schema
+var tenantSettingsSchema = mustParseSchema(TenantSettingsSchemaJSON)
+
+// TenantSettingsError lists the problems of a settings change
+type TenantSettingsError struct {
+	Problems []string
+}
+
+// Error summarizes the problems
+func (e *TenantSettingsError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidTenantSettings, strings.Join(e.Problems, "; "))
+}
+
+// Unwrap makes the error match ErrInvalidTenantSettings
+func (e *TenantSettingsError) Unwrap() error {
+	return ErrInvalidTenantSettings
+}
+
+// DefaultCommission is the commission influencers earn on referrals no commission rule covers
+type DefaultCommission struct {
+	Type   CommissionAmountType `json:"type"`
+	Amount float64              `json:"amount"`
+}
+
+// TenantBranding is how a tenant's storefront and emails look
+type TenantBranding struct {
+	PrimaryColor   string `json:"primary_color"`
+	SecondaryColor string `json:"secondary_color"`
+	LogoKey        string `json:"logo_key"`
+}
+
+// TenantSettings are a tenant's settings. Settings the tenant never set read as their defaults.
+type TenantSettings struct {
+	Version               int               `json:"version"`
+	DefaultCommission     DefaultCommission `json:"default_commission"`
+	AttributionWindowDays int               `json:"attribution_window_days"`
+	CashbackPercentage    float64           `json:"cashback_percentage"`
+	Branding              TenantBranding    `json:"branding"`
+	SupportEmail          string            `json:"support_email"`
+	AllowedMarketplaces   []string          `json:"allowed_marketplaces"`
+	RequireSuperAdminMFA  bool              `json:"require_super_admin_mfa"`
+}
+
+// DefaultTenantSettings gets the settings of a tenant that changed none
+func DefaultTenantSettings() TenantSettings {
+	return TenantSettings{
+		Version: TenantSettingsVersion,
+		DefaultCommission: DefaultCommission{
+			Type:   CommissionAmountPercent,
+			Amount: 0,
+		},
+		AttributionWindowDays: 30,
+		// Cashback is opt-in; tenants that never set a percentage refund nothing
+		CashbackPercentage: 0,
+		Branding: TenantBranding{
+			PrimaryColor:   "#4f46e5",
+			SecondaryColor: "#0f172a",
+		},
+		AllowedMarketplaces: []string{"amazon.com"},
+	}
+}
+
+// UnmarshalJSON reads stored settings over the defaults, upgrading older versions
+func (s *TenantSettings) UnmarshalJSON(data []byte) error {
+	// The alias has no UnmarshalJSON of its own, so decoding it does not recurse
+	type storedSettings TenantSettings
+	settings := storedSettings(DefaultTenantSettings())
+	settings.Version = 0
+
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+
+	// Version 0 settings used the same key for the only setting they had
+	if settings.Version < TenantSettingsVersion {
+		settings.Version = TenantSettingsVersion
+	}
+
+	*s = TenantSettings(settings)
+	return nil
+}
+
+// Apply validates a partial settings document against the settings schema and applies it.
+// Objects are merged with the current settings; arrays and other values replace them.
+func (s *TenantSettings) Apply(patch []byte) error {
+	problems, err := tenantSettingsSchema.ValidateWrite(patch)
+	if err != nil {
+		return &TenantSettingsError{Problems: []string{err.Error()}}
+	}
+	if len(problems) > 0 {
+		return &TenantSettingsError{Problems: problems}
+	}
+
+	// Decoding into the alias merges with the current settings rather than the defaults
+	type storedSettings TenantSettings
+	settings := *s
+	settings.AllowedMarketplaces = append([]string(nil), s.AllowedMarketplaces...) // decoding reuses the array
+	if err := json.Unmarshal(patch, (*storedSettings)(&settings)); err != nil {
+		return &TenantSettingsError{Problems: []string{err.Error()}}
+	}
+
+	// The schema checks values one at a time; this depends on the commission type as well
+	if settings.DefaultCommission.Type == CommissionAmountPercent && settings.DefaultCommission.Amount > 100 {
+		return &TenantSettingsError{Problems: []string{"$.default_commission.amount: percentage must not exceed 100"}}
+	}
+
+	settings.Version = TenantSettingsVersion
+	*s = settings
+	return nil
+}
+
+// AttributionWindow gets how long after a referral click signups and bookings are still credited to it
+func (s *TenantSettings) AttributionWindow() time.Duration {
+	return time.Duration(s.AttributionWindowDays) * 24 * time.Hour
+}
+
+// Cashback gets the cashback refunded on an approved booking of a product with the given price, rounded to cents
+func (s *TenantSettings) Cashback(price float64) float64 {
+	return math.Round(price*s.CashbackPercentage) / 100
+}
+
+// DefaultCommissionRule gets the commission rule applied to a tenant's referrals that no rule covers,
+// or nil when the tenant pays no default commission. The rule is not stored.
+func (s *TenantSettings) DefaultCommissionRule(tenantID uuid.UUID, source ReferralSource) *CommissionRule {
+	if s.DefaultCommission.Amount <= 0 {
+		return nil
+	}
+
+	rule := NewCommissionRule("Tenant default commission", source, s.DefaultCommission.Type, s.DefaultCommission.Amount)
+	rule.ID = uuid.Nil
+	rule.TenantID = &tenantID
+	return rule
+}
+
+// AllowsProductLink checks if a product link points to one of the tenant's allowed marketplaces,
+// either the marketplace's own host or one of its subdomains
+func (s *TenantSettings) AllowsProductLink(link string) bool {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, marketplace := range s.AllowedMarketplaces {
+		if host == marketplace || strings.HasSuffix(host, "."+marketplace) {
+			return true
+		}
+	}
+	return false
+}
+
+// mustParseSchema parses a schema embedded in the binary
+func mustParseSchema(data []byte) *util.JSONSchema {
+	schema, err := util.ParseJSONSchema(data)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTenantSettingsCashback(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored string
+		price  float64
+		want   float64
+	}{
+		{name: "never set", stored: `{}`, price: 19.99, want: 0},
+		{name: "set before cashback existed", stored: `{"version": 1, "attribution_window_days": 14}`, price: 19.99, want: 0},
+		{name: "full refund", stored: `{"cashback_percentage": 100}`, price: 19.99, want: 19.99},
+		{name: "partial refund", stored: `{"cashback_percentage": 50}`, price: 25, want: 12.5},
+		{name: "fractional percentage", stored: `{"cashback_percentage": 12.5}`, price: 40, want: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var settings TenantSettings
+			if err := json.Unmarshal([]byte(tt.stored), &settings); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := settings.Cashback(tt.price); got != tt.want {
+				t.Errorf("Cashback(%v) = %v, want %v", tt.price, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,27 +12,29 @@ type Role string
 // User roles
 const (
 	RoleSuperAdmin Role = "super_admin"
+	RoleSupport    Role = "support"
 	RoleInfluencer Role = "influencer"
 	RolePublic     Role = "public"
 )
 
 // User represents a user entity
 type User struct {
-	ID             uuid.UUID  `json:"id" db:"id"`
-	TenantID       uuid.UUID  `json:"tenant_id" db:"tenant_id"`
-	Email          string     `json:"email" db:"email"`
-	PasswordHash   string     `json:"-" db:"password_hash"`
-	FullName       string     `json:"full_name" db:"full_name"`
-	Role           Role       `json:"role" db:"role"`
-	Phone          string     `json:"phone,omitempty" db:"phone"`
-	ReferralCode   string     `json:"referral_code,omitempty" db:"referral_code"`
-	Status         string     `json:"status" db:"status"`
-	ProfilePicture string     `json:"profile_picture,omitempty" db:"profile_picture"`
-	SocialLinks    []string   `json:"social_links,omitempty" db:"social_links"`
-	FollowerCount  int        `json:"follower_count,omitempty" db:"follower_count"`
-	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt      *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID              uuid.UUID  `json:"id" db:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	Email           string     `json:"email" db:"email"`
+	PasswordHash    string     `json:"-" db:"password_hash"`
+	FullName        string     `json:"full_name" db:"full_name"`
+	Role            Role       `json:"role" db:"role"`
+	Phone           string     `json:"phone,omitempty" db:"phone"`
+	ReferralCode    string     `json:"referral_code,omitempty" db:"referral_code"`
+	Status          string     `json:"status" db:"status"`
+	ProfilePicture  string     `json:"profile_picture,omitempty" db:"profile_picture"`
+	SocialLinks     []string   `json:"social_links,omitempty" db:"social_links"`
+	FollowerCount   int        `json:"follower_count,omitempty" db:"follower_count"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 // NewUser creates a new user instance
@@ -83,11 +85,31 @@ func (u *User) IsActive() bool {
 	return u.Status == "active" && u.DeletedAt == nil
 }
 
+// CanActInTenant checks if the user may act on a tenant's data; only super admins reach beyond their own tenant
+func (u *User) CanActInTenant(tenantID uuid.UUID) bool {
+	return u.Role == RoleSuperAdmin || u.TenantID == tenantID
+}
+
 // IsPendingApproval checks if user is pending approval
 func (u *User) IsPendingApproval() bool {
 	return u.Status == "pending_approval"
 }
 
+// IsEmailVerified checks if the user has verified their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// MarkEmailVerified records that the user verified their email address
+func (u *User) MarkEmailVerified() {
+	if u.EmailVerifiedAt != nil {
+		return
+	}
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
 // SetActive sets user status to active
 func (u *User) SetActive() {
 	u.Status = "active"
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links a user to their account at an external identity provider
+type UserIdentity struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Provider  string    `json:"provider" db:"provider"`
+	Subject   string    `json:"subject" db:"subject"`
+	Email     string    `json:"email" db:"email"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NewUserIdentity creates a new user identity
+func NewUserIdentity(userID uuid.UUID, provider, subject, email string) *UserIdentity {
+	return &UserIdentity{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IdentityLink is a pending request to link an external identity to an existing
+// account with the same email. It is completed once the account owner confirms it.
+type IdentityLink struct {
+	Token     string    `json:"token"`
+	UserID    uuid.UUID `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewIdentityLink creates a pending identity link with a random token
+func NewIdentityLink(userID uuid.UUID, provider, subject, email string, ttl time.Duration) (*IdentityLink, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate link token: %w", err)
+	}
+
+	return &IdentityLink{
+		Token:     hex.EncodeToString(token),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// Identity returns the user identity the link creates once confirmed
+func (l *IdentityLink) Identity() *UserIdentity {
+	return NewUserIdentity(l.UserID, l.Provider, l.Subject, l.Email)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// AuditLogFilter narrows down a tenant's audit log
+type AuditLogFilter struct {
+	TenantID   uuid.UUID
+	UserID     string
+	Action     string
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditLogRepository defines operations for the audit log
+type AuditLogRepository interface {
+	// CreateAuditLogs stores audit log entries in one statement
+	CreateAuditLogs(ctx context.Context, logs []*entity.AuditLog) error
+
+	// ListAuditLogs retrieves a tenant's audit log entries matching a filter, newest first, with the total count
+	ListAuditLogs(ctx context.Context, filter AuditLogFilter) ([]*entity.AuditLog, int, error)
+}
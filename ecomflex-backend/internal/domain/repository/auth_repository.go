@@ -2,28 +2,107 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrRefreshTokenNotFound is returned when a refresh token is unknown, expired or revoked
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+	// ErrRefreshTokenReused is returned when a refresh token that was already rotated is presented again
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
+
+	// ErrSessionNotFound is returned when a session is unknown, expired or signed out
+	ErrSessionNotFound = errors.New("session not found")
+
+	// ErrIdentityLinkNotFound is returned when an identity link is unknown, used or expired
+	ErrIdentityLinkNotFound = errors.New("identity link not found")
+
+	// ErrMFAChallengeNotFound is returned when an MFA challenge is unknown, used or expired
+	ErrMFAChallengeNotFound = errors.New("mfa challenge not found")
+
+	// ErrAccountTokenNotFound is returned when an account token is unknown, used or expired
+	ErrAccountTokenNotFound = errors.New("invalid or expired token")
 )
 
 // AuthRepository defines the interface for authentication repository operations
 type AuthRepository interface {
-	// SaveRefreshToken stores a refresh token in Redis
-	SaveRefreshToken(ctx context.Context, userID uuid.UUID, tokenID string, token string, expiresAt time.Time) error
+	// CreateSession stores a new session together with the first refresh token of its family
+	CreateSession(ctx context.Context, session *entity.Session, token *entity.RefreshToken) error
+	
+	// GetRefreshToken retrieves a refresh token by its hash.
+	// Rotated tokens are kept until they expire so that their reuse can be detected.
+	GetRefreshToken(ctx context.Context, hash string) (*entity.RefreshToken, error)
+	
+	// RotateRefreshToken makes next the current token of the session in place of current
+	// and saves the session's renewed details.
+	// It returns ErrRefreshTokenReused if current is no longer the session's latest token
+	// and ErrRefreshTokenNotFound if the session has been revoked or has expired.
+	RotateRefreshToken(ctx context.Context, session *entity.Session, current, next *entity.RefreshToken) error
 	
-	// GetRefreshToken retrieves a refresh token from Redis
-	GetRefreshToken(ctx context.Context, tokenID string) (string, error)
+	// GetSession retrieves a session by ID
+	GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error)
 	
-	// DeleteRefreshToken deletes a refresh token from Redis
-	DeleteRefreshToken(ctx context.Context, tokenID string) error
+	// ListSessions lists the active sessions of a user, most recently used first
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error)
 	
-	// DeleteAllUserRefreshTokens deletes all refresh tokens for a user
-	DeleteAllUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	// DeleteSession revokes a session of a user and its refresh tokens
+	DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	
+	// DeleteAllSessions revokes every session of a user and their refresh tokens
+	DeleteAllSessions(ctx context.Context, userID uuid.UUID) error
 	
 	// IsTokenRevoked checks if a token has been revoked
 	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
 	
 	// RevokeToken adds a token to the revocation list
 	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
-}
\ No newline at end of file
+	
+	// SaveIdentityLink stores a pending identity link until it expires
+	SaveIdentityLink(ctx context.Context, link *entity.IdentityLink) error
+	
+	// ConsumeIdentityLink retrieves and deletes a pending identity link so it is used once
+	ConsumeIdentityLink(ctx context.Context, token string) (*entity.IdentityLink, error)
+	
+	// SaveAccountToken stores a single-use account token until it expires, replacing any
+	// earlier token of the user for the same purpose
+	SaveAccountToken(ctx context.Context, token *entity.AccountToken) error
+	
+	// ConsumeAccountToken retrieves and deletes an account token by its hash so it is used once
+	ConsumeAccountToken(ctx context.Context, purpose entity.AccountTokenPurpose, hash string) (*entity.AccountToken, error)
+	
+	// SaveMFAChallenge stores a pending second factor login until it expires
+	SaveMFAChallenge(ctx context.Context, challenge *entity.MFAChallenge) error
+	
+	// GetMFAChallenge retrieves a pending second factor login by its hash
+	GetMFAChallenge(ctx context.Context, hash string) (*entity.MFAChallenge, error)
+	
+	// RecordMFAChallengeFailure counts a wrong code for a challenge and returns the failures so far
+	RecordMFAChallengeFailure(ctx context.Context, challenge *entity.MFAChallenge) (int64, error)
+	
+	// DeleteMFAChallenge deletes a pending second factor login
+	DeleteMFAChallenge(ctx context.Context, hash string) error
+	
+	// GetLoginFailures gets the failed logins counted under a key since a time
+	GetLoginFailures(ctx context.Context, key entity.LoginThrottleKey, since time.Time) (*entity.LoginFailures, error)
+	
+	// RecordLoginFailure counts a failed login under a key, forgets failures older than
+	// the window, and returns the failures within it
+	RecordLoginFailure(ctx context.Context, key entity.LoginThrottleKey, at time.Time, window time.Duration) (*entity.LoginFailures, error)
+	
+	// ClearLoginFailures forgets the failed logins counted under a key
+	ClearLoginFailures(ctx context.Context, key entity.LoginThrottleKey) error
+	
+	// LockLogin blocks logins counted under a key until a time and starts its failure count over
+	LockLogin(ctx context.Context, key entity.LoginThrottleKey, until time.Time) error
+	
+	// GetLoginLock gets when the lock on a key lifts, or the zero time if it is not locked
+	GetLoginLock(ctx context.Context, key entity.LoginThrottleKey) (time.Time, error)
+	
+	// UnlockLogin lifts the lock on a key and forgets its failed logins
+	UnlockLogin(ctx context.Context, key entity.LoginThrottleKey) error
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrBookingNotFound is returned when a booking does not exist
+var ErrBookingNotFound = errors.New("booking not found")
+
+// ErrNoSlotsAvailable is returned when a product has no booking slots left
+var ErrNoSlotsAvailable = errors.New("no booking slots available for this product")
+
+// BookingFilter represents filters for listing bookings
+type BookingFilter struct {
+	TenantID  string
+	Status    string
+	UserID    string
+	ProductID string
+	Limit     int
+	Offset    int
+}
+
+// BookingRepository defines operations for managing bookings
+type BookingRepository interface {
+	// CreateBooking creates a new booking and atomically claims one of the product's slots
+	CreateBooking(ctx context.Context, booking *entity.Booking) error
+
+	// GetBookingByID retrieves a booking by ID
+	GetBookingByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error)
+
+	// UpdateBooking persists a booking that left fromStatus
+	UpdateBooking(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus) error
+
+	// CreditCashback marks the cashback of an approved booking as paid and posts its
+	// wallet entries in one transaction
+	CreditCashback(ctx context.Context, booking *entity.Booking, entries []*entity.LedgerEntry) error
+
+	// GetPendingCashback sums the cashback of a user's bookings that is not yet released
+	GetPendingCashback(ctx context.Context, userID uuid.UUID) (float64, error)
+
+	// ReleaseBooking persists a booking that left fromStatus, withdraws its undecided proof
+	// and returns its slot to the product
+	ReleaseBooking(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus) error
+
+	// ExpireStaleBookings expires initiated bookings created before a cutoff and releases their slots
+	ExpireStaleBookings(ctx context.Context, before time.Time) (int, error)
+
+	// GetBookingsByUser retrieves all bookings of a user
+	GetBookingsByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Booking, error)
+
+	// ListBookings retrieves bookings matching a filter with the total count
+	ListBookings(ctx context.Context, filter BookingFilter) ([]*entity.Booking, int, error)
+
+	// HasOpenBooking checks if a user already holds a booking slot for a product
+	HasOpenBooking(ctx context.Context, userID, productID uuid.UUID) (bool, error)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrCommissionRuleNotFound is returned when a commission rule does not exist
+var ErrCommissionRuleNotFound = errors.New("commission rule not found")
+
+// CommissionRuleRepository defines operations for managing commission rules
+type CommissionRuleRepository interface {
+	// CreateRule creates a new commission rule
+	CreateRule(ctx context.Context, rule *entity.CommissionRule) error
+
+	// GetRuleByID retrieves a commission rule by ID
+	GetRuleByID(ctx context.Context, id uuid.UUID) (*entity.CommissionRule, error)
+
+	// UpdateRule updates a commission rule
+	UpdateRule(ctx context.Context, rule *entity.CommissionRule) error
+
+	// DeleteRule deletes a commission rule; referrals keep their snapshot of it
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// ListRules retrieves all commission rules
+	ListRules(ctx context.Context) ([]*entity.CommissionRule, error)
+
+	// FindCandidateRules retrieves active rules scoped to a tenant, a product or nothing that are valid at a time
+	FindCandidateRules(ctx context.Context, tenantID uuid.UUID, productID *uuid.UUID, at time.Time) ([]*entity.CommissionRule, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrEmailTemplateNotFound is returned when a tenant has not overridden an email template
+var ErrEmailTemplateNotFound = errors.New("email template not found")
+
+// EmailTemplateRepository defines operations for tenants' email template overrides
+type EmailTemplateRepository interface {
+	// GetEmailTemplate retrieves a tenant's override of an email template
+	GetEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName) (*entity.EmailTemplate, error)
+
+	// ListEmailTemplates retrieves all email template overrides of a tenant
+	ListEmailTemplates(ctx context.Context, tenantID uuid.UUID) ([]*entity.EmailTemplate, error)
+
+	// SaveEmailTemplate creates or replaces a tenant's override of an email template
+	SaveEmailTemplate(ctx context.Context, template *entity.EmailTemplate) error
+
+	// DeleteEmailTemplate removes a tenant's override so the built-in template is used again
+	DeleteEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrIdentityNotFound is returned when no user is linked to an external identity
+	ErrIdentityNotFound = errors.New("identity not found")
+
+	// ErrIdentityAlreadyLinked is returned when an external identity belongs to another user
+	ErrIdentityAlreadyLinked = errors.New("identity is already linked to an account")
+)
+
+// IdentityRepository defines operations for identities at external identity providers
+type IdentityRepository interface {
+	// GetIdentity retrieves the identity with a subject at a provider
+	GetIdentity(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+
+	// CreateIdentity links an identity to an existing user
+	CreateIdentity(ctx context.Context, identity *entity.UserIdentity) error
+
+	// CreateUserWithIdentity creates a user and links an identity to them in one transaction
+	CreateUserWithIdentity(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error
+}
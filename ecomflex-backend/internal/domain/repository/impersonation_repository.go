@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrImpersonationNotFound is returned when an impersonation does not exist
+var ErrImpersonationNotFound = errors.New("impersonation not found")
+
+// ImpersonationRepository defines operations for the log of admins acting as other users
+type ImpersonationRepository interface {
+	// CreateImpersonation records the start of an impersonation
+	CreateImpersonation(ctx context.Context, impersonation *entity.Impersonation) error
+
+	// GetImpersonation retrieves an impersonation by ID
+	GetImpersonation(ctx context.Context, id uuid.UUID) (*entity.Impersonation, error)
+
+	// EndImpersonation records when an impersonation was ended; ending it again keeps the first time
+	EndImpersonation(ctx context.Context, id uuid.UUID, endedAt time.Time) error
+
+	// ListImpersonations retrieves the impersonations of a user or by a user, newest first
+	ListImpersonations(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Impersonation, int, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// AccountBalance represents the balance of an account owned by a user
+type AccountBalance struct {
+	TenantID uuid.UUID            `json:"tenant_id" db:"tenant_id"`
+	OwnerID  uuid.UUID            `json:"owner_id" db:"owner_id"`
+	Account  entity.LedgerAccount `json:"account" db:"account"`
+	Balance  float64              `json:"balance" db:"balance"`
+}
+
+// BalanceSummary breaks down the balance of an account owned by a user
+type BalanceSummary struct {
+	Available   float64 `json:"available" db:"available"`
+	TotalEarned float64 `json:"total_earned" db:"total_earned"`
+	Reversed    float64 `json:"reversed" db:"reversed"`
+	PaidOut     float64 `json:"paid_out" db:"paid_out"`
+}
+
+// LedgerRepository defines read operations on the append-only earnings ledger.
+// Entries are written by the repositories recording the events behind them.
+type LedgerRepository interface {
+	// GetBalanceSummary retrieves the balance breakdown of a user's account
+	GetBalanceSummary(ctx context.Context, account entity.LedgerAccount, ownerID uuid.UUID) (BalanceSummary, error)
+
+	// ListBalancesAbove retrieves the balances of an account type at or above threshold
+	ListBalancesAbove(ctx context.Context, account entity.LedgerAccount, threshold float64) ([]AccountBalance, error)
+
+	// ListEntries retrieves the entries of a user's account, newest first, with the total count
+	ListEntries(ctx context.Context, account entity.LedgerAccount, ownerID uuid.UUID, limit, offset int) ([]*entity.LedgerEntry, int, error)
+}
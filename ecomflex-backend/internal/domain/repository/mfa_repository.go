@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrMFAFactorNotFound is returned when a user has no TOTP authenticator
+	ErrMFAFactorNotFound = errors.New("mfa factor not found")
+
+	// ErrMFACodeReused is returned when a TOTP code of an already used time step is presented
+	ErrMFACodeReused = errors.New("mfa code has already been used")
+
+	// ErrRecoveryCodeNotFound is returned when a recovery code is unknown or already used
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+)
+
+// MFARepository defines operations for users' second authentication factors
+type MFARepository interface {
+	// GetFactor retrieves the TOTP authenticator of a user
+	GetFactor(ctx context.Context, userID uuid.UUID) (*entity.MFAFactor, error)
+
+	// SaveFactor creates or replaces the TOTP authenticator of a user
+	SaveFactor(ctx context.Context, factor *entity.MFAFactor) error
+
+	// EnableFactor confirms a user's authenticator and replaces their recovery codes in one transaction
+	EnableFactor(ctx context.Context, factor *entity.MFAFactor, codes []*entity.MFARecoveryCode) error
+
+	// MarkStepUsed records the time step of an accepted TOTP code.
+	// It returns ErrMFACodeReused if that step or a later one was already used.
+	MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) error
+
+	// DeleteFactor removes the authenticator and recovery codes of a user
+	DeleteFactor(ctx context.Context, userID uuid.UUID) error
+
+	// ReplaceRecoveryCodes replaces all recovery codes of a user
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []*entity.MFARecoveryCode) error
+
+	// UseRecoveryCode marks an unused recovery code of a user as used
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+
+	// CountUnusedRecoveryCodes counts the recovery codes a user has left
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrPayoutNotFound is returned when a payout does not exist
+	ErrPayoutNotFound = errors.New("payout not found")
+
+	// ErrPayoutBeneficiaryNotFound is returned when a user has no beneficiary at a provider
+	ErrPayoutBeneficiaryNotFound = errors.New("payout beneficiary not found")
+
+	// ErrPayoutMethodNotFound is returned when a user has not chosen a payout method
+	ErrPayoutMethodNotFound = errors.New("payout method not found")
+)
+
+// PayoutFilter represents filters for listing payouts
+type PayoutFilter struct {
+	TenantID string
+	UserID   string
+	RunID    string
+	Status   string
+	Account  string
+	Provider string
+	Limit    int
+	Offset   int
+}
+
+// PayoutRepository defines operations for managing payouts and payout runs
+type PayoutRepository interface {
+	// StartRun stores a new payout run, failing with ErrPayoutRunInProgress if one is running
+	StartRun(ctx context.Context, run *entity.PayoutRun) error
+
+	// CompleteRun stores the outcome of a payout run
+	CompleteRun(ctx context.Context, run *entity.PayoutRun) error
+
+	// ListRuns retrieves payout runs, newest first, with the total count
+	ListRuns(ctx context.Context, limit, offset int) ([]*entity.PayoutRun, int, error)
+
+	// CreatePayout stores a payout and posts its ledger entries in one transaction.
+	// It fails with ErrInsufficientBalance if the account no longer covers the payout.
+	CreatePayout(ctx context.Context, payout *entity.Payout, entries []*entity.LedgerEntry) error
+
+	// GetPayoutByID retrieves a payout by ID
+	GetPayoutByID(ctx context.Context, id uuid.UUID) (*entity.Payout, error)
+
+	// GetPayoutByReference retrieves a payout by its transfer reference at a provider
+	GetPayoutByReference(ctx context.Context, provider, reference string) (*entity.Payout, error)
+
+	// ListPayouts retrieves payouts matching a filter, newest first, with the total count
+	ListPayouts(ctx context.Context, filter PayoutFilter) ([]*entity.Payout, int, error)
+
+	// UpdatePayoutStatus persists a payout that is still in previousStatus, posting its ledger
+	// entries in the same transaction. A non-nil event is recorded alongside; the update fails
+	// with ErrDuplicatePayoutEvent if the event was already processed.
+	UpdatePayoutStatus(ctx context.Context, payout *entity.Payout, previousStatus entity.PayoutStatus, entries []*entity.LedgerEntry, event *entity.PayoutEvent) error
+
+	// GetBeneficiary retrieves the beneficiary of a user at a provider
+	GetBeneficiary(ctx context.Context, userID uuid.UUID, provider string) (*entity.PayoutBeneficiary, error)
+
+	// SaveBeneficiary stores the beneficiary of a user at a provider
+	SaveBeneficiary(ctx context.Context, beneficiary *entity.PayoutBeneficiary) error
+
+	// GetPayoutMethod retrieves the payout method chosen by a user
+	GetPayoutMethod(ctx context.Context, userID uuid.UUID) (*entity.PayoutMethod, error)
+
+	// SavePayoutMethod stores the payout method of a user and forgets their provider
+	// beneficiaries so the next payout registers the new method
+	SavePayoutMethod(ctx context.Context, method *entity.PayoutMethod) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrPlanNotFound is returned when there is no plan with an ID
+var ErrPlanNotFound = errors.New("plan not found")
+
+// PlanRepository defines operations for the plans catalogue and what tenants use of it
+type PlanRepository interface {
+	// GetPlan retrieves a plan by ID
+	GetPlan(ctx context.Context, id string) (*entity.Plan, error)
+
+	// ListPlans retrieves every plan, cheapest first
+	ListPlans(ctx context.Context) ([]*entity.Plan, error)
+
+	// GetTenantUsage measures what a tenant uses; monthly bookings count from periodStart
+	GetTenantUsage(ctx context.Context, tenantID uuid.UUID, periodStart time.Time) (*entity.TenantUsage, error)
+
+	// CreateStoredFile records an uploaded file against its tenant's storage
+	CreateStoredFile(ctx context.Context, file *entity.StoredFile) error
+}
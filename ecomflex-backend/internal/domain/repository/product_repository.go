@@ -3,10 +3,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 )
 
+// ErrProductInUse is returned when deleting a product that bookings still refer to
+var ErrProductInUse = errors.New("product has bookings and cannot be deleted; deactivate it instead")
+
 type ProductRepository interface {
 	Create(ctx context.Context, product *entity.Product) error
 	FindByID(ctx context.Context, id string) (*entity.Product, error)
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrProofNotFound is returned when a proof does not exist
+var ErrProofNotFound = errors.New("proof not found")
+
+// ProofFilter represents filters for the proof moderation queue
+type ProofFilter struct {
+	TenantID        string
+	ProductID       string
+	Status          string
+	SubmittedBefore *time.Time
+	SubmittedAfter  *time.Time
+	Limit           int
+	Offset          int
+}
+
+// ProofRepository defines operations for managing proofs of purchase
+type ProofRepository interface {
+	// SubmitProof stores a proof and moves its booking to pending in one transaction
+	SubmitProof(ctx context.Context, booking *entity.Booking, proof *entity.Proof) error
+
+	// GetProofByID retrieves a proof by ID
+	GetProofByID(ctx context.Context, id uuid.UUID) (*entity.Proof, error)
+
+	// GetLatestProofByBooking retrieves the most recent proof of a booking
+	GetLatestProofByBooking(ctx context.Context, bookingID uuid.UUID) (*entity.Proof, error)
+
+	// ListProofs retrieves proofs matching a filter with the total count, oldest first
+	ListProofs(ctx context.Context, filter ProofFilter) ([]*entity.Proof, int, error)
+
+	// ClaimProof assigns a proof to a reviewer unless someone else claimed it after staleBefore
+	ClaimProof(ctx context.Context, proof *entity.Proof, staleBefore time.Time) error
+
+	// RecordBookingDecision persists a booking that left fromStatus together with the reviewed proof
+	// and its history entry in one transaction, releasing the slot of a rejected booking.
+	// proof and decision are nil for bookings without a proof to decide on.
+	RecordBookingDecision(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus, proof *entity.Proof, decision *entity.ProofDecision) error
+
+	// GetProofDecisions retrieves the moderation history of a proof
+	GetProofDecisions(ctx context.Context, proofID uuid.UUID) ([]*entity.ProofDecision, error)
+}
@@ -2,6 +2,19 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrReferralNotFound is returned when a referral does not exist
+	ErrReferralNotFound = errors.New("referral not found")
+
+	// ErrReferralClickNotFound is returned when a referral click does not exist
+	ErrReferralClickNotFound = errors.New("referral click not found")
 )
 
 // ReferralStats represents statistics for referrals
@@ -22,6 +35,16 @@ type ReferralRecord struct {
 	Earnings float64 `json:"earnings" db:"earnings"`
 }
 
+// ReferralFilter represents filters for listing referrals
+type ReferralFilter struct {
+	TenantID     string
+	Status       string
+	Source       string
+	InfluencerID string
+	Limit        int
+	Offset       int
+}
+
 // ReferralRepository defines operations for managing referrals
 type ReferralRepository interface {
 	// GetReferralStats retrieves referral statistics for a user
@@ -29,4 +52,32 @@ type ReferralRepository interface {
 	
 	// GetRecentReferrals retrieves recent referrals for a user
 	GetRecentReferrals(ctx context.Context, userID string, tenantID string, limit int) ([]ReferralRecord, error)
+
+	// CreateReferral creates a referral, returning false if the user or booking was already attributed
+	CreateReferral(ctx context.Context, referral *entity.Referral) (bool, error)
+
+	// GetRegistrationReferral retrieves the signup referral of a user whose click happened after since
+	GetRegistrationReferral(ctx context.Context, userID uuid.UUID, since time.Time) (*entity.Referral, error)
+
+	// RecordClick stores a click on a referral link
+	RecordClick(ctx context.Context, click *entity.ReferralClick) error
+
+	// GetClickByID retrieves a referral click by ID
+	GetClickByID(ctx context.Context, id uuid.UUID) (*entity.ReferralClick, error)
+
+	// GetReferralByID retrieves a referral by ID
+	GetReferralByID(ctx context.Context, id uuid.UUID) (*entity.Referral, error)
+
+	// GetReferralByBooking retrieves the referral attributed to a booking
+	GetReferralByBooking(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error)
+
+	// ListReferrals retrieves referrals matching a filter with the total count
+	ListReferrals(ctx context.Context, filter ReferralFilter) ([]*entity.Referral, int, error)
+
+	// CountApprovedReferrals counts the approved referrals of an influencer
+	CountApprovedReferrals(ctx context.Context, influencerID uuid.UUID) (int, error)
+
+	// UpdateReferralDecision persists the approval or rejection of a referral that is still in
+	// previousStatus, posting its ledger entries in the same transaction
+	UpdateReferralDecision(ctx context.Context, referral *entity.Referral, previousStatus string, entries []*entity.LedgerEntry) error
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// SecurityEventRepository defines operations for security events
+type SecurityEventRepository interface {
+	// CreateSecurityEvent records a security event
+	CreateSecurityEvent(ctx context.Context, event *entity.SecurityEvent) error
+}
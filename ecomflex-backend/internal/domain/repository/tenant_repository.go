@@ -2,10 +2,27 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 )
 
+var (
+	// ErrTenantNotFound is returned when a tenant does not exist
+	ErrTenantNotFound = errors.New("tenant not found")
+
+	// ErrTenantDomainTaken is returned when another tenant already uses a domain
+	ErrTenantDomainTaken = errors.New("domain is already used by another tenant")
+)
+
+// TenantFilter narrows down the tenants listed for admins
+type TenantFilter struct {
+	Search string // matches the name or domain
+	Status string // active or suspended
+	Limit  int
+	Offset int
+}
+
 // TenantRepository defines operations for managing tenants
 type TenantRepository interface {
 	// CreateTenant creates a new tenant
@@ -17,9 +34,12 @@ type TenantRepository interface {
 	// GetTenantByDomain retrieves a tenant by domain
 	GetTenantByDomain(ctx context.Context, domain string) (*entity.Tenant, error)
 
+	// ListTenants retrieves the tenants matching a filter, newest first, with the total count
+	ListTenants(ctx context.Context, filter TenantFilter) ([]*entity.Tenant, int, error)
+
 	// UpdateTenant updates a tenant
 	UpdateTenant(ctx context.Context, tenant *entity.Tenant) error
 
-	// DeleteTenant marks a tenant as deleted
+	// DeleteTenant marks a tenant and its users as deleted and deactivates its products
 	DeleteTenant(ctx context.Context, id uuid.UUID) error
-}
\ No newline at end of file
+}
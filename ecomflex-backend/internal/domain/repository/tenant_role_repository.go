@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrTenantRoleNotFound is returned when a tenant has no custom role with an ID or name
+	ErrTenantRoleNotFound = errors.New("role not found")
+
+	// ErrTenantRoleNameTaken is returned when a tenant already has a custom role with a name
+	ErrTenantRoleNameTaken = errors.New("role name already taken")
+)
+
+// TenantRoleRepository defines operations for tenants' custom roles
+type TenantRoleRepository interface {
+	// CreateRole creates a new custom role
+	CreateRole(ctx context.Context, role *entity.TenantRole) error
+
+	// GetRoleByID retrieves a custom role of a tenant by ID
+	GetRoleByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.TenantRole, error)
+
+	// GetRoleByName retrieves a custom role of a tenant by name
+	GetRoleByName(ctx context.Context, tenantID uuid.UUID, name entity.Role) (*entity.TenantRole, error)
+
+	// UpdateRole updates the description and permissions of a custom role
+	UpdateRole(ctx context.Context, role *entity.TenantRole) error
+
+	// DeleteRole deletes a custom role of a tenant
+	DeleteRole(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// ListRoles retrieves the custom roles of a tenant
+	ListRoles(ctx context.Context, tenantID uuid.UUID) ([]*entity.TenantRole, error)
+}
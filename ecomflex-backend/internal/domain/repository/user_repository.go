@@ -2,11 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 )
 
+// ErrUserNotFound is returned when a user does not exist
+var ErrUserNotFound = errors.New("user not found")
+
 // UserRepository defines the interface for user repository operations
 type UserRepository interface {
 	// CreateUser creates a new user
@@ -27,12 +32,24 @@ type UserRepository interface {
 	// DeleteUser deletes a user
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	
-	// GetInfluencersByStatus gets influencers by status
-	GetInfluencersByStatus(ctx context.Context, status string, limit, offset int) ([]*entity.User, int, error)
+	// GetInfluencersByStatus gets influencers by status, of one tenant or of every tenant when tenantID is nil
+	GetInfluencersByStatus(ctx context.Context, tenantID *uuid.UUID, status string, limit, offset int) ([]*entity.User, int, error)
+	
+	// UpdatePassword replaces the password hash of a user
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	
+	// MarkEmailVerified records when a user verified their email address
+	MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error
 	
 	// IsEmailTaken checks if an email is already taken
 	IsEmailTaken(ctx context.Context, email string) (bool, error)
 	
+	// UpdateRole changes the role of a user
+	UpdateRole(ctx context.Context, id uuid.UUID, role entity.Role) error
+	
+	// CountUsersWithRole counts a tenant's users that have a role
+	CountUsersWithRole(ctx context.Context, tenantID uuid.UUID, role entity.Role) (int, error)
+	
 	// IsReferralCodeTaken checks if a referral code is already taken
 	IsReferralCodeTaken(ctx context.Context, referralCode string) (bool, error)
 }
\ No newline at end of file
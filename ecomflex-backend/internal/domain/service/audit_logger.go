@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// Actor is the signed-in user a request acts as
+type Actor struct {
+	UserID   uuid.UUID
+	TenantID uuid.UUID
+	Role     entity.Role
+	// ImpersonatorID is the admin behind the request when it was made with an impersonation token
+	ImpersonatorID *uuid.UUID
+}
+
+// actorKey is the context key of a request's Actor
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the user a request acts as
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the user a request acts as, if signed in
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// AuditEntry describes a change to record in the audit log.
+// Before and After are snapshots of the entity; either may be nil when it did not exist.
+type AuditEntry struct {
+	TenantID   uuid.UUID
+	Action     entity.AuditAction
+	EntityType string
+	EntityID   uuid.UUID
+	Before     interface{}
+	After      interface{}
+	// UserID attributes the change to a user when the request has no signed-in actor, like a login
+	UserID *uuid.UUID
+}
+
+// AuditLogger defines the interface for the tenant audit log.
+// Entries are written in the background so recording a change never holds up the request that made it.
+type AuditLogger interface {
+	// Record queues a change for the audit log, attributing it to the actor and device of ctx
+	Record(ctx context.Context, entry AuditEntry)
+
+	// List lists a tenant's audit log entries matching a filter, newest first
+	List(ctx context.Context, filter repository.AuditLogFilter) ([]*entity.AuditLog, int, error)
+
+	// Run writes queued entries until ctx is done
+	Run(ctx context.Context)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// AuthNotifier delivers account verification and recovery tokens to users
+type AuthNotifier interface {
+	// SendEmailVerification sends a link that verifies the user's email address
+	SendEmailVerification(ctx context.Context, user *entity.User, token string) error
+
+	// SendPasswordReset sends a link that lets the user choose a new password
+	SendPasswordReset(ctx context.Context, user *entity.User, token string) error
+
+	// SendAccountUnlock tells the user their account was locked and sends a link that unlocks it
+	SendAccountUnlock(ctx context.Context, user *entity.User, token string) error
+}
@@ -2,11 +2,69 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 )
 
+var (
+	// ErrAccountPendingApproval is returned when an influencer has not been approved yet
+	ErrAccountPendingApproval = errors.New("your account is pending approval")
+
+	// ErrAccountInactive is returned when a user's account is not active
+	ErrAccountInactive = errors.New("your account is inactive")
+
+	// ErrEmailNotVerified is returned when a provider has not verified the email of an identity
+	ErrEmailNotVerified = errors.New("email is not verified by the identity provider")
+
+	// ErrInvalidPassword is returned when an account's password does not match
+	ErrInvalidPassword = errors.New("invalid password")
+
+	// ErrEmailVerificationRequired is returned on login when the user has not verified their email
+	ErrEmailVerificationRequired = errors.New("please verify your email address before logging in")
+
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired, revoked or reused
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+	// ErrPasswordMismatch is returned when a new password and its confirmation differ
+	ErrPasswordMismatch = errors.New("passwords do not match")
+
+	// ErrCaptchaRequired is returned when a request that needs a captcha comes without one
+	ErrCaptchaRequired = errors.New("please complete the captcha")
+
+	// ErrInvalidCaptcha is returned when a captcha token does not verify
+	ErrInvalidCaptcha = errors.New("invalid captcha")
+)
+
+// LoginThrottledError is returned on login after too many failures, either until the
+// delay after the last failure has passed or, if Locked, until the lockout lifts
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+// Error implements error
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return "too many failed logins; login is temporarily locked"
+	}
+	return "too many failed logins; please wait before trying again"
+}
+
+// IdentityLinkRequiredError is returned when an external identity matches the email of an
+// existing account. The owner links them by confirming the LinkToken with their password.
+type IdentityLinkRequiredError struct {
+	LinkToken string
+	Email     string
+}
+
+// Error implements error
+func (e *IdentityLinkRequiredError) Error() string {
+	return "an account with this email already exists; confirm to link it"
+}
+
 // AuthRequest represents a user authentication request
 type AuthRequest struct {
 	Email     string `json:"email" binding:"required,email"`
@@ -33,6 +91,28 @@ type RegisterRequest struct {
 type GoogleOAuthRequest struct {
 	Code        string `json:"code" binding:"required"`
 	RedirectURI string `json:"redirect_uri" binding:"required"`
+
+	// Role of the account created on first login; existing accounts keep theirs
+	Role string `json:"role" binding:"omitempty,oneof=public influencer"`
+}
+
+// ConfirmIdentityLinkRequest represents a request to link an external identity to an existing account
+type ConfirmIdentityLinkRequest struct {
+	LinkToken string `json:"link_token" binding:"required"`
+	Password  string `json:"password" binding:"required"`
+}
+
+// PasswordResetRequest represents a request for a password reset link
+type PasswordResetRequest struct {
+	Email        string `json:"email" binding:"required,email"`
+	CaptchaToken string `json:"captcha_token"`
+}
+
+// ConfirmPasswordResetRequest represents a request to choose a new password with a reset token
+type ConfirmPasswordResetRequest struct {
+	Token           string `json:"token" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+	ConfirmPassword string `json:"confirm_password" binding:"required"`
 }
 
 // TokenResponse represents a token response
@@ -43,26 +123,66 @@ type TokenResponse struct {
 	ExpiresIn    int64  `json:"expires_in"` // seconds
 }
 
+// AccessToken is a validated access token and the user it was issued to.
+// SessionID is empty for tokens that do not belong to a session.
+type AccessToken struct {
+	User      *entity.User
+	TokenID   string
+	SessionID string
+
+	// Impersonation is set when an admin is acting as User
+	Impersonation *entity.Impersonation
+}
+
 // AuthService defines the interface for authentication service
 type AuthService interface {
 	// Register registers a new user
 	Register(ctx context.Context, req RegisterRequest, tenantID uuid.UUID) (*entity.User, error)
 	
-	// Login authenticates a user and returns tokens
+	// Login authenticates a user and returns tokens.
+	// It returns an *MFARequiredError instead when the user has to pass a second factor.
+	// Repeated failures are throttled with a *LoginThrottledError.
 	Login(ctx context.Context, req AuthRequest) (*TokenResponse, *entity.User, error)
 	
-	// RefreshToken refreshes an access token using a refresh token
+	// RefreshToken rotates a refresh token and issues a new access token
 	RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error)
 	
-	// Logout logs out a user and revokes their tokens
-	Logout(ctx context.Context, userID uuid.UUID, tokenID string) error
+	// Logout logs out the session a user's access token belongs to and revokes the token
+	Logout(ctx context.Context, userID uuid.UUID, tokenID, sessionID string) error
 	
 	// GoogleOAuthLogin handles Google OAuth login
 	GoogleOAuthLogin(ctx context.Context, req GoogleOAuthRequest, tenantID uuid.UUID) (*TokenResponse, *entity.User, error)
 	
-	// ValidateCaptcha validates a reCAPTCHA token
+	// ConfirmIdentityLink links a pending external identity to its account and logs the user in
+	ConfirmIdentityLink(ctx context.Context, req ConfirmIdentityLinkRequest) (*TokenResponse, *entity.User, error)
+	
+	// VerifyMFA completes a login with a TOTP or recovery code
+	VerifyMFA(ctx context.Context, req VerifyMFARequest) (*MFALoginResult, error)
+	
+	// EnrollMFA creates an authenticator for a user whose tenant requires MFA, during their login
+	EnrollMFA(ctx context.Context, challengeToken string) (*MFAEnrollment, error)
+	
+	// RequestPasswordReset sends a password reset link if the email belongs to an account
+	RequestPasswordReset(ctx context.Context, req PasswordResetRequest) error
+	
+	// ConfirmPasswordReset sets a new password with a reset token and logs out all sessions
+	ConfirmPasswordReset(ctx context.Context, req ConfirmPasswordResetRequest) error
+	
+	// RequestEmailVerification sends a new verification link if the email belongs to an unverified account
+	RequestEmailVerification(ctx context.Context, email string) error
+	
+	// ConfirmEmailVerification verifies the email address a verification token was sent to
+	ConfirmEmailVerification(ctx context.Context, token string) (*entity.User, error)
+	
+	// ConfirmAccountUnlock lifts the lockout of the account an unlock token was sent to
+	ConfirmAccountUnlock(ctx context.Context, token string) error
+	
+	// ValidateCaptcha validates a captcha token with the configured provider
 	ValidateCaptcha(ctx context.Context, captchaToken string, remoteIP string) (bool, error)
 	
 	// GetUserFromToken retrieves a user from a JWT token
 	GetUserFromToken(ctx context.Context, token string) (*entity.User, error)
+	
+	// AuthenticateToken validates a JWT access token and returns its user and session
+	AuthenticateToken(ctx context.Context, token string) (*AccessToken, error)
 }
\ No newline at end of file
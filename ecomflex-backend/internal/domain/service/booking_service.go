@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// CreateBookingRequest represents a request to book a product
+type CreateBookingRequest struct {
+	ProductID    string `json:"productId" binding:"required,uuid"`
+	ReferralCode string `json:"referralCode" binding:"omitempty"`
+
+	// Token from a referral link click; the attribution cookie is used when empty
+	AttributionToken string `json:"attributionToken" binding:"omitempty"`
+}
+
+// SubmitProofRequest represents a proof of purchase submitted for a booking
+type SubmitProofRequest struct {
+	ProofURL string            `json:"proofUrl" form:"proofUrl" binding:"required,url"`
+	OrderID  string            `json:"orderId" form:"orderId" binding:"omitempty,max=100"`
+	Fields   map[string]string `json:"fields" form:"-"`
+}
+
+// RejectBookingRequest represents a request to reject a booking
+type RejectBookingRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// CashbackUpdateRequest represents a request to update a booking's cashback status
+type CashbackUpdateRequest struct {
+	Status string `json:"status" binding:"required,oneof=paid"`
+}
+
+// BookingService defines the interface for booking service
+type BookingService interface {
+	// CreateBooking creates a new booking for a user
+	CreateBooking(ctx context.Context, userID uuid.UUID, req CreateBookingRequest) (*entity.Booking, error)
+
+	// GetUserBooking gets a booking owned by a user
+	GetUserBooking(ctx context.Context, id, userID uuid.UUID) (*entity.Booking, error)
+
+	// GetUserBookings gets all bookings of a user
+	GetUserBookings(ctx context.Context, userID uuid.UUID) ([]*entity.Booking, error)
+
+	// SubmitProof records a proof of purchase for a user's booking and queues it for review
+	SubmitProof(ctx context.Context, id, userID uuid.UUID, req SubmitProofRequest) (*entity.Booking, error)
+
+	// CancelBooking cancels a user's booking and releases its slot
+	CancelBooking(ctx context.Context, id, userID uuid.UUID) error
+
+	// ListBookings lists bookings for admins
+	ListBookings(ctx context.Context, filter repository.BookingFilter) ([]*entity.Booking, int, error)
+
+	// ApproveBooking approves a pending booking and records the decision on its proof
+	ApproveBooking(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Booking, error)
+
+	// RejectBooking rejects a pending booking, records the decision on its proof and releases its slot
+	RejectBooking(ctx context.Context, id, reviewerID uuid.UUID, req RejectBookingRequest) (*entity.Booking, error)
+
+	// MarkCashbackPaid marks the cashback of an approved booking as paid and credits it to the user's wallet
+	MarkCashbackPaid(ctx context.Context, id uuid.UUID) (*entity.Booking, error)
+
+	// ExpireStaleBookings expires initiated bookings that were never given a proof
+	ExpireStaleBookings(ctx context.Context) (int, error)
+}
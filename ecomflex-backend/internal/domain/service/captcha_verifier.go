@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+)
+
+// CaptchaVerifier checks captcha tokens solved by clients with the captcha provider
+type CaptchaVerifier interface {
+	// Verify checks a captcha token solved at remoteIP.
+	// It returns ErrCaptchaRequired for an empty token and ErrInvalidCaptcha when the provider rejects it.
+	Verify(ctx context.Context, token, remoteIP string) error
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// CommissionRuleRequest represents a request to create or update a commission rule
+type CommissionRuleRequest struct {
+	Name                 string     `json:"name" binding:"required"`
+	TenantID             string     `json:"tenant_id" binding:"omitempty,uuid"`
+	ProductID            string     `json:"product_id" binding:"omitempty,uuid"`
+	Source               string     `json:"source" binding:"omitempty,oneof=registration booking"`
+	AmountType           string     `json:"amount_type" binding:"required,oneof=flat percent"`
+	Amount               float64    `json:"amount" binding:"min=0"`
+	MinApprovedReferrals int        `json:"min_approved_referrals" binding:"min=0"`
+	StartsAt             *time.Time `json:"starts_at"`
+	EndsAt               *time.Time `json:"ends_at"`
+	IsActive             *bool      `json:"is_active"`
+}
+
+// CommissionService defines the interface for commission rules and their evaluation
+type CommissionService interface {
+	// CreateRule creates a new commission rule
+	CreateRule(ctx context.Context, req CommissionRuleRequest) (*entity.CommissionRule, error)
+
+	// GetRule gets a commission rule by ID
+	GetRule(ctx context.Context, id uuid.UUID) (*entity.CommissionRule, error)
+
+	// UpdateRule updates a commission rule
+	UpdateRule(ctx context.Context, id uuid.UUID, req CommissionRuleRequest) (*entity.CommissionRule, error)
+
+	// DeleteRule deletes a commission rule
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// ListRules lists all commission rules
+	ListRules(ctx context.Context) ([]*entity.CommissionRule, error)
+
+	// CalculateCommission picks the rule for a referral and computes its earnings.
+	// It returns nil when no rule applies.
+	CalculateCommission(ctx context.Context, referral *entity.Referral) (*entity.CommissionSnapshot, error)
+}
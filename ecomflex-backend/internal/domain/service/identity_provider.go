@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrIdentityProviderNotConfigured is returned when a login provider has no client credentials
+	ErrIdentityProviderNotConfigured = errors.New("identity provider is not configured")
+
+	// ErrInvalidRedirectURI is returned when a login redirect URI is not the registered one
+	ErrInvalidRedirectURI = errors.New("invalid redirect uri")
+
+	// ErrInvalidAuthorizationCode is returned when a provider rejects an authorization code
+	ErrInvalidAuthorizationCode = errors.New("invalid authorization code")
+
+	// ErrInvalidIdentityToken is returned when an ID token fails verification
+	ErrInvalidIdentityToken = errors.New("invalid identity token")
+)
+
+// ExternalIdentity represents a user authenticated by an external identity provider
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// IdentityProvider authenticates users with an external OpenID Connect provider
+type IdentityProvider interface {
+	// Name identifies the provider; it is stored with linked identities
+	Name() string
+
+	// Authenticate exchanges an authorization code and returns the verified identity behind it
+	Authenticate(ctx context.Context, code, redirectURI string) (*ExternalIdentity, error)
+}
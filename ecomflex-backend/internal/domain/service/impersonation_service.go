@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrImpersonationNotAllowed is returned when an admin tries to impersonate a user with
+	// permissions they lack, another impersonator or themselves
+	ErrImpersonationNotAllowed = errors.New("this user cannot be impersonated")
+
+	// ErrImpersonationInactive is returned when an impersonation token's impersonation has ended or expired
+	ErrImpersonationInactive = errors.New("impersonation has ended")
+)
+
+// ImpersonationRequest represents a request to act as another user
+type ImpersonationRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ImpersonationToken is a short-lived access token that acts as another user.
+// There is no refresh token; the admin starts a new impersonation when it expires.
+type ImpersonationToken struct {
+	AccessToken   string                `json:"access_token"`
+	TokenType     string                `json:"token_type"`
+	ExpiresIn     int64                 `json:"expires_in"` // seconds
+	Impersonation *entity.Impersonation `json:"impersonation"`
+}
+
+// ImpersonationService defines the interface for admins acting as other users.
+// Every impersonation is recorded with who started it, why, and when it started and ended.
+type ImpersonationService interface {
+	// Start mints an impersonation token for a user
+	Start(ctx context.Context, impersonator *entity.User, userID uuid.UUID, reason string) (*ImpersonationToken, error)
+
+	// End ends an impersonation; its token stops working immediately
+	End(ctx context.Context, id uuid.UUID) error
+
+	// List lists the impersonations of a user or by a user, newest first
+	List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Impersonation, int, error)
+}
@@ -0,0 +1,25 @@
+package service
+
+// JSONWebKey is a public key other services verify our access tokens with
+type JSONWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is a JWKS document
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// TokenKeyPublisher publishes the public keys access tokens are verified with
+type TokenKeyPublisher interface {
+	// PublicKeys returns the keys tokens are or will soon be signed with.
+	// Symmetric keys are never published.
+	PublicKeys() JSONWebKeySet
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrMailQueueFull is returned when an email cannot be queued because too many are waiting
+var ErrMailQueueFull = errors.New("mail queue is full")
+
+// EmailMessage represents an email to render from a template and send
+type EmailMessage struct {
+	// Tenant whose template overrides and branding are used
+	TenantID uuid.UUID
+	To       string
+	Template entity.EmailTemplateName
+	Data     map[string]interface{}
+}
+
+// Mailer renders and sends emails.
+// Send returns once the email is queued; delivery is retried in the background.
+type Mailer interface {
+	Send(ctx context.Context, msg EmailMessage) error
+}
+
+// SaveEmailTemplateRequest represents a request to override an email template for a tenant
+type SaveEmailTemplateRequest struct {
+	Subject  string `json:"subject" binding:"required"`
+	HTMLBody string `json:"html_body"`
+	TextBody string `json:"text_body"`
+}
+
+// EmailTemplateService defines the interface for managing tenants' email templates
+type EmailTemplateService interface {
+	// ListEmailTemplates lists the email template overrides of a tenant
+	ListEmailTemplates(ctx context.Context, tenantID uuid.UUID) ([]*entity.EmailTemplate, error)
+
+	// SaveEmailTemplate overrides an email template for a tenant
+	SaveEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName, req SaveEmailTemplateRequest) (*entity.EmailTemplate, error)
+
+	// DeleteEmailTemplate removes a tenant's override of an email template
+	DeleteEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName) error
+}
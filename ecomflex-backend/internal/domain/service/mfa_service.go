@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrMFANotEnrolled is returned when a user has not set up an authenticator
+	ErrMFANotEnrolled = errors.New("two-factor authentication is not set up")
+
+	// ErrMFAAlreadyEnabled is returned when enrolling a user whose authenticator is already enabled
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	// ErrInvalidMFACode is returned when a TOTP or recovery code does not match
+	ErrInvalidMFACode = errors.New("invalid authentication code")
+
+	// ErrMFARequiredByPolicy is returned when a user tries to turn off MFA their tenant requires
+	ErrMFARequiredByPolicy = errors.New("two-factor authentication is required for your account")
+
+	// ErrInvalidMFAChallenge is returned when an MFA challenge is unknown, expired or used up
+	ErrInvalidMFAChallenge = errors.New("invalid or expired mfa challenge")
+)
+
+// MFARequiredError is returned on login when the password was correct but a second
+// factor is needed. The client completes the login by verifying ChallengeToken with a code,
+// after enrolling an authenticator first if EnrollmentRequired is set.
+type MFARequiredError struct {
+	ChallengeToken     string
+	EnrollmentRequired bool
+	ExpiresIn          int64 // seconds
+}
+
+// Error implements error
+func (e *MFARequiredError) Error() string {
+	if e.EnrollmentRequired {
+		return "two-factor authentication must be set up to log in"
+	}
+	return "two-factor authentication code required"
+}
+
+// MFARequirement is what a user has to do after their password to log in
+type MFARequirement string
+
+// MFA requirements
+const (
+	MFARequirementNone   MFARequirement = ""
+	MFARequirementVerify MFARequirement = "verify"
+	MFARequirementEnroll MFARequirement = "enroll"
+)
+
+// MFAEnrollment is a new authenticator secret to load into an app
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"` // shown as a QR code
+}
+
+// MFAStatus describes a user's two-factor authentication
+type MFAStatus struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// MFAPolicy is a tenant's two-factor authentication policy
+type MFAPolicy struct {
+	RequireSuperAdmin bool `json:"require_super_admin"`
+}
+
+// VerifyMFARequest represents completing a login with a second factor.
+// Either Code or RecoveryCode is given.
+type VerifyMFARequest struct {
+	ChallengeToken string
+	Code           string
+	RecoveryCode   string
+}
+
+// MFALoginResult is a login completed with a second factor.
+// RecoveryCodes is only set when the login also completed a required enrollment.
+type MFALoginResult struct {
+	Tokens        *TokenResponse
+	User          *entity.User
+	RecoveryCodes []string
+}
+
+// MFAService defines the interface for TOTP two-factor authentication
+type MFAService interface {
+	// GetStatus gets a user's two-factor authentication status
+	GetStatus(ctx context.Context, userID uuid.UUID) (*MFAStatus, error)
+
+	// Enroll creates a new pending authenticator for a user
+	Enroll(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error)
+
+	// Enable confirms a pending authenticator with a code from it and returns new recovery codes
+	Enable(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+
+	// Disable removes a user's authenticator after checking a code or recovery code
+	Disable(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error
+
+	// RegenerateRecoveryCodes replaces a user's recovery codes after checking a code
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+
+	// LoginRequirement gets what a user has to do after their password to log in
+	LoginRequirement(ctx context.Context, user *entity.User) (MFARequirement, error)
+
+	// Verify checks a code or a recovery code of a user's enabled authenticator.
+	// Each code can be used only once.
+	Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error
+
+	// GetTenantPolicy gets a tenant's two-factor authentication policy
+	GetTenantPolicy(ctx context.Context, tenantID uuid.UUID) (*MFAPolicy, error)
+
+	// UpdateTenantPolicy sets a tenant's two-factor authentication policy
+	UpdateTenantPolicy(ctx context.Context, tenantID uuid.UUID, policy MFAPolicy) (*MFAPolicy, error)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrInvalidWebhookSignature is returned when a payout webhook fails verification
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+	// ErrTransferNotFound is returned when a provider does not know a transfer
+	ErrTransferNotFound = errors.New("transfer not found")
+
+	// ErrPayoutsDisabled is returned when payouts are attempted while no payout provider is configured
+	ErrPayoutsDisabled = errors.New("payouts are disabled")
+)
+
+// PayoutBeneficiary describes the payee registered with a payout provider.
+// Method and Details are empty when the payee has not chosen a payout method.
+type PayoutBeneficiary struct {
+	UserID  uuid.UUID
+	Name    string
+	Email   string
+	Phone   string
+	Method  entity.PayoutMethodType
+	Details map[string]string
+}
+
+// TransferRequest represents a request to send a payout to a beneficiary.
+// PayoutID doubles as the idempotency key, so retries never send money twice.
+type TransferRequest struct {
+	PayoutID       uuid.UUID
+	BeneficiaryRef string
+	Amount         float64
+	Currency       string
+}
+
+// TransferStatus represents the state of a transfer at the provider
+type TransferStatus struct {
+	Reference     string
+	Status        entity.PayoutStatus
+	FailureReason string
+}
+
+// PayoutWebhookEvent represents a verified transfer status update pushed by a provider
+type PayoutWebhookEvent struct {
+	EventID string
+	TransferStatus
+}
+
+// PayoutProvider sends payouts through an external payment rail.
+// Statuses are reported as processing, paid or failed.
+type PayoutProvider interface {
+	// Name identifies the provider; it is stored with beneficiaries and payouts
+	Name() string
+
+	// CreateBeneficiary registers a payee and returns the provider's reference for them
+	CreateBeneficiary(ctx context.Context, beneficiary PayoutBeneficiary) (string, error)
+
+	// InitiateTransfer starts a transfer; repeating a request for the same payout returns the original transfer
+	InitiateTransfer(ctx context.Context, req TransferRequest) (*TransferStatus, error)
+
+	// GetTransfer polls the current status of a transfer
+	GetTransfer(ctx context.Context, reference string) (*TransferStatus, error)
+
+	// ParseWebhook verifies a status webhook and extracts its event.
+	// It fails with ErrInvalidWebhookSignature if the request is not from the provider.
+	ParseWebhook(header http.Header, body []byte) (*PayoutWebhookEvent, error)
+}
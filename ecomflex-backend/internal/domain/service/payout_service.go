@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// UpdatePayoutStatusRequest represents a request to move a payout along its lifecycle
+type UpdatePayoutStatusRequest struct {
+	Status    entity.PayoutStatus `json:"status" binding:"required,oneof=processing paid failed"`
+	Reference string              `json:"reference"`
+	Reason    string              `json:"reason"`
+}
+
+// PayoutService defines the interface for earnings balances and payouts
+type PayoutService interface {
+	// RunPayouts creates payouts for every payable balance at or above the payout threshold
+	RunPayouts(ctx context.Context) (*entity.PayoutRun, error)
+
+	// ListRuns lists payout runs, newest first
+	ListRuns(ctx context.Context, limit, offset int) ([]*entity.PayoutRun, int, error)
+
+	// ListPayouts lists payouts for admins
+	ListPayouts(ctx context.Context, filter repository.PayoutFilter) ([]*entity.Payout, int, error)
+
+	// GetPayout gets a payout by ID
+	GetPayout(ctx context.Context, id uuid.UUID) (*entity.Payout, error)
+
+	// UpdatePayoutStatus moves a payout along its lifecycle.
+	// Failed payouts return their amount to the payee's balance.
+	UpdatePayoutStatus(ctx context.Context, id uuid.UUID, req UpdatePayoutStatusRequest) (*entity.Payout, error)
+
+	// DispatchPayouts hands pending payouts to the payout provider and returns how many were sent
+	DispatchPayouts(ctx context.Context) (int, error)
+
+	// SyncPayouts polls the payout provider for payouts still in flight and returns how many settled
+	SyncPayouts(ctx context.Context) (int, error)
+
+	// HandleWebhook verifies and applies a transfer status webhook from the payout provider.
+	// Redelivered and outdated events are acknowledged without changing anything.
+	HandleWebhook(ctx context.Context, header http.Header, body []byte) error
+
+	// GetEarningsBalance gets the earnings balance of an influencer
+	GetEarningsBalance(ctx context.Context, influencerID uuid.UUID) (repository.BalanceSummary, error)
+
+	// ListEarningsEntries lists the ledger entries of an influencer's earnings, newest first
+	ListEarningsEntries(ctx context.Context, influencerID uuid.UUID, limit, offset int) ([]*entity.LedgerEntry, int, error)
+
+	// ListInfluencerPayouts lists the payouts of an influencer, newest first
+	ListInfluencerPayouts(ctx context.Context, influencerID uuid.UUID, limit, offset int) ([]*entity.Payout, int, error)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// RejectProofRequest represents a request to reject a proof of purchase
+type RejectProofRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ProofService defines the interface for the proof moderation service
+type ProofService interface {
+	// ListProofQueue lists proofs awaiting or past moderation, oldest first
+	ListProofQueue(ctx context.Context, filter repository.ProofFilter) ([]*entity.Proof, int, error)
+
+	// GetProof gets a proof by ID
+	GetProof(ctx context.Context, id uuid.UUID) (*entity.Proof, error)
+
+	// GetProofHistory gets the moderation history of a proof
+	GetProofHistory(ctx context.Context, id uuid.UUID) ([]*entity.ProofDecision, error)
+
+	// ClaimProof assigns a proof to a reviewer so others do not review it concurrently
+	ClaimProof(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Proof, error)
+
+	// ApproveProof approves a proof and its booking
+	ApproveProof(ctx context.Context, id, reviewerID uuid.UUID) (*entity.Proof, error)
+
+	// RejectProof rejects a proof and its booking with a reason
+	RejectProof(ctx context.Context, id, reviewerID uuid.UUID, req RejectProofRequest) (*entity.Proof, error)
+}
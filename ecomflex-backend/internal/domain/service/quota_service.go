@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// QuotaExceededError is returned when an action would take a tenant past a limit of its plan
+type QuotaExceededError struct {
+	PlanID    string               `json:"plan_id"`
+	Resource  entity.QuotaResource `json:"resource"`
+	Limit     int64                `json:"limit"`
+	Used      int64                `json:"used"`
+	Requested int64                `json:"requested"`
+}
+
+// Error describes the limit that was hit
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("the %s plan allows %d %s and %d are in use", e.PlanID, e.Limit, e.Resource, e.Used)
+}
+
+// FeatureUnavailableError is returned when a tenant's plan does not include a feature
+type FeatureUnavailableError struct {
+	PlanID  string             `json:"plan_id"`
+	Feature entity.PlanFeature `json:"feature"`
+}
+
+// Error describes the missing feature
+func (e *FeatureUnavailableError) Error() string {
+	return fmt.Sprintf("the %s plan does not include %s", e.PlanID, e.Feature)
+}
+
+// ResourceUsage is how much of a resource a tenant uses; a nil limit means unlimited
+type ResourceUsage struct {
+	Resource entity.QuotaResource `json:"resource"`
+	Used     int64                `json:"used"`
+	Limit    *int64               `json:"limit"`
+}
+
+// UsageReport is a tenant's usage of the limits of its plan
+type UsageReport struct {
+	TenantID    uuid.UUID       `json:"tenant_id"`
+	Plan        *entity.Plan    `json:"plan"`
+	PeriodStart time.Time       `json:"period_start"`
+	Resources   []ResourceUsage `json:"resources"`
+}
+
+// QuotaService defines the interface for the plans catalogue and enforcing its limits
+type QuotaService interface {
+	// ListPlans lists the plans tenants can be on, cheapest first
+	ListPlans(ctx context.Context) ([]*entity.Plan, error)
+
+	// GetUsage reports a tenant's usage of the limits of its plan
+	GetUsage(ctx context.Context, tenantID uuid.UUID) (*UsageReport, error)
+
+	// CheckQuota checks that a tenant's plan allows using amount more of a resource.
+	// It returns a *QuotaExceededError when it does not.
+	CheckQuota(ctx context.Context, tenantID uuid.UUID, resource entity.QuotaResource, amount int64) error
+
+	// RequireFeature checks that a tenant's plan includes a feature.
+	// It returns a *FeatureUnavailableError when it does not.
+	RequireFeature(ctx context.Context, tenantID uuid.UUID, feature entity.PlanFeature) error
+
+	// RecordStoredFile counts an uploaded file against its tenant's storage
+	RecordStoredFile(ctx context.Context, file *entity.StoredFile) error
+}
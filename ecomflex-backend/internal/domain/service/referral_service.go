@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// TrackClickRequest represents a visit through a referral link
+type TrackClickRequest struct {
+	Code      string
+	ProductID string
+	IPAddress string
+	UserAgent string
+}
+
+// ReferralService defines the interface for referral tracking and attribution
+type ReferralService interface {
+	// TrackClick records a click on a referral link; the click ID is the attribution token
+	TrackClick(ctx context.Context, req TrackClickRequest) (*entity.ReferralClick, error)
+
+	// AttributeRegistration creates a referral for a new user from an attribution token.
+	// It returns nil when there is nothing to attribute.
+	AttributeRegistration(ctx context.Context, token string, user *entity.User) (*entity.Referral, error)
+
+	// AttributeBooking creates a referral for a booking from an attribution token, the booking's
+	// referral code or the user's signup referral. It returns nil when there is nothing to attribute.
+	AttributeBooking(ctx context.Context, token string, booking *entity.Booking) (*entity.Referral, error)
+
+	// ListReferrals lists referrals for admins
+	ListReferrals(ctx context.Context, filter repository.ReferralFilter) ([]*entity.Referral, int, error)
+
+	// ApproveReferral approves a pending referral and computes its earnings from the commission rules
+	ApproveReferral(ctx context.Context, id uuid.UUID) (*entity.Referral, error)
+
+	// RejectReferral rejects a referral, reversing its earnings if it was already approved
+	RejectReferral(ctx context.Context, id uuid.UUID) (*entity.Referral, error)
+
+	// ApproveBookingReferral approves the referral of an approved booking, if there is one
+	ApproveBookingReferral(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error)
+
+	// RejectBookingReferral rejects the referral of a rejected or cancelled booking, if there is one
+	RejectBookingReferral(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+var (
+	// ErrUnknownRole is returned when assigning a role that is neither built-in nor defined by the user's tenant
+	ErrUnknownRole = errors.New("unknown role")
+
+	// ErrRoleInUse is returned when deleting a custom role that users still have
+	ErrRoleInUse = errors.New("role is assigned to users")
+
+	// ErrPermissionEscalation is returned when granting permissions the acting user does not hold
+	ErrPermissionEscalation = errors.New("cannot grant permissions you do not have")
+)
+
+// RoleRequest represents a request to create or update a tenant's custom role
+type RoleRequest struct {
+	Name        string              `json:"name" binding:"omitempty,max=50"`
+	Description string              `json:"description" binding:"max=255"`
+	Permissions []entity.Permission `json:"permissions" binding:"required"`
+}
+
+// AssignRoleRequest represents a request to change the role of a user
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// BuiltInRole is a role every tenant has with its fixed permissions
+type BuiltInRole struct {
+	Name        entity.Role         `json:"name"`
+	Permissions []entity.Permission `json:"permissions"`
+}
+
+// RoleList lists the roles a tenant's users can have and the permissions roles can grant
+type RoleList struct {
+	BuiltIn     []BuiltInRole        `json:"built_in"`
+	Custom      []*entity.TenantRole `json:"custom"`
+	Permissions []entity.Permission  `json:"permissions"`
+}
+
+// RoleService defines the interface for roles and the permissions they grant.
+// Changes that grant permissions are checked against the acting user, who cannot
+// hand out permissions they do not hold themselves, and only super admins can reach other tenants.
+type RoleService interface {
+	// GetPermissions gets the permissions a user has through their role
+	GetPermissions(ctx context.Context, user *entity.User) (entity.PermissionSet, error)
+
+	// ListRoles lists the built-in roles and a tenant's custom roles
+	ListRoles(ctx context.Context, actor *entity.User, tenantID uuid.UUID) (*RoleList, error)
+
+	// CreateRole creates a custom role for a tenant
+	CreateRole(ctx context.Context, actor *entity.User, tenantID uuid.UUID, req RoleRequest) (*entity.TenantRole, error)
+
+	// UpdateRole replaces the description and permissions of a tenant's custom role
+	UpdateRole(ctx context.Context, actor *entity.User, tenantID, roleID uuid.UUID, req RoleRequest) (*entity.TenantRole, error)
+
+	// DeleteRole deletes a tenant's custom role that no user has
+	DeleteRole(ctx context.Context, actor *entity.User, tenantID, roleID uuid.UUID) error
+
+	// AssignRole gives a user a built-in role or a custom role of their tenant
+	AssignRole(ctx context.Context, actor *entity.User, userID uuid.UUID, role entity.Role) (*entity.User, error)
+}
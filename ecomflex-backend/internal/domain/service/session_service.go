@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClientInfo describes the device a request comes from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// clientInfoKey is the context key of a request's ClientInfo
+type clientInfoKey struct{}
+
+// WithClientInfo returns a copy of ctx carrying the device a request comes from
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext returns the device a request comes from, if known
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+// SessionResponse represents a signed-in device of a user
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// SessionService defines the interface for managing where users are signed in
+type SessionService interface {
+	// ListSessions lists the sessions of a user, flagging the one making the request
+	ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*SessionResponse, error)
+
+	// RevokeSession signs a user out of one of their sessions
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+
+	// RevokeAllSessions signs a user out everywhere
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+}
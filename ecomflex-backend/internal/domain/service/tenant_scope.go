@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// ErrOtherTenant is returned when a user who is not a super admin acts on another tenant's data
+var ErrOtherTenant = errors.New("cannot act on another tenant")
+
+// TenantScope returns the tenant the data a request reaches is limited to.
+// Requests of users other than super admins are limited to their own tenant;
+// requests without an actor, like background jobs, are not limited.
+func TenantScope(ctx context.Context) (uuid.UUID, bool) {
+	actor, ok := ActorFromContext(ctx)
+	if !ok || actor.Role == entity.RoleSuperAdmin {
+		return uuid.Nil, false
+	}
+	return actor.TenantID, true
+}
+
+// CheckTenant returns ErrOtherTenant when a request is limited to a tenant other than tenantID
+func CheckTenant(ctx context.Context, tenantID uuid.UUID) error {
+	if scope, limited := TenantScope(ctx); limited && scope != tenantID {
+		return ErrOtherTenant
+	}
+	return nil
+}
+
+// RequirePlatformScope returns ErrOtherTenant when a request is limited to a tenant.
+// It guards operations that span every tenant, like payout runs.
+func RequirePlatformScope(ctx context.Context) error {
+	if _, limited := TenantScope(ctx); limited {
+		return ErrOtherTenant
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+func TestTenantScope(t *testing.T) {
+	tenantID := uuid.New()
+	otherTenantID := uuid.New()
+
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		wantLimited       bool
+		wantOtherTenant   error
+		wantPlatformScope error
+	}{
+		{
+			name: "no actor",
+			ctx:  context.Background(),
+		},
+		{
+			name: "super admin",
+			ctx:  WithActor(context.Background(), Actor{UserID: uuid.New(), TenantID: tenantID, Role: entity.RoleSuperAdmin}),
+		},
+		{
+			name:              "support agent",
+			ctx:               WithActor(context.Background(), Actor{UserID: uuid.New(), TenantID: tenantID, Role: entity.RoleSupport}),
+			wantLimited:       true,
+			wantOtherTenant:   ErrOtherTenant,
+			wantPlatformScope: ErrOtherTenant,
+		},
+		{
+			name:              "custom role",
+			ctx:               WithActor(context.Background(), Actor{UserID: uuid.New(), TenantID: tenantID, Role: "moderator"}),
+			wantLimited:       true,
+			wantOtherTenant:   ErrOtherTenant,
+			wantPlatformScope: ErrOtherTenant,
+		},
+		{
+			name: "super admin impersonating a tenant user",
+			ctx: WithActor(context.Background(), Actor{
+				UserID: uuid.New(), TenantID: tenantID, Role: entity.RoleSupport, ImpersonatorID: ptrUUID(uuid.New()),
+			}),
+			wantLimited:       true,
+			wantOtherTenant:   ErrOtherTenant,
+			wantPlatformScope: ErrOtherTenant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, limited := TenantScope(tt.ctx)
+			if limited != tt.wantLimited {
+				t.Fatalf("TenantScope() limited = %t, want %t", limited, tt.wantLimited)
+			}
+			if limited && scope != tenantID {
+				t.Errorf("TenantScope() = %s, want %s", scope, tenantID)
+			}
+
+			if err := CheckTenant(tt.ctx, tenantID); err != nil {
+				t.Errorf("CheckTenant(own tenant) error = %v", err)
+			}
+			if err := CheckTenant(tt.ctx, otherTenantID); !errors.Is(err, tt.wantOtherTenant) {
+				t.Errorf("CheckTenant(other tenant) error = %v, want %v", err, tt.wantOtherTenant)
+			}
+			if err := RequirePlatformScope(tt.ctx); !errors.Is(err, tt.wantPlatformScope) {
+				t.Errorf("RequirePlatformScope() error = %v, want %v", err, tt.wantPlatformScope)
+			}
+		})
+	}
+}
+
+func ptrUUID(id uuid.UUID) *uuid.UUID {
+	return &id
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+var (
+	// ErrTenantSuspended is returned when a user of a suspended tenant tries to sign in
+	ErrTenantSuspended = errors.New("your store has been suspended")
+
+	// ErrDefaultTenant is returned when suspending or deleting the tenant the platform runs in
+	ErrDefaultTenant = errors.New("the default tenant cannot be suspended or deleted")
+
+	// ErrPlatformDomain is returned when a tenant is given a domain the platform itself serves
+	ErrPlatformDomain = errors.New("domain is reserved for the platform")
+)
+
+// CreateTenantRequest represents a request to create a tenant.
+// Without a domain the tenant gets a subdomain of the platform based on Subdomain, or on its name.
+type CreateTenantRequest struct {
+	Name      string `json:"name" binding:"required,max=255"`
+	Domain    string `json:"domain" binding:"max=253"`
+	Subdomain string `json:"subdomain" binding:"max=63"`
+	PlanID    string `json:"plan_id" binding:"max=50"`
+}
+
+// UpdateTenantRequest represents a request to update a tenant.
+// Settings are changed through UpdateSettings, which validates them.
+type UpdateTenantRequest struct {
+	Name   string `json:"name" binding:"required,max=255"`
+	Domain string `json:"domain" binding:"required,max=253"`
+	PlanID string `json:"plan_id" binding:"required,max=50"`
+}
+
+// TenantService defines the interface for administering tenants
+type TenantService interface {
+	// CreateTenant creates a tenant, picking a free subdomain when no domain is given
+	CreateTenant(ctx context.Context, req CreateTenantRequest) (*entity.Tenant, error)
+
+	// GetTenant gets a tenant by ID
+	GetTenant(ctx context.Context, id uuid.UUID) (*entity.Tenant, error)
+
+	// ListTenants lists tenants for admins, newest first
+	ListTenants(ctx context.Context, filter repository.TenantFilter) ([]*entity.Tenant, int, error)
+
+	// UpdateTenant replaces the name, domain and plan of a tenant
+	UpdateTenant(ctx context.Context, id uuid.UUID, req UpdateTenantRequest) (*entity.Tenant, error)
+
+	// SuspendTenant deactivates a tenant; its users cannot sign in until it is activated again
+	SuspendTenant(ctx context.Context, id uuid.UUID) (*entity.Tenant, error)
+
+	// ActivateTenant lifts the suspension of a tenant
+	ActivateTenant(ctx context.Context, id uuid.UUID) (*entity.Tenant, error)
+
+	// VerifyDomain records that a tenant proved it owns its custom domain, so the domain starts serving it
+	VerifyDomain(ctx context.Context, id uuid.UUID) (*entity.Tenant, error)
+
+	// DeleteTenant deletes a tenant together with its users and deactivates its products
+	DeleteTenant(ctx context.Context, id uuid.UUID) error
+
+	// GetSettings gets the settings of a tenant, with defaults for the ones it never set
+	GetSettings(ctx context.Context, id uuid.UUID) (*entity.TenantSettings, error)
+
+	// UpdateSettings applies a partial settings document to a tenant's settings.
+	// Documents that do not match the settings schema fail with an *entity.TenantSettingsError.
+	UpdateSettings(ctx context.Context, id uuid.UUID, patch []byte) (*entity.TenantSettings, error)
+}
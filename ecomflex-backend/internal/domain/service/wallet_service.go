@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+// Wallet summarises the cashback a user is owed
+type Wallet struct {
+	// Available is the released cashback not yet paid out
+	Available float64 `json:"available"`
+
+	// Pending is the cashback of bookings still awaiting review or release
+	Pending     float64 `json:"pending"`
+	TotalEarned float64 `json:"total_earned"`
+	PaidOut     float64 `json:"paid_out"`
+	Currency    string  `json:"currency"`
+}
+
+// SavePayoutMethodRequest represents a request to choose where payouts are sent
+type SavePayoutMethodRequest struct {
+	Type    entity.PayoutMethodType `json:"type" binding:"required,oneof=bank_transfer upi paypal"`
+	Details map[string]string       `json:"details" binding:"required"`
+}
+
+// WalletService defines the interface for the cashback wallet of public users
+type WalletService interface {
+	// GetWallet gets the cashback wallet of a user
+	GetWallet(ctx context.Context, userID uuid.UUID) (*Wallet, error)
+
+	// ListTransactions lists the ledger entries of a user's wallet, newest first
+	ListTransactions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.LedgerEntry, int, error)
+
+	// ListPayouts lists the payouts of a user's wallet, newest first
+	ListPayouts(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Payout, int, error)
+
+	// GetPayoutMethod gets the payout method chosen by a user
+	GetPayoutMethod(ctx context.Context, userID uuid.UUID) (*entity.PayoutMethod, error)
+
+	// SavePayoutMethod chooses where a user's payouts are sent
+	SavePayoutMethod(ctx context.Context, userID uuid.UUID, req SavePayoutMethodRequest) (*entity.PayoutMethod, error)
+}
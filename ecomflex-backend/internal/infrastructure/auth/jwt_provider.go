@@ -5,38 +5,77 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/naresh6454/ecomflex-backend/config"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
 )
 
+// minRefreshTokenSize is the fewest random bytes a refresh token is made of
+const minRefreshTokenSize = 32
+
 // CustomClaims represents custom JWT claims
 type CustomClaims struct {
-	UserID   string `json:"user_id"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
-	TenantID string `json:"tenant_id"`
-	TokenID  string `json:"token_id"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TenantID  string `json:"tenant_id"`
+	TokenID   string `json:"token_id"`
+	SessionID string `json:"session_id,omitempty"`
+
+	// Act identifies the admin behind an impersonation token, as in RFC 8693
+	Act             *ActorClaims `json:"act,omitempty"`
+	ImpersonationID string       `json:"impersonation_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// ActorClaims identifies who is acting as the token's subject
+type ActorClaims struct {
+	Subject string `json:"sub"`
+	Email   string `json:"email"`
+}
+
 // JWTProvider provides JWT token generation and validation
 type JWTProvider struct {
 	config *config.JWTConfig
+	method jwt.SigningMethod
+	keys   *KeySet // nil for HS256, which signs with the shared secret
 }
 
-// NewJWTProvider creates a new JWT provider
-func NewJWTProvider(config *config.JWTConfig) *JWTProvider {
-	return &JWTProvider{
+// NewJWTProvider creates a new JWT provider for the configured algorithm.
+// HS256 signs with the shared secret; RS256 and EdDSA sign with the configured key set.
+func NewJWTProvider(config *config.JWTConfig) (*JWTProvider, error) {
+	p := &JWTProvider{
 		config: config,
 	}
+	
+	switch strings.ToUpper(config.Algorithm) {
+	case "", "HS256":
+		p.method = jwt.SigningMethodHS256
+		return p, nil
+	case "RS256":
+		p.method = jwt.SigningMethodRS256
+	case "EDDSA":
+		p.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", config.Algorithm)
+	}
+	
+	keys, err := LoadKeySet(config, p.method)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	
+	return p, nil
 }
 
-// GenerateAccessToken generates a new JWT access token for a user
-func (p *JWTProvider) GenerateAccessToken(user *entity.User) (string, string, time.Time, error) {
+// GenerateAccessToken generates a new JWT access token for a user's session
+func (p *JWTProvider) GenerateAccessToken(user *entity.User, sessionID string) (string, string, time.Time, error) {
 	// Generate a unique token ID
 	tokenID := uuid.New().String()
 	
@@ -44,25 +83,56 @@ func (p *JWTProvider) GenerateAccessToken(user *entity.User) (string, string, ti
 	expirationTime := time.Now().Add(p.config.AccessTokenExp)
 	
 	// Create claims
+	claims := CustomClaims{
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		Role:      string(user.Role),
+		TenantID:  user.TenantID.String(),
+		TokenID:   tokenID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Subject:   user.ID.String(),
+		},
+	}
+	
+	tokenString, err := p.sign(claims)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	
+	return tokenString, tokenID, expirationTime, nil
+}
+
+// GenerateImpersonationToken generates a JWT access token that lets an admin act as a user.
+// The token names the admin in its act claim and belongs to no session, so it cannot be refreshed.
+func (p *JWTProvider) GenerateImpersonationToken(user, impersonator *entity.User, impersonationID string, ttl time.Duration) (string, string, time.Time, error) {
+	tokenID := uuid.New().String()
+	now := time.Now()
+	expirationTime := now.Add(ttl)
+	
 	claims := CustomClaims{
 		UserID:   user.ID.String(),
 		Email:    user.Email,
 		Role:     string(user.Role),
 		TenantID: user.TenantID.String(),
 		TokenID:  tokenID,
+		Act: &ActorClaims{
+			Subject: impersonator.ID.String(),
+			Email:   impersonator.Email,
+		},
+		ImpersonationID: impersonationID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Subject:   user.ID.String(),
 		},
 	}
 	
-	// Create token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	
-	// Sign token
-	tokenString, err := token.SignedString([]byte(p.config.Secret))
+	tokenString, err := p.sign(claims)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
@@ -70,15 +140,37 @@ func (p *JWTProvider) GenerateAccessToken(user *entity.User) (string, string, ti
 	return tokenString, tokenID, expirationTime, nil
 }
 
-// GenerateRefreshToken generates a new refresh token
-func (p *JWTProvider) GenerateRefreshToken() (string, error) {
-	b := make([]byte, p.config.RefreshTokenSize)
-	_, err := p.randomReader().Read(b)
+// sign signs claims with the secret or the current key, which verifiers find by its ID
+func (p *JWTProvider) sign(claims CustomClaims) (string, error) {
+	token := jwt.NewWithClaims(p.method, claims)
+	
+	var key interface{} = []byte(p.config.Secret)
+	if p.keys != nil {
+		signingKey := p.keys.signingKey(time.Now())
+		if signingKey == nil {
+			return "", errors.New("no jwt key is active")
+		}
+		token.Header["kid"] = signingKey.id
+		key = signingKey.private
+	}
+	
+	return token.SignedString(key)
+}
+
+// GenerateRefreshToken generates a new opaque refresh token and its expiration time
+func (p *JWTProvider) GenerateRefreshToken() (string, time.Time, error) {
+	size := p.config.RefreshTokenSize
+	if size < minRefreshTokenSize {
+		size = minRefreshTokenSize
+	}
+	
+	b := make([]byte, size)
+	_, err := io.ReadFull(p.randomReader(), b)
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 	
-	return fmt.Sprintf("%x", b), nil
+	return fmt.Sprintf("%x", b), time.Now().Add(p.config.RefreshTokenExp), nil
 }
 
 // ValidateToken validates a JWT token
@@ -89,10 +181,14 @@ func (p *JWTProvider) ValidateToken(tokenString string) (*CustomClaims, error) {
 		&CustomClaims{},
 		func(token *jwt.Token) (interface{}, error) {
 			// Validate signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			if token.Method.Alg() != p.method.Alg() {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return []byte(p.config.Secret), nil
+			if p.keys == nil {
+				return []byte(p.config.Secret), nil
+			}
+			kid, _ := token.Header["kid"].(string)
+			return p.keys.verificationKey(kid, time.Now())
 		},
 	)
 	
@@ -109,6 +205,14 @@ func (p *JWTProvider) ValidateToken(tokenString string) (*CustomClaims, error) {
 	return claims, nil
 }
 
+// PublicKeys returns the keys other services verify access tokens with
+func (p *JWTProvider) PublicKeys() service.JSONWebKeySet {
+	if p.keys == nil {
+		return service.JSONWebKeySet{Keys: []service.JSONWebKey{}}
+	}
+	return p.keys.PublicKeys(time.Now())
+}
+
 // randomReader is a helper function to get a reader for random bytes
 // This is extracted to make testing easier (can be mocked)
 func (p *JWTProvider) randomReader() io.Reader {
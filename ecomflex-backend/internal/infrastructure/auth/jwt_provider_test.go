@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+)
+
+func TestJWTProviderValidateToken(t *testing.T) {
+	user := entity.NewUser(uuid.New(), "user@example.com", "hash", "User", entity.RoleInfluencer, "")
+	current := newTestEd25519Key(t, "current")
+
+	hs256, err := NewJWTProvider(&config.JWTConfig{Secret: "test-secret", AccessTokenExp: time.Minute})
+	if err != nil {
+		t.Fatalf("NewJWTProvider(HS256) error = %v", err)
+	}
+	eddsa, err := NewJWTProvider(&config.JWTConfig{Algorithm: "EdDSA", Keys: []string{current}, AccessTokenExp: time.Minute})
+	if err != nil {
+		t.Fatalf("NewJWTProvider(EdDSA) error = %v", err)
+	}
+	rotated, err := NewJWTProvider(&config.JWTConfig{Algorithm: "EdDSA", Keys: []string{newTestEd25519Key(t, "other")}, AccessTokenExp: time.Minute})
+	if err != nil {
+		t.Fatalf("NewJWTProvider(EdDSA) error = %v", err)
+	}
+
+	hs256Token, _, _, err := hs256.GenerateAccessToken(user, "session")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken(HS256) error = %v", err)
+	}
+	eddsaToken, tokenID, _, err := eddsa.GenerateAccessToken(user, "session")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken(EdDSA) error = %v", err)
+	}
+
+	claims, err := eddsa.ValidateToken(eddsaToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != user.ID.String() || claims.TokenID != tokenID || claims.SessionID != "session" {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	if _, err := eddsa.ValidateToken(hs256Token); err == nil {
+		t.Error("EdDSA provider accepted an HS256 token")
+	}
+	if _, err := rotated.ValidateToken(eddsaToken); err == nil {
+		t.Error("accepted a token signed with a key the provider does not have")
+	}
+
+	keys := eddsa.PublicKeys().Keys
+	if len(keys) != 1 || keys[0].Kid != "current" || keys[0].Kty != "OKP" || keys[0].Alg != "EdDSA" {
+		t.Errorf("public keys = %+v", keys)
+	}
+	if keys := hs256.PublicKeys().Keys; len(keys) != 0 {
+		t.Errorf("HS256 public keys = %+v, want none", keys)
+	}
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+const (
+	// minRSAKeyBits is the smallest RSA key accepted for signing
+	minRSAKeyBits = 2048
+
+	// keyActivationLayout is the date a key ID may start with to schedule its activation
+	keyActivationLayout = "2006-01-02"
+)
+
+// errUnknownKey is returned when a token names a key that is unknown or retired
+var errUnknownKey = errors.New("unknown signing key")
+
+// signingKey is a private key access tokens are signed with
+type signingKey struct {
+	id          string
+	activatesAt time.Time
+	private     crypto.Signer
+}
+
+// KeySet holds the asymmetric keys access tokens are signed and verified with.
+//
+// Keys rotate on a schedule set by their IDs: a key whose ID starts with a date
+// (e.g. "2026-11-01-main") becomes the signing key on that date, and is published
+// ahead of it so verifiers can cache it in time. Keys without a date are active at once.
+// Once a newer key takes over, the old one keeps verifying tokens for the retention
+// period and is then retired.
+type KeySet struct {
+	method    jwt.SigningMethod
+	keys      []*signingKey
+	retention time.Duration
+}
+
+// LoadKeySet loads the private keys of the configured algorithm from KeysDir,
+// where each <kid>.pem file holds one key, and from Keys, where each entry is
+// kid=<base64 encoded PEM> so that keys can be passed in the environment
+func LoadKeySet(cfg *config.JWTConfig, method jwt.SigningMethod) (*KeySet, error) {
+	set := &KeySet{
+		method:    method,
+		retention: cfg.KeyRetention,
+	}
+	// Tokens signed just before a rotation must stay valid until they expire
+	if set.retention < cfg.AccessTokenExp {
+		set.retention = cfg.AccessTokenExp
+	}
+
+	seen := map[string]bool{}
+	add := func(kid string, data []byte) error {
+		if seen[kid] {
+			return fmt.Errorf("duplicate jwt key %q", kid)
+		}
+		seen[kid] = true
+
+		key, err := set.parseKey(kid, data)
+		if err != nil {
+			return err
+		}
+		set.keys = append(set.keys, key)
+		return nil
+	}
+
+	if cfg.KeysDir != "" {
+		paths, err := filepath.Glob(filepath.Join(cfg.KeysDir, "*.pem"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jwt keys: %w", err)
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read jwt key: %w", err)
+			}
+			if err := add(strings.TrimSuffix(filepath.Base(path), ".pem"), data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, entry := range cfg.Keys {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, encoded, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" {
+			return nil, errors.New("jwt keys must be given as kid=<base64 encoded PEM>")
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encoding for jwt key %q: %w", kid, err)
+		}
+		if err := add(kid, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(set.keys) == 0 {
+		return nil, fmt.Errorf("no keys configured for jwt algorithm %s", method.Alg())
+	}
+
+	sort.Slice(set.keys, func(i, j int) bool {
+		a, b := set.keys[i], set.keys[j]
+		if !a.activatesAt.Equal(b.activatesAt) {
+			return a.activatesAt.Before(b.activatesAt)
+		}
+		return a.id < b.id
+	})
+
+	if set.signingKey(time.Now()) == nil {
+		return nil, errors.New("no jwt key is active yet")
+	}
+
+	return set, nil
+}
+
+// parseKey decodes a PEM encoded private key and checks it suits the algorithm
+func (s *KeySet) parseKey(kid string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt key %q is not PEM encoded", kid)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("jwt key %q is not a private key", kid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt key %q: %w", kid, err)
+	}
+
+	key := &signingKey{id: kid}
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if _, ok := s.method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("jwt key %q is an RSA key but the algorithm is %s", kid, s.method.Alg())
+		}
+		if private.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("jwt key %q must have at least %d bits", kid, minRSAKeyBits)
+		}
+		key.private = private
+	case ed25519.PrivateKey:
+		if _, ok := s.method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("jwt key %q is an Ed25519 key but the algorithm is %s", kid, s.method.Alg())
+		}
+		key.private = private
+	default:
+		return nil, fmt.Errorf("jwt key %q has an unsupported type", kid)
+	}
+
+	if len(kid) >= len(keyActivationLayout) {
+		if activatesAt, err := time.Parse(keyActivationLayout, kid[:len(keyActivationLayout)]); err == nil {
+			key.activatesAt = activatesAt
+		}
+	}
+
+	return key, nil
+}
+
+// signingKey returns the newest key that is active at now
+func (s *KeySet) signingKey(now time.Time) *signingKey {
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if !s.keys[i].activatesAt.After(now) {
+			return s.keys[i]
+		}
+	}
+	return nil
+}
+
+// retired reports whether the key at index i was replaced longer ago than the retention period
+func (s *KeySet) retired(i int, now time.Time) bool {
+	if i+1 >= len(s.keys) {
+		return false
+	}
+	successor := s.keys[i+1]
+	return now.After(successor.activatesAt.Add(s.retention))
+}
+
+// verificationKey returns the public key of a key that is not retired
+func (s *KeySet) verificationKey(kid string, now time.Time) (crypto.PublicKey, error) {
+	for i, key := range s.keys {
+		if key.id == kid && !s.retired(i, now) {
+			return key.private.Public(), nil
+		}
+	}
+	return nil, errUnknownKey
+}
+
+// PublicKeys returns the keys that are scheduled, active or still verifying tokens
+func (s *KeySet) PublicKeys(now time.Time) service.JSONWebKeySet {
+	set := service.JSONWebKeySet{Keys: []service.JSONWebKey{}}
+	for i, key := range s.keys {
+		if s.retired(i, now) {
+			continue
+		}
+
+		jwk := service.JSONWebKey{
+			Kid: key.id,
+			Alg: s.method.Alg(),
+			Use: "sig",
+		}
+		switch public := key.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
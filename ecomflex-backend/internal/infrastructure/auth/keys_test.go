@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/naresh6454/ecomflex-backend/config"
+)
+
+// newTestKey generates a private key and returns it as a kid=<base64 encoded PEM> entry
+func newTestKey(t *testing.T, kid string, private interface{}) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return kid + "=" + base64.StdEncoding.EncodeToString(data)
+}
+
+// newTestEd25519Key generates an Ed25519 key entry
+func newTestEd25519Key(t *testing.T, kid string) string {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return newTestKey(t, kid, private)
+}
+
+func TestLoadKeySetRotation(t *testing.T) {
+	now := time.Now()
+	currentID := now.AddDate(0, 0, -1).Format(keyActivationLayout) + "-current"
+	nextID := now.AddDate(0, 1, 0).Format(keyActivationLayout) + "-next"
+	cfg := &config.JWTConfig{
+		Keys: []string{
+			newTestEd25519Key(t, "2020-01-01-first"),
+			newTestEd25519Key(t, "2020-06-01-second"),
+			newTestEd25519Key(t, currentID),
+			newTestEd25519Key(t, nextID),
+		},
+		KeyRetention:   72 * time.Hour,
+		AccessTokenExp: 15 * time.Minute,
+	}
+
+	set, err := LoadKeySet(cfg, jwt.SigningMethodEdDSA)
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+
+	if key := set.signingKey(now); key == nil || key.id != currentID {
+		t.Fatalf("signing key = %v, want the newest active key", key)
+	}
+
+	// The key replaced yesterday still verifies, the one replaced long ago is retired
+	// and the scheduled one is published ahead of its activation
+	var published []string
+	for _, jwk := range set.PublicKeys(now).Keys {
+		published = append(published, jwk.Kid)
+	}
+	want := []string{"2020-06-01-second", currentID, nextID}
+	if strings.Join(published, ",") != strings.Join(want, ",") {
+		t.Fatalf("published keys = %v, want %v", published, want)
+	}
+
+	if _, err := set.verificationKey("2020-01-01-first", now); !errors.Is(err, errUnknownKey) {
+		t.Errorf("retired key: error = %v, want %v", err, errUnknownKey)
+	}
+	if _, err := set.verificationKey("2020-06-01-second", now); err != nil {
+		t.Errorf("replaced key within retention: error = %v", err)
+	}
+	if _, err := set.verificationKey("unknown", now); !errors.Is(err, errUnknownKey) {
+		t.Errorf("unknown key: error = %v, want %v", err, errUnknownKey)
+	}
+}
+
+func TestLoadKeySetErrors(t *testing.T) {
+	weakRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	future := time.Now().AddDate(1, 0, 0).Format(keyActivationLayout)
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		keys   []string
+	}{
+		{name: "no keys", method: jwt.SigningMethodEdDSA},
+		{name: "key of another algorithm", method: jwt.SigningMethodRS256, keys: []string{newTestEd25519Key(t, "main")}},
+		{name: "weak RSA key", method: jwt.SigningMethodRS256, keys: []string{newTestKey(t, "main", weakRSA)}},
+		{name: "duplicate key ID", method: jwt.SigningMethodEdDSA, keys: []string{newTestEd25519Key(t, "main"), newTestEd25519Key(t, "main")}},
+		{name: "only scheduled keys", method: jwt.SigningMethodEdDSA, keys: []string{newTestEd25519Key(t, future+"-next")}},
+		{name: "missing key ID", method: jwt.SigningMethodEdDSA, keys: []string{"bm90IGEga2V5"}},
+		{name: "not PEM encoded", method: jwt.SigningMethodEdDSA, keys: []string{"main=bm90IGEga2V5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadKeySet(&config.JWTConfig{Keys: tt.keys}, tt.method); err == nil {
+				t.Fatal("LoadKeySet() error = nil, want the keys refused")
+			}
+		})
+	}
+}
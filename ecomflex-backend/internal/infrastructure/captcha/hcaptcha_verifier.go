@@ -0,0 +1,55 @@
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// hcaptchaVerifyURL is hCaptcha's siteverify endpoint
+const hcaptchaVerifyURL = "https://api.hcaptcha.com/siteverify"
+
+// HCaptchaVerifier verifies hCaptcha tokens
+type HCaptchaVerifier struct {
+	cfg    config.CaptchaConfig
+	client *http.Client
+}
+
+// NewHCaptchaVerifier creates an hCaptcha verifier using the configured endpoint
+func NewHCaptchaVerifier(cfg config.CaptchaConfig, client *http.Client) service.CaptchaVerifier {
+	if cfg.VerifyURL == "" {
+		cfg.VerifyURL = hcaptchaVerifyURL
+	}
+	return &HCaptchaVerifier{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+// Verify checks an hCaptcha token with hCaptcha
+func (v *HCaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return service.ErrCaptchaRequired
+	}
+
+	form := verifyForm(v.cfg.SecretKey, token, remoteIP)
+	// Tokens solved for another site are rejected when the site key is sent along
+	if v.cfg.SiteKey != "" {
+		form.Set("sitekey", v.cfg.SiteKey)
+	}
+
+	result, err := siteVerify(ctx, v.client, v.cfg.VerifyURL, form)
+	if err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return fmt.Errorf("%w: %s", service.ErrInvalidCaptcha, strings.Join(result.ErrorCodes, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package captcha
+
+import (
+	"context"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// NoopVerifier accepts every request, with or without a captcha token.
+// It is meant for development, where no captcha provider is set up.
+type NoopVerifier struct{}
+
+// NewNoopVerifier creates a verifier that accepts every request
+func NewNoopVerifier() service.CaptchaVerifier {
+	return &NoopVerifier{}
+}
+
+// Verify accepts any token
+func (v *NoopVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	return nil
+}
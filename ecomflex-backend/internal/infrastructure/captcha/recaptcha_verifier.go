@@ -0,0 +1,56 @@
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// recaptchaVerifyURL is Google's siteverify endpoint
+const recaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+
+// RecaptchaVerifier verifies Google reCAPTCHA tokens.
+// v2 tokens pass when Google accepts them; v3 tokens also need at least the minimum
+// score and, when one is configured, the expected action.
+type RecaptchaVerifier struct {
+	cfg    config.CaptchaConfig
+	client *http.Client
+}
+
+// NewRecaptchaVerifier creates a reCAPTCHA verifier using the configured endpoint
+func NewRecaptchaVerifier(cfg config.CaptchaConfig, client *http.Client) service.CaptchaVerifier {
+	if cfg.VerifyURL == "" {
+		cfg.VerifyURL = recaptchaVerifyURL
+	}
+	return &RecaptchaVerifier{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+// Verify checks a reCAPTCHA token with Google
+func (v *RecaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return service.ErrCaptchaRequired
+	}
+
+	result, err := siteVerify(ctx, v.client, v.cfg.VerifyURL, verifyForm(v.cfg.SecretKey, token, remoteIP))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !result.Success:
+		return fmt.Errorf("%w: %s", service.ErrInvalidCaptcha, strings.Join(result.ErrorCodes, ", "))
+	case result.Score != nil && *result.Score < v.cfg.MinScore:
+		return fmt.Errorf("%w: score %.1f is below %.1f", service.ErrInvalidCaptcha, *result.Score, v.cfg.MinScore)
+	case result.Score != nil && v.cfg.Action != "" && result.Action != v.cfg.Action:
+		return fmt.Errorf("%w: unexpected action %q", service.ErrInvalidCaptcha, result.Action)
+	}
+
+	return nil
+}
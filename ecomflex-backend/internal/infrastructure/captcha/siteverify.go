@@ -0,0 +1,58 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// siteVerifyResponse is the answer of a siteverify endpoint.
+// reCAPTCHA and hCaptcha share the format; Score and Action are only sent by reCAPTCHA v3.
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score"`
+	Action     string   `json:"action"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// siteVerify posts a token to a siteverify endpoint and decodes the answer
+func siteVerify(ctx context.Context, client *http.Client, endpoint string, form url.Values) (*siteVerifyResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create captcha request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("captcha endpoint returned status %d", resp.StatusCode)
+	}
+
+	result := &siteVerifyResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+
+	return result, nil
+}
+
+// verifyForm builds the siteverify parameters shared by the providers
+func verifyForm(secret, token, remoteIP string) url.Values {
+	form := url.Values{
+		"secret":   {secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	return form
+}
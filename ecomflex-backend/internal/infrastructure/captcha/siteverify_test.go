@@ -0,0 +1,152 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+const (
+	testSecretKey = "test-secret"
+	testSiteKey   = "test-site-key"
+)
+
+// newTestSiteVerify answers siteverify requests with the response registered for their token.
+// Requests with the wrong secret, or for another site when a site key is sent, are refused.
+func newTestSiteVerify(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.PostForm.Get("secret") != testSecretKey:
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-secret"]}`))
+		case r.PostForm.Has("sitekey") && r.PostForm.Get("sitekey") != testSiteKey:
+			w.Write([]byte(`{"success":false,"error-codes":["sitekey-secret-mismatch"]}`))
+		case r.PostForm.Get("response") == "server-error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			response, ok := responses[r.PostForm.Get("response")]
+			if !ok {
+				response = `{"success":false,"error-codes":["invalid-input-response"]}`
+			}
+			w.Write([]byte(response))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecaptchaVerifierVerify(t *testing.T) {
+	server := newTestSiteVerify(t, map[string]string{
+		"v2-token":         `{"success":true}`,
+		"v3-token":         `{"success":true,"score":0.9,"action":"login"}`,
+		"v3-low-score":     `{"success":true,"score":0.1,"action":"login"}`,
+		"v3-other-action":  `{"success":true,"score":0.9,"action":"register"}`,
+		"malformed-answer": `{"success":`,
+	})
+
+	verifier := NewRecaptchaVerifier(config.CaptchaConfig{
+		SecretKey: testSecretKey,
+		VerifyURL: server.URL,
+		MinScore:  0.5,
+		Action:    "login",
+	}, server.Client())
+
+	wrongSecret := NewRecaptchaVerifier(config.CaptchaConfig{
+		SecretKey: "wrong-secret",
+		VerifyURL: server.URL,
+	}, server.Client())
+
+	tests := []struct {
+		name     string
+		verifier service.CaptchaVerifier
+		token    string
+		wantErr  error
+		wantFail bool // fails without being the user's fault
+	}{
+		{name: "v2 token", verifier: verifier, token: "v2-token"},
+		{name: "v3 token", verifier: verifier, token: "v3-token"},
+		{name: "missing token", verifier: verifier, token: "", wantErr: service.ErrCaptchaRequired},
+		{name: "rejected token", verifier: verifier, token: "forged-token", wantErr: service.ErrInvalidCaptcha},
+		{name: "v3 score below minimum", verifier: verifier, token: "v3-low-score", wantErr: service.ErrInvalidCaptcha},
+		{name: "v3 token for another action", verifier: verifier, token: "v3-other-action", wantErr: service.ErrInvalidCaptcha},
+		{name: "wrong secret", verifier: wrongSecret, token: "v2-token", wantErr: service.ErrInvalidCaptcha},
+		{name: "endpoint error", verifier: verifier, token: "server-error", wantFail: true},
+		{name: "malformed answer", verifier: verifier, token: "malformed-answer", wantFail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.verifier.Verify(context.Background(), tt.token, "203.0.113.7")
+			assertCaptchaResult(t, err, tt.wantErr, tt.wantFail)
+		})
+	}
+}
+
+func TestHCaptchaVerifierVerify(t *testing.T) {
+	server := newTestSiteVerify(t, map[string]string{
+		"valid-token": `{"success":true}`,
+	})
+
+	verifier := NewHCaptchaVerifier(config.CaptchaConfig{
+		SecretKey: testSecretKey,
+		SiteKey:   testSiteKey,
+		VerifyURL: server.URL,
+	}, server.Client())
+
+	otherSite := NewHCaptchaVerifier(config.CaptchaConfig{
+		SecretKey: testSecretKey,
+		SiteKey:   "another-site-key",
+		VerifyURL: server.URL,
+	}, server.Client())
+
+	tests := []struct {
+		name     string
+		verifier service.CaptchaVerifier
+		token    string
+		wantErr  error
+		wantFail bool
+	}{
+		{name: "valid token", verifier: verifier, token: "valid-token"},
+		{name: "missing token", verifier: verifier, token: "", wantErr: service.ErrCaptchaRequired},
+		{name: "rejected token", verifier: verifier, token: "forged-token", wantErr: service.ErrInvalidCaptcha},
+		{name: "token for another site", verifier: otherSite, token: "valid-token", wantErr: service.ErrInvalidCaptcha},
+		{name: "endpoint error", verifier: verifier, token: "server-error", wantFail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.verifier.Verify(context.Background(), tt.token, "")
+			assertCaptchaResult(t, err, tt.wantErr, tt.wantFail)
+		})
+	}
+}
+
+// assertCaptchaResult checks the outcome of a verification
+func assertCaptchaResult(t *testing.T, err, wantErr error, wantFail bool) {
+	t.Helper()
+
+	switch {
+	case wantErr != nil:
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("Verify() error = %v, want %v", err, wantErr)
+		}
+	case wantFail:
+		if err == nil || errors.Is(err, service.ErrInvalidCaptcha) || errors.Is(err, service.ErrCaptchaRequired) {
+			t.Fatalf("Verify() error = %v, want a verification failure", err)
+		}
+	case err != nil:
+		t.Fatalf("Verify() error = %v", err)
+	}
+}
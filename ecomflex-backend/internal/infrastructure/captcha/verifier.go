@@ -0,0 +1,39 @@
+package captcha
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// Captcha providers
+const (
+	NoopProviderName      = "noop"
+	RecaptchaProviderName = "recaptcha"
+	HCaptchaProviderName  = "hcaptcha"
+)
+
+// NewVerifier creates the captcha verifier selected in the configuration
+func NewVerifier(cfg config.CaptchaConfig) (service.CaptchaVerifier, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	switch cfg.Provider {
+	case "", NoopProviderName:
+		return NewNoopVerifier(), nil
+	case RecaptchaProviderName:
+		if cfg.SecretKey == "" {
+			return nil, fmt.Errorf("captcha provider %q needs a secret key", cfg.Provider)
+		}
+		return NewRecaptchaVerifier(cfg, client), nil
+	case HCaptchaProviderName:
+		if cfg.SecretKey == "" {
+			return nil, fmt.Errorf("captcha provider %q needs a secret key", cfg.Provider)
+		}
+		return NewHCaptchaVerifier(cfg, client), nil
+	default:
+		return nil, fmt.Errorf("unknown captcha provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const auditLogColumns = `
+	id, tenant_id, user_id, impersonator_id, action, entity_type, entity_id,
+	old_value, new_value, ip_address, user_agent, created_at
+`
+
+// PostgresAuditLogRepository implements AuditLogRepository interface using PostgreSQL
+type PostgresAuditLogRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAuditLogRepository creates a new PostgresAuditLogRepository
+func NewPostgresAuditLogRepository(db *sqlx.DB) repository.AuditLogRepository {
+	return &PostgresAuditLogRepository{
+		db: db,
+	}
+}
+
+// CreateAuditLogs stores audit log entries in one statement
+func (r *PostgresAuditLogRepository) CreateAuditLogs(ctx context.Context, logs []*entity.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO audit_logs (` + auditLogColumns + `) VALUES (
+			:id, :tenant_id, :user_id, :impersonator_id, :action, :entity_type, :entity_id,
+			:old_value, :new_value, :ip_address, :user_agent, :created_at
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, logs); err != nil {
+		return fmt.Errorf("failed to create audit logs: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditLogs retrieves a tenant's audit log entries matching a filter, newest first, with the total count
+func (r *PostgresAuditLogRepository) ListAuditLogs(ctx context.Context, filter repository.AuditLogFilter) ([]*entity.AuditLog, int, error) {
+	args := []interface{}{filter.TenantID}
+	conditions := []string{"tenant_id = $1"}
+
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("(user_id = $%d OR impersonator_id = $%d)", len(args), len(args)))
+	}
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+	if filter.EntityType != "" {
+		args = append(args, filter.EntityType)
+		conditions = append(conditions, fmt.Sprintf("entity_type = $%d", len(args)))
+	}
+	if filter.EntityID != "" {
+		args = append(args, filter.EntityID)
+		conditions = append(conditions, fmt.Sprintf("entity_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total entries matching the filter
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM audit_logs"+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	query := `SELECT ` + auditLogColumns + ` FROM audit_logs` + where + ` ORDER BY created_at DESC, id`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	logs := []*entity.AuditLog{}
+	if err := r.db.SelectContext(ctx, &logs, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return logs, total, nil
+}
@@ -2,19 +2,32 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
 )
 
 const (
 	// Keys for Redis
-	refreshTokenKeyPrefix = "refresh_token:"
-	revokedTokenKeyPrefix = "revoked_token:"
-	userTokensKeyPrefix   = "user_tokens:"
+	refreshTokenKeyPrefix  = "refresh_token:"
+	sessionKeyPrefix       = "session:"
+	userSessionsKeyPrefix  = "user_sessions:"
+	revokedTokenKeyPrefix  = "revoked_token:"
+	identityLinkKeyPrefix  = "identity_link:"
+	accountTokenKeyPrefix  = "account_token:"
+	userTokenKeyPrefix     = "user_account_token:"
+	mfaChallengeKeyPrefix  = "mfa_challenge:"
+	mfaFailuresKeyPrefix   = "mfa_challenge_failures:"
+	loginFailuresKeyPrefix = "login_failures:"
+	loginLockKeyPrefix     = "login_lock:"
 )
 
 // RedisAuthRepository implements AuthRepository interface using Redis
@@ -29,94 +42,228 @@ func NewRedisAuthRepository(redis *redis.Client) repository.AuthRepository {
 	}
 }
 
-// SaveRefreshToken stores a refresh token in Redis
-func (r *RedisAuthRepository) SaveRefreshToken(ctx context.Context, userID uuid.UUID, tokenID string, token string, expiresAt time.Time) error {
-	// Create the refresh token key
-	refreshTokenKey := refreshTokenKeyPrefix + tokenID
-	
-	// Set refresh token with expiration
-	duration := time.Until(expiresAt)
-	err := r.redis.Set(ctx, refreshTokenKey, token, duration).Err()
+// CreateSession stores a new session together with the first refresh token of its family
+func (r *RedisAuthRepository) CreateSession(ctx context.Context, session *entity.Session, token *entity.RefreshToken) error {
+	data, err := json.Marshal(token)
 	if err != nil {
-		return fmt.Errorf("failed to save refresh token: %w", err)
+		return fmt.Errorf("failed to encode refresh token: %w", err)
 	}
 	
-	// Add token to user's token set
-	userTokensKey := userTokensKeyPrefix + userID.String()
-	err = r.redis.SAdd(ctx, userTokensKey, tokenID).Err()
-	if err != nil {
-		return fmt.Errorf("failed to add token to user tokens: %w", err)
-	}
+	ttl := time.Until(token.ExpiresAt)
+	sessionKey := sessionKeyPrefix + session.ID.String()
+	userSessionsKey := userSessionsKeyPrefix + session.UserID.String()
 	
-	// Set expiration on the user tokens set too (for cleanup)
-	err = r.redis.ExpireAt(ctx, userTokensKey, expiresAt).Err()
-	if err != nil {
-		return fmt.Errorf("failed to set expiration on user tokens: %w", err)
+	fields := sessionFields(session)
+	fields["user_id"] = session.UserID.String()
+	fields["created_at"] = session.CreatedAt.Format(time.RFC3339Nano)
+	fields["current_hash"] = token.Hash
+	
+	pipe := r.redis.TxPipeline()
+	pipe.Set(ctx, refreshTokenKeyPrefix+token.Hash, data, ttl)
+	pipe.HSet(ctx, sessionKey, fields)
+	pipe.Expire(ctx, sessionKey, ttl)
+	pipe.SAdd(ctx, userSessionsKey, session.ID.String())
+	// Every token lives for the same duration, so the newest session expires last
+	pipe.ExpireAt(ctx, userSessionsKey, token.ExpiresAt)
+	
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
 	}
 	
 	return nil
 }
 
-// GetRefreshToken retrieves a refresh token from Redis
-func (r *RedisAuthRepository) GetRefreshToken(ctx context.Context, tokenID string) (string, error) {
-	refreshTokenKey := refreshTokenKeyPrefix + tokenID
-	token, err := r.redis.Get(ctx, refreshTokenKey).Result()
+// GetRefreshToken retrieves a refresh token by its hash.
+// Rotated tokens are kept until they expire so that their reuse can be detected.
+func (r *RedisAuthRepository) GetRefreshToken(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	data, err := r.redis.Get(ctx, refreshTokenKeyPrefix+hash).Bytes()
 	if err != nil {
-		if err == redis.Nil {
-			return "", fmt.Errorf("refresh token not found")
+		if errors.Is(err, redis.Nil) {
+			return nil, repository.ErrRefreshTokenNotFound
 		}
-		return "", fmt.Errorf("failed to get refresh token: %w", err)
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	
+	token := &entity.RefreshToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("failed to decode refresh token: %w", err)
 	}
 	
 	return token, nil
 }
 
-// DeleteRefreshToken deletes a refresh token from Redis
-func (r *RedisAuthRepository) DeleteRefreshToken(ctx context.Context, tokenID string) error {
-	refreshTokenKey := refreshTokenKeyPrefix + tokenID
-	err := r.redis.Del(ctx, refreshTokenKey).Err()
+// rotateRefreshTokenScript swaps the current token of a session only if it is still the expected one,
+// so two requests presenting the same token cannot both rotate it.
+// It returns 1 when rotated, 0 when the session is gone and -1 when the token was already rotated.
+var rotateRefreshTokenScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'current_hash')
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	return -1
+end
+redis.call('HSET', KEYS[1], 'current_hash', ARGV[2], 'user_agent', ARGV[5], 'ip_address', ARGV[6], 'last_used_at', ARGV[7], 'expires_at', ARGV[8])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+redis.call('SET', KEYS[2], ARGV[3], 'PX', ARGV[4])
+redis.call('PEXPIRE', KEYS[3], ARGV[4])
+return 1
+`)
+
+// RotateRefreshToken makes next the current token of the session in place of current
+// and saves the session's renewed details.
+// It returns ErrRefreshTokenReused if current is no longer the session's latest token
+// and ErrRefreshTokenNotFound if the session has been revoked or has expired.
+func (r *RedisAuthRepository) RotateRefreshToken(ctx context.Context, session *entity.Session, current, next *entity.RefreshToken) error {
+	data, err := json.Marshal(next)
+	if err != nil {
+		return fmt.Errorf("failed to encode refresh token: %w", err)
+	}
+	
+	keys := []string{
+		sessionKeyPrefix + session.ID.String(),
+		refreshTokenKeyPrefix + next.Hash,
+		userSessionsKeyPrefix + session.UserID.String(),
+	}
+	ttl := time.Until(next.ExpiresAt).Milliseconds()
+	fields := sessionFields(session)
+	
+	result, err := rotateRefreshTokenScript.Run(ctx, r.redis, keys,
+		current.Hash, next.Hash, data, ttl,
+		fields["user_agent"], fields["ip_address"], fields["last_used_at"], fields["expires_at"],
+	).Int()
 	if err != nil {
-		return fmt.Errorf("failed to delete refresh token: %w", err)
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
 	}
 	
-	// Note: We don't remove it from the user's token set here for efficiency,
-	// as it would require knowing the userID. The tokens will be cleaned up
-	// when they expire.
+	switch result {
+	case 1:
+		return nil
+	case -1:
+		return repository.ErrRefreshTokenReused
+	default:
+		return repository.ErrRefreshTokenNotFound
+	}
+}
+
+// GetSession retrieves a session by ID
+func (r *RedisAuthRepository) GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	fields, err := r.redis.HGetAll(ctx, sessionKeyPrefix+sessionID.String()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
 	
-	return nil
+	if len(fields) == 0 {
+		return nil, repository.ErrSessionNotFound
+	}
+	
+	return parseSession(sessionID, fields)
 }
 
-// DeleteAllUserRefreshTokens deletes all refresh tokens for a user
-func (r *RedisAuthRepository) DeleteAllUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
-	userTokensKey := userTokensKeyPrefix + userID.String()
+// ListSessions lists the active sessions of a user, most recently used first
+func (r *RedisAuthRepository) ListSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
+	userSessionsKey := userSessionsKeyPrefix + userID.String()
 	
-	// Get all token IDs for this user
-	tokenIDs, err := r.redis.SMembers(ctx, userTokensKey).Result()
+	sessionIDs, err := r.redis.SMembers(ctx, userSessionsKey).Result()
 	if err != nil {
-		return fmt.Errorf("failed to get user tokens: %w", err)
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
 	}
 	
-	// If there are no tokens, we're done
-	if len(tokenIDs) == 0 {
-		return nil
+	if len(sessionIDs) == 0 {
+		return []*entity.Session{}, nil
 	}
 	
-	// Create a pipeline to efficiently delete all tokens
 	pipe := r.redis.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(sessionIDs))
+	for i, sessionID := range sessionIDs {
+		cmds[i] = pipe.HGetAll(ctx, sessionKeyPrefix+sessionID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
 	
-	// Delete each refresh token
-	for _, tokenID := range tokenIDs {
-		refreshTokenKey := refreshTokenKeyPrefix + tokenID
-		pipe.Del(ctx, refreshTokenKey)
+	sessions := make([]*entity.Session, 0, len(sessionIDs))
+	var expired []interface{}
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		
+		// Sessions expire on their own; forget the ones that are gone
+		if len(fields) == 0 {
+			expired = append(expired, sessionIDs[i])
+			continue
+		}
+		
+		sessionID, err := uuid.Parse(sessionIDs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid session ID: %w", err)
+		}
+		
+		session, err := parseSession(sessionID, fields)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	
+	if len(expired) > 0 {
+		if err := r.redis.SRem(ctx, userSessionsKey, expired...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to prune expired sessions: %w", err)
+		}
 	}
 	
-	// Delete the user tokens set
-	pipe.Del(ctx, userTokensKey)
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
 	
-	// Execute the pipeline
-	_, err = pipe.Exec(ctx)
+	return sessions, nil
+}
+
+// DeleteSession revokes a session of a user and its refresh tokens
+func (r *RedisAuthRepository) DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	sessionKey := sessionKeyPrefix + sessionID.String()
+	
+	owner, err := r.redis.HGet(ctx, sessionKey, "user_id").Result()
 	if err != nil {
-		return fmt.Errorf("failed to delete user tokens: %w", err)
+		if errors.Is(err, redis.Nil) {
+			return repository.ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	
+	if owner != userID.String() {
+		return repository.ErrSessionNotFound
+	}
+	
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, sessionKey)
+	pipe.SRem(ctx, userSessionsKeyPrefix+userID.String(), sessionID.String())
+	
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteAllSessions revokes every session of a user and their refresh tokens
+func (r *RedisAuthRepository) DeleteAllSessions(ctx context.Context, userID uuid.UUID) error {
+	userSessionsKey := userSessionsKeyPrefix + userID.String()
+	
+	// Get all sessions of this user
+	sessionIDs, err := r.redis.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get user sessions: %w", err)
+	}
+	
+	// Create a pipeline to efficiently delete all sessions
+	pipe := r.redis.TxPipeline()
+	for _, sessionID := range sessionIDs {
+		pipe.Del(ctx, sessionKeyPrefix+sessionID)
+	}
+	pipe.Del(ctx, userSessionsKey)
+	
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
 	}
 	
 	return nil
@@ -145,4 +292,272 @@ func (r *RedisAuthRepository) RevokeToken(ctx context.Context, tokenID string, e
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// SaveIdentityLink stores a pending identity link until it expires
+func (r *RedisAuthRepository) SaveIdentityLink(ctx context.Context, link *entity.IdentityLink) error {
+	data, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to encode identity link: %w", err)
+	}
+
+	if err := r.redis.Set(ctx, identityLinkKeyPrefix+link.Token, data, time.Until(link.ExpiresAt)).Err(); err != nil {
+		return fmt.Errorf("failed to save identity link: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeIdentityLink retrieves and deletes a pending identity link so it is used once
+func (r *RedisAuthRepository) ConsumeIdentityLink(ctx context.Context, token string) (*entity.IdentityLink, error) {
+	data, err := r.redis.GetDel(ctx, identityLinkKeyPrefix+token).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, repository.ErrIdentityLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get identity link: %w", err)
+	}
+
+	link := &entity.IdentityLink{}
+	if err := json.Unmarshal(data, link); err != nil {
+		return nil, fmt.Errorf("failed to decode identity link: %w", err)
+	}
+
+	return link, nil
+}
+
+// SaveAccountToken stores a single-use account token until it expires, replacing any
+// earlier token of the user for the same purpose
+func (r *RedisAuthRepository) SaveAccountToken(ctx context.Context, token *entity.AccountToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to encode account token: %w", err)
+	}
+
+	userKey := userTokenKeyPrefix + string(token.Purpose) + ":" + token.UserID.String()
+	previous, err := r.redis.Get(ctx, userKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("failed to get account token: %w", err)
+	}
+
+	ttl := time.Until(token.ExpiresAt)
+	pipe := r.redis.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, accountTokenKey(token.Purpose, previous))
+	}
+	pipe.Set(ctx, accountTokenKey(token.Purpose, token.Hash), data, ttl)
+	pipe.Set(ctx, userKey, token.Hash, ttl)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save account token: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeAccountToken retrieves and deletes an account token by its hash so it is used once
+func (r *RedisAuthRepository) ConsumeAccountToken(ctx context.Context, purpose entity.AccountTokenPurpose, hash string) (*entity.AccountToken, error) {
+	data, err := r.redis.GetDel(ctx, accountTokenKey(purpose, hash)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, repository.ErrAccountTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get account token: %w", err)
+	}
+
+	token := &entity.AccountToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("failed to decode account token: %w", err)
+	}
+
+	if err := r.redis.Del(ctx, userTokenKeyPrefix+string(purpose)+":"+token.UserID.String()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete account token: %w", err)
+	}
+
+	return token, nil
+}
+
+// SaveMFAChallenge stores a pending second factor login until it expires
+func (r *RedisAuthRepository) SaveMFAChallenge(ctx context.Context, challenge *entity.MFAChallenge) error {
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return fmt.Errorf("failed to encode mfa challenge: %w", err)
+	}
+
+	if err := r.redis.Set(ctx, mfaChallengeKeyPrefix+challenge.Hash, data, time.Until(challenge.ExpiresAt)).Err(); err != nil {
+		return fmt.Errorf("failed to save mfa challenge: %w", err)
+	}
+
+	return nil
+}
+
+// GetMFAChallenge retrieves a pending second factor login by its hash
+func (r *RedisAuthRepository) GetMFAChallenge(ctx context.Context, hash string) (*entity.MFAChallenge, error) {
+	data, err := r.redis.Get(ctx, mfaChallengeKeyPrefix+hash).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, repository.ErrMFAChallengeNotFound
+		}
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+
+	challenge := &entity.MFAChallenge{}
+	if err := json.Unmarshal(data, challenge); err != nil {
+		return nil, fmt.Errorf("failed to decode mfa challenge: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// RecordMFAChallengeFailure counts a wrong code for a challenge and returns the failures so far
+func (r *RedisAuthRepository) RecordMFAChallengeFailure(ctx context.Context, challenge *entity.MFAChallenge) (int64, error) {
+	key := mfaFailuresKeyPrefix + challenge.Hash
+
+	pipe := r.redis.TxPipeline()
+	failures := pipe.Incr(ctx, key)
+	pipe.ExpireAt(ctx, key, challenge.ExpiresAt)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to record mfa failure: %w", err)
+	}
+
+	return failures.Val(), nil
+}
+
+// DeleteMFAChallenge deletes a pending second factor login
+func (r *RedisAuthRepository) DeleteMFAChallenge(ctx context.Context, hash string) error {
+	if err := r.redis.Del(ctx, mfaChallengeKeyPrefix+hash, mfaFailuresKeyPrefix+hash).Err(); err != nil {
+		return fmt.Errorf("failed to delete mfa challenge: %w", err)
+	}
+
+	return nil
+}
+
+// GetLoginFailures gets the failed logins counted under a key since a time
+func (r *RedisAuthRepository) GetLoginFailures(ctx context.Context, key entity.LoginThrottleKey, since time.Time) (*entity.LoginFailures, error) {
+	failuresKey := loginFailuresKeyPrefix + string(key)
+
+	pipe := r.redis.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, failuresKey, "-inf", "("+strconv.FormatInt(since.UnixMilli(), 10))
+	count := pipe.ZCard(ctx, failuresKey)
+	last := pipe.ZRevRangeWithScores(ctx, failuresKey, 0, 0)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get login failures: %w", err)
+	}
+
+	failures := &entity.LoginFailures{Count: count.Val()}
+	if latest := last.Val(); len(latest) > 0 {
+		failures.LastAt = time.UnixMilli(int64(latest[0].Score))
+	}
+
+	return failures, nil
+}
+
+// RecordLoginFailure counts a failed login under a key, forgets failures older than
+// the window, and returns the failures within it
+func (r *RedisAuthRepository) RecordLoginFailure(ctx context.Context, key entity.LoginThrottleKey, at time.Time, window time.Duration) (*entity.LoginFailures, error) {
+	failuresKey := loginFailuresKeyPrefix + string(key)
+
+	// Failures form a sorted set scored by time, so the window slides with every attempt
+	pipe := r.redis.TxPipeline()
+	pipe.ZAdd(ctx, failuresKey, &redis.Z{Score: float64(at.UnixMilli()), Member: uuid.NewString()})
+	pipe.ZRemRangeByScore(ctx, failuresKey, "-inf", "("+strconv.FormatInt(at.Add(-window).UnixMilli(), 10))
+	count := pipe.ZCard(ctx, failuresKey)
+	pipe.Expire(ctx, failuresKey, window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return &entity.LoginFailures{Count: count.Val(), LastAt: at}, nil
+}
+
+// ClearLoginFailures forgets the failed logins counted under a key
+func (r *RedisAuthRepository) ClearLoginFailures(ctx context.Context, key entity.LoginThrottleKey) error {
+	if err := r.redis.Del(ctx, loginFailuresKeyPrefix+string(key)).Err(); err != nil {
+		return fmt.Errorf("failed to clear login failures: %w", err)
+	}
+
+	return nil
+}
+
+// LockLogin blocks logins counted under a key until a time and starts its failure count over
+func (r *RedisAuthRepository) LockLogin(ctx context.Context, key entity.LoginThrottleKey, until time.Time) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Set(ctx, loginLockKeyPrefix+string(key), until.Format(time.RFC3339Nano), time.Until(until))
+	pipe.Del(ctx, loginFailuresKeyPrefix+string(key))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return nil
+}
+
+// GetLoginLock gets when the lock on a key lifts, or the zero time if it is not locked
+func (r *RedisAuthRepository) GetLoginLock(ctx context.Context, key entity.LoginThrottleKey) (time.Time, error) {
+	value, err := r.redis.Get(ctx, loginLockKeyPrefix+string(key)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get login lock: %w", err)
+	}
+
+	until, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode login lock: %w", err)
+	}
+
+	return until, nil
+}
+
+// UnlockLogin lifts the lock on a key and forgets its failed logins
+func (r *RedisAuthRepository) UnlockLogin(ctx context.Context, key entity.LoginThrottleKey) error {
+	if err := r.redis.Del(ctx, loginLockKeyPrefix+string(key), loginFailuresKeyPrefix+string(key)).Err(); err != nil {
+		return fmt.Errorf("failed to unlock login: %w", err)
+	}
+
+	return nil
+}
+
+// accountTokenKey builds the key of an account token
+func accountTokenKey(purpose entity.AccountTokenPurpose, hash string) string {
+	return accountTokenKeyPrefix + string(purpose) + ":" + hash
+}
+
+// sessionFields encodes the details of a session that change as it is used
+func sessionFields(session *entity.Session) map[string]interface{} {
+	return map[string]interface{}{
+		"user_agent":   session.UserAgent,
+		"ip_address":   session.IPAddress,
+		"last_used_at": session.LastUsedAt.Format(time.RFC3339Nano),
+		"expires_at":   session.ExpiresAt.Format(time.RFC3339Nano),
+	}
+}
+
+// parseSession decodes a session stored as a hash
+func parseSession(sessionID uuid.UUID, fields map[string]string) (*entity.Session, error) {
+	session := &entity.Session{
+		ID:        sessionID,
+		UserAgent: fields["user_agent"],
+		IPAddress: fields["ip_address"],
+	}
+	
+	var err error
+	if session.UserID, err = uuid.Parse(fields["user_id"]); err != nil {
+		return nil, fmt.Errorf("invalid session user ID: %w", err)
+	}
+	if session.CreatedAt, err = time.Parse(time.RFC3339Nano, fields["created_at"]); err != nil {
+		return nil, fmt.Errorf("invalid session creation time: %w", err)
+	}
+	if session.LastUsedAt, err = time.Parse(time.RFC3339Nano, fields["last_used_at"]); err != nil {
+		return nil, fmt.Errorf("invalid session last use time: %w", err)
+	}
+	if session.ExpiresAt, err = time.Parse(time.RFC3339Nano, fields["expires_at"]); err != nil {
+		return nil, fmt.Errorf("invalid session expiration time: %w", err)
+	}
+	
+	return session, nil
+}
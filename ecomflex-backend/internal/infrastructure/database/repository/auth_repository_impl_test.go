@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// testRedisAddrEnv names the Redis server the Redis-backed repository tests run against.
+// The tests are skipped when it is not set; they only write keys of the records they create.
+const testRedisAddrEnv = "ECOMFLEX_TEST_REDIS_ADDR"
+
+// newTestRedisClient connects to the test Redis server
+func newTestRedisClient(t *testing.T) *redis.Client {
+	t.Helper()
+
+	addr := os.Getenv(testRedisAddrEnv)
+	if addr == "" {
+		t.Skipf("%s is not set", testRedisAddrEnv)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatalf("Ping %s: %v", addr, err)
+	}
+
+	return client
+}
+
+func newTestAuthRepository(t *testing.T) *RedisAuthRepository {
+	t.Helper()
+
+	return NewRedisAuthRepository(newTestRedisClient(t)).(*RedisAuthRepository)
+}
+
+// newTestSession signs in a new user and returns the session with its first refresh token
+func newTestSession(t *testing.T, repo *RedisAuthRepository) (*entity.Session, *entity.RefreshToken) {
+	t.Helper()
+
+	token := entity.NewRefreshToken(uuid.NewString(), uuid.New(), time.Now().Add(time.Hour))
+	session := entity.NewSession(token, "test-agent", "203.0.113.7")
+	if err := repo.CreateSession(context.Background(), session, token); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	t.Cleanup(func() { repo.DeleteAllSessions(context.Background(), session.UserID) })
+
+	return session, token
+}
+
+func TestRedisAuthRepositoryRotateRefreshToken(t *testing.T) {
+	repo := newTestAuthRepository(t)
+
+	type rotation struct {
+		from    int // index of the presented token among those issued so far
+		wantErr error
+	}
+
+	tests := []struct {
+		name      string
+		revoke    bool // sign the session out before rotating
+		rotations []rotation
+	}{
+		{
+			name:      "current token",
+			rotations: []rotation{{from: 0}},
+		},
+		{
+			name:      "successive rotations",
+			rotations: []rotation{{from: 0}, {from: 1}, {from: 2}},
+		},
+		{
+			name:      "rotated token presented again",
+			rotations: []rotation{{from: 0}, {from: 0, wantErr: repository.ErrRefreshTokenReused}},
+		},
+		{
+			name:      "older ancestor presented again",
+			rotations: []rotation{{from: 0}, {from: 1}, {from: 0, wantErr: repository.ErrRefreshTokenReused}},
+		},
+		{
+			name:      "revoked session",
+			revoke:    true,
+			rotations: []rotation{{from: 0, wantErr: repository.ErrRefreshTokenNotFound}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			session, first := newTestSession(t, repo)
+			if tt.revoke {
+				if err := repo.DeleteSession(ctx, session.UserID, session.ID); err != nil {
+					t.Fatalf("DeleteSession: %v", err)
+				}
+			}
+
+			issued := []*entity.RefreshToken{first}
+			for i, r := range tt.rotations {
+				next := issued[r.from].Rotate(uuid.NewString(), time.Now().Add(time.Hour))
+				session.Touch(next, "", "")
+
+				err := repo.RotateRefreshToken(ctx, session, issued[r.from], next)
+				if !errors.Is(err, r.wantErr) {
+					t.Fatalf("rotation %d: RotateRefreshToken() error = %v, want %v", i, err, r.wantErr)
+				}
+
+				_, lookupErr := repo.GetRefreshToken(ctx, next.Hash)
+				if r.wantErr == nil {
+					if lookupErr != nil {
+						t.Fatalf("rotation %d: GetRefreshToken(next) error = %v", i, lookupErr)
+					}
+					issued = append(issued, next)
+				} else if !errors.Is(lookupErr, repository.ErrRefreshTokenNotFound) {
+					t.Fatalf("rotation %d: GetRefreshToken(next) error = %v, want %v", i, lookupErr, repository.ErrRefreshTokenNotFound)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisAuthRepositoryRotateRefreshTokenConcurrently(t *testing.T) {
+	repo := newTestAuthRepository(t)
+	session, current := newTestSession(t, repo)
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			attempt := *session
+			next := current.Rotate(uuid.NewString(), time.Now().Add(time.Hour))
+			errs[i] = repo.RotateRefreshToken(context.Background(), &attempt, current, next)
+		}(i)
+	}
+	wg.Wait()
+
+	rotated := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			rotated++
+		case !errors.Is(err, repository.ErrRefreshTokenReused):
+			t.Errorf("attempt %d: RotateRefreshToken() error = %v, want nil or %v", i, err, repository.ErrRefreshTokenReused)
+		}
+	}
+	if rotated != 1 {
+		t.Errorf("%d of %d concurrent rotations succeeded, want exactly 1", rotated, attempts)
+	}
+}
+
+func TestRedisAuthRepositoryDeleteSession(t *testing.T) {
+	repo := newTestAuthRepository(t)
+	ctx := context.Background()
+
+	session, _ := newTestSession(t, repo)
+	token := entity.NewRefreshToken(uuid.NewString(), session.UserID, time.Now().Add(time.Hour))
+	other := entity.NewSession(token, "phone", "198.51.100.9")
+	if err := repo.CreateSession(ctx, other, token); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	sessions, err := repo.ListSessions(ctx, session.UserID)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("listed %d sessions, want 2", len(sessions))
+	}
+
+	if err := repo.DeleteSession(ctx, uuid.New(), session.ID); !errors.Is(err, repository.ErrSessionNotFound) {
+		t.Fatalf("DeleteSession() of another user's session: error = %v, want %v", err, repository.ErrSessionNotFound)
+	}
+	if err := repo.DeleteSession(ctx, session.UserID, session.ID); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if _, err := repo.GetSession(ctx, session.ID); !errors.Is(err, repository.ErrSessionNotFound) {
+		t.Fatalf("GetSession() of a revoked session: error = %v, want %v", err, repository.ErrSessionNotFound)
+	}
+
+	sessions, err = repo.ListSessions(ctx, session.UserID)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != other.ID {
+		t.Fatalf("listed %v, want only the session that was kept", sessions)
+	}
+}
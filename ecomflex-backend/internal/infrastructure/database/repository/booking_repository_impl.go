@@ -0,0 +1,386 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const bookingColumns = `
+	id, tenant_id, user_id, product_id, referral_code, status, proof_url,
+	rejection_reason, cashback_status, cashback_amount, created_at, updated_at, deleted_at
+`
+
+// PostgresBookingRepository implements BookingRepository interface using PostgreSQL
+type PostgresBookingRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresBookingRepository creates a new PostgresBookingRepository
+func NewPostgresBookingRepository(db *sqlx.DB) repository.BookingRepository {
+	return &PostgresBookingRepository{
+		db: db,
+	}
+}
+
+// CreateBooking creates a new booking and atomically claims one of the product's slots
+func (r *PostgresBookingRepository) CreateBooking(ctx context.Context, booking *entity.Booking) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Claim a slot with a conditional update; the row lock serialises concurrent claims.
+	// The product is closed automatically once its last slot is taken.
+	reserveQuery := `
+		UPDATE products
+		SET
+			current_bookings = current_bookings + 1,
+			is_active = current_bookings + 1 < required_bookings,
+			auto_closed = current_bookings + 1 >= required_bookings,
+			updated_at = NOW()
+		WHERE id = $1 AND is_active = true AND current_bookings < required_bookings
+	`
+
+	result, err := tx.ExecContext(ctx, reserveQuery, booking.ProductID)
+	if err != nil {
+		return fmt.Errorf("failed to reserve booking slot: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNoSlotsAvailable
+	}
+
+	insertQuery := `
+		INSERT INTO bookings (
+			id, tenant_id, user_id, product_id, referral_code, status, proof_url,
+			rejection_reason, cashback_status, cashback_amount, created_at, updated_at
+		) VALUES (
+			:id, :tenant_id, :user_id, :product_id, :referral_code, :status, :proof_url,
+			:rejection_reason, :cashback_status, :cashback_amount, :created_at, :updated_at
+		)
+	`
+
+	if _, err := tx.NamedExecContext(ctx, insertQuery, booking); err != nil {
+		return fmt.Errorf("failed to create booking: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit booking: %w", err)
+	}
+
+	return nil
+}
+
+// GetBookingByID retrieves a booking by ID
+func (r *PostgresBookingRepository) GetBookingByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE id = $1 AND deleted_at IS NULL`
+
+	booking := &entity.Booking{}
+	err := r.db.GetContext(ctx, booking, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+// UpdateBooking persists a booking that left fromStatus
+func (r *PostgresBookingRepository) UpdateBooking(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus) error {
+	// Guard on the previous status so concurrent decisions cannot both apply
+	query := `
+		UPDATE bookings
+		SET
+			status = $1,
+			proof_url = $2,
+			rejection_reason = $3,
+			cashback_status = $4,
+			updated_at = $5
+		WHERE id = $6 AND status = $7 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		booking.Status, booking.ProofURL, booking.RejectionReason, booking.CashbackStatus, booking.UpdatedAt,
+		booking.ID, fromStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrInvalidBookingTransition
+	}
+
+	return nil
+}
+
+// CreditCashback marks the cashback of an approved booking as paid and posts its
+// wallet entries in one transaction
+func (r *PostgresBookingRepository) CreditCashback(ctx context.Context, booking *entity.Booking, entries []*entity.LedgerEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Guard on the unpaid status so the cashback is credited once
+	query := `
+		UPDATE bookings
+		SET cashback_status = $1, updated_at = $2
+		WHERE id = $3 AND status = 'approved' AND cashback_status = 'not_paid' AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, query, booking.CashbackStatus, booking.UpdatedAt, booking.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrInvalidBookingTransition
+	}
+
+	if err := insertLedgerEntries(ctx, tx, entries); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit cashback: %w", err)
+	}
+
+	return nil
+}
+
+// GetPendingCashback sums the cashback of a user's bookings that is not yet released
+func (r *PostgresBookingRepository) GetPendingCashback(ctx context.Context, userID uuid.UUID) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(cashback_amount), 0)
+		FROM bookings
+		WHERE user_id = $1 AND status IN ('pending', 'approved') AND cashback_status = 'not_paid' AND deleted_at IS NULL
+	`
+
+	var pending float64
+	if err := r.db.GetContext(ctx, &pending, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to get pending cashback: %w", err)
+	}
+
+	return pending, nil
+}
+
+// ReleaseBooking persists a booking that left fromStatus, withdraws its undecided proof
+// and returns its slot to the product
+func (r *PostgresBookingRepository) ReleaseBooking(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Guard on the previous status so a slot is never released twice
+	updateQuery := `
+		UPDATE bookings
+		SET status = $1, rejection_reason = $2, updated_at = $3, deleted_at = $4
+		WHERE id = $5 AND status = $6 AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, updateQuery,
+		booking.Status, booking.RejectionReason, booking.UpdatedAt, booking.DeletedAt,
+		booking.ID, fromStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrInvalidBookingTransition
+	}
+
+	// A proof nobody decided on leaves the moderation queue with its booking
+	proofQuery := `
+		UPDATE booking_proofs
+		SET status = $1, updated_at = $2
+		WHERE booking_id = $3 AND status IN ('submitted', 'in_review')
+	`
+
+	if _, err := tx.ExecContext(ctx, proofQuery, entity.ProofStatusWithdrawn, booking.UpdatedAt, booking.ID); err != nil {
+		return fmt.Errorf("failed to withdraw proof: %w", err)
+	}
+
+	if err := releaseSlots(ctx, tx, booking.ProductID, 1); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit booking release: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireStaleBookings expires initiated bookings created before a cutoff, rejects their referrals
+// and releases their slots
+func (r *PostgresBookingRepository) ExpireStaleBookings(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		WITH expired AS (
+			UPDATE bookings
+			SET status = 'expired', updated_at = NOW()
+			WHERE status = 'initiated' AND created_at < $1 AND deleted_at IS NULL
+			RETURNING id, product_id
+		), referrals_rejected AS (
+			UPDATE referrals
+			SET status = 'rejected', updated_at = NOW()
+			WHERE booking_id IN (SELECT id FROM expired) AND status = 'pending'
+			RETURNING id
+		), counts AS (
+			SELECT product_id, COUNT(*) AS released FROM expired GROUP BY product_id
+		), products_released AS (
+			UPDATE products p
+			SET
+				current_bookings = GREATEST(p.current_bookings - c.released, 0),
+				is_active = p.is_active OR p.auto_closed,
+				auto_closed = false,
+				updated_at = NOW()
+			FROM counts c
+			WHERE p.id = c.product_id
+			RETURNING p.id
+		)
+		SELECT COALESCE(SUM(released), 0) FROM counts
+	`
+
+	var expired int
+	if err := r.db.GetContext(ctx, &expired, query, before); err != nil {
+		return 0, fmt.Errorf("failed to expire stale bookings: %w", err)
+	}
+
+	return expired, nil
+}
+
+// releaseSlots returns booking slots to a product, reopening it if it was closed for being full
+func releaseSlots(ctx context.Context, tx *sqlx.Tx, productID uuid.UUID, count int) error {
+	query := `
+		UPDATE products
+		SET
+			current_bookings = GREATEST(current_bookings - $1, 0),
+			is_active = is_active OR auto_closed,
+			auto_closed = false,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, query, count, productID); err != nil {
+		return fmt.Errorf("failed to release booking slot: %w", err)
+	}
+
+	return nil
+}
+
+// GetBookingsByUser retrieves all bookings of a user
+func (r *PostgresBookingRepository) GetBookingsByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	bookings := []*entity.Booking{}
+	if err := r.db.SelectContext(ctx, &bookings, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// ListBookings retrieves bookings matching a filter with the total count
+func (r *PostgresBookingRepository) ListBookings(ctx context.Context, filter repository.BookingFilter) ([]*entity.Booking, int, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := []interface{}{}
+
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.ProductID != "" {
+		args = append(args, filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("product_id = $%d", len(args)))
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	// Count total bookings matching the filter
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM bookings WHERE "+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count bookings: %w", err)
+	}
+
+	query := `SELECT ` + bookingColumns + ` FROM bookings WHERE ` + where + ` ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	bookings := []*entity.Booking{}
+	if err := r.db.SelectContext(ctx, &bookings, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list bookings: %w", err)
+	}
+
+	return bookings, total, nil
+}
+
+// HasOpenBooking checks if a user already holds a booking slot for a product
+func (r *PostgresBookingRepository) HasOpenBooking(ctx context.Context, userID, productID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM bookings
+			WHERE user_id = $1 AND product_id = $2
+			  AND status NOT IN ('rejected', 'expired') AND deleted_at IS NULL
+		)
+	`
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, userID, productID); err != nil {
+		return false, fmt.Errorf("failed to check open booking: %w", err)
+	}
+
+	return exists, nil
+}
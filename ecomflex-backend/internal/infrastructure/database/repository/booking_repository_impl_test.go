@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	domainRepo "github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+func TestBookingRepositoryReleaseBookingWithdrawsProof(t *testing.T) {
+	db, gormDB := newTestDatabase(t)
+	products := NewProductRepository(gormDB)
+	bookings := NewPostgresBookingRepository(db)
+	proofs := NewPostgresProofRepository(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		submit    bool
+		claim     bool
+		wantProof entity.ProofStatus
+	}{
+		{name: "initiated booking"},
+		{name: "pending booking", submit: true, wantProof: entity.ProofStatusWithdrawn},
+		{name: "pending booking in review", submit: true, claim: true, wantProof: entity.ProofStatusWithdrawn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, product := newTestProduct(t, db, products)
+
+			booking := entity.NewBooking(product.TenantID, user.ID, product.ID, "")
+			if err := bookings.CreateBooking(ctx, booking); err != nil {
+				t.Fatalf("CreateBooking: %v", err)
+			}
+
+			var proof *entity.Proof
+			if tt.submit {
+				if err := booking.SubmitProof("https://example.com/proof.png"); err != nil {
+					t.Fatalf("SubmitProof: %v", err)
+				}
+				proof = entity.NewProof(booking, "", booking.ProofURL, "111-2222222-3333333", nil)
+				if err := proofs.SubmitProof(ctx, booking, proof); err != nil {
+					t.Fatalf("SubmitProof: %v", err)
+				}
+			}
+			if tt.claim {
+				if err := proof.Claim(user.ID); err != nil {
+					t.Fatalf("Claim: %v", err)
+				}
+				if err := proofs.ClaimProof(ctx, proof, booking.CreatedAt); err != nil {
+					t.Fatalf("ClaimProof: %v", err)
+				}
+			}
+
+			fromStatus := booking.Status
+			if err := booking.Cancel(); err != nil {
+				t.Fatalf("Cancel: %v", err)
+			}
+			if err := bookings.ReleaseBooking(ctx, booking, fromStatus); err != nil {
+				t.Fatalf("ReleaseBooking() error = %v", err)
+			}
+
+			released, err := products.FindByID(ctx, product.ID.String())
+			if err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			if released.CurrentBookings != 0 {
+				t.Errorf("current bookings = %d, want the slot released", released.CurrentBookings)
+			}
+
+			if proof == nil {
+				return
+			}
+			stored, err := proofs.GetProofByID(ctx, proof.ID)
+			if err != nil {
+				t.Fatalf("GetProofByID: %v", err)
+			}
+			if stored.Status != tt.wantProof {
+				t.Errorf("proof status = %s, want %s", stored.Status, tt.wantProof)
+			}
+		})
+	}
+}
+
+func TestBookingRepositoryCreateBookingClaimsSlots(t *testing.T) {
+	db, gormDB := newTestDatabase(t)
+	products := NewProductRepository(gormDB)
+	bookings := NewPostgresBookingRepository(db)
+	ctx := context.Background()
+
+	user, product := newTestProduct(t, db, products)
+
+	// Claim more slots than the product has at once; the surplus must be refused
+	attempts := product.RequiredBookings * 2
+	created := make(chan *entity.Booking, attempts)
+	errs := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			booking := entity.NewBooking(product.TenantID, user.ID, product.ID, "")
+			if err := bookings.CreateBooking(ctx, booking); err != nil {
+				errs <- err
+				return
+			}
+			created <- booking
+		}()
+	}
+	wg.Wait()
+	close(created)
+	close(errs)
+
+	if len(created) != product.RequiredBookings {
+		t.Fatalf("created %d bookings, want %d", len(created), product.RequiredBookings)
+	}
+	for err := range errs {
+		if !errors.Is(err, domainRepo.ErrNoSlotsAvailable) {
+			t.Fatalf("CreateBooking() error = %v, want %v", err, domainRepo.ErrNoSlotsAvailable)
+		}
+	}
+
+	full, err := products.FindByID(ctx, product.ID.String())
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if full.CurrentBookings != product.RequiredBookings || full.IsActive || !full.AutoClosed {
+		t.Fatalf("full product: current bookings = %d, active = %t, auto closed = %t, want %d, closed automatically",
+			full.CurrentBookings, full.IsActive, full.AutoClosed, product.RequiredBookings)
+	}
+
+	booking := <-created
+	fromStatus := booking.Status
+	if err := booking.Cancel(); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if err := bookings.ReleaseBooking(ctx, booking, fromStatus); err != nil {
+		t.Fatalf("ReleaseBooking() error = %v", err)
+	}
+	if err := bookings.ReleaseBooking(ctx, booking, fromStatus); !errors.Is(err, entity.ErrInvalidBookingTransition) {
+		t.Fatalf("releasing twice: error = %v, want %v", err, entity.ErrInvalidBookingTransition)
+	}
+
+	reopened, err := products.FindByID(ctx, product.ID.String())
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if reopened.CurrentBookings != product.RequiredBookings-1 || !reopened.IsActive || reopened.AutoClosed {
+		t.Fatalf("reopened product: current bookings = %d, active = %t, auto closed = %t, want %d, open again",
+			reopened.CurrentBookings, reopened.IsActive, reopened.AutoClosed, product.RequiredBookings-1)
+	}
+}
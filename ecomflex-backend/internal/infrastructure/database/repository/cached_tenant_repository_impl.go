@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const (
+	// Keys for Redis
+	tenantIDKeyPrefix     = "tenant:id:"
+	tenantDomainKeyPrefix = "tenant:domain:"
+
+	// tenantNotFound is cached for lookups that found no tenant
+	tenantNotFound = "-"
+)
+
+// CachedTenantRepository implements TenantRepository interface by caching another TenantRepository in Redis.
+// Every request looks its tenant up, so lookups are cached; writes drop the cached entries they affect.
+type CachedTenantRepository struct {
+	repo        repository.TenantRepository
+	redis       *redis.Client
+	ttl         time.Duration
+	notFoundTTL time.Duration
+}
+
+// NewCachedTenantRepository creates a new CachedTenantRepository.
+// Tenants are cached for ttl; lookups that find nothing are cached for notFoundTTL.
+func NewCachedTenantRepository(repo repository.TenantRepository, redis *redis.Client, ttl, notFoundTTL time.Duration) repository.TenantRepository {
+	return &CachedTenantRepository{
+		repo:        repo,
+		redis:       redis,
+		ttl:         ttl,
+		notFoundTTL: notFoundTTL,
+	}
+}
+
+// CreateTenant creates a new tenant
+func (r *CachedTenantRepository) CreateTenant(ctx context.Context, tenant *entity.Tenant) error {
+	if err := r.repo.CreateTenant(ctx, tenant); err != nil {
+		return err
+	}
+
+	// The domain may have been remembered as unknown
+	return r.invalidate(ctx, tenant.ID, tenant.Domain)
+}
+
+// GetTenantByID retrieves a tenant by ID
+func (r *CachedTenantRepository) GetTenantByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	return r.get(ctx, tenantIDKeyPrefix+id.String(), func() (*entity.Tenant, error) {
+		return r.repo.GetTenantByID(ctx, id)
+	})
+}
+
+// GetTenantByDomain retrieves a tenant by domain
+func (r *CachedTenantRepository) GetTenantByDomain(ctx context.Context, domain string) (*entity.Tenant, error) {
+	return r.get(ctx, tenantDomainKeyPrefix+domain, func() (*entity.Tenant, error) {
+		return r.repo.GetTenantByDomain(ctx, domain)
+	})
+}
+
+// ListTenants retrieves the tenants matching a filter; lists are not cached
+func (r *CachedTenantRepository) ListTenants(ctx context.Context, filter repository.TenantFilter) ([]*entity.Tenant, int, error) {
+	return r.repo.ListTenants(ctx, filter)
+}
+
+// UpdateTenant updates a tenant
+func (r *CachedTenantRepository) UpdateTenant(ctx context.Context, tenant *entity.Tenant) error {
+	// The tenant may be cached under the domain it is moving away from
+	previous, err := r.repo.GetTenantByID(ctx, tenant.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.UpdateTenant(ctx, tenant); err != nil {
+		return err
+	}
+
+	return r.invalidate(ctx, tenant.ID, previous.Domain, tenant.Domain)
+}
+
+// DeleteTenant marks a tenant as deleted
+func (r *CachedTenantRepository) DeleteTenant(ctx context.Context, id uuid.UUID) error {
+	previous, err := r.repo.GetTenantByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.DeleteTenant(ctx, id); err != nil {
+		return err
+	}
+
+	return r.invalidate(ctx, id, previous.Domain)
+}
+
+// get returns the tenant cached under key, loading and caching it on a miss.
+// Redis failures fall back to loading the tenant so requests are not refused while the cache is down.
+func (r *CachedTenantRepository) get(ctx context.Context, key string, load func() (*entity.Tenant, error)) (*entity.Tenant, error) {
+	data, err := r.redis.Get(ctx, key).Bytes()
+	if err == nil {
+		if string(data) == tenantNotFound {
+			return nil, repository.ErrTenantNotFound
+		}
+
+		tenant := &entity.Tenant{}
+		if err := json.Unmarshal(data, tenant); err == nil {
+			return tenant, nil
+		}
+	}
+
+	tenant, err := load()
+	if err != nil {
+		if errors.Is(err, repository.ErrTenantNotFound) {
+			r.redis.Set(ctx, key, tenantNotFound, r.notFoundTTL)
+		}
+		return nil, err
+	}
+
+	if data, err := json.Marshal(tenant); err == nil {
+		r.redis.Set(ctx, key, data, r.ttl)
+	}
+
+	return tenant, nil
+}
+
+// invalidate drops the cached entries of a tenant
+func (r *CachedTenantRepository) invalidate(ctx context.Context, id uuid.UUID, domains ...string) error {
+	keys := []string{tenantIDKeyPrefix + id.String()}
+	for _, domain := range domains {
+		if domain != "" {
+			keys = append(keys, tenantDomainKeyPrefix+domain)
+		}
+	}
+
+	if err := r.redis.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate cached tenant: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	domainRepo "github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// countingTenantRepo keeps tenants in memory and counts the lookups that reach it
+type countingTenantRepo struct {
+	domainRepo.TenantRepository
+	tenants map[uuid.UUID]*entity.Tenant
+	lookups int
+}
+
+func (r *countingTenantRepo) GetTenantByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	r.lookups++
+	tenant, ok := r.tenants[id]
+	if !ok {
+		return nil, domainRepo.ErrTenantNotFound
+	}
+	stored := *tenant
+	return &stored, nil
+}
+
+func (r *countingTenantRepo) GetTenantByDomain(ctx context.Context, domain string) (*entity.Tenant, error) {
+	r.lookups++
+	for _, tenant := range r.tenants {
+		if tenant.Domain == domain {
+			stored := *tenant
+			return &stored, nil
+		}
+	}
+	return nil, domainRepo.ErrTenantNotFound
+}
+
+func (r *countingTenantRepo) UpdateTenant(ctx context.Context, tenant *entity.Tenant) error {
+	stored := *tenant
+	r.tenants[tenant.ID] = &stored
+	return nil
+}
+
+func TestCachedTenantRepositoryCachesLookups(t *testing.T) {
+	client := newTestRedisClient(t)
+	ctx := context.Background()
+
+	tenant := entity.NewTenant("Cache Test", uuid.NewString()+".test", "free")
+	unknownDomain := uuid.NewString() + ".test"
+	t.Cleanup(func() {
+		client.Del(ctx, tenantIDKeyPrefix+tenant.ID.String(), tenantDomainKeyPrefix+tenant.Domain, tenantDomainKeyPrefix+unknownDomain)
+	})
+
+	backing := &countingTenantRepo{tenants: map[uuid.UUID]*entity.Tenant{tenant.ID: tenant}}
+	repo := NewCachedTenantRepository(backing, client, time.Minute, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		found, err := repo.GetTenantByDomain(ctx, tenant.Domain)
+		if err != nil {
+			t.Fatalf("GetTenantByDomain() error = %v", err)
+		}
+		if found.ID != tenant.ID {
+			t.Fatalf("GetTenantByDomain() = %s, want %s", found.ID, tenant.ID)
+		}
+		if _, err := repo.GetTenantByDomain(ctx, unknownDomain); !errors.Is(err, domainRepo.ErrTenantNotFound) {
+			t.Fatalf("GetTenantByDomain() of an unknown domain: error = %v, want %v", err, domainRepo.ErrTenantNotFound)
+		}
+	}
+	if backing.lookups != 2 {
+		t.Fatalf("%d lookups reached the database, want 2", backing.lookups)
+	}
+
+	// Deactivating the tenant must not leave the active tenant cached
+	tenant.Deactivate()
+	if err := repo.UpdateTenant(ctx, tenant); err != nil {
+		t.Fatalf("UpdateTenant() error = %v", err)
+	}
+	found, err := repo.GetTenantByDomain(ctx, tenant.Domain)
+	if err != nil {
+		t.Fatalf("GetTenantByDomain() after an update: %v", err)
+	}
+	if found.IsActive {
+		t.Fatalf("GetTenantByDomain() after deactivating = active tenant, want the update seen")
+	}
+}
+
+func TestCachedTenantRepositoryWithoutRedis(t *testing.T) {
+	// Nothing listens on the discard port, so every Redis call fails
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:9", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { client.Close() })
+
+	tenant := entity.NewTenant("Cache Test", "acme.test", "free")
+	backing := &countingTenantRepo{tenants: map[uuid.UUID]*entity.Tenant{tenant.ID: tenant}}
+	repo := NewCachedTenantRepository(backing, client, time.Minute, time.Minute)
+
+	found, err := repo.GetTenantByID(context.Background(), tenant.ID)
+	if err != nil {
+		t.Fatalf("GetTenantByID() error = %v, want the tenant loaded while the cache is down", err)
+	}
+	if found.ID != tenant.ID {
+		t.Fatalf("GetTenantByID() = %s, want %s", found.ID, tenant.ID)
+	}
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const commissionRuleColumns = `
+	id, name, tenant_id, product_id, source, amount_type, amount, min_approved_referrals,
+	starts_at, ends_at, is_active, created_at, updated_at
+`
+
+// PostgresCommissionRuleRepository implements CommissionRuleRepository interface using PostgreSQL
+type PostgresCommissionRuleRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresCommissionRuleRepository creates a new PostgresCommissionRuleRepository
+func NewPostgresCommissionRuleRepository(db *sqlx.DB) repository.CommissionRuleRepository {
+	return &PostgresCommissionRuleRepository{
+		db: db,
+	}
+}
+
+// CreateRule creates a new commission rule
+func (r *PostgresCommissionRuleRepository) CreateRule(ctx context.Context, rule *entity.CommissionRule) error {
+	query := `
+		INSERT INTO commission_rules (` + commissionRuleColumns + `) VALUES (
+			:id, :name, :tenant_id, :product_id, :source, :amount_type, :amount, :min_approved_referrals,
+			:starts_at, :ends_at, :is_active, :created_at, :updated_at
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, rule); err != nil {
+		return fmt.Errorf("failed to create commission rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetRuleByID retrieves a commission rule by ID
+func (r *PostgresCommissionRuleRepository) GetRuleByID(ctx context.Context, id uuid.UUID) (*entity.CommissionRule, error) {
+	query := `SELECT ` + commissionRuleColumns + ` FROM commission_rules WHERE id = $1`
+
+	rule := &entity.CommissionRule{}
+	if err := r.db.GetContext(ctx, rule, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrCommissionRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get commission rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// UpdateRule updates a commission rule
+func (r *PostgresCommissionRuleRepository) UpdateRule(ctx context.Context, rule *entity.CommissionRule) error {
+	query := `
+		UPDATE commission_rules
+		SET
+			name = :name,
+			tenant_id = :tenant_id,
+			product_id = :product_id,
+			source = :source,
+			amount_type = :amount_type,
+			amount = :amount,
+			min_approved_referrals = :min_approved_referrals,
+			starts_at = :starts_at,
+			ends_at = :ends_at,
+			is_active = :is_active,
+			updated_at = :updated_at
+		WHERE id = :id
+	`
+
+	result, err := r.db.NamedExecContext(ctx, query, rule)
+	if err != nil {
+		return fmt.Errorf("failed to update commission rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrCommissionRuleNotFound
+	}
+
+	return nil
+}
+
+// DeleteRule deletes a commission rule; referrals keep their snapshot of it
+func (r *PostgresCommissionRuleRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM commission_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete commission rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrCommissionRuleNotFound
+	}
+
+	return nil
+}
+
+// ListRules retrieves all commission rules
+func (r *PostgresCommissionRuleRepository) ListRules(ctx context.Context) ([]*entity.CommissionRule, error) {
+	query := `SELECT ` + commissionRuleColumns + ` FROM commission_rules ORDER BY created_at DESC`
+
+	rules := []*entity.CommissionRule{}
+	if err := r.db.SelectContext(ctx, &rules, query); err != nil {
+		return nil, fmt.Errorf("failed to list commission rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// FindCandidateRules retrieves active rules scoped to a tenant, a product or nothing that are valid at a time
+func (r *PostgresCommissionRuleRepository) FindCandidateRules(ctx context.Context, tenantID uuid.UUID, productID *uuid.UUID, at time.Time) ([]*entity.CommissionRule, error) {
+	query := `
+		SELECT ` + commissionRuleColumns + `
+		FROM commission_rules
+		WHERE is_active = true
+		  AND (tenant_id IS NULL OR tenant_id = $1)
+		  AND (product_id IS NULL OR product_id = $2)
+		  AND (starts_at IS NULL OR starts_at <= $3)
+		  AND (ends_at IS NULL OR ends_at > $3)
+	`
+
+	rules := []*entity.CommissionRule{}
+	if err := r.db.SelectContext(ctx, &rules, query, tenantID, productID, at); err != nil {
+		return nil, fmt.Errorf("failed to find commission rules: %w", err)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// emailTemplateColumns lists the columns selected for email templates
+const emailTemplateColumns = `tenant_id, name, subject, html_body, text_body, created_at, updated_at`
+
+// PostgresEmailTemplateRepository implements EmailTemplateRepository interface using PostgreSQL
+type PostgresEmailTemplateRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresEmailTemplateRepository creates a new PostgresEmailTemplateRepository
+func NewPostgresEmailTemplateRepository(db *sqlx.DB) repository.EmailTemplateRepository {
+	return &PostgresEmailTemplateRepository{
+		db: db,
+	}
+}
+
+// GetEmailTemplate retrieves a tenant's override of an email template
+func (r *PostgresEmailTemplateRepository) GetEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName) (*entity.EmailTemplate, error) {
+	query := `SELECT ` + emailTemplateColumns + ` FROM email_templates WHERE tenant_id = $1 AND name = $2`
+
+	template := &entity.EmailTemplate{}
+	if err := r.db.GetContext(ctx, template, query, tenantID, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrEmailTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get email template: %w", err)
+	}
+
+	return template, nil
+}
+
+// ListEmailTemplates retrieves all email template overrides of a tenant
+func (r *PostgresEmailTemplateRepository) ListEmailTemplates(ctx context.Context, tenantID uuid.UUID) ([]*entity.EmailTemplate, error) {
+	query := `SELECT ` + emailTemplateColumns + ` FROM email_templates WHERE tenant_id = $1 ORDER BY name`
+
+	templates := []*entity.EmailTemplate{}
+	if err := r.db.SelectContext(ctx, &templates, query, tenantID); err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// SaveEmailTemplate creates or replaces a tenant's override of an email template
+func (r *PostgresEmailTemplateRepository) SaveEmailTemplate(ctx context.Context, template *entity.EmailTemplate) error {
+	query := `
+		INSERT INTO email_templates (` + emailTemplateColumns + `)
+		VALUES (:tenant_id, :name, :subject, :html_body, :text_body, :created_at, :updated_at)
+		ON CONFLICT (tenant_id, name) DO UPDATE SET
+			subject = EXCLUDED.subject,
+			html_body = EXCLUDED.html_body,
+			text_body = EXCLUDED.text_body,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, template); err != nil {
+		return fmt.Errorf("failed to save email template: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEmailTemplate removes a tenant's override so the built-in template is used again
+func (r *PostgresEmailTemplateRepository) DeleteEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM email_templates WHERE tenant_id = $1 AND name = $2`, tenantID, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete email template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrEmailTemplateNotFound
+	}
+
+	return nil
+}
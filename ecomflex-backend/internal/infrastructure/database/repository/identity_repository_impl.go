@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// PostgresIdentityRepository implements IdentityRepository interface using PostgreSQL
+type PostgresIdentityRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresIdentityRepository creates a new PostgresIdentityRepository
+func NewPostgresIdentityRepository(db *sqlx.DB) repository.IdentityRepository {
+	return &PostgresIdentityRepository{
+		db: db,
+	}
+}
+
+// GetIdentity retrieves the identity with a subject at a provider
+func (r *PostgresIdentityRepository) GetIdentity(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	identity := &entity.UserIdentity{}
+	if err := r.db.GetContext(ctx, identity, query, provider, subject); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// CreateIdentity links an identity to an existing user
+func (r *PostgresIdentityRepository) CreateIdentity(ctx context.Context, identity *entity.UserIdentity) error {
+	return insertIdentity(ctx, r.db, identity)
+}
+
+// CreateUserWithIdentity creates a user and links an identity to them in one transaction
+func (r *PostgresIdentityRepository) CreateUserWithIdentity(ctx context.Context, user *entity.User, identity *entity.UserIdentity) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	if err := insertIdentity(ctx, tx, identity); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user: %w", err)
+	}
+
+	return nil
+}
+
+// insertIdentity inserts an identity with the given database or transaction
+func insertIdentity(ctx context.Context, db sqlx.ExtContext, identity *entity.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+		VALUES (:id, :user_id, :provider, :subject, :email, :created_at)
+	`
+
+	if _, err := sqlx.NamedExecContext(ctx, db, query, identity); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return repository.ErrIdentityAlreadyLinked
+		}
+		return fmt.Errorf("failed to create identity: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const impersonationColumns = `
+	id, impersonator_id, user_id, tenant_id, reason, token_id, ip_address, user_agent,
+	started_at, expires_at, ended_at
+`
+
+// PostgresImpersonationRepository implements ImpersonationRepository interface using PostgreSQL
+type PostgresImpersonationRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresImpersonationRepository creates a new PostgresImpersonationRepository
+func NewPostgresImpersonationRepository(db *sqlx.DB) repository.ImpersonationRepository {
+	return &PostgresImpersonationRepository{
+		db: db,
+	}
+}
+
+// CreateImpersonation records the start of an impersonation
+func (r *PostgresImpersonationRepository) CreateImpersonation(ctx context.Context, impersonation *entity.Impersonation) error {
+	query := `
+		INSERT INTO impersonations (` + impersonationColumns + `) VALUES (
+			:id, :impersonator_id, :user_id, :tenant_id, :reason, :token_id, :ip_address, :user_agent,
+			:started_at, :expires_at, :ended_at
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, impersonation); err != nil {
+		return fmt.Errorf("failed to create impersonation: %w", err)
+	}
+
+	return nil
+}
+
+// GetImpersonation retrieves an impersonation by ID
+func (r *PostgresImpersonationRepository) GetImpersonation(ctx context.Context, id uuid.UUID) (*entity.Impersonation, error) {
+	query := `SELECT ` + impersonationColumns + ` FROM impersonations WHERE id = $1`
+
+	impersonation := &entity.Impersonation{}
+	if err := r.db.GetContext(ctx, impersonation, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrImpersonationNotFound
+		}
+		return nil, fmt.Errorf("failed to get impersonation: %w", err)
+	}
+
+	return impersonation, nil
+}
+
+// EndImpersonation records when an impersonation was ended; ending it again keeps the first time
+func (r *PostgresImpersonationRepository) EndImpersonation(ctx context.Context, id uuid.UUID, endedAt time.Time) error {
+	query := `UPDATE impersonations SET ended_at = COALESCE(ended_at, $1) WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, endedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to end impersonation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrImpersonationNotFound
+	}
+
+	return nil
+}
+
+// ListImpersonations retrieves the impersonations of a user or by a user, newest first, with the total count
+func (r *PostgresImpersonationRepository) ListImpersonations(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Impersonation, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM impersonations WHERE user_id = $1 OR impersonator_id = $1`
+	if err := r.db.GetContext(ctx, &total, countQuery, userID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count impersonations: %w", err)
+	}
+
+	query := `
+		SELECT ` + impersonationColumns + `
+		FROM impersonations
+		WHERE user_id = $1 OR impersonator_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	impersonations := []*entity.Impersonation{}
+	if err := r.db.SelectContext(ctx, &impersonations, query, userID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list impersonations: %w", err)
+	}
+
+	return impersonations, total, nil
+}
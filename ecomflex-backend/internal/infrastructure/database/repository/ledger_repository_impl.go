@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const ledgerEntryColumns = `
+	id, transaction_id, tenant_id, account, owner_id, direction, amount, entry_type,
+	referral_id, booking_id, payout_id, created_at
+`
+
+// signedLedgerAmount is the effect of an entry on the balance of a credit-normal account
+const signedLedgerAmount = `CASE WHEN direction = 'credit' THEN amount ELSE -amount END`
+
+// PostgresLedgerRepository implements LedgerRepository interface using PostgreSQL
+type PostgresLedgerRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresLedgerRepository creates a new PostgresLedgerRepository
+func NewPostgresLedgerRepository(db *sqlx.DB) repository.LedgerRepository {
+	return &PostgresLedgerRepository{
+		db: db,
+	}
+}
+
+// GetBalanceSummary retrieves the balance breakdown of a user's account
+func (r *PostgresLedgerRepository) GetBalanceSummary(ctx context.Context, account entity.LedgerAccount, ownerID uuid.UUID) (repository.BalanceSummary, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(` + signedLedgerAmount + `), 0) AS available,
+			COALESCE(SUM(amount) FILTER (WHERE direction = 'credit' AND entry_type <> 'payout_failure'), 0) AS total_earned,
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = 'reversal'), 0) AS reversed,
+			COALESCE(SUM(CASE
+				WHEN entry_type = 'payout' THEN amount
+				WHEN entry_type = 'payout_failure' THEN -amount
+				ELSE 0
+			END), 0) AS paid_out
+		FROM ledger_entries
+		WHERE account = $1 AND owner_id = $2
+	`
+
+	var summary repository.BalanceSummary
+	if err := r.db.GetContext(ctx, &summary, query, account, ownerID); err != nil {
+		return repository.BalanceSummary{}, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	return summary, nil
+}
+
+// ListBalancesAbove retrieves the balances of an account type at or above threshold
+func (r *PostgresLedgerRepository) ListBalancesAbove(ctx context.Context, account entity.LedgerAccount, threshold float64) ([]repository.AccountBalance, error) {
+	query := `
+		SELECT tenant_id, owner_id, account, SUM(` + signedLedgerAmount + `) AS balance
+		FROM ledger_entries
+		WHERE account = $1 AND owner_id IS NOT NULL
+		GROUP BY tenant_id, owner_id, account
+		HAVING SUM(` + signedLedgerAmount + `) >= $2
+		ORDER BY owner_id
+	`
+
+	balances := []repository.AccountBalance{}
+	if err := r.db.SelectContext(ctx, &balances, query, account, threshold); err != nil {
+		return nil, fmt.Errorf("failed to list balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// ListEntries retrieves the entries of a user's account, newest first, with the total count
+func (r *PostgresLedgerRepository) ListEntries(ctx context.Context, account entity.LedgerAccount, ownerID uuid.UUID, limit, offset int) ([]*entity.LedgerEntry, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM ledger_entries WHERE account = $1 AND owner_id = $2`
+	if err := r.db.GetContext(ctx, &total, countQuery, account, ownerID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count ledger entries: %w", err)
+	}
+
+	query := `
+		SELECT ` + ledgerEntryColumns + `
+		FROM ledger_entries
+		WHERE account = $1 AND owner_id = $2
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	entries := []*entity.LedgerEntry{}
+	if err := r.db.SelectContext(ctx, &entries, query, account, ownerID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// insertLedgerEntries appends the entries of a ledger transaction
+func insertLedgerEntries(ctx context.Context, tx *sqlx.Tx, entries []*entity.LedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO ledger_entries (
+			id, transaction_id, tenant_id, account, owner_id, direction, amount, entry_type,
+			referral_id, booking_id, payout_id, created_at
+		) VALUES (
+			:id, :transaction_id, :tenant_id, :account, :owner_id, :direction, :amount, :entry_type,
+			:referral_id, :booking_id, :payout_id, :created_at
+		)
+	`
+
+	if _, err := tx.NamedExecContext(ctx, query, entries); err != nil {
+		return fmt.Errorf("failed to post ledger entries: %w", err)
+	}
+
+	return nil
+}
+
+// lockLedgerAccount serializes debits on a user's account until the transaction ends
+func lockLedgerAccount(ctx context.Context, tx *sqlx.Tx, account entity.LedgerAccount, ownerID uuid.UUID) error {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, string(account)+":"+ownerID.String()); err != nil {
+		return fmt.Errorf("failed to lock ledger account: %w", err)
+	}
+	return nil
+}
+
+// ledgerAccountBalance retrieves the current balance of a user's account inside a transaction
+func ledgerAccountBalance(ctx context.Context, tx *sqlx.Tx, account entity.LedgerAccount, ownerID uuid.UUID) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(` + signedLedgerAmount + `), 0)
+		FROM ledger_entries
+		WHERE account = $1 AND owner_id = $2
+	`
+
+	var balance float64
+	if err := tx.GetContext(ctx, &balance, query, account, ownerID); err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	return balance, nil
+}
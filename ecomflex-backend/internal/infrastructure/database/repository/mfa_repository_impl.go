@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// PostgresMFARepository implements MFARepository interface using PostgreSQL
+type PostgresMFARepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresMFARepository creates a new PostgresMFARepository
+func NewPostgresMFARepository(db *sqlx.DB) repository.MFARepository {
+	return &PostgresMFARepository{
+		db: db,
+	}
+}
+
+// GetFactor retrieves the TOTP authenticator of a user
+func (r *PostgresMFARepository) GetFactor(ctx context.Context, userID uuid.UUID) (*entity.MFAFactor, error) {
+	query := `
+		SELECT user_id, secret, enabled_at, last_used_step, created_at, updated_at
+		FROM mfa_factors
+		WHERE user_id = $1
+	`
+
+	factor := &entity.MFAFactor{}
+	if err := r.db.GetContext(ctx, factor, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrMFAFactorNotFound
+		}
+		return nil, fmt.Errorf("failed to get mfa factor: %w", err)
+	}
+
+	return factor, nil
+}
+
+// SaveFactor creates or replaces the TOTP authenticator of a user
+func (r *PostgresMFARepository) SaveFactor(ctx context.Context, factor *entity.MFAFactor) error {
+	query := `
+		INSERT INTO mfa_factors (user_id, secret, enabled_at, last_used_step, created_at, updated_at)
+		VALUES (:user_id, :secret, :enabled_at, :last_used_step, :created_at, :updated_at)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret,
+			enabled_at = EXCLUDED.enabled_at,
+			last_used_step = EXCLUDED.last_used_step,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, factor); err != nil {
+		return fmt.Errorf("failed to save mfa factor: %w", err)
+	}
+
+	return nil
+}
+
+// EnableFactor confirms a user's authenticator and replaces their recovery codes in one transaction
+func (r *PostgresMFARepository) EnableFactor(ctx context.Context, factor *entity.MFAFactor, codes []*entity.MFARecoveryCode) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE mfa_factors
+		SET enabled_at = $2, last_used_step = $3, updated_at = $4
+		WHERE user_id = $1
+	`
+
+	result, err := tx.ExecContext(ctx, query, factor.UserID, factor.EnabledAt, factor.LastUsedStep, factor.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to enable mfa factor: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return repository.ErrMFAFactorNotFound
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, factor.UserID, codes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit mfa factor: %w", err)
+	}
+
+	return nil
+}
+
+// MarkStepUsed records the time step of an accepted TOTP code.
+// It returns ErrMFACodeReused if that step or a later one was already used.
+func (r *PostgresMFARepository) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) error {
+	query := `
+		UPDATE mfa_factors
+		SET last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND last_used_step < $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to record mfa code use: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to record mfa code use: %w", err)
+	}
+	if rows == 0 {
+		return repository.ErrMFACodeReused
+	}
+
+	return nil
+}
+
+// DeleteFactor removes the authenticator and recovery codes of a user
+func (r *PostgresMFARepository) DeleteFactor(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_factors WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete mfa factor: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit mfa factor: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes replaces all recovery codes of a user
+func (r *PostgresMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []*entity.MFARecoveryCode) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code of a user as used
+func (r *PostgresMFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if rows == 0 {
+		return repository.ErrRecoveryCodeNotFound
+	}
+
+	return nil
+}
+
+// CountUnusedRecoveryCodes counts the recovery codes a user has left
+func (r *PostgresMFARepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM mfa_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
+
+// replaceRecoveryCodes deletes a user's recovery codes and inserts new ones within a transaction
+func replaceRecoveryCodes(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, codes []*entity.MFARecoveryCode) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	query := `
+		INSERT INTO mfa_recovery_codes (id, user_id, code_hash, used_at, created_at)
+		VALUES (:id, :user_id, :code_hash, :used_at, :created_at)
+	`
+
+	for _, code := range codes {
+		if _, err := tx.NamedExecContext(ctx, query, code); err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	return nil
+}
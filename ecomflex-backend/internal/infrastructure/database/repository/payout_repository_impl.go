@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const payoutColumns = `
+	id, tenant_id, run_id, user_id, account, amount, status, provider, reference, failure_reason,
+	paid_at, created_at, updated_at
+`
+
+const payoutRunColumns = `
+	id, threshold, status, payout_count, total_amount, error, started_at, completed_at
+`
+
+// PostgresPayoutRepository implements PayoutRepository interface using PostgreSQL
+type PostgresPayoutRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresPayoutRepository creates a new PostgresPayoutRepository
+func NewPostgresPayoutRepository(db *sqlx.DB) repository.PayoutRepository {
+	return &PostgresPayoutRepository{
+		db: db,
+	}
+}
+
+// StartRun stores a new payout run, failing with ErrPayoutRunInProgress if one is running
+func (r *PostgresPayoutRepository) StartRun(ctx context.Context, run *entity.PayoutRun) error {
+	// A partial unique index allows a single running run
+	query := `
+		INSERT INTO payout_runs (` + payoutRunColumns + `)
+		VALUES (:id, :threshold, :status, :payout_count, :total_amount, :error, :started_at, :completed_at)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, run); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return entity.ErrPayoutRunInProgress
+		}
+		return fmt.Errorf("failed to start payout run: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteRun stores the outcome of a payout run
+func (r *PostgresPayoutRepository) CompleteRun(ctx context.Context, run *entity.PayoutRun) error {
+	query := `
+		UPDATE payout_runs
+		SET
+			status = :status,
+			payout_count = :payout_count,
+			total_amount = :total_amount,
+			error = :error,
+			completed_at = :completed_at
+		WHERE id = :id
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, run); err != nil {
+		return fmt.Errorf("failed to complete payout run: %w", err)
+	}
+
+	return nil
+}
+
+// ListRuns retrieves payout runs, newest first, with the total count
+func (r *PostgresPayoutRepository) ListRuns(ctx context.Context, limit, offset int) ([]*entity.PayoutRun, int, error) {
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM payout_runs`); err != nil {
+		return nil, 0, fmt.Errorf("failed to count payout runs: %w", err)
+	}
+
+	query := `SELECT ` + payoutRunColumns + ` FROM payout_runs ORDER BY started_at DESC LIMIT $1 OFFSET $2`
+
+	runs := []*entity.PayoutRun{}
+	if err := r.db.SelectContext(ctx, &runs, query, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list payout runs: %w", err)
+	}
+
+	return runs, total, nil
+}
+
+// CreatePayout stores a payout and posts its ledger entries in one transaction.
+// It fails with ErrInsufficientBalance if the account no longer covers the payout.
+func (r *PostgresPayoutRepository) CreatePayout(ctx context.Context, payout *entity.Payout, entries []*entity.LedgerEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Re-check the balance under the account lock so concurrent payouts cannot overdraw it
+	if err := lockLedgerAccount(ctx, tx, payout.Account, payout.UserID); err != nil {
+		return err
+	}
+
+	balance, err := ledgerAccountBalance(ctx, tx, payout.Account, payout.UserID)
+	if err != nil {
+		return err
+	}
+
+	if balance < payout.Amount {
+		return entity.ErrInsufficientBalance
+	}
+
+	query := `
+		INSERT INTO payouts (` + payoutColumns + `)
+		VALUES (
+			:id, :tenant_id, :run_id, :user_id, :account, :amount, :status, :provider, :reference, :failure_reason,
+			:paid_at, :created_at, :updated_at
+		)
+	`
+
+	if _, err := tx.NamedExecContext(ctx, query, payout); err != nil {
+		return fmt.Errorf("failed to create payout: %w", err)
+	}
+
+	if err := insertLedgerEntries(ctx, tx, entries); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payout: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayoutByID retrieves a payout by ID
+func (r *PostgresPayoutRepository) GetPayoutByID(ctx context.Context, id uuid.UUID) (*entity.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE id = $1`
+
+	payout := &entity.Payout{}
+	if err := r.db.GetContext(ctx, payout, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPayoutNotFound
+		}
+		return nil, fmt.Errorf("failed to get payout: %w", err)
+	}
+
+	return payout, nil
+}
+
+// GetPayoutByReference retrieves a payout by its transfer reference at a provider
+func (r *PostgresPayoutRepository) GetPayoutByReference(ctx context.Context, provider, reference string) (*entity.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE provider = $1 AND reference = $2`
+
+	payout := &entity.Payout{}
+	if err := r.db.GetContext(ctx, payout, query, provider, reference); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPayoutNotFound
+		}
+		return nil, fmt.Errorf("failed to get payout: %w", err)
+	}
+
+	return payout, nil
+}
+
+// ListPayouts retrieves payouts matching a filter, newest first, with the total count
+func (r *PostgresPayoutRepository) ListPayouts(ctx context.Context, filter repository.PayoutFilter) ([]*entity.Payout, int, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.RunID != "" {
+		args = append(args, filter.RunID)
+		conditions = append(conditions, fmt.Sprintf("run_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Account != "" {
+		args = append(args, filter.Account)
+		conditions = append(conditions, fmt.Sprintf("account = $%d", len(args)))
+	}
+	if filter.Provider != "" {
+		args = append(args, filter.Provider)
+		conditions = append(conditions, fmt.Sprintf("provider = $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Count total payouts matching the filter
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM payouts"+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count payouts: %w", err)
+	}
+
+	query := `SELECT ` + payoutColumns + ` FROM payouts` + where + ` ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	payouts := []*entity.Payout{}
+	if err := r.db.SelectContext(ctx, &payouts, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list payouts: %w", err)
+	}
+
+	return payouts, total, nil
+}
+
+// UpdatePayoutStatus persists a payout that is still in previousStatus, posting its ledger
+// entries in the same transaction. A non-nil event is recorded alongside; the update fails
+// with ErrDuplicatePayoutEvent if the event was already processed.
+func (r *PostgresPayoutRepository) UpdatePayoutStatus(ctx context.Context, payout *entity.Payout, previousStatus entity.PayoutStatus, entries []*entity.LedgerEntry, event *entity.PayoutEvent) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if event != nil {
+		eventQuery := `
+			INSERT INTO payout_events (id, provider, event_id, payout_id, status, received_at)
+			VALUES (:id, :provider, :event_id, :payout_id, :status, :received_at)
+			ON CONFLICT (provider, event_id) DO NOTHING
+		`
+
+		result, err := tx.NamedExecContext(ctx, eventQuery, event)
+		if err != nil {
+			return fmt.Errorf("failed to record payout event: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return entity.ErrDuplicatePayoutEvent
+		}
+	}
+
+	// Guard on the previous status so concurrent updates apply once
+	query := `
+		UPDATE payouts
+		SET status = $1, provider = $2, reference = $3, failure_reason = $4, paid_at = $5, updated_at = $6
+		WHERE id = $7 AND status = $8
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		payout.Status, payout.Provider, payout.Reference, payout.FailureReason, payout.PaidAt, payout.UpdatedAt,
+		payout.ID, previousStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update payout: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrInvalidPayoutTransition
+	}
+
+	if err := insertLedgerEntries(ctx, tx, entries); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payout status: %w", err)
+	}
+
+	return nil
+}
+
+// GetBeneficiary retrieves the beneficiary of a user at a provider
+func (r *PostgresPayoutRepository) GetBeneficiary(ctx context.Context, userID uuid.UUID, provider string) (*entity.PayoutBeneficiary, error) {
+	query := `
+		SELECT id, user_id, provider, reference, created_at
+		FROM payout_beneficiaries
+		WHERE user_id = $1 AND provider = $2
+	`
+
+	beneficiary := &entity.PayoutBeneficiary{}
+	if err := r.db.GetContext(ctx, beneficiary, query, userID, provider); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPayoutBeneficiaryNotFound
+		}
+		return nil, fmt.Errorf("failed to get payout beneficiary: %w", err)
+	}
+
+	return beneficiary, nil
+}
+
+// SaveBeneficiary stores the beneficiary of a user at a provider
+func (r *PostgresPayoutRepository) SaveBeneficiary(ctx context.Context, beneficiary *entity.PayoutBeneficiary) error {
+	query := `
+		INSERT INTO payout_beneficiaries (id, user_id, provider, reference, created_at)
+		VALUES (:id, :user_id, :provider, :reference, :created_at)
+		ON CONFLICT (user_id, provider) DO UPDATE SET reference = EXCLUDED.reference
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, beneficiary); err != nil {
+		return fmt.Errorf("failed to save payout beneficiary: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayoutMethod retrieves the payout method chosen by a user
+func (r *PostgresPayoutRepository) GetPayoutMethod(ctx context.Context, userID uuid.UUID) (*entity.PayoutMethod, error) {
+	query := `SELECT user_id, type, details, created_at, updated_at FROM payout_methods WHERE user_id = $1`
+
+	method := &entity.PayoutMethod{}
+	if err := r.db.GetContext(ctx, method, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPayoutMethodNotFound
+		}
+		return nil, fmt.Errorf("failed to get payout method: %w", err)
+	}
+
+	return method, nil
+}
+
+// SavePayoutMethod stores the payout method of a user and forgets their provider
+// beneficiaries so the next payout registers the new method
+func (r *PostgresPayoutRepository) SavePayoutMethod(ctx context.Context, method *entity.PayoutMethod) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO payout_methods (user_id, type, details, created_at, updated_at)
+		VALUES (:user_id, :type, :details, :created_at, :updated_at)
+		ON CONFLICT (user_id) DO UPDATE SET type = EXCLUDED.type, details = EXCLUDED.details, updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := tx.NamedExecContext(ctx, query, method); err != nil {
+		return fmt.Errorf("failed to save payout method: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM payout_beneficiaries WHERE user_id = $1`, method.UserID); err != nil {
+		return fmt.Errorf("failed to reset payout beneficiaries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payout method: %w", err)
+	}
+
+	return nil
+}
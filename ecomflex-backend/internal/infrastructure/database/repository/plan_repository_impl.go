@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const planColumns = `
+	id, name, max_active_products, max_influencers, max_monthly_bookings, max_storage_bytes,
+	features, sort_order, created_at, updated_at
+`
+
+// planRow is a plan as stored, with its features as a text array
+type planRow struct {
+	entity.Plan
+	Features pq.StringArray `db:"features"`
+}
+
+// toEntity converts the row to a plan
+func (r *planRow) toEntity() *entity.Plan {
+	plan := r.Plan
+	plan.Features = make([]entity.PlanFeature, len(r.Features))
+	for i, feature := range r.Features {
+		plan.Features[i] = entity.PlanFeature(feature)
+	}
+	return &plan
+}
+
+// PostgresPlanRepository implements PlanRepository interface using PostgreSQL
+type PostgresPlanRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresPlanRepository creates a new PostgresPlanRepository
+func NewPostgresPlanRepository(db *sqlx.DB) repository.PlanRepository {
+	return &PostgresPlanRepository{
+		db: db,
+	}
+}
+
+// GetPlan retrieves a plan by ID
+func (r *PostgresPlanRepository) GetPlan(ctx context.Context, id string) (*entity.Plan, error) {
+	var row planRow
+	if err := r.db.GetContext(ctx, &row, `SELECT `+planColumns+` FROM plans WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPlanNotFound
+		}
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+
+	return row.toEntity(), nil
+}
+
+// ListPlans retrieves every plan, cheapest first
+func (r *PostgresPlanRepository) ListPlans(ctx context.Context) ([]*entity.Plan, error) {
+	var rows []planRow
+	if err := r.db.SelectContext(ctx, &rows, `SELECT `+planColumns+` FROM plans ORDER BY sort_order, id`); err != nil {
+		return nil, fmt.Errorf("failed to list plans: %w", err)
+	}
+
+	plans := make([]*entity.Plan, len(rows))
+	for i := range rows {
+		plans[i] = rows[i].toEntity()
+	}
+
+	return plans, nil
+}
+
+// GetTenantUsage measures what a tenant uses; monthly bookings count from periodStart.
+// Bookings made in the period count even if they were cancelled since.
+func (r *PostgresPlanRepository) GetTenantUsage(ctx context.Context, tenantID uuid.UUID, periodStart time.Time) (*entity.TenantUsage, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM products WHERE tenant_id = $1 AND is_active) AS active_products,
+			(SELECT COUNT(*) FROM users
+				WHERE tenant_id = $1 AND role = 'influencer' AND status = 'active' AND deleted_at IS NULL) AS influencers,
+			(SELECT COUNT(*) FROM bookings WHERE tenant_id = $1 AND created_at >= $2) AS monthly_bookings,
+			(SELECT COALESCE(SUM(size_bytes), 0) FROM stored_files WHERE tenant_id = $1) AS storage_bytes
+	`
+
+	var usage entity.TenantUsage
+	if err := r.db.GetContext(ctx, &usage, query, tenantID, periodStart); err != nil {
+		return nil, fmt.Errorf("failed to get tenant usage: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// CreateStoredFile records an uploaded file against its tenant's storage
+func (r *PostgresPlanRepository) CreateStoredFile(ctx context.Context, file *entity.StoredFile) error {
+	query := `
+		INSERT INTO stored_files (id, tenant_id, user_id, key, size_bytes, created_at)
+		VALUES (:id, :tenant_id, :user_id, :key, :size_bytes, :created_at)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, file); err != nil {
+		return fmt.Errorf("failed to record stored file: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
@@ -38,11 +39,18 @@ func (r *productRepositoryImpl) FindByID(ctx context.Context, id string) (*entit
 }
 
 func (r *productRepositoryImpl) Update(ctx context.Context, product *entity.Product) error {
-	return r.db.WithContext(ctx).Save(product).Error
+	// Slot counters are owned by booking reservations and must not be overwritten with stale values
+	return r.db.WithContext(ctx).Omit("current_bookings", "auto_closed").Save(product).Error
 }
 
+// Delete deletes a product. Products with bookings are kept for their bookings' history
+// and proofs, so deleting one fails with ErrProductInUse.
 func (r *productRepositoryImpl) Delete(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Product{}).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Product{}).Error
+	if isForeignKeyViolation(err) {
+		return domainRepo.ErrProductInUse
+	}
+	return err
 }
 
 func (r *productRepositoryImpl) FindAll(ctx context.Context, filter entity.ProductFilter) ([]*entity.Product, int64, error) {
@@ -96,7 +104,9 @@ func (r *productRepositoryImpl) FindAll(ctx context.Context, filter entity.Produ
 }
 
 func (r *productRepositoryImpl) UpdateStatus(ctx context.Context, id string, isActive bool) error {
-	return r.db.WithContext(ctx).Model(&entity.Product{}).Where("id = ?", id).Update("is_active", isActive).Error
+	// A manual status change overrides any automatic close on a full campaign
+	return r.db.WithContext(ctx).Model(&entity.Product{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_active": isActive, "auto_closed": false}).Error
 }
 
 func (r *productRepositoryImpl) FindTrending(ctx context.Context, limit int) ([]*entity.Product, error) {
@@ -125,4 +135,10 @@ func (r *productRepositoryImpl) UpdateImage(ctx context.Context, id string, imag
 	return r.db.WithContext(ctx).Model(&entity.Product{}).
 		Where("id = ?", id).
 		Update("image_url", imageURL).Error
-}
\ No newline at end of file
+}
+
+// isForeignKeyViolation checks if an error from GORM's pgx driver comes from a foreign key constraint
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
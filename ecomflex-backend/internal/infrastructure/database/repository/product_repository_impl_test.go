@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	domainRepo "github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// testDatabaseURLEnv names the migrated Postgres database the repository tests run against.
+// The tests are skipped when it is not set; they remove the rows they create.
+const testDatabaseURLEnv = "ECOMFLEX_TEST_DATABASE_URL"
+
+// newTestDatabase connects to the test database with both the sqlx and the GORM driver, like the server does
+func newTestDatabase(t *testing.T) (*sqlx.DB, *gorm.DB) {
+	t.Helper()
+
+	dsn := os.Getenv(testDatabaseURLEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+
+	return db, gormDB
+}
+
+// newTestProduct creates a tenant with a user and a product, removed again when the test ends
+func newTestProduct(t *testing.T, db *sqlx.DB, products domainRepo.ProductRepository) (*entity.User, *entity.Product) {
+	t.Helper()
+	ctx := context.Background()
+
+	tenant := entity.NewTenant("Product Test", uuid.NewString()+".test", "free")
+	if err := NewTenantRepository(db).CreateTenant(ctx, tenant); err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+
+	user := entity.NewUser(tenant.ID, uuid.NewString()+"@example.com", "hash", "Product Test", entity.RoleInfluencer, "")
+	if err := NewPostgresUserRepository(db).CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	now := time.Now()
+	product := &entity.Product{
+		ID:               uuid.New(),
+		Name:             "Test product",
+		ASIN:             "B000TEST",
+		Founder:          "Test",
+		ProductLink:      "https://www.amazon.com/dp/B000TEST",
+		Price:            20,
+		RequiredBookings: 5,
+		IsActive:         true,
+		TenantID:         tenant.ID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := products.Create(ctx, product); err != nil {
+		t.Fatalf("Create product: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM booking_proofs WHERE product_id = $1`, product.ID)
+		db.Exec(`DELETE FROM bookings WHERE product_id = $1`, product.ID)
+		db.Exec(`DELETE FROM referral_clicks WHERE tenant_id = $1`, tenant.ID)
+		db.Exec(`DELETE FROM products WHERE id = $1`, product.ID)
+		db.Exec(`DELETE FROM users WHERE id = $1`, user.ID)
+		db.Exec(`DELETE FROM tenants WHERE id = $1`, tenant.ID)
+	})
+
+	return user, product
+}
+
+func TestProductRepositoryDeleteBookedProduct(t *testing.T) {
+	db, gormDB := newTestDatabase(t)
+	products := NewProductRepository(gormDB)
+	ctx := context.Background()
+
+	user, product := newTestProduct(t, db, products)
+	booking := entity.NewBooking(product.TenantID, user.ID, product.ID, "")
+	if err := NewPostgresBookingRepository(db).CreateBooking(ctx, booking); err != nil {
+		t.Fatalf("CreateBooking: %v", err)
+	}
+
+	if err := products.Delete(ctx, product.ID.String()); !errors.Is(err, domainRepo.ErrProductInUse) {
+		t.Fatalf("Delete() error = %v, want %v", err, domainRepo.ErrProductInUse)
+	}
+	if _, err := products.FindByID(ctx, product.ID.String()); err != nil {
+		t.Fatalf("FindByID() after a refused delete: %v", err)
+	}
+}
+
+func TestProductRepositoryDeleteUnbookedProduct(t *testing.T) {
+	db, gormDB := newTestDatabase(t)
+	products := NewProductRepository(gormDB)
+	ctx := context.Background()
+
+	_, product := newTestProduct(t, db, products)
+
+	if err := products.Delete(ctx, product.ID.String()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := products.FindByID(ctx, product.ID.String()); err == nil {
+		t.Fatal("FindByID() after delete found the product")
+	}
+}
+
+func TestProductRepositoryDeleteClickedProduct(t *testing.T) {
+	db, gormDB := newTestDatabase(t)
+	products := NewProductRepository(gormDB)
+	ctx := context.Background()
+
+	user, product := newTestProduct(t, db, products)
+	click := entity.NewReferralClick(user, &product.ID, "hash", "Mozilla/5.0")
+	if err := NewPostgresReferralRepository(db).RecordClick(ctx, click); err != nil {
+		t.Fatalf("RecordClick: %v", err)
+	}
+
+	if err := products.Delete(ctx, product.ID.String()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	var productID *uuid.UUID
+	if err := db.Get(&productID, `SELECT product_id FROM referral_clicks WHERE id = $1`, click.ID); err != nil {
+		t.Fatalf("get click: %v", err)
+	}
+	if productID != nil {
+		t.Errorf("click product = %s, want it cleared with the product", productID)
+	}
+}
+
+func TestIsForeignKeyViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "foreign key violation", err: &pgconn.PgError{Code: "23503"}, want: true},
+		{name: "wrapped foreign key violation", err: fmt.Errorf("delete: %w", &pgconn.PgError{Code: "23503"}), want: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "other error", err: errors.New("connection refused")},
+		{name: "no error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isForeignKeyViolation(tt.err); got != tt.want {
+				t.Errorf("isForeignKeyViolation(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
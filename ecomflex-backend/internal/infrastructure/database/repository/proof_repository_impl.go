@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const proofColumns = `
+	id, tenant_id, booking_id, product_id, user_id, storage_key, file_url, order_id,
+	submitted_fields, status, claimed_by, claimed_at, reviewed_by, reviewed_at,
+	rejection_reason, created_at, updated_at
+`
+
+// PostgresProofRepository implements ProofRepository interface using PostgreSQL
+type PostgresProofRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresProofRepository creates a new PostgresProofRepository
+func NewPostgresProofRepository(db *sqlx.DB) repository.ProofRepository {
+	return &PostgresProofRepository{
+		db: db,
+	}
+}
+
+// SubmitProof stores a proof and moves its booking to pending in one transaction
+func (r *PostgresProofRepository) SubmitProof(ctx context.Context, booking *entity.Booking, proof *entity.Proof) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Guard on the initiated status so concurrent submissions create a single proof
+	bookingQuery := `
+		UPDATE bookings
+		SET status = $1, proof_url = $2, updated_at = $3
+		WHERE id = $4 AND status = $5 AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, bookingQuery,
+		booking.Status, booking.ProofURL, booking.UpdatedAt,
+		booking.ID, entity.BookingStatusInitiated,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrInvalidBookingTransition
+	}
+
+	insertQuery := `
+		INSERT INTO booking_proofs (
+			id, tenant_id, booking_id, product_id, user_id, storage_key, file_url, order_id,
+			submitted_fields, status, rejection_reason, created_at, updated_at
+		) VALUES (
+			:id, :tenant_id, :booking_id, :product_id, :user_id, :storage_key, :file_url, :order_id,
+			:submitted_fields, :status, :rejection_reason, :created_at, :updated_at
+		)
+	`
+
+	if _, err := tx.NamedExecContext(ctx, insertQuery, proof); err != nil {
+		return fmt.Errorf("failed to create proof: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit proof: %w", err)
+	}
+
+	return nil
+}
+
+// GetProofByID retrieves a proof by ID
+func (r *PostgresProofRepository) GetProofByID(ctx context.Context, id uuid.UUID) (*entity.Proof, error) {
+	query := `SELECT ` + proofColumns + ` FROM booking_proofs WHERE id = $1`
+
+	proof := &entity.Proof{}
+	if err := r.db.GetContext(ctx, proof, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrProofNotFound
+		}
+		return nil, fmt.Errorf("failed to get proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// GetLatestProofByBooking retrieves the most recent proof of a booking
+func (r *PostgresProofRepository) GetLatestProofByBooking(ctx context.Context, bookingID uuid.UUID) (*entity.Proof, error) {
+	query := `
+		SELECT ` + proofColumns + `
+		FROM booking_proofs
+		WHERE booking_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	proof := &entity.Proof{}
+	if err := r.db.GetContext(ctx, proof, query, bookingID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrProofNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// ListProofs retrieves proofs matching a filter with the total count, oldest first
+func (r *PostgresProofRepository) ListProofs(ctx context.Context, filter repository.ProofFilter) ([]*entity.Proof, int, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.ProductID != "" {
+		args = append(args, filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("product_id = $%d", len(args)))
+	}
+	if filter.SubmittedBefore != nil {
+		args = append(args, *filter.SubmittedBefore)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if filter.SubmittedAfter != nil {
+		args = append(args, *filter.SubmittedAfter)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Count total proofs matching the filter
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM booking_proofs"+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count proofs: %w", err)
+	}
+
+	query := `SELECT ` + proofColumns + ` FROM booking_proofs` + where + ` ORDER BY created_at ASC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	proofs := []*entity.Proof{}
+	if err := r.db.SelectContext(ctx, &proofs, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list proofs: %w", err)
+	}
+
+	return proofs, total, nil
+}
+
+// ClaimProof assigns a proof to a reviewer unless someone else claimed it after staleBefore
+func (r *PostgresProofRepository) ClaimProof(ctx context.Context, proof *entity.Proof, staleBefore time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A claim is only taken over once it has gone stale
+	claimQuery := `
+		UPDATE booking_proofs
+		SET status = $1, claimed_by = $2, claimed_at = $3, updated_at = $3
+		WHERE id = $4
+		  AND status IN ('submitted', 'in_review')
+		  AND (claimed_by IS NULL OR claimed_by = $2 OR claimed_at < $5)
+	`
+
+	result, err := tx.ExecContext(ctx, claimQuery,
+		proof.Status, proof.ClaimedBy, proof.ClaimedAt, proof.ID, staleBefore,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to claim proof: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrProofAlreadyClaimed
+	}
+
+	decision := entity.NewProofDecision(proof, *proof.ClaimedBy, entity.ProofActionClaimed, "")
+	if err := insertProofDecision(ctx, tx, decision); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit proof claim: %w", err)
+	}
+
+	return nil
+}
+
+// RecordBookingDecision persists a booking that left fromStatus together with the reviewed proof
+// and its history entry in one transaction, releasing the slot of a rejected booking.
+// proof and decision are nil for bookings without a proof to decide on.
+func (r *PostgresProofRepository) RecordBookingDecision(ctx context.Context, booking *entity.Booking, fromStatus entity.BookingStatus, proof *entity.Proof, decision *entity.ProofDecision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Guard on the previous status so concurrent decisions cannot both apply
+	bookingQuery := `
+		UPDATE bookings
+		SET status = $1, rejection_reason = $2, updated_at = $3
+		WHERE id = $4 AND status = $5 AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, bookingQuery,
+		booking.Status, booking.RejectionReason, booking.UpdatedAt,
+		booking.ID, fromStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrInvalidBookingTransition
+	}
+
+	if booking.Status == entity.BookingStatusRejected {
+		if err := releaseSlots(ctx, tx, booking.ProductID, 1); err != nil {
+			return err
+		}
+	}
+
+	if proof != nil {
+		if err := updateReviewedProof(ctx, tx, proof); err != nil {
+			return err
+		}
+
+		if err := insertProofDecision(ctx, tx, decision); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit booking decision: %w", err)
+	}
+
+	return nil
+}
+
+// GetProofDecisions retrieves the moderation history of a proof
+func (r *PostgresProofRepository) GetProofDecisions(ctx context.Context, proofID uuid.UUID) ([]*entity.ProofDecision, error) {
+	query := `
+		SELECT id, proof_id, booking_id, actor_id, action, reason, created_at
+		FROM proof_decisions
+		WHERE proof_id = $1
+		ORDER BY created_at ASC
+	`
+
+	decisions := []*entity.ProofDecision{}
+	if err := r.db.SelectContext(ctx, &decisions, query, proofID); err != nil {
+		return nil, fmt.Errorf("failed to get proof decisions: %w", err)
+	}
+
+	return decisions, nil
+}
+
+// updateReviewedProof persists the review of a proof
+func updateReviewedProof(ctx context.Context, tx *sqlx.Tx, proof *entity.Proof) error {
+	// Guard on the open statuses so a proof is only decided once
+	query := `
+		UPDATE booking_proofs
+		SET status = $1, reviewed_by = $2, reviewed_at = $3, rejection_reason = $4, updated_at = $5
+		WHERE id = $6 AND status IN ('submitted', 'in_review')
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		proof.Status, proof.ReviewedBy, proof.ReviewedAt, proof.RejectionReason, proof.UpdatedAt, proof.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update proof: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrProofAlreadyReviewed
+	}
+
+	return nil
+}
+
+// insertProofDecision adds an entry to the moderation history
+func insertProofDecision(ctx context.Context, tx *sqlx.Tx, decision *entity.ProofDecision) error {
+	query := `
+		INSERT INTO proof_decisions (id, proof_id, booking_id, actor_id, action, reason, created_at)
+		VALUES (:id, :proof_id, :booking_id, :actor_id, :action, :reason, :created_at)
+	`
+
+	if _, err := tx.NamedExecContext(ctx, query, decision); err != nil {
+		return fmt.Errorf("failed to record proof decision: %w", err)
+	}
+
+	return nil
+}
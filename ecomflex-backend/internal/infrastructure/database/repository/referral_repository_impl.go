@@ -2,11 +2,28 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
 )
 
+const referralColumns = `
+	id, tenant_id, influencer_id, user_id, product_id, booking_id, click_id, source, status,
+	earnings, commission_rule_id, commission_snapshot, created_at, updated_at, deleted_at
+`
+
+const prefixedReferralColumns = `
+	r.id, r.tenant_id, r.influencer_id, r.user_id, r.product_id, r.booking_id, r.click_id, r.source, r.status,
+	r.earnings, r.commission_rule_id, r.commission_snapshot, r.created_at, r.updated_at, r.deleted_at
+`
+
 // PostgresReferralRepository implements ReferralRepository
 type PostgresReferralRepository struct {
 	db *sqlx.DB
@@ -38,8 +55,8 @@ func (r *PostgresReferralRepository) GetReferralStats(ctx context.Context, userI
 	err = r.db.GetContext(ctx, &stats, `
 		SELECT 
 			COUNT(*) as total_referrals,
-			COUNT(CASE WHEN status = 'approved' THEN 1 END) as approved_bookings,
-			COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending_bookings,
+			COUNT(CASE WHEN status = 'approved' AND source = 'booking' THEN 1 END) as approved_bookings,
+			COUNT(CASE WHEN status = 'pending' AND source = 'booking' THEN 1 END) as pending_bookings,
 			COALESCE(SUM(earnings), 0) as total_earnings
 		FROM referrals
 		WHERE influencer_id = $1 AND tenant_id = $2 AND deleted_at IS NULL
@@ -78,7 +95,7 @@ func (r *PostgresReferralRepository) GetRecentReferrals(ctx context.Context, use
 		SELECT 
 			r.id,
 			u.full_name as user,
-			p.name as product,
+			COALESCE(p.name, 'Sign-up') as product,
 			TO_CHAR(r.created_at, 'Mon DD, YYYY') as date,
 			CASE 
 				WHEN r.status = 'approved' THEN 'Approved'
@@ -87,8 +104,8 @@ func (r *PostgresReferralRepository) GetRecentReferrals(ctx context.Context, use
 			END as status,
 			r.earnings
 		FROM referrals r
-		JOIN users u ON r.user_id = u.id AND u.tenant_id = r.tenant_id
-		JOIN products p ON r.product_id = p.id AND p.tenant_id = r.tenant_id
+		JOIN users u ON r.user_id = u.id
+		LEFT JOIN products p ON r.product_id = p.id
 		WHERE r.influencer_id = $1 
 		  AND r.tenant_id = $2 
 		  AND r.deleted_at IS NULL
@@ -102,4 +119,229 @@ func (r *PostgresReferralRepository) GetRecentReferrals(ctx context.Context, use
 	}
 	
 	return referrals, nil
-}
\ No newline at end of file
+}
+
+// CreateReferral creates a referral, returning false if the user or booking was already attributed
+func (r *PostgresReferralRepository) CreateReferral(ctx context.Context, referral *entity.Referral) (bool, error) {
+	query := `
+		INSERT INTO referrals (
+			id, tenant_id, influencer_id, user_id, product_id, booking_id, click_id,
+			source, status, earnings, created_at, updated_at
+		) VALUES (
+			:id, :tenant_id, :influencer_id, :user_id, :product_id, :booking_id, :click_id,
+			:source, :status, :earnings, :created_at, :updated_at
+		)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := r.db.NamedExecContext(ctx, query, referral)
+	if err != nil {
+		return false, fmt.Errorf("failed to create referral: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetRegistrationReferral retrieves the signup referral of a user whose click happened after since
+func (r *PostgresReferralRepository) GetRegistrationReferral(ctx context.Context, userID uuid.UUID, since time.Time) (*entity.Referral, error) {
+	query := `
+		SELECT ` + prefixedReferralColumns + `
+		FROM referrals r
+		JOIN referral_clicks c ON c.id = r.click_id
+		WHERE r.user_id = $1 AND r.source = 'registration' AND r.deleted_at IS NULL
+		  AND c.created_at >= $2
+	`
+
+	referral := &entity.Referral{}
+	if err := r.db.GetContext(ctx, referral, query, userID, since); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrReferralNotFound
+		}
+		return nil, fmt.Errorf("failed to get registration referral: %w", err)
+	}
+
+	return referral, nil
+}
+
+// RecordClick stores a click on a referral link
+func (r *PostgresReferralRepository) RecordClick(ctx context.Context, click *entity.ReferralClick) error {
+	query := `
+		INSERT INTO referral_clicks (
+			id, tenant_id, influencer_id, referral_code, product_id, ip_hash, user_agent, created_at
+		) VALUES (
+			:id, :tenant_id, :influencer_id, :referral_code, :product_id, :ip_hash, :user_agent, :created_at
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, click); err != nil {
+		return fmt.Errorf("failed to record referral click: %w", err)
+	}
+
+	return nil
+}
+
+// GetClickByID retrieves a referral click by ID
+func (r *PostgresReferralRepository) GetClickByID(ctx context.Context, id uuid.UUID) (*entity.ReferralClick, error) {
+	query := `
+		SELECT id, tenant_id, influencer_id, referral_code, product_id, ip_hash, user_agent, created_at
+		FROM referral_clicks
+		WHERE id = $1
+	`
+
+	click := &entity.ReferralClick{}
+	if err := r.db.GetContext(ctx, click, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrReferralClickNotFound
+		}
+		return nil, fmt.Errorf("failed to get referral click: %w", err)
+	}
+
+	return click, nil
+}
+
+// GetReferralByID retrieves a referral by ID
+func (r *PostgresReferralRepository) GetReferralByID(ctx context.Context, id uuid.UUID) (*entity.Referral, error) {
+	query := `SELECT ` + referralColumns + ` FROM referrals WHERE id = $1 AND deleted_at IS NULL`
+
+	referral := &entity.Referral{}
+	if err := r.db.GetContext(ctx, referral, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrReferralNotFound
+		}
+		return nil, fmt.Errorf("failed to get referral: %w", err)
+	}
+
+	return referral, nil
+}
+
+// GetReferralByBooking retrieves the referral attributed to a booking
+func (r *PostgresReferralRepository) GetReferralByBooking(ctx context.Context, bookingID uuid.UUID) (*entity.Referral, error) {
+	query := `SELECT ` + referralColumns + ` FROM referrals WHERE booking_id = $1 AND deleted_at IS NULL`
+
+	referral := &entity.Referral{}
+	if err := r.db.GetContext(ctx, referral, query, bookingID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrReferralNotFound
+		}
+		return nil, fmt.Errorf("failed to get booking referral: %w", err)
+	}
+
+	return referral, nil
+}
+
+// ListReferrals retrieves referrals matching a filter with the total count
+func (r *PostgresReferralRepository) ListReferrals(ctx context.Context, filter repository.ReferralFilter) ([]*entity.Referral, int, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := []interface{}{}
+
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if filter.InfluencerID != "" {
+		args = append(args, filter.InfluencerID)
+		conditions = append(conditions, fmt.Sprintf("influencer_id = $%d", len(args)))
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	// Count total referrals matching the filter
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM referrals WHERE "+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count referrals: %w", err)
+	}
+
+	query := `SELECT ` + referralColumns + ` FROM referrals WHERE ` + where + ` ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	referrals := []*entity.Referral{}
+	if err := r.db.SelectContext(ctx, &referrals, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list referrals: %w", err)
+	}
+
+	return referrals, total, nil
+}
+
+// CountApprovedReferrals counts the approved referrals of an influencer
+func (r *PostgresReferralRepository) CountApprovedReferrals(ctx context.Context, influencerID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM referrals
+		WHERE influencer_id = $1 AND status = 'approved' AND deleted_at IS NULL
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, influencerID); err != nil {
+		return 0, fmt.Errorf("failed to count approved referrals: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdateReferralDecision persists the approval or rejection of a referral that is still in
+// previousStatus, posting its ledger entries in the same transaction
+func (r *PostgresReferralRepository) UpdateReferralDecision(ctx context.Context, referral *entity.Referral, previousStatus string, entries []*entity.LedgerEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Guard on the previous status so a referral is only decided once
+	query := `
+		UPDATE referrals
+		SET
+			status = $1,
+			earnings = $2,
+			commission_rule_id = $3,
+			commission_snapshot = $4,
+			updated_at = $5
+		WHERE id = $6 AND status = $7 AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		referral.Status, referral.Earnings, referral.CommissionRuleID, referral.CommissionSnapshot,
+		referral.UpdatedAt, referral.ID, previousStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update referral: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		if previousStatus == "pending" {
+			return entity.ErrReferralNotPending
+		}
+		return entity.ErrReferralAlreadyRejected
+	}
+
+	if err := insertLedgerEntries(ctx, tx, entries); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit referral decision: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+// PostgresSecurityEventRepository implements SecurityEventRepository interface using PostgreSQL
+type PostgresSecurityEventRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresSecurityEventRepository creates a new PostgresSecurityEventRepository
+func NewPostgresSecurityEventRepository(db *sqlx.DB) repository.SecurityEventRepository {
+	return &PostgresSecurityEventRepository{
+		db: db,
+	}
+}
+
+// CreateSecurityEvent records a security event
+func (r *PostgresSecurityEventRepository) CreateSecurityEvent(ctx context.Context, event *entity.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (id, user_id, type, details, created_at)
+		VALUES (:id, :user_id, :type, :details, :created_at)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, event); err != nil {
+		return fmt.Errorf("failed to create security event: %w", err)
+	}
+
+	return nil
+}
@@ -6,12 +6,20 @@ import (
 	"encoding/json" // Add this import
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
 	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
 )
 
+const tenantColumns = `
+	id, name, domain, domain_verified_at, plan_id, is_active, settings, created_at, updated_at, deleted_at
+`
+
 // TenantRepositoryImpl implements TenantRepository interface
 type TenantRepositoryImpl struct {
 	db *sqlx.DB
@@ -26,7 +34,7 @@ func NewTenantRepository(db *sqlx.DB) repository.TenantRepository {
 
 // CreateTenant creates a new tenant
 func (r *TenantRepositoryImpl) CreateTenant(ctx context.Context, tenant *entity.Tenant) error {
-	// Convert map to JSON before storing
+	// Convert settings to JSON before storing
 	settingsJSON, err := json.Marshal(tenant.Settings)
 	if err != nil {
 		return fmt.Errorf("failed to marshal tenant settings: %w", err)
@@ -34,9 +42,9 @@ func (r *TenantRepositoryImpl) CreateTenant(ctx context.Context, tenant *entity.
 
 	query := `
 		INSERT INTO tenants (
-			id, name, domain, plan_id, is_active, settings, created_at, updated_at
+			id, name, domain, domain_verified_at, plan_id, is_active, settings, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
 		)
 	`
 
@@ -46,14 +54,18 @@ func (r *TenantRepositoryImpl) CreateTenant(ctx context.Context, tenant *entity.
 		tenant.ID,
 		tenant.Name,
 		tenant.Domain,
+		tenant.DomainVerifiedAt,
 		tenant.PlanID,
 		tenant.IsActive,
-		settingsJSON, // Pass the JSON representation instead of the map
+		settingsJSON, // Pass the JSON representation instead of the struct
 		tenant.CreatedAt,
 		tenant.UpdatedAt,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrTenantDomainTaken
+		}
 		return fmt.Errorf("failed to create tenant: %w", err)
 	}
 
@@ -62,132 +74,216 @@ func (r *TenantRepositoryImpl) CreateTenant(ctx context.Context, tenant *entity.
 
 // GetTenantByID retrieves a tenant by ID
 func (r *TenantRepositoryImpl) GetTenantByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
-	query := `
-		SELECT id, name, domain, plan_id, is_active, settings, created_at, updated_at, deleted_at
-		FROM tenants
-		WHERE id = $1 AND deleted_at IS NULL
-	`
-
-	var tenant entity.Tenant
-	var settingsJSON []byte
-	
-	err := r.db.QueryRowxContext(ctx, query, id).Scan(
-		&tenant.ID,
-		&tenant.Name,
-		&tenant.Domain,
-		&tenant.PlanID,
-		&tenant.IsActive,
-		&settingsJSON,
-		&tenant.CreatedAt,
-		&tenant.UpdatedAt,
-		&tenant.DeletedAt,
-	)
+	query := `SELECT ` + tenantColumns + ` FROM tenants WHERE id = $1 AND deleted_at IS NULL`
 
+	tenant, err := scanTenant(r.db.QueryRowxContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("tenant not found")
+			return nil, repository.ErrTenantNotFound
 		}
 		return nil, fmt.Errorf("failed to get tenant: %w", err)
 	}
-	
-	// Unmarshal the settings
-	tenant.Settings = make(map[string]interface{})
-	if settingsJSON != nil {
-		if err := json.Unmarshal(settingsJSON, &tenant.Settings); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal tenant settings: %w", err)
-		}
-	}
 
-	return &tenant, nil
+	return tenant, nil
 }
 
 // GetTenantByDomain retrieves a tenant by domain
 func (r *TenantRepositoryImpl) GetTenantByDomain(ctx context.Context, domain string) (*entity.Tenant, error) {
-	query := `
-		SELECT id, name, domain, plan_id, is_active, settings, created_at, updated_at, deleted_at
-		FROM tenants
-		WHERE domain = $1 AND deleted_at IS NULL
-	`
-
-	var tenant entity.Tenant
-	var settingsJSON []byte
-	
-	err := r.db.QueryRowxContext(ctx, query, domain).Scan(
-		&tenant.ID,
-		&tenant.Name,
-		&tenant.Domain,
-		&tenant.PlanID,
-		&tenant.IsActive,
-		&settingsJSON,
-		&tenant.CreatedAt,
-		&tenant.UpdatedAt,
-		&tenant.DeletedAt,
-	)
+	query := `SELECT ` + tenantColumns + ` FROM tenants WHERE domain = $1 AND deleted_at IS NULL`
 
+	tenant, err := scanTenant(r.db.QueryRowxContext(ctx, query, domain))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("tenant not found")
+			return nil, repository.ErrTenantNotFound
 		}
 		return nil, fmt.Errorf("failed to get tenant: %w", err)
 	}
-	
-	// Unmarshal the settings
-	tenant.Settings = make(map[string]interface{})
-	if settingsJSON != nil {
-		if err := json.Unmarshal(settingsJSON, &tenant.Settings); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal tenant settings: %w", err)
+
+	return tenant, nil
+}
+
+// ListTenants retrieves the tenants matching a filter, newest first, with the total count
+func (r *TenantRepositoryImpl) ListTenants(ctx context.Context, filter repository.TenantFilter) ([]*entity.Tenant, int, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := []interface{}{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("(name ILIKE $%d OR domain ILIKE $%d)", len(args), len(args)))
+	}
+	switch filter.Status {
+	case "active":
+		conditions = append(conditions, "is_active")
+	case "suspended":
+		conditions = append(conditions, "NOT is_active")
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	// Count total tenants matching the filter
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM tenants"+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count tenants: %w", err)
+	}
+
+	query := `SELECT ` + tenantColumns + ` FROM tenants` + where + ` ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := []*entity.Tenant{}
+	for rows.Next() {
+		tenant, err := scanTenant(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list tenants: %w", err)
 		}
+		tenants = append(tenants, tenant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list tenants: %w", err)
 	}
 
-	return &tenant, nil
+	return tenants, total, nil
 }
 
 // UpdateTenant updates a tenant
 func (r *TenantRepositoryImpl) UpdateTenant(ctx context.Context, tenant *entity.Tenant) error {
-	// Convert map to JSON before storing
+	// Convert settings to JSON before storing
 	settingsJSON, err := json.Marshal(tenant.Settings)
 	if err != nil {
 		return fmt.Errorf("failed to marshal tenant settings: %w", err)
 	}
-	
+
 	query := `
 		UPDATE tenants
-		SET name = $1, domain = $2, plan_id = $3, is_active = $4,
-			settings = $5, updated_at = $6
-		WHERE id = $7 AND deleted_at IS NULL
+		SET name = $1, domain = $2, domain_verified_at = $3, plan_id = $4, is_active = $5,
+			settings = $6, updated_at = $7
+		WHERE id = $8 AND deleted_at IS NULL
 	`
 
-	_, err = r.db.ExecContext(
+	result, err := r.db.ExecContext(
 		ctx,
 		query,
 		tenant.Name,
 		tenant.Domain,
+		tenant.DomainVerifiedAt,
 		tenant.PlanID,
 		tenant.IsActive,
-		settingsJSON, // Pass the JSON representation instead of the map
+		settingsJSON, // Pass the JSON representation instead of the struct
 		tenant.UpdatedAt,
 		tenant.ID,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrTenantDomainTaken
+		}
 		return fmt.Errorf("failed to update tenant: %w", err)
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrTenantNotFound
+	}
+
 	return nil
 }
 
-// DeleteTenant marks a tenant as deleted
+// DeleteTenant marks a tenant and its users as deleted and deactivates its products.
+// Bookings, earnings and payouts are kept for the records.
 func (r *TenantRepositoryImpl) DeleteTenant(ctx context.Context, id uuid.UUID) error {
-	query := `
-		UPDATE tenants
-		SET deleted_at = NOW(), updated_at = NOW()
-		WHERE id = $1 AND deleted_at IS NULL
-	`
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, `
+		UPDATE tenants
+		SET is_active = false, deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`, now, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete tenant: %w", err)
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrTenantNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET deleted_at = $1, updated_at = $1
+		WHERE tenant_id = $2 AND deleted_at IS NULL
+	`, now, id); err != nil {
+		return fmt.Errorf("failed to delete tenant users: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE products SET is_active = false, updated_at = $1
+		WHERE tenant_id = $2 AND is_active
+	`, now, id); err != nil {
+		return fmt.Errorf("failed to deactivate tenant products: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
-}
\ No newline at end of file
+}
+
+// scanTenant reads a tenant selected with tenantColumns
+func scanTenant(row interface{ Scan(...interface{}) error }) (*entity.Tenant, error) {
+	var tenant entity.Tenant
+	var settingsJSON []byte
+
+	err := row.Scan(
+		&tenant.ID,
+		&tenant.Name,
+		&tenant.Domain,
+		&tenant.DomainVerifiedAt,
+		&tenant.PlanID,
+		&tenant.IsActive,
+		&settingsJSON,
+		&tenant.CreatedAt,
+		&tenant.UpdatedAt,
+		&tenant.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the settings; settings the tenant never set read as their defaults
+	tenant.Settings = entity.DefaultTenantSettings()
+	if settingsJSON != nil {
+		if err := json.Unmarshal(settingsJSON, &tenant.Settings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tenant settings: %w", err)
+		}
+	}
+
+	return &tenant, nil
+}
+
+// isUniqueViolation checks if an error comes from a unique constraint
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+)
+
+const tenantRoleColumns = `id, tenant_id, name, description, permissions, created_at, updated_at`
+
+// tenantRoleRow is a tenant role as stored, with its permissions as a text array
+type tenantRoleRow struct {
+	entity.TenantRole
+	Permissions pq.StringArray `db:"permissions"`
+}
+
+// toEntity converts the row to a tenant role
+func (r *tenantRoleRow) toEntity() *entity.TenantRole {
+	role := r.TenantRole
+	role.Permissions = make([]entity.Permission, len(r.Permissions))
+	for i, permission := range r.Permissions {
+		role.Permissions[i] = entity.Permission(permission)
+	}
+	return &role
+}
+
+// PostgresTenantRoleRepository implements TenantRoleRepository interface using PostgreSQL
+type PostgresTenantRoleRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresTenantRoleRepository creates a new PostgresTenantRoleRepository
+func NewPostgresTenantRoleRepository(db *sqlx.DB) repository.TenantRoleRepository {
+	return &PostgresTenantRoleRepository{
+		db: db,
+	}
+}
+
+// CreateRole creates a new custom role
+func (r *PostgresTenantRoleRepository) CreateRole(ctx context.Context, role *entity.TenantRole) error {
+	query := `INSERT INTO tenant_roles (` + tenantRoleColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		role.ID, role.TenantID, role.Name, role.Description, permissionArray(role.Permissions),
+		role.CreatedAt, role.UpdatedAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return repository.ErrTenantRoleNameTaken
+		}
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return repository.ErrTenantNotFound
+		}
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return nil
+}
+
+// GetRoleByID retrieves a custom role of a tenant by ID
+func (r *PostgresTenantRoleRepository) GetRoleByID(ctx context.Context, tenantID, id uuid.UUID) (*entity.TenantRole, error) {
+	query := `SELECT ` + tenantRoleColumns + ` FROM tenant_roles WHERE tenant_id = $1 AND id = $2`
+	return r.getRole(ctx, query, tenantID, id)
+}
+
+// GetRoleByName retrieves a custom role of a tenant by name
+func (r *PostgresTenantRoleRepository) GetRoleByName(ctx context.Context, tenantID uuid.UUID, name entity.Role) (*entity.TenantRole, error) {
+	query := `SELECT ` + tenantRoleColumns + ` FROM tenant_roles WHERE tenant_id = $1 AND name = $2`
+	return r.getRole(ctx, query, tenantID, name)
+}
+
+// UpdateRole updates the description and permissions of a custom role
+func (r *PostgresTenantRoleRepository) UpdateRole(ctx context.Context, role *entity.TenantRole) error {
+	query := `
+		UPDATE tenant_roles
+		SET description = $1, permissions = $2, updated_at = $3
+		WHERE tenant_id = $4 AND id = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		role.Description, permissionArray(role.Permissions), role.UpdatedAt, role.TenantID, role.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrTenantRoleNotFound
+	}
+
+	return nil
+}
+
+// DeleteRole deletes a custom role of a tenant
+func (r *PostgresTenantRoleRepository) DeleteRole(ctx context.Context, tenantID, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tenant_roles WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrTenantRoleNotFound
+	}
+
+	return nil
+}
+
+// ListRoles retrieves the custom roles of a tenant
+func (r *PostgresTenantRoleRepository) ListRoles(ctx context.Context, tenantID uuid.UUID) ([]*entity.TenantRole, error) {
+	query := `SELECT ` + tenantRoleColumns + ` FROM tenant_roles WHERE tenant_id = $1 ORDER BY name`
+
+	var rows []*tenantRoleRow
+	if err := r.db.SelectContext(ctx, &rows, query, tenantID); err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	roles := make([]*entity.TenantRole, len(rows))
+	for i, row := range rows {
+		roles[i] = row.toEntity()
+	}
+
+	return roles, nil
+}
+
+// getRole retrieves a single custom role with a query
+func (r *PostgresTenantRoleRepository) getRole(ctx context.Context, query string, args ...interface{}) (*entity.TenantRole, error) {
+	row := &tenantRoleRow{}
+	if err := r.db.GetContext(ctx, row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrTenantRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return row.toEntity(), nil
+}
+
+// permissionArray converts permissions to a text array parameter
+func permissionArray(permissions []entity.Permission) interface{} {
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = string(permission)
+	}
+	return pq.Array(names)
+}
@@ -28,15 +28,20 @@ func NewPostgresUserRepository(db *sqlx.DB) repository.UserRepository {
 
 // CreateUser creates a new user
 func (r *PostgresUserRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	return insertUser(ctx, r.db, user)
+}
+
+// insertUser inserts a user with the given database or transaction
+func insertUser(ctx context.Context, db sqlx.ExtContext, user *entity.User) error {
 	query := `
 		INSERT INTO users (
 			id, tenant_id, email, password_hash, full_name, role, phone, 
 			referral_code, status, profile_picture, social_links, follower_count, 
-			created_at, updated_at
+			email_verified_at, created_at, updated_at
 		) VALUES (
 			:id, :tenant_id, :email, :password_hash, :full_name, :role, :phone, 
 			:referral_code, :status, :profile_picture, :social_links, :follower_count, 
-			:created_at, :updated_at
+			:email_verified_at, :created_at, :updated_at
 		)
 	`
 	
@@ -54,12 +59,13 @@ func (r *PostgresUserRepository) CreateUser(ctx context.Context, user *entity.Us
 		"profile_picture": user.ProfilePicture,
 		"social_links":    pq.Array(user.SocialLinks),
 		"follower_count":  user.FollowerCount,
+		"email_verified_at": user.EmailVerifiedAt,
 		"created_at":      user.CreatedAt,
 		"updated_at":      user.UpdatedAt,
 	}
 	
 	// Execute the query
-	_, err := r.db.NamedExecContext(ctx, query, params)
+	_, err := sqlx.NamedExecContext(ctx, db, query, params)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			// Check for unique violation error
@@ -84,7 +90,7 @@ func (r *PostgresUserRepository) GetUserByID(ctx context.Context, id uuid.UUID)
 		SELECT 
 			id, tenant_id, email, password_hash, full_name, role, phone, 
 			referral_code, status, profile_picture, social_links, follower_count, 
-			created_at, updated_at, deleted_at 
+			email_verified_at, created_at, updated_at, deleted_at 
 		FROM users 
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -96,12 +102,12 @@ func (r *PostgresUserRepository) GetUserByID(ctx context.Context, id uuid.UUID)
 	err := r.db.QueryRowxContext(ctx, query, id).Scan(
 		&user.ID, &user.TenantID, &user.Email, &user.PasswordHash, &user.FullName, &user.Role, 
 		&user.Phone, &user.ReferralCode, &user.Status, &user.ProfilePicture, &socialLinks, 
-		&user.FollowerCount, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.FollowerCount, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 	)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user not found")
+			return nil, repository.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
@@ -118,7 +124,7 @@ func (r *PostgresUserRepository) GetUserByEmail(ctx context.Context, email strin
 		SELECT 
 			id, tenant_id, email, password_hash, full_name, role, phone, 
 			referral_code, status, profile_picture, social_links, follower_count, 
-			created_at, updated_at, deleted_at 
+			email_verified_at, created_at, updated_at, deleted_at 
 		FROM users 
 		WHERE email = $1 AND deleted_at IS NULL
 	`
@@ -130,12 +136,12 @@ func (r *PostgresUserRepository) GetUserByEmail(ctx context.Context, email strin
 	err := r.db.QueryRowxContext(ctx, query, email).Scan(
 		&user.ID, &user.TenantID, &user.Email, &user.PasswordHash, &user.FullName, &user.Role, 
 		&user.Phone, &user.ReferralCode, &user.Status, &user.ProfilePicture, &socialLinks, 
-		&user.FollowerCount, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.FollowerCount, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 	)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user not found")
+			return nil, repository.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
@@ -152,7 +158,7 @@ func (r *PostgresUserRepository) GetUserByReferralCode(ctx context.Context, refe
 		SELECT 
 			id, tenant_id, email, password_hash, full_name, role, phone, 
 			referral_code, status, profile_picture, social_links, follower_count, 
-			created_at, updated_at, deleted_at 
+			email_verified_at, created_at, updated_at, deleted_at 
 		FROM users 
 		WHERE referral_code = $1 AND deleted_at IS NULL AND status = 'active'
 	`
@@ -164,12 +170,12 @@ func (r *PostgresUserRepository) GetUserByReferralCode(ctx context.Context, refe
 	err := r.db.QueryRowxContext(ctx, query, referralCode).Scan(
 		&user.ID, &user.TenantID, &user.Email, &user.PasswordHash, &user.FullName, &user.Role, 
 		&user.Phone, &user.ReferralCode, &user.Status, &user.ProfilePicture, &socialLinks, 
-		&user.FollowerCount, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+		&user.FollowerCount, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 	)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user not found")
+			return nil, repository.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by referral code: %w", err)
 	}
@@ -223,7 +229,7 @@ func (r *PostgresUserRepository) UpdateUser(ctx context.Context, user *entity.Us
 	}
 	
 	if rowsAffected == 0 {
-		return errors.New("user not found")
+		return repository.ErrUserNotFound
 	}
 	
 	return nil
@@ -254,24 +260,25 @@ func (r *PostgresUserRepository) DeleteUser(ctx context.Context, id uuid.UUID) e
 	}
 	
 	if rowsAffected == 0 {
-		return errors.New("user not found")
+		return repository.ErrUserNotFound
 	}
 	
 	return nil
 }
 
-// GetInfluencersByStatus gets influencers by status
-func (r *PostgresUserRepository) GetInfluencersByStatus(ctx context.Context, status string, limit, offset int) ([]*entity.User, int, error) {
+// GetInfluencersByStatus gets influencers by status, of one tenant or of every tenant when tenantID is nil
+func (r *PostgresUserRepository) GetInfluencersByStatus(ctx context.Context, tenantID *uuid.UUID, status string, limit, offset int) ([]*entity.User, int, error) {
 	// Query to get influencers with the given status
 	query := `
 		SELECT 
 			id, tenant_id, email, password_hash, full_name, role, phone, 
 			referral_code, status, profile_picture, social_links, follower_count, 
-			created_at, updated_at, deleted_at 
+			email_verified_at, created_at, updated_at, deleted_at 
 		FROM users 
 		WHERE role = 'influencer' AND status = $1 AND deleted_at IS NULL
+			AND ($2::uuid IS NULL OR tenant_id = $2)
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		LIMIT $3 OFFSET $4
 	`
 	
 	// Query to count total influencers with the given status
@@ -279,17 +286,18 @@ func (r *PostgresUserRepository) GetInfluencersByStatus(ctx context.Context, sta
 		SELECT COUNT(*) 
 		FROM users 
 		WHERE role = 'influencer' AND status = $1 AND deleted_at IS NULL
+			AND ($2::uuid IS NULL OR tenant_id = $2)
 	`
 	
 	// Execute count query
 	var total int
-	err := r.db.GetContext(ctx, &total, countQuery, status)
+	err := r.db.GetContext(ctx, &total, countQuery, status, tenantID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count influencers: %w", err)
 	}
 	
 	// Execute main query
-	rows, err := r.db.QueryxContext(ctx, query, status, limit, offset)
+	rows, err := r.db.QueryxContext(ctx, query, status, tenantID, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get influencers: %w", err)
 	}
@@ -304,7 +312,7 @@ func (r *PostgresUserRepository) GetInfluencersByStatus(ctx context.Context, sta
 		err := rows.Scan(
 			&user.ID, &user.TenantID, &user.Email, &user.PasswordHash, &user.FullName, &user.Role, 
 			&user.Phone, &user.ReferralCode, &user.Status, &user.ProfilePicture, &socialLinks, 
-			&user.FollowerCount, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
+			&user.FollowerCount, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt,
 		)
 		
 		if err != nil {
@@ -320,6 +328,52 @@ func (r *PostgresUserRepository) GetInfluencersByStatus(ctx context.Context, sta
 	return influencers, total, nil
 }
 
+// UpdatePassword replaces the password hash of a user
+func (r *PostgresUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, passwordHash, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// MarkEmailVerified records when a user verified their email address
+func (r *PostgresUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	query := `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, $1), updated_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, verifiedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+
+	return nil
+}
+
 // IsEmailTaken checks if an email is already taken
 func (r *PostgresUserRepository) IsEmailTaken(ctx context.Context, email string) (bool, error) {
 	query := `
@@ -339,6 +393,39 @@ func (r *PostgresUserRepository) IsEmailTaken(ctx context.Context, email string)
 	return exists, nil
 }
 
+// UpdateRole changes the role of a user
+func (r *PostgresUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role entity.Role) error {
+	query := `UPDATE users SET role = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, role, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// CountUsersWithRole counts a tenant's users that have a role
+func (r *PostgresUserRepository) CountUsersWithRole(ctx context.Context, tenantID uuid.UUID, role entity.Role) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE tenant_id = $1 AND role = $2 AND deleted_at IS NULL`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, tenantID, role); err != nil {
+		return 0, fmt.Errorf("failed to count users with role: %w", err)
+	}
+
+	return count, nil
+}
+
 // IsReferralCodeTaken checks if a referral code is already taken
 func (r *PostgresUserRepository) IsReferralCodeTaken(ctx context.Context, referralCode string) (bool, error) {
 	query := `
@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileTransport writes each email as an .eml file into a directory, for development
+type FileTransport struct {
+	dir string
+}
+
+// NewFileTransport creates a FileTransport writing into dir, creating it if needed
+func NewFileTransport(dir string) (*FileTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail outbox: %w", err)
+	}
+	return &FileTransport{dir: dir}, nil
+}
+
+// Deliver writes an email into the outbox directory
+func (t *FileTransport) Deliver(ctx context.Context, email *Email) error {
+	message, err := buildMessage(email)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405"), uuid.New().String())
+	if err := os.WriteFile(filepath.Join(t.dir, name), message, 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	return nil
+}
+
+// MemoryTransport keeps delivered emails in memory, for tests
+type MemoryTransport struct {
+	mu     sync.Mutex
+	emails []*Email
+}
+
+// NewMemoryTransport creates an empty MemoryTransport
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+// Deliver records an email
+func (t *MemoryTransport) Deliver(ctx context.Context, email *Email) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.emails = append(t.emails, email)
+	return nil
+}
+
+// Emails returns the emails delivered so far
+func (t *MemoryTransport) Emails() []*Email {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]*Email(nil), t.emails...)
+}
@@ -0,0 +1,163 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// Built-in templates; each email has <name>.subject.tmpl, <name>.html.tmpl and <name>.txt.tmpl
+//
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Mailer renders emails from tenant or built-in templates and queues them for delivery
+type Mailer struct {
+	templateRepo repository.EmailTemplateRepository
+	tenantRepo   repository.TenantRepository
+	defaults     map[entity.EmailTemplateName]*entity.ParsedEmailTemplate
+	queue        *Queue
+	from         mail.Address
+	appURL       string
+	logger       loggerPkg.Logger
+}
+
+// NewMailer creates a Mailer sending from the given address.
+// appURL is the frontend the templates link to; the sender name doubles as the default brand.
+func NewMailer(
+	templateRepo repository.EmailTemplateRepository,
+	tenantRepo repository.TenantRepository,
+	queue *Queue,
+	from mail.Address,
+	appURL string,
+	logger loggerPkg.Logger,
+) (service.Mailer, error) {
+	defaults, err := loadBuiltinTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mailer{
+		templateRepo: templateRepo,
+		tenantRepo:   tenantRepo,
+		defaults:     defaults,
+		queue:        queue,
+		from:         from,
+		appURL:       strings.TrimRight(appURL, "/"),
+		logger:       logger,
+	}, nil
+}
+
+// Send renders an email and queues it for delivery
+func (m *Mailer) Send(ctx context.Context, msg service.EmailMessage) error {
+	template, err := m.template(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"AppURL":     m.appURL,
+		"TenantName": m.tenantName(ctx, msg),
+	}
+	for key, value := range msg.Data {
+		data[key] = value
+	}
+
+	email := &Email{
+		From: m.from.String(),
+		To:   msg.To,
+	}
+
+	var buf bytes.Buffer
+	if err := template.Subject.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render email subject: %w", err)
+	}
+	// Header values cannot span lines
+	email.Subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	if template.HTML != nil {
+		buf.Reset()
+		if err := template.HTML.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to render email html: %w", err)
+		}
+		email.HTML = buf.String()
+	}
+
+	if template.Text != nil {
+		buf.Reset()
+		if err := template.Text.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to render email text: %w", err)
+		}
+		email.Text = buf.String()
+	}
+
+	return m.queue.Enqueue(email)
+}
+
+// template returns the tenant's override of an email, or the built-in template if it has none.
+// A broken override falls back to the built-in template so the email still goes out.
+func (m *Mailer) template(ctx context.Context, msg service.EmailMessage) (*entity.ParsedEmailTemplate, error) {
+	defaultTemplate, ok := m.defaults[msg.Template]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown template %q", entity.ErrInvalidEmailTemplate, msg.Template)
+	}
+
+	override, err := m.templateRepo.GetEmailTemplate(ctx, msg.TenantID, msg.Template)
+	if err != nil {
+		if !errors.Is(err, repository.ErrEmailTemplateNotFound) {
+			m.logger.Error("Failed to get email template", err, "tenant_id", msg.TenantID.String(), "template", string(msg.Template))
+		}
+		return defaultTemplate, nil
+	}
+
+	parsed, err := override.Parse()
+	if err != nil {
+		m.logger.Error("Failed to parse email template", err, "tenant_id", msg.TenantID.String(), "template", string(msg.Template))
+		return defaultTemplate, nil
+	}
+
+	return parsed, nil
+}
+
+// tenantName returns the name emails of a tenant are branded with
+func (m *Mailer) tenantName(ctx context.Context, msg service.EmailMessage) string {
+	tenant, err := m.tenantRepo.GetTenantByID(ctx, msg.TenantID)
+	if err != nil || tenant.Name == "" {
+		return m.from.Name
+	}
+	return tenant.Name
+}
+
+// loadBuiltinTemplates parses the embedded template of every email
+func loadBuiltinTemplates() (map[entity.EmailTemplateName]*entity.ParsedEmailTemplate, error) {
+	templates := make(map[entity.EmailTemplateName]*entity.ParsedEmailTemplate, len(entity.EmailTemplateNames))
+
+	for _, name := range entity.EmailTemplateNames {
+		var parts [3]string
+		for i, suffix := range []string{"subject", "html", "txt"} {
+			content, err := builtinTemplates.ReadFile(fmt.Sprintf("templates/%s.%s.tmpl", name, suffix))
+			if err != nil {
+				return nil, fmt.Errorf("missing built-in email template %s: %w", name, err)
+			}
+			parts[i] = string(content)
+		}
+
+		template := &entity.EmailTemplate{Name: name, Subject: parts[0], HTMLBody: parts[1], TextBody: parts[2]}
+		parsed, err := template.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("built-in email template %s: %w", name, err)
+		}
+		templates[name] = parsed
+	}
+
+	return templates, nil
+}
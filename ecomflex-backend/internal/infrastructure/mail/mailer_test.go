@@ -0,0 +1,149 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/repository"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// overrideTemplateRepo holds the template overrides of tenants
+type overrideTemplateRepo struct {
+	repository.EmailTemplateRepository
+	templates map[uuid.UUID]*entity.EmailTemplate
+}
+
+func (r *overrideTemplateRepo) GetEmailTemplate(ctx context.Context, tenantID uuid.UUID, name entity.EmailTemplateName) (*entity.EmailTemplate, error) {
+	template, ok := r.templates[tenantID]
+	if !ok || template.Name != name {
+		return nil, repository.ErrEmailTemplateNotFound
+	}
+	return template, nil
+}
+
+// namedTenantRepo knows the names of tenants
+type namedTenantRepo struct {
+	repository.TenantRepository
+	names map[uuid.UUID]string
+}
+
+func (r *namedTenantRepo) GetTenantByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	name, ok := r.names[id]
+	if !ok {
+		return nil, repository.ErrTenantNotFound
+	}
+	return &entity.Tenant{ID: id, Name: name}, nil
+}
+
+// newTestMailer creates a mailer queueing into a memory transport
+func newTestMailer(t *testing.T, templates *overrideTemplateRepo, tenants *namedTenantRepo) (service.Mailer, *Queue, *MemoryTransport) {
+	t.Helper()
+
+	transport := NewMemoryTransport()
+	queue := NewQueue(transport, 10, 1, time.Millisecond, loggerPkg.NewLogger("error"))
+	mailer, err := NewMailer(templates, tenants, queue, mail.Address{Name: "Ecomflex", Address: "noreply@ecomflex.com"}, "https://app.ecomflex.com/", loggerPkg.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("NewMailer() error = %v", err)
+	}
+	return mailer, queue, transport
+}
+
+// sendAndDeliver sends a message and delivers it
+func sendAndDeliver(t *testing.T, templates *overrideTemplateRepo, tenants *namedTenantRepo, msg service.EmailMessage) (*Email, error) {
+	t.Helper()
+
+	mailer, queue, transport := newTestMailer(t, templates, tenants)
+	if err := mailer.Send(context.Background(), msg); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx, 1)
+
+	emails := transport.Emails()
+	if len(emails) != 1 {
+		t.Fatalf("delivered %d emails, want 1", len(emails))
+	}
+	return emails[0], nil
+}
+
+func TestMailerSend(t *testing.T) {
+	tenantID := uuid.New()
+	overriddenID := uuid.New()
+	brokenID := uuid.New()
+
+	templates := &overrideTemplateRepo{templates: map[uuid.UUID]*entity.EmailTemplate{
+		overriddenID: {
+			Name:     entity.EmailTemplatePasswordReset,
+			Subject:  "{{.TenantName}}: new password",
+			TextBody: "Go to {{.Link}}",
+		},
+		brokenID: {
+			Name:     entity.EmailTemplatePasswordReset,
+			Subject:  "{{.TenantName",
+			TextBody: "broken",
+		},
+	}}
+	tenants := &namedTenantRepo{names: map[uuid.UUID]string{tenantID: "Acme", overriddenID: "Acme", brokenID: "Acme"}}
+
+	tests := []struct {
+		name        string
+		tenantID    uuid.UUID
+		wantSubject string
+		wantText    string
+		wantHTML    bool
+	}{
+		{name: "built-in template", tenantID: tenantID, wantSubject: "Reset your Acme password", wantText: "Hi Jane <b>Doe</b>,", wantHTML: true},
+		{name: "tenant override", tenantID: overriddenID, wantSubject: "Acme: new password", wantText: "Go to https://app.ecomflex.com/reset?token=abc"},
+		{name: "broken override", tenantID: brokenID, wantSubject: "Reset your Acme password", wantText: "Hi Jane <b>Doe</b>,", wantHTML: true},
+		{name: "unknown tenant", tenantID: uuid.New(), wantSubject: "Reset your Ecomflex password", wantText: "Hi Jane <b>Doe</b>,", wantHTML: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := sendAndDeliver(t, templates, tenants, service.EmailMessage{
+				TenantID: tt.tenantID,
+				To:       "jane@example.com",
+				Template: entity.EmailTemplatePasswordReset,
+				Data:     map[string]interface{}{"Name": "Jane <b>Doe</b>", "Link": "https://app.ecomflex.com/reset?token=abc"},
+			})
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			if email.To != "jane@example.com" || !strings.Contains(email.From, "noreply@ecomflex.com") {
+				t.Errorf("email from %q to %q", email.From, email.To)
+			}
+			if email.Subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", email.Subject, tt.wantSubject)
+			}
+			if !strings.Contains(email.Text, tt.wantText) {
+				t.Errorf("text = %q, want it to contain %q", email.Text, tt.wantText)
+			}
+			if (email.HTML != "") != tt.wantHTML {
+				t.Fatalf("html = %q, want html %t", email.HTML, tt.wantHTML)
+			}
+			if tt.wantHTML && strings.Contains(email.HTML, "<b>Doe</b>") {
+				t.Errorf("html = %q, want the name escaped", email.HTML)
+			}
+		})
+	}
+}
+
+func TestMailerSendUnknownTemplate(t *testing.T) {
+	mailer, _, _ := newTestMailer(t, &overrideTemplateRepo{}, &namedTenantRepo{})
+
+	err := mailer.Send(context.Background(), service.EmailMessage{To: "jane@example.com", Template: "unknown"})
+	if !errors.Is(err, entity.ErrInvalidEmailTemplate) {
+		t.Fatalf("Send() error = %v, want %v", err, entity.ErrInvalidEmailTemplate)
+	}
+}
@@ -0,0 +1,132 @@
+package mail
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// deliveryTimeout bounds a single delivery attempt
+const deliveryTimeout = 30 * time.Second
+
+// drainTimeout bounds how long the emails still queued on shutdown get to be delivered
+const drainTimeout = 10 * time.Second
+
+// queuedEmail is an email waiting for delivery
+type queuedEmail struct {
+	email    *Email
+	attempts int
+}
+
+// Queue delivers emails in the background, retrying failures with exponential backoff.
+// Queued emails live in memory; those still waiting when Run stops get one last attempt,
+// while retries not yet due are lost.
+type Queue struct {
+	transport   Transport
+	emails      chan *queuedEmail
+	maxAttempts int
+	backoff     time.Duration
+	logger      loggerPkg.Logger
+}
+
+// NewQueue creates a Queue holding up to size emails.
+// A failed email is retried after backoff, doubling for each attempt, until maxAttempts is reached.
+func NewQueue(transport Transport, size, maxAttempts int, backoff time.Duration, logger loggerPkg.Logger) *Queue {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Queue{
+		transport:   transport,
+		emails:      make(chan *queuedEmail, size),
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		logger:      logger,
+	}
+}
+
+// Enqueue queues an email for delivery without waiting for it to be sent
+func (q *Queue) Enqueue(email *Email) error {
+	return q.push(&queuedEmail{email: email})
+}
+
+// Run delivers queued emails with the given number of workers until the context is done,
+// then drains the queue before returning
+func (q *Queue) Run(ctx context.Context, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case queued := <-q.emails:
+					q.deliver(queued)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	q.drain()
+}
+
+// drain makes one last attempt at the emails still queued, giving up once drainTimeout passes
+func (q *Queue) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case queued := <-q.emails:
+			if err := q.transport.Deliver(ctx, queued.email); err != nil {
+				q.logger.Error("Dropped email on shutdown", err, "to", queued.email.To, "subject", queued.email.Subject)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// deliver attempts to send an email, scheduling a retry if it fails.
+// An attempt in progress is allowed to finish when Run is stopped.
+func (q *Queue) deliver(queued *queuedEmail) {
+	attemptCtx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	err := q.transport.Deliver(attemptCtx, queued.email)
+	cancel()
+	if err == nil {
+		return
+	}
+
+	queued.attempts++
+	if queued.attempts >= q.maxAttempts {
+		q.logger.Error("Giving up on email", err, "to", queued.email.To, "subject", queued.email.Subject, "attempts", queued.attempts)
+		return
+	}
+
+	delay := q.backoff << (queued.attempts - 1)
+	q.logger.Warn("Failed to deliver email, retrying", "to", queued.email.To, "error", err.Error(), "retry_in", delay.String())
+	time.AfterFunc(delay, func() {
+		if err := q.push(queued); err != nil {
+			q.logger.Error("Dropped email retry", err, "to", queued.email.To, "subject", queued.email.Subject)
+		}
+	})
+}
+
+// push adds an email to the queue unless it is full
+func (q *Queue) push(queued *queuedEmail) error {
+	select {
+	case q.emails <- queued:
+		return nil
+	default:
+		return service.ErrMailQueueFull
+	}
+}
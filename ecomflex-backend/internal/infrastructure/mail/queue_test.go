@@ -0,0 +1,123 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+	loggerPkg "github.com/naresh6454/ecomflex-backend/internal/util/logger"
+)
+
+// flakyTransport fails a number of deliveries before it starts working
+type flakyTransport struct {
+	mu        sync.Mutex
+	failures  int
+	attempts  int
+	delivered chan *Email
+}
+
+func (t *flakyTransport) Deliver(ctx context.Context, email *Email) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.attempts++
+	if t.attempts <= t.failures {
+		return errors.New("connection refused")
+	}
+	t.delivered <- email
+	return nil
+}
+
+func (t *flakyTransport) Attempts() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.attempts
+}
+
+func TestQueueRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		wantDelivered bool
+	}{
+		{name: "delivered at once", failures: 0, wantDelivered: true},
+		{name: "delivered on a retry", failures: 2, wantDelivered: true},
+		{name: "given up", failures: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &flakyTransport{failures: tt.failures, delivered: make(chan *Email, 1)}
+			queue := NewQueue(transport, 10, 3, time.Millisecond, loggerPkg.NewLogger("error"))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				queue.Run(ctx, 2)
+				close(done)
+			}()
+			defer func() {
+				cancel()
+				<-done
+			}()
+
+			if err := queue.Enqueue(&Email{To: "jane@example.com", Subject: "Hello"}); err != nil {
+				t.Fatalf("Enqueue() error = %v", err)
+			}
+
+			select {
+			case email := <-transport.delivered:
+				if !tt.wantDelivered {
+					t.Fatalf("delivered %q, want it given up", email.Subject)
+				}
+			case <-time.After(500 * time.Millisecond):
+				if tt.wantDelivered {
+					t.Fatal("email was not delivered")
+				}
+			}
+
+			want := tt.failures + 1
+			if !tt.wantDelivered {
+				want = 3
+			}
+			if got := transport.Attempts(); got != want {
+				t.Errorf("%d attempts, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	queue := NewQueue(NewMemoryTransport(), 1, 1, time.Millisecond, loggerPkg.NewLogger("error"))
+
+	if err := queue.Enqueue(&Email{Subject: "first"}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	// Nothing is delivering yet, so the second email does not fit
+	if err := queue.Enqueue(&Email{Subject: "second"}); !errors.Is(err, service.ErrMailQueueFull) {
+		t.Fatalf("Enqueue() error = %v, want %v", err, service.ErrMailQueueFull)
+	}
+}
+
+func TestQueueDrainsOnShutdown(t *testing.T) {
+	transport := NewMemoryTransport()
+	queue := NewQueue(transport, 10, 1, time.Millisecond, loggerPkg.NewLogger("error"))
+
+	for _, subject := range []string{"first", "second", "third"} {
+		if err := queue.Enqueue(&Email{Subject: subject}); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx, 1)
+
+	if emails := transport.Emails(); len(emails) != 3 {
+		t.Fatalf("delivered %d emails on shutdown, want 3", len(emails))
+	}
+}
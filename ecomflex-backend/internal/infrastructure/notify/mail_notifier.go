@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// Frontend pages that complete the account flows
+const (
+	verifyEmailPath   = "/auth/verify-email"
+	resetPasswordPath = "/auth/reset-password"
+	unlockAccountPath = "/auth/unlock-account"
+)
+
+// MailNotifier emails account links to users
+type MailNotifier struct {
+	mailer service.Mailer
+	appURL string
+}
+
+// NewMailNotifier creates a MailNotifier building links to the frontend at appURL
+func NewMailNotifier(mailer service.Mailer, appURL string) service.AuthNotifier {
+	return &MailNotifier{
+		mailer: mailer,
+		appURL: strings.TrimRight(appURL, "/"),
+	}
+}
+
+// SendEmailVerification emails the email verification link of a user
+func (n *MailNotifier) SendEmailVerification(ctx context.Context, user *entity.User, token string) error {
+	return n.send(ctx, user, entity.EmailTemplateVerification, verifyEmailPath, token)
+}
+
+// SendPasswordReset emails the password reset link of a user
+func (n *MailNotifier) SendPasswordReset(ctx context.Context, user *entity.User, token string) error {
+	return n.send(ctx, user, entity.EmailTemplatePasswordReset, resetPasswordPath, token)
+}
+
+// SendAccountUnlock emails the account unlock link of a locked out user
+func (n *MailNotifier) SendAccountUnlock(ctx context.Context, user *entity.User, token string) error {
+	return n.send(ctx, user, entity.EmailTemplateAccountLocked, unlockAccountPath, token)
+}
+
+// send emails a user a frontend link carrying a token
+func (n *MailNotifier) send(ctx context.Context, user *entity.User, template entity.EmailTemplateName, path, token string) error {
+	return n.mailer.Send(ctx, service.EmailMessage{
+		TenantID: user.TenantID,
+		To:       user.Email,
+		Template: template,
+		Data: map[string]interface{}{
+			"Name": user.FullName,
+			"Link": n.appURL + path + "?token=" + url.QueryEscape(token),
+		},
+	})
+}
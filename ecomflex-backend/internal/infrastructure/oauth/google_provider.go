@@ -0,0 +1,231 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// GoogleProviderName identifies Google in linked identities
+const GoogleProviderName = "google"
+
+// GoogleProvider signs users in with Google's OpenID Connect authorization code flow
+type GoogleProvider struct {
+	cfg    config.OAuthConfig
+	client *http.Client
+	keys   *KeySet
+}
+
+// NewGoogleProvider creates a Google identity provider using the configured endpoints
+func NewGoogleProvider(cfg config.OAuthConfig) service.IdentityProvider {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return &GoogleProvider{
+		cfg:    cfg,
+		client: client,
+		keys:   NewKeySet(cfg.GoogleJWKSURL, client),
+	}
+}
+
+// tokenResponse is the token endpoint's answer to a code exchange
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// flexibleBool accepts booleans that some providers send as strings
+type flexibleBool bool
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	*b = flexibleBool(strings.Trim(string(data), `"`) == "true")
+	return nil
+}
+
+// googleClaims are the ID token claims used to identify a user
+type googleClaims struct {
+	jwt.RegisteredClaims
+	Email         string       `json:"email"`
+	EmailVerified flexibleBool `json:"email_verified"`
+	Name          string       `json:"name"`
+	Picture       string       `json:"picture"`
+}
+
+// userInfo is the profile returned by the userinfo endpoint
+type userInfo struct {
+	Subject string `json:"sub"`
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
+}
+
+// Name identifies the provider
+func (p *GoogleProvider) Name() string {
+	return GoogleProviderName
+}
+
+// Authenticate exchanges an authorization code and returns the verified identity behind it
+func (p *GoogleProvider) Authenticate(ctx context.Context, code, redirectURI string) (*service.ExternalIdentity, error) {
+	if p.cfg.GoogleClientID == "" || p.cfg.GoogleClientSecret == "" {
+		return nil, service.ErrIdentityProviderNotConfigured
+	}
+
+	if p.cfg.GoogleRedirectURL != "" && redirectURI != p.cfg.GoogleRedirectURL {
+		return nil, service.ErrInvalidRedirectURI
+	}
+
+	tokens, err := p.exchange(ctx, code, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := p.verifyIDToken(ctx, tokens.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &service.ExternalIdentity{
+		Provider:      GoogleProviderName,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}
+
+	// The ID token only carries profile claims when the profile scope was granted
+	if p.cfg.GoogleUserInfoURL != "" && tokens.AccessToken != "" && (identity.Name == "" || identity.Picture == "") {
+		if err := p.fillProfile(ctx, tokens.AccessToken, identity); err != nil {
+			return nil, err
+		}
+	}
+
+	return identity, nil
+}
+
+// exchange trades an authorization code for tokens at the token endpoint
+func (p *GoogleProvider) exchange(ctx context.Context, code, redirectURI string) (*tokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.cfg.GoogleClientID},
+		"client_secret": {p.cfg.GoogleClientSecret},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.GoogleTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	tokens := &tokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// Expired, reused and forged codes are all reported as invalid_grant
+		if resp.StatusCode == http.StatusBadRequest && tokens.Error == "invalid_grant" {
+			return nil, fmt.Errorf("%w: %s", service.ErrInvalidAuthorizationCode, tokens.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, tokens.Error)
+	}
+
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in token response", service.ErrInvalidIdentityToken)
+	}
+
+	return tokens, nil
+}
+
+// verifyIDToken checks the signature of an ID token against the provider's keys and
+// that it was issued by the provider, for this client, and has not expired
+func (p *GoogleProvider) verifyIDToken(ctx context.Context, rawToken string) (*googleClaims, error) {
+	claims := &googleClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+
+	_, err := parser.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", service.ErrInvalidIdentityToken, err)
+	}
+
+	switch {
+	case claims.ExpiresAt == nil:
+		return nil, fmt.Errorf("%w: missing expiry", service.ErrInvalidIdentityToken)
+	case !claims.VerifyAudience(p.cfg.GoogleClientID, true):
+		return nil, fmt.Errorf("%w: wrong audience", service.ErrInvalidIdentityToken)
+	case !p.trustedIssuer(claims.Issuer):
+		return nil, fmt.Errorf("%w: untrusted issuer %q", service.ErrInvalidIdentityToken, claims.Issuer)
+	case claims.Subject == "" || claims.Email == "":
+		return nil, fmt.Errorf("%w: missing subject or email", service.ErrInvalidIdentityToken)
+	}
+
+	return claims, nil
+}
+
+// trustedIssuer checks an ID token issuer against the configured ones
+func (p *GoogleProvider) trustedIssuer(issuer string) bool {
+	for _, trusted := range p.cfg.GoogleIssuers {
+		if issuer == trusted {
+			return true
+		}
+	}
+	return false
+}
+
+// fillProfile completes an identity with the profile from the userinfo endpoint
+func (p *GoogleProvider) fillProfile(ctx context.Context, accessToken string, identity *service.ExternalIdentity) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.GoogleUserInfoURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create userinfo request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch userinfo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("userinfo endpoint returned status %d", resp.StatusCode)
+	}
+
+	var info userInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return fmt.Errorf("failed to decode userinfo: %w", err)
+	}
+
+	// The access token must belong to the user the ID token was issued for
+	if info.Subject != identity.Subject {
+		return fmt.Errorf("%w: userinfo subject mismatch", service.ErrInvalidIdentityToken)
+	}
+
+	if identity.Name == "" {
+		identity.Name = info.Name
+	}
+	if identity.Picture == "" {
+		identity.Picture = info.Picture
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+const (
+	testClientID = "test-client.apps.googleusercontent.com"
+	testKeyID    = "test-key"
+)
+
+// newTestJWKS serves the public half of key under testKeyID
+func newTestJWKS(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		json.NewEncoder(w).Encode(map[string][]jsonWebKey{
+			"keys": {{
+				Kid: testKeyID,
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGoogleProviderVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	jwks := newTestJWKS(t, key)
+	provider := NewGoogleProvider(config.OAuthConfig{
+		GoogleClientID: testClientID,
+		GoogleJWKSURL:  jwks.URL,
+		GoogleIssuers:  []string{"https://accounts.google.com", "accounts.google.com"},
+	}).(*GoogleProvider)
+
+	validClaims := func() *googleClaims {
+		now := time.Now()
+		return &googleClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "https://accounts.google.com",
+				Subject:   "1234567890",
+				Audience:  jwt.ClaimStrings{testClientID},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+			Email:         "user@example.com",
+			EmailVerified: true,
+		}
+	}
+
+	sign := func(t *testing.T, method jwt.SigningMethod, signingKey interface{}, claims *googleClaims) string {
+		t.Helper()
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = testKeyID
+		signed, err := token.SignedString(signingKey)
+		if err != nil {
+			t.Fatalf("SignedString: %v", err)
+		}
+		return signed
+	}
+
+	tests := []struct {
+		name    string
+		token   func(t *testing.T) string
+		wantErr bool
+	}{
+		{
+			name:  "valid token",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, key, validClaims()) },
+		},
+		{
+			name: "issuer without scheme",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims.Issuer = "accounts.google.com"
+				return sign(t, jwt.SigningMethodRS256, key, claims)
+			},
+		},
+		{
+			name: "issued for another client",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims.Audience = jwt.ClaimStrings{"another-client.apps.googleusercontent.com"}
+				return sign(t, jwt.SigningMethodRS256, key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing audience",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims.Audience = nil
+				return sign(t, jwt.SigningMethodRS256, key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "untrusted issuer",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims.Issuer = "https://evil.example.com"
+				return sign(t, jwt.SigningMethodRS256, key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+				return sign(t, jwt.SigningMethodRS256, key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing expiry",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims.ExpiresAt = nil
+				return sign(t, jwt.SigningMethodRS256, key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing email",
+			token: func(t *testing.T) string {
+				claims := validClaims()
+				claims.Email = ""
+				return sign(t, jwt.SigningMethodRS256, key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "signed with an unpublished key",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, otherKey, validClaims()) },
+			wantErr: true,
+		},
+		{
+			name:    "signed with HMAC",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, []byte(testClientID), validClaims()) },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := provider.verifyIDToken(context.Background(), tt.token(t))
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("verifyIDToken() error = %v", err)
+				}
+				if claims.Subject != "1234567890" || claims.Email != "user@example.com" {
+					t.Errorf("verifyIDToken() = %+v, want the signed claims", claims)
+				}
+				return
+			}
+
+			if !errors.Is(err, service.ErrInvalidIdentityToken) {
+				t.Fatalf("verifyIDToken() error = %v, want %v", err, service.ErrInvalidIdentityToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultKeySetTTL is how long keys are cached when the provider sends no max-age
+	defaultKeySetTTL = time.Hour
+
+	// minKeySetRefresh bounds how often an unknown key ID triggers a refetch
+	minKeySetRefresh = time.Minute
+)
+
+// errUnknownKey is returned when a token is signed with a key the provider does not publish
+var errUnknownKey = errors.New("unknown signing key")
+
+var maxAgePattern = regexp.MustCompile(`max-age=(\d+)`)
+
+// jsonWebKey is an RSA key published in a JWKS document
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// KeySet caches the signing keys a provider publishes at its JWKS endpoint.
+// Keys are refetched when the cache expires or a token names an unknown key.
+type KeySet struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// NewKeySet creates a key set backed by a JWKS endpoint
+func NewKeySet(url string, client *http.Client) *KeySet {
+	return &KeySet{
+		url:    url,
+		client: client,
+		keys:   map[string]*rsa.PublicKey{},
+	}
+}
+
+// Key returns the public key with the given key ID
+func (s *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	key, ok := s.keys[kid]
+	if ok && now.Before(s.expiresAt) {
+		return key, nil
+	}
+
+	// Keys rotate; refetch for an unknown ID, but not on every forged token
+	if !ok && now.Before(s.expiresAt) && now.Sub(s.fetchedAt) < minKeySetRefresh {
+		return nil, errUnknownKey
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	key, ok = s.keys[kid]
+	if !ok {
+		return nil, errUnknownKey
+	}
+	return key, nil
+}
+
+// refresh fetches the key set, honouring the cache lifetime the provider sends
+func (s *KeySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create jwks request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch jwks: status %d", resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range document.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		// A malformed key is skipped rather than locking out the valid ones
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	ttl := defaultKeySetTTL
+	if match := maxAgePattern.FindStringSubmatch(resp.Header.Get("Cache-Control")); match != nil {
+		if seconds, err := strconv.Atoi(match[1]); err == nil {
+			ttl = time.Duration(seconds) * time.Second
+		}
+	}
+
+	now := time.Now()
+	s.keys = keys
+	s.fetchedAt = now
+	s.expiresAt = now.Add(ttl)
+	return nil
+}
+
+// publicKey decodes the modulus and exponent of an RSA key
+func (k jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus for key %q: %w", k.Kid, err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent for key %q: %w", k.Kid, err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid exponent for key %q", k.Kid)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
@@ -0,0 +1,267 @@
+package payout
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// FakeProviderName is the name of the local fake payout provider
+const FakeProviderName = "fake"
+
+// fakeFailureMarker makes transfers to a beneficiary fail when it appears in their email,
+// e.g. influencer+fail@example.com
+const fakeFailureMarker = "+fail"
+
+// SignatureHeader carries the hex HMAC-SHA256 of a fake webhook body
+const SignatureHeader = "X-Payout-Signature"
+
+// fakeWebhook is the body of a fake payout webhook
+type fakeWebhook struct {
+	EventID       string              `json:"event_id"`
+	Reference     string              `json:"reference"`
+	Status        entity.PayoutStatus `json:"status"`
+	FailureReason string              `json:"failure_reason,omitempty"`
+}
+
+// fakeTransfer is a transfer held by the fake provider
+type fakeTransfer struct {
+	Reference      string              `json:"reference"`
+	PayoutID       uuid.UUID           `json:"payout_id"`
+	BeneficiaryRef string              `json:"beneficiary_ref"`
+	Amount         float64             `json:"amount"`
+	Currency       string              `json:"currency"`
+	Status         entity.PayoutStatus `json:"status"`
+	FailureReason  string              `json:"failure_reason,omitempty"`
+}
+
+// fakeState is everything the fake provider knows, persisted as JSON when a path is set
+type fakeState struct {
+	Beneficiaries map[string]service.PayoutBeneficiary `json:"beneficiaries"`
+	Transfers     map[string]*fakeTransfer             `json:"transfers"`
+}
+
+// FakeProvider is an in-memory payout provider for tests and local development.
+// Transfers start out processing and settle the first time they are polled; transfers to
+// beneficiaries whose email contains "+fail" fail. With a path, state survives restarts.
+type FakeProvider struct {
+	mu            sync.Mutex
+	path          string
+	webhookSecret string
+	state         fakeState
+}
+
+// NewFakeProvider creates a FakeProvider, loading its state from path if it is not empty
+func NewFakeProvider(path, webhookSecret string) (*FakeProvider, error) {
+	p := &FakeProvider{
+		path:          path,
+		webhookSecret: webhookSecret,
+		state: fakeState{
+			Beneficiaries: map[string]service.PayoutBeneficiary{},
+			Transfers:     map[string]*fakeTransfer{},
+		},
+	}
+
+	if path == "" {
+		return p, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return p, nil
+		}
+		return nil, fmt.Errorf("failed to read fake payout state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &p.state); err != nil {
+		return nil, fmt.Errorf("failed to parse fake payout state: %w", err)
+	}
+
+	return p, nil
+}
+
+// Name identifies the provider
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+// CreateBeneficiary registers a payee
+func (p *FakeProvider) CreateBeneficiary(ctx context.Context, beneficiary service.PayoutBeneficiary) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	reference := "ben_" + uuid.NewString()
+	p.state.Beneficiaries[reference] = beneficiary
+
+	if err := p.save(); err != nil {
+		return "", err
+	}
+
+	return reference, nil
+}
+
+// InitiateTransfer starts a transfer, returning the existing one for a repeated payout
+func (p *FakeProvider) InitiateTransfer(ctx context.Context, req service.TransferRequest) (*service.TransferStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, transfer := range p.state.Transfers {
+		if transfer.PayoutID == req.PayoutID {
+			return transfer.status(), nil
+		}
+	}
+
+	if _, ok := p.state.Beneficiaries[req.BeneficiaryRef]; !ok {
+		return nil, fmt.Errorf("unknown beneficiary %s", req.BeneficiaryRef)
+	}
+
+	transfer := &fakeTransfer{
+		Reference:      "tr_" + uuid.NewString(),
+		PayoutID:       req.PayoutID,
+		BeneficiaryRef: req.BeneficiaryRef,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		Status:         entity.PayoutStatusProcessing,
+	}
+	p.state.Transfers[transfer.Reference] = transfer
+
+	if err := p.save(); err != nil {
+		return nil, err
+	}
+
+	return transfer.status(), nil
+}
+
+// GetTransfer polls a transfer, settling it if it is still processing
+func (p *FakeProvider) GetTransfer(ctx context.Context, reference string) (*service.TransferStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transfer, ok := p.state.Transfers[reference]
+	if !ok {
+		return nil, service.ErrTransferNotFound
+	}
+
+	if transfer.Status == entity.PayoutStatusProcessing {
+		beneficiary := p.state.Beneficiaries[transfer.BeneficiaryRef]
+		transfer.Status = entity.PayoutStatusPaid
+		if strings.Contains(beneficiary.Email, fakeFailureMarker) {
+			transfer.Status = entity.PayoutStatusFailed
+			transfer.FailureReason = "beneficiary account rejected the transfer"
+		}
+
+		if err := p.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	return transfer.status(), nil
+}
+
+// Settle forces the outcome of a transfer and returns a signed webhook body reporting it,
+// so tests and local setups can exercise the webhook endpoint
+func (p *FakeProvider) Settle(reference string, status entity.PayoutStatus, failureReason string) ([]byte, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transfer, ok := p.state.Transfers[reference]
+	if !ok {
+		return nil, "", service.ErrTransferNotFound
+	}
+
+	transfer.Status = status
+	transfer.FailureReason = failureReason
+	if err := p.save(); err != nil {
+		return nil, "", err
+	}
+
+	body, err := json.Marshal(fakeWebhook{
+		EventID:       "evt_" + uuid.NewString(),
+		Reference:     reference,
+		Status:        status,
+		FailureReason: failureReason,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode webhook: %w", err)
+	}
+
+	return body, p.sign(body), nil
+}
+
+// ParseWebhook verifies the HMAC signature of a webhook and extracts its event
+func (p *FakeProvider) ParseWebhook(header http.Header, body []byte) (*service.PayoutWebhookEvent, error) {
+	signature, err := hex.DecodeString(header.Get(SignatureHeader))
+	if err != nil || p.webhookSecret == "" {
+		return nil, service.ErrInvalidWebhookSignature
+	}
+
+	expected, _ := hex.DecodeString(p.sign(body))
+	if !hmac.Equal(signature, expected) {
+		return nil, service.ErrInvalidWebhookSignature
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	if webhook.EventID == "" || webhook.Reference == "" {
+		return nil, fmt.Errorf("webhook is missing its event ID or reference")
+	}
+
+	return &service.PayoutWebhookEvent{
+		EventID: webhook.EventID,
+		TransferStatus: service.TransferStatus{
+			Reference:     webhook.Reference,
+			Status:        webhook.Status,
+			FailureReason: webhook.FailureReason,
+		},
+	}, nil
+}
+
+// sign computes the hex HMAC-SHA256 of a webhook body
+func (p *FakeProvider) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.webhookSecret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// save writes the state to disk when the provider is file-backed; callers hold the lock
+func (p *FakeProvider) save() error {
+	if p.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(p.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fake payout state: %w", err)
+	}
+
+	if err := os.WriteFile(p.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write fake payout state: %w", err)
+	}
+
+	return nil
+}
+
+// status reports a fake transfer in provider terms
+func (t *fakeTransfer) status() *service.TransferStatus {
+	return &service.TransferStatus{
+		Reference:     t.Reference,
+		Status:        t.Status,
+		FailureReason: t.FailureReason,
+	}
+}
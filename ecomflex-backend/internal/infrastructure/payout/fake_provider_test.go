@@ -0,0 +1,95 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/entity"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+func TestFakeProviderParseWebhook(t *testing.T) {
+	provider, err := NewFakeProvider("", "test-webhook-secret")
+	if err != nil {
+		t.Fatalf("NewFakeProvider: %v", err)
+	}
+
+	ctx := context.Background()
+	beneficiaryRef, err := provider.CreateBeneficiary(ctx, service.PayoutBeneficiary{UserID: uuid.New(), Email: "influencer@example.com"})
+	if err != nil {
+		t.Fatalf("CreateBeneficiary: %v", err)
+	}
+	transfer, err := provider.InitiateTransfer(ctx, service.TransferRequest{PayoutID: uuid.New(), BeneficiaryRef: beneficiaryRef, Amount: 75, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("InitiateTransfer: %v", err)
+	}
+
+	body, signature, err := provider.Settle(transfer.Reference, entity.PayoutStatusPaid, "")
+	if err != nil {
+		t.Fatalf("Settle: %v", err)
+	}
+
+	otherSecret, err := NewFakeProvider("", "another-secret")
+	if err != nil {
+		t.Fatalf("NewFakeProvider: %v", err)
+	}
+	noSecret, err := NewFakeProvider("", "")
+	if err != nil {
+		t.Fatalf("NewFakeProvider: %v", err)
+	}
+
+	tampered := append([]byte(nil), body...)
+	tampered[len(tampered)-2] = ' '
+
+	missingEvent := []byte(`{"reference":"tr_1","status":"paid"}`)
+
+	tests := []struct {
+		name      string
+		provider  *FakeProvider
+		signature string
+		body      []byte
+		wantErr   error
+		wantParse bool
+	}{
+		{name: "valid signature", provider: provider, signature: signature, body: body, wantParse: true},
+		{name: "missing signature", provider: provider, signature: "", body: body, wantErr: service.ErrInvalidWebhookSignature},
+		{name: "signature is not hex", provider: provider, signature: "not-a-signature", body: body, wantErr: service.ErrInvalidWebhookSignature},
+		{name: "tampered body", provider: provider, signature: signature, body: tampered, wantErr: service.ErrInvalidWebhookSignature},
+		{name: "signed with another secret", provider: provider, signature: otherSecret.sign(body), body: body, wantErr: service.ErrInvalidWebhookSignature},
+		{name: "provider without a secret", provider: noSecret, signature: noSecret.sign(body), body: body, wantErr: service.ErrInvalidWebhookSignature},
+		{name: "signed body missing its event ID", provider: provider, signature: provider.sign(missingEvent), body: missingEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.signature != "" {
+				header.Set(SignatureHeader, tt.signature)
+			}
+
+			event, err := tt.provider.ParseWebhook(header, tt.body)
+			if tt.wantParse {
+				if err != nil {
+					t.Fatalf("ParseWebhook() error = %v", err)
+				}
+				if event.Reference != transfer.Reference || event.Status != entity.PayoutStatusPaid || event.EventID == "" {
+					t.Errorf("ParseWebhook() = %+v, want a paid event for %s", event, transfer.Reference)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("ParseWebhook() = %+v, want an error", event)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && errors.Is(err, service.ErrInvalidWebhookSignature) {
+				t.Errorf("ParseWebhook() error = %v, want a parse error for a correctly signed body", err)
+			}
+		})
+	}
+}
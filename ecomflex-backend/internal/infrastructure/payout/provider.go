@@ -0,0 +1,22 @@
+package payout
+
+import (
+	"fmt"
+
+	"github.com/naresh6454/ecomflex-backend/config"
+	"github.com/naresh6454/ecomflex-backend/internal/domain/service"
+)
+
+// NewProvider creates the payout provider selected in the configuration.
+// Real payment rails are added here behind the PayoutProvider interface.
+// It returns nil when payouts are disabled.
+func NewProvider(cfg config.PayoutConfig) (service.PayoutProvider, error) {
+	switch cfg.Provider {
+	case config.PayoutProviderDisabled:
+		return nil, nil
+	case "", FakeProviderName:
+		return NewFakeProvider(cfg.FakeStatePath, cfg.WebhookSecret)
+	default:
+		return nil, fmt.Errorf("unknown payout provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,263 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSONSchema is a JSON Schema supporting the keywords settings documents need:
+// type, properties, required, additionalProperties, items, enum, minimum, maximum,
+// minLength, maxLength, pattern, format (email), minItems, maxItems, uniqueItems and readOnly.
+// Unsupported keywords are ignored, as the specification does for unknown ones.
+type JSONSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	UniqueItems          bool                   `json:"uniqueItems,omitempty"`
+	ReadOnly             bool                   `json:"readOnly,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// ParseJSONSchema parses a schema and compiles its patterns
+func ParseJSONSchema(data []byte) (*JSONSchema, error) {
+	var schema JSONSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// compile compiles the patterns of the schema and its subschemas
+func (s *JSONSchema) compile() error {
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid json schema pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+	for _, property := range s.Properties {
+		if err := property.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// ValidateWrite validates a document a client sends to change the data the schema describes.
+// Unlike plain validation, properties marked readOnly are rejected. It returns one message per
+// problem, each starting with the path of the offending value.
+func (s *JSONSchema) ValidateWrite(data []byte) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid json: unexpected data after the document")
+	}
+
+	var problems []string
+	s.validate("$", doc, true, &problems)
+	return problems, nil
+}
+
+// validate checks a decoded value against the schema, collecting problems
+func (s *JSONSchema) validate(path string, value interface{}, write bool, problems *[]string) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if write && s.ReadOnly {
+		report("is read-only")
+		return
+	}
+
+	if s.Type != "" && !jsonTypeMatches(s.Type, value) {
+		report("must be of type %s", s.Type)
+		return
+	}
+
+	if len(s.Enum) > 0 && !jsonEnumContains(s.Enum, value) {
+		report("must be one of %s", jsonEnumList(s.Enum))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(path, v, write, problems)
+	case []interface{}:
+		s.validateArray(path, v, write, problems)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			report("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			report("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			report("must match %s", s.Pattern)
+		}
+		if s.Format == "email" && v != "" {
+			if address, err := mail.ParseAddress(v); err != nil || address.Address != v {
+				report("must be an email address")
+			}
+		}
+	case json.Number:
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			report("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			report("must be at most %v", *s.Maximum)
+		}
+	}
+}
+
+// validateObject checks the properties of an object
+func (s *JSONSchema) validateObject(path string, object map[string]interface{}, write bool, problems *[]string) {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			*problems = append(*problems, fmt.Sprintf("%s.%s: is required", path, name))
+		}
+	}
+
+	// Properties are checked in a stable order so clients see the same messages every time
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*problems = append(*problems, fmt.Sprintf("%s.%s: is not a known property", path, name))
+			}
+			continue
+		}
+		property.validate(path+"."+name, object[name], write, problems)
+	}
+}
+
+// validateArray checks the items of an array
+func (s *JSONSchema) validateArray(path string, array []interface{}, write bool, problems *[]string) {
+	if s.MinItems != nil && len(array) < *s.MinItems {
+		*problems = append(*problems, fmt.Sprintf("%s: must have at least %d items", path, *s.MinItems))
+	}
+	if s.MaxItems != nil && len(array) > *s.MaxItems {
+		*problems = append(*problems, fmt.Sprintf("%s: must have at most %d items", path, *s.MaxItems))
+	}
+
+	for i, item := range array {
+		if s.UniqueItems {
+			for _, earlier := range array[:i] {
+				if jsonEqual(item, earlier) {
+					*problems = append(*problems, fmt.Sprintf("%s[%d]: is a duplicate", path, i))
+					break
+				}
+			}
+		}
+		if s.Items != nil {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, write, problems)
+		}
+	}
+}
+
+// jsonTypeMatches checks if a decoded value has a JSON Schema type
+func jsonTypeMatches(typ string, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return typ == "null"
+	case bool:
+		return typ == "boolean"
+	case string:
+		return typ == "string"
+	case []interface{}:
+		return typ == "array"
+	case map[string]interface{}:
+		return typ == "object"
+	case json.Number:
+		if typ == "number" {
+			return true
+		}
+		if typ != "integer" {
+			return false
+		}
+		n, err := v.Float64()
+		return err == nil && n == math.Trunc(n)
+	default:
+		return false
+	}
+}
+
+// jsonEnumContains checks if a decoded value is one of the values of an enum
+func jsonEnumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if jsonEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEnumList formats the values of an enum for messages
+func jsonEnumList(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, allowed := range enum {
+		encoded, _ := json.Marshal(allowed)
+		values[i] = string(encoded)
+	}
+	return strings.Join(values, ", ")
+}
+
+// jsonEqual compares decoded values; numbers are equal when their values are
+func jsonEqual(a, b interface{}) bool {
+	if n, ok := jsonNumber(a); ok {
+		m, ok := jsonNumber(b)
+		return ok && n == m
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// jsonNumber gets the value of a decoded number, whether decoded as json.Number or float64
+func jsonNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
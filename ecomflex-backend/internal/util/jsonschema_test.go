@@ -0,0 +1,213 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testSettingsSchema = `{
+	"type": "object",
+	"additionalProperties": false,
+	"required": ["name"],
+	"properties": {
+		"id": {"type": "string", "readOnly": true},
+		"name": {"type": "string", "minLength": 2, "maxLength": 10},
+		"slug": {"type": "string", "pattern": "^[a-z0-9-]+$"},
+		"contact_email": {"type": "string", "format": "email"},
+		"plan": {"type": "string", "enum": ["free", "pro"]},
+		"cashback_percent": {"type": "number", "minimum": 0, "maximum": 100},
+		"attribution_days": {"type": "integer", "minimum": 1},
+		"marketplaces": {
+			"type": "array",
+			"minItems": 1,
+			"maxItems": 3,
+			"uniqueItems": true,
+			"items": {"type": "string", "minLength": 1}
+		},
+		"payout": {
+			"type": "object",
+			"properties": {
+				"verified": {"type": "boolean", "readOnly": true},
+				"threshold": {"type": "number", "minimum": 0}
+			}
+		}
+	}
+}`
+
+func TestJSONSchemaValidateWrite(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(testSettingsSchema))
+	if err != nil {
+		t.Fatalf("ParseJSONSchema: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		doc          string
+		wantProblems []string
+	}{
+		{
+			name: "valid document",
+			doc: `{"name": "Acme", "slug": "acme-shop", "contact_email": "ops@acme.test", "plan": "pro",
+				"cashback_percent": 12.5, "attribution_days": 30, "marketplaces": ["amazon.com", "flipkart.com"],
+				"payout": {"threshold": 50}}`,
+		},
+		{
+			name:         "missing required property",
+			doc:          `{"slug": "acme"}`,
+			wantProblems: []string{"$.name: is required"},
+		},
+		{
+			name:         "unknown property",
+			doc:          `{"name": "Acme", "theme": "dark"}`,
+			wantProblems: []string{"$.theme: is not a known property"},
+		},
+		{
+			name:         "read-only property",
+			doc:          `{"id": "0d6f", "name": "Acme"}`,
+			wantProblems: []string{"$.id: is read-only"},
+		},
+		{
+			name:         "nested read-only property",
+			doc:          `{"name": "Acme", "payout": {"verified": true, "threshold": 10}}`,
+			wantProblems: []string{"$.payout.verified: is read-only"},
+		},
+		{
+			name:         "type mismatch",
+			doc:          `{"name": 42}`,
+			wantProblems: []string{"$.name: must be of type string"},
+		},
+		{
+			name:         "fraction for an integer",
+			doc:          `{"name": "Acme", "attribution_days": 1.5}`,
+			wantProblems: []string{"$.attribution_days: must be of type integer"},
+		},
+		{
+			name: "whole number written as a fraction",
+			doc:  `{"name": "Acme", "attribution_days": 7.0}`,
+		},
+		{
+			name:         "null for a string",
+			doc:          `{"name": null}`,
+			wantProblems: []string{"$.name: must be of type string"},
+		},
+		{
+			name:         "value outside the enum",
+			doc:          `{"name": "Acme", "plan": "enterprise"}`,
+			wantProblems: []string{`$.plan: must be one of "free", "pro"`},
+		},
+		{
+			name:         "below minimum",
+			doc:          `{"name": "Acme", "cashback_percent": -1}`,
+			wantProblems: []string{"$.cashback_percent: must be at least 0"},
+		},
+		{
+			name:         "above maximum",
+			doc:          `{"name": "Acme", "cashback_percent": 100.5}`,
+			wantProblems: []string{"$.cashback_percent: must be at most 100"},
+		},
+		{
+			name: "bounds are inclusive",
+			doc:  `{"name": "Acme", "cashback_percent": 100, "attribution_days": 1}`,
+		},
+		{
+			name:         "too short",
+			doc:          `{"name": "A"}`,
+			wantProblems: []string{"$.name: must be at least 2 characters long"},
+		},
+		{
+			name:         "too long",
+			doc:          `{"name": "Acme Corporation"}`,
+			wantProblems: []string{"$.name: must be at most 10 characters long"},
+		},
+		{
+			name: "length counts characters",
+			doc:  `{"name": "Ünïcödé"}`,
+		},
+		{
+			name:         "pattern mismatch",
+			doc:          `{"name": "Acme", "slug": "Acme Shop"}`,
+			wantProblems: []string{"$.slug: must match ^[a-z0-9-]+$"},
+		},
+		{
+			name:         "invalid email",
+			doc:          `{"name": "Acme", "contact_email": "not-an-email"}`,
+			wantProblems: []string{"$.contact_email: must be an email address"},
+		},
+		{
+			name:         "email with a display name",
+			doc:          `{"name": "Acme", "contact_email": "Ops <ops@acme.test>"}`,
+			wantProblems: []string{"$.contact_email: must be an email address"},
+		},
+		{
+			name: "empty email",
+			doc:  `{"name": "Acme", "contact_email": ""}`,
+		},
+		{
+			name:         "duplicate items",
+			doc:          `{"name": "Acme", "marketplaces": ["amazon.com", "flipkart.com", "amazon.com"]}`,
+			wantProblems: []string{"$.marketplaces[2]: is a duplicate"},
+		},
+		{
+			name:         "too few items",
+			doc:          `{"name": "Acme", "marketplaces": []}`,
+			wantProblems: []string{"$.marketplaces: must have at least 1 items"},
+		},
+		{
+			name:         "too many items",
+			doc:          `{"name": "Acme", "marketplaces": ["a", "b", "c", "d"]}`,
+			wantProblems: []string{"$.marketplaces: must have at most 3 items"},
+		},
+		{
+			name:         "invalid item",
+			doc:          `{"name": "Acme", "marketplaces": ["amazon.com", 7]}`,
+			wantProblems: []string{"$.marketplaces[1]: must be of type string"},
+		},
+		{
+			name: "problems are reported in property order",
+			doc:  `{"theme": "dark", "plan": "gold", "id": "x", "name": "A"}`,
+			wantProblems: []string{
+				"$.id: is read-only",
+				"$.name: must be at least 2 characters long",
+				`$.plan: must be one of "free", "pro"`,
+				"$.theme: is not a known property",
+			},
+		},
+		{
+			name:         "not an object",
+			doc:          `["Acme"]`,
+			wantProblems: []string{"$: must be of type object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := schema.ValidateWrite([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("ValidateWrite() error = %v", err)
+			}
+			if !reflect.DeepEqual(problems, tt.wantProblems) {
+				t.Errorf("ValidateWrite() = %q, want %q", problems, tt.wantProblems)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaValidateWriteRejectsInvalidJSON(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(testSettingsSchema))
+	if err != nil {
+		t.Fatalf("ParseJSONSchema: %v", err)
+	}
+
+	for _, doc := range []string{``, `{"name": "Acme"`, `{"name": "Acme"} {"name": "Other"}`} {
+		if problems, err := schema.ValidateWrite([]byte(doc)); err == nil {
+			t.Errorf("ValidateWrite(%q) = %q, want an error", doc, problems)
+		}
+	}
+}
+
+func TestParseJSONSchemaRejectsInvalidPattern(t *testing.T) {
+	_, err := ParseJSONSchema([]byte(`{"type": "object", "properties": {"items": {"type": "array", "items": {"pattern": "("}}}}`))
+	if err == nil {
+		t.Fatal("ParseJSONSchema() error = nil, want an error for an invalid pattern")
+	}
+}
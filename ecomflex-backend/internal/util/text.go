@@ -0,0 +1,16 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TruncateRunes shortens s to at most max characters without splitting a multi-byte character.
+// Invalid UTF-8 is dropped so the result can always be stored in a text column.
+func TruncateRunes(s string, max int) string {
+	s = strings.ToValidUTF8(s, "")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		max  int
+		want string
+	}{
+		{name: "shorter", s: "Mozilla", max: 10, want: "Mozilla"},
+		{name: "exact", s: "Mozilla", max: 7, want: "Mozilla"},
+		{name: "ascii", s: "Mozilla/5.0", max: 7, want: "Mozilla"},
+		{name: "multi-byte", s: "日本語のブラウザ", max: 3, want: "日本語"},
+		{name: "multi-byte within byte limit", s: "ééé", max: 4, want: "ééé"},
+		{name: "invalid utf-8", s: "Mozilla\xff/5.0", max: 20, want: "Mozilla/5.0"},
+		{name: "empty", s: "", max: 5, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateRunes(tt.s, tt.max)
+			if got != tt.want {
+				t.Errorf("TruncateRunes(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateRunes(%q, %d) = %q is not valid UTF-8", tt.s, tt.max, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_bookings_status_created_at;
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check
+    CHECK (status IN ('initiated', 'pending', 'approved', 'rejected'));
+
+ALTER TABLE products DROP COLUMN IF EXISTS auto_closed;
@@ -0,0 +1,10 @@
+-- Track products that were closed automatically because all booking slots were taken
+ALTER TABLE products ADD COLUMN IF NOT EXISTS auto_closed BOOLEAN NOT NULL DEFAULT false;
+
+-- Bookings that never receive a proof expire and give their slot back
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check
+    CHECK (status IN ('initiated', 'pending', 'approved', 'rejected', 'expired'));
+
+-- Speed up the stale booking sweep
+CREATE INDEX IF NOT EXISTS idx_bookings_status_created_at ON bookings(status, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS proof_decisions;
+DROP TABLE IF EXISTS booking_proofs;
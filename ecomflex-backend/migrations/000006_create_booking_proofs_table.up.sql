@@ -0,0 +1,39 @@
+-- Proofs of purchase submitted for bookings, moderated by admins
+-- Reviewer columns are not foreign keys because the built-in superadmin has no users row
+CREATE TABLE IF NOT EXISTS booking_proofs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    booking_id UUID NOT NULL REFERENCES bookings(id),
+    product_id UUID NOT NULL REFERENCES products(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    storage_key TEXT NOT NULL DEFAULT '',
+    file_url TEXT NOT NULL,
+    order_id VARCHAR(100) NOT NULL DEFAULT '',
+    submitted_fields JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(50) NOT NULL DEFAULT 'submitted' CHECK (status IN ('submitted', 'in_review', 'approved', 'rejected')),
+    claimed_by UUID,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by UUID,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indices for the moderation queue
+CREATE INDEX idx_booking_proofs_booking_id ON booking_proofs(booking_id);
+CREATE INDEX idx_booking_proofs_product_id ON booking_proofs(product_id);
+CREATE INDEX idx_booking_proofs_status_created_at ON booking_proofs(status, created_at);
+
+-- History of moderation decisions on proofs
+CREATE TABLE IF NOT EXISTS proof_decisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    proof_id UUID NOT NULL REFERENCES booking_proofs(id),
+    booking_id UUID NOT NULL REFERENCES bookings(id),
+    actor_id UUID NOT NULL,
+    action VARCHAR(50) NOT NULL CHECK (action IN ('claimed', 'approved', 'rejected')),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_proof_decisions_proof_id ON proof_decisions(proof_id);
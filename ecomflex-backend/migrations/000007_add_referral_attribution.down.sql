@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_referrals_booking_id;
+DROP INDEX IF EXISTS idx_referrals_registration_user;
+
+ALTER TABLE referrals DROP COLUMN IF EXISTS source;
+ALTER TABLE referrals DROP COLUMN IF EXISTS click_id;
+ALTER TABLE referrals DROP COLUMN IF EXISTS booking_id;
+
+DELETE FROM referrals WHERE product_id IS NULL;
+ALTER TABLE referrals ALTER COLUMN product_id SET NOT NULL;
+
+DROP TABLE IF EXISTS referral_clicks;
@@ -0,0 +1,27 @@
+-- Clicks on influencer referral links, used to attribute later registrations and bookings
+CREATE TABLE IF NOT EXISTS referral_clicks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    influencer_id UUID NOT NULL REFERENCES users(id),
+    referral_code VARCHAR(50) NOT NULL,
+    product_id UUID REFERENCES products(id),
+    ip_hash VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_referral_clicks_influencer_id ON referral_clicks(influencer_id);
+CREATE INDEX idx_referral_clicks_created_at ON referral_clicks(created_at);
+
+-- Registrations are attributed without a product
+ALTER TABLE referrals ALTER COLUMN product_id DROP NOT NULL;
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS booking_id UUID REFERENCES bookings(id);
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS click_id UUID REFERENCES referral_clicks(id);
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS source VARCHAR(50) NOT NULL DEFAULT 'booking'
+    CHECK (source IN ('registration', 'booking'));
+
+-- A user is attributed at most once at signup and a booking at most once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_referrals_registration_user
+    ON referrals(user_id) WHERE source = 'registration' AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_referrals_booking_id
+    ON referrals(booking_id) WHERE booking_id IS NOT NULL AND deleted_at IS NULL;
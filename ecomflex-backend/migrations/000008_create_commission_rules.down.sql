@@ -0,0 +1,6 @@
+ALTER TABLE referrals DROP COLUMN IF EXISTS commission_snapshot;
+ALTER TABLE referrals DROP COLUMN IF EXISTS commission_rule_id;
+
+DROP TABLE IF EXISTS commission_rules;
+
+ALTER TABLE products DROP COLUMN IF EXISTS price;
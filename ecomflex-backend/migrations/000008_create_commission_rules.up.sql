@@ -0,0 +1,27 @@
+-- Product price is the base for percentage commissions
+ALTER TABLE products ADD COLUMN IF NOT EXISTS price DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+-- Rules that decide the earnings of approved referrals
+CREATE TABLE IF NOT EXISTS commission_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE,
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL DEFAULT 'booking' CHECK (source IN ('registration', 'booking')),
+    amount_type VARCHAR(20) NOT NULL CHECK (amount_type IN ('flat', 'percent')),
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+    min_approved_referrals INT NOT NULL DEFAULT 0 CHECK (min_approved_referrals >= 0),
+    starts_at TIMESTAMP WITH TIME ZONE,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_commission_rules_tenant_id ON commission_rules(tenant_id);
+CREATE INDEX idx_commission_rules_product_id ON commission_rules(product_id);
+
+-- Referrals keep a copy of the rule applied on approval; rules may change or be deleted later
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS commission_rule_id UUID;
+ALTER TABLE referrals ADD COLUMN IF NOT EXISTS commission_snapshot JSONB;
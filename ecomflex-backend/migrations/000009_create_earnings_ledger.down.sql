@@ -0,0 +1,8 @@
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_payout_id_fkey;
+
+DROP TABLE IF EXISTS payouts;
+DROP TABLE IF EXISTS payout_runs;
+
+DROP TRIGGER IF EXISTS ledger_entries_append_only ON ledger_entries;
+DROP TABLE IF EXISTS ledger_entries;
+DROP FUNCTION IF EXISTS prevent_ledger_entry_changes();
@@ -0,0 +1,84 @@
+-- Append-only double-entry ledger; every transaction is one debit and one credit of the same amount
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    account VARCHAR(50) NOT NULL,
+    owner_id UUID REFERENCES users(id),
+    direction VARCHAR(10) NOT NULL CHECK (direction IN ('debit', 'credit')),
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    entry_type VARCHAR(50) NOT NULL,
+    referral_id UUID REFERENCES referrals(id),
+    payout_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT ledger_entries_account_check CHECK (account IN ('influencer_earnings', 'commission_expense', 'payouts_clearing')),
+    CONSTRAINT ledger_entries_entry_type_check CHECK (entry_type IN ('commission', 'reversal', 'payout', 'payout_failure')),
+    UNIQUE (transaction_id, direction)
+);
+
+CREATE INDEX idx_ledger_entries_account_owner ON ledger_entries(account, owner_id, created_at);
+
+-- Each referral and payout event is posted at most once
+CREATE UNIQUE INDEX idx_ledger_entries_referral_event ON ledger_entries(referral_id, entry_type, direction) WHERE referral_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_ledger_entries_payout_event ON ledger_entries(payout_id, entry_type, direction) WHERE payout_id IS NOT NULL;
+
+-- Corrections are posted as new transactions, never by editing history
+CREATE OR REPLACE FUNCTION prevent_ledger_entry_changes() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger entries are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION prevent_ledger_entry_changes();
+
+-- Batches of payouts created from balances above a threshold
+CREATE TABLE IF NOT EXISTS payout_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    threshold DECIMAL(12, 2) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+    payout_count INT NOT NULL DEFAULT 0,
+    total_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Only one run may be in progress at a time
+CREATE UNIQUE INDEX idx_payout_runs_running ON payout_runs(status) WHERE status = 'running';
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    run_id UUID NOT NULL REFERENCES payout_runs(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    account VARCHAR(50) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    status VARCHAR(50) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'paid', 'failed')),
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    failure_reason TEXT NOT NULL DEFAULT '',
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_payouts_user_id ON payouts(user_id, created_at);
+CREATE INDEX idx_payouts_run_id ON payouts(run_id);
+CREATE INDEX idx_payouts_status ON payouts(status);
+
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_payout_id_fkey FOREIGN KEY (payout_id) REFERENCES payouts(id);
+
+-- Earnings of referrals approved before the ledger existed become opening credits
+WITH approved AS (
+    SELECT id, tenant_id, influencer_id, earnings, updated_at, uuid_generate_v4() AS transaction_id
+    FROM referrals
+    WHERE status = 'approved' AND earnings > 0 AND deleted_at IS NULL
+)
+INSERT INTO ledger_entries (transaction_id, tenant_id, account, owner_id, direction, amount, entry_type, referral_id, created_at)
+SELECT a.transaction_id, a.tenant_id, e.account, e.owner_id, e.direction, a.earnings, 'commission', a.id, a.updated_at
+FROM approved a
+CROSS JOIN LATERAL (VALUES
+    ('commission_expense', NULL::UUID, 'debit'),
+    ('influencer_earnings', a.influencer_id, 'credit')
+) AS e(account, owner_id, direction);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS payout_events;
+DROP TABLE IF EXISTS payout_beneficiaries;
+
+DROP INDEX IF EXISTS idx_payouts_provider_reference;
+ALTER TABLE payouts DROP COLUMN IF EXISTS provider;
@@ -0,0 +1,27 @@
+-- Payouts remember the provider and transfer reference they were sent with
+ALTER TABLE payouts ADD COLUMN IF NOT EXISTS provider VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_payouts_provider_reference ON payouts(provider, reference) WHERE reference <> '' AND provider <> 'manual';
+
+-- Payees registered with a payout provider
+CREATE TABLE IF NOT EXISTS payout_beneficiaries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    provider VARCHAR(50) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, provider)
+);
+
+-- Status webhooks already applied, so redeliveries are ignored
+CREATE TABLE IF NOT EXISTS payout_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    provider VARCHAR(50) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    payout_id UUID NOT NULL REFERENCES payouts(id),
+    status VARCHAR(50) NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, event_id)
+);
+
+CREATE INDEX idx_payout_events_payout_id ON payout_events(payout_id);
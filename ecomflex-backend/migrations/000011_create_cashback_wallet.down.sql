@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS payout_methods;
+
+DROP INDEX IF EXISTS idx_ledger_entries_booking_event;
+
+-- Cashback entries cannot satisfy the original constraints, and the ledger is append-only
+ALTER TABLE ledger_entries DISABLE TRIGGER ledger_entries_append_only;
+DELETE FROM ledger_entries WHERE account IN ('cashback_wallet', 'cashback_expense')
+    OR transaction_id IN (SELECT transaction_id FROM ledger_entries WHERE account = 'cashback_wallet');
+ALTER TABLE ledger_entries ENABLE TRIGGER ledger_entries_append_only;
+
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_entry_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_entry_type_check
+    CHECK (entry_type IN ('commission', 'reversal', 'payout', 'payout_failure'));
+
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_account_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('influencer_earnings', 'commission_expense', 'payouts_clearing'));
+
+ALTER TABLE ledger_entries DROP COLUMN IF EXISTS booking_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS cashback_amount;
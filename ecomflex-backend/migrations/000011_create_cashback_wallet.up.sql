@@ -0,0 +1,26 @@
+-- Bookings snapshot the cashback owed when booked, from the tenant's cashback percentage.
+-- Bookings made before cashback existed owe none; tenants opt in by setting a percentage.
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS cashback_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+-- Cashback approved by an admin is credited to the user's wallet in the ledger
+ALTER TABLE ledger_entries ADD COLUMN IF NOT EXISTS booking_id UUID REFERENCES bookings(id);
+
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_account_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_account_check
+    CHECK (account IN ('influencer_earnings', 'commission_expense', 'payouts_clearing', 'cashback_wallet', 'cashback_expense'));
+
+ALTER TABLE ledger_entries DROP CONSTRAINT IF EXISTS ledger_entries_entry_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_entry_type_check
+    CHECK (entry_type IN ('commission', 'cashback', 'reversal', 'payout', 'payout_failure'));
+
+-- Each booking's cashback is posted at most once
+CREATE UNIQUE INDEX idx_ledger_entries_booking_event ON ledger_entries(booking_id, entry_type, direction) WHERE booking_id IS NOT NULL;
+
+-- The method a user wants to be paid out with
+CREATE TABLE IF NOT EXISTS payout_methods (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    type VARCHAR(50) NOT NULL CHECK (type IN ('bank_transfer', 'upi', 'paypal')),
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
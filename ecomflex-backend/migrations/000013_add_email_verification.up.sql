@@ -0,0 +1,5 @@
+-- Users confirm their email address through a link sent to it
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Existing accounts predate verification and are trusted as they are
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
@@ -0,0 +1,15 @@
+-- Tenant overrides of the platform's email templates; emails without one use the built-in template
+CREATE TABLE IF NOT EXISTS email_templates (
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    name VARCHAR(50) NOT NULL CHECK (name IN (
+        'email_verification', 'password_reset',
+        'influencer_approved', 'influencer_rejected',
+        'booking_approved', 'booking_rejected'
+    )),
+    subject TEXT NOT NULL,
+    html_body TEXT NOT NULL DEFAULT '',
+    text_body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, name)
+);
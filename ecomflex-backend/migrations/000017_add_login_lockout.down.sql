@@ -0,0 +1,10 @@
+DELETE FROM email_templates WHERE name = 'account_locked';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_name_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_name_check CHECK (name IN (
+    'email_verification', 'password_reset',
+    'influencer_approved', 'influencer_rejected',
+    'booking_approved', 'booking_rejected'
+));
+
+DELETE FROM security_events WHERE user_id IS NULL;
+ALTER TABLE security_events ALTER COLUMN user_id SET NOT NULL;
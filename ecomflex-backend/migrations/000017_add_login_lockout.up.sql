@@ -0,0 +1,10 @@
+-- Lockouts of IP addresses are not tied to an account
+ALTER TABLE security_events ALTER COLUMN user_id DROP NOT NULL;
+
+-- Tenants can override the email that lets a locked out user unlock their account
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_name_check;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_name_check CHECK (name IN (
+    'email_verification', 'password_reset', 'account_locked',
+    'influencer_approved', 'influencer_rejected',
+    'booking_approved', 'booking_rejected'
+));
@@ -0,0 +1,2 @@
+-- Deactivated accounts are not reactivated with a known password
+SELECT 1;
@@ -0,0 +1,9 @@
+-- The server used to seed admin@ecomflex.com with a password published in the source.
+-- Accounts still using it are deactivated; run bootstrap-admin to set a new password.
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+UPDATE users
+SET status = 'inactive', updated_at = NOW()
+WHERE email = 'admin@ecomflex.com'
+  AND password_hash LIKE '$2%'
+  AND password_hash = crypt('Admin@123', password_hash);
@@ -0,0 +1,4 @@
+UPDATE users SET role = 'public' WHERE role NOT IN ('super_admin', 'influencer', 'public');
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('super_admin', 'influencer', 'public'));
+
+DROP TABLE IF EXISTS tenant_roles;
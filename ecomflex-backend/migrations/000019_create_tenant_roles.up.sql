@@ -0,0 +1,14 @@
+-- Custom roles tenants define with their own permissions
+CREATE TABLE IF NOT EXISTS tenant_roles (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
+
+-- Users can have the support role or a custom role of their tenant, which the application checks
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
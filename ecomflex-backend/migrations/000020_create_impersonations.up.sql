@@ -0,0 +1,17 @@
+-- Admins acting as other users; ended_at stays empty when the token simply expires
+CREATE TABLE IF NOT EXISTS impersonations (
+    id UUID PRIMARY KEY,
+    impersonator_id UUID NOT NULL REFERENCES users(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    reason TEXT NOT NULL,
+    token_id VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_impersonations_user_id ON impersonations(user_id, started_at DESC);
+CREATE INDEX idx_impersonations_impersonator_id ON impersonations(impersonator_id, started_at DESC);
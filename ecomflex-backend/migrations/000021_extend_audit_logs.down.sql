@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+DROP INDEX IF EXISTS idx_audit_logs_tenant_created_at;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS impersonator_id;
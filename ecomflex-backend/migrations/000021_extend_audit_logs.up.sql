@@ -0,0 +1,6 @@
+-- Changes made while impersonating a user are attributed to the admin as well
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS impersonator_id UUID REFERENCES users(id);
+
+-- Audit logs are always read per tenant, newest first
+CREATE INDEX IF NOT EXISTS idx_audit_logs_tenant_created_at ON audit_logs(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
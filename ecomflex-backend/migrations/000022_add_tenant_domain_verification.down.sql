@@ -0,0 +1 @@
+ALTER TABLE tenants DROP COLUMN IF EXISTS domain_verified_at;
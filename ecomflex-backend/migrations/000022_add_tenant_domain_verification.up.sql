@@ -0,0 +1,3 @@
+-- Custom domains only serve a tenant once their ownership is verified;
+-- subdomains of the platform's base domain need no verification
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS domain_verified_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_bookings_tenant_created_at;
+DROP TABLE IF EXISTS stored_files;
+ALTER TABLE tenants DROP CONSTRAINT IF EXISTS tenants_plan_id_fkey;
+DROP TABLE IF EXISTS plans;
@@ -0,0 +1,40 @@
+-- Subscription plans tenants are billed on; a NULL limit means unlimited
+CREATE TABLE IF NOT EXISTS plans (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    max_active_products BIGINT,
+    max_influencers BIGINT,
+    max_monthly_bookings BIGINT,
+    max_storage_bytes BIGINT,
+    features TEXT[] NOT NULL DEFAULT '{}',
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO plans (id, name, max_active_products, max_influencers, max_monthly_bookings, max_storage_bytes, features, sort_order) VALUES
+    ('free', 'Free', 5, 5, 50, 1073741824, '{}', 0),
+    ('starter', 'Starter', 25, 50, 500, 10737418240, '{custom_domain}', 1),
+    ('pro', 'Pro', 100, 500, 5000, 107374182400, '{custom_domain,custom_roles,audit_log_export}', 2),
+    ('enterprise', 'Enterprise', NULL, NULL, NULL, NULL, '{custom_domain,custom_roles,audit_log_export}', 3)
+ON CONFLICT (id) DO NOTHING;
+
+-- The platform itself runs in the default tenant and must not hit a limit
+UPDATE tenants SET plan_id = 'enterprise' WHERE id = '00000000-0000-0000-0000-000000000000';
+UPDATE tenants SET plan_id = 'free' WHERE plan_id NOT IN (SELECT id FROM plans);
+ALTER TABLE tenants ADD CONSTRAINT tenants_plan_id_fkey FOREIGN KEY (plan_id) REFERENCES plans(id);
+
+-- Uploaded files, kept to measure the storage each tenant uses
+CREATE TABLE IF NOT EXISTS stored_files (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id),
+    key TEXT NOT NULL UNIQUE,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stored_files_tenant_id ON stored_files(tenant_id);
+
+-- Monthly bookings are counted per tenant
+CREATE INDEX IF NOT EXISTS idx_bookings_tenant_created_at ON bookings(tenant_id, created_at);
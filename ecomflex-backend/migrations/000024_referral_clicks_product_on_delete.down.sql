@@ -0,0 +1,3 @@
+ALTER TABLE referral_clicks DROP CONSTRAINT IF EXISTS referral_clicks_product_id_fkey;
+ALTER TABLE referral_clicks ADD CONSTRAINT referral_clicks_product_id_fkey
+    FOREIGN KEY (product_id) REFERENCES products(id);
@@ -0,0 +1,4 @@
+-- A click only records the landing product; deleting the product keeps the click for attribution
+ALTER TABLE referral_clicks DROP CONSTRAINT IF EXISTS referral_clicks_product_id_fkey;
+ALTER TABLE referral_clicks ADD CONSTRAINT referral_clicks_product_id_fkey
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL;
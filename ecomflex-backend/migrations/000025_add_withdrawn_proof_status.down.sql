@@ -0,0 +1,5 @@
+UPDATE booking_proofs SET status = 'rejected' WHERE status = 'withdrawn';
+
+ALTER TABLE booking_proofs DROP CONSTRAINT IF EXISTS booking_proofs_status_check;
+ALTER TABLE booking_proofs ADD CONSTRAINT booking_proofs_status_check
+    CHECK (status IN ('submitted', 'in_review', 'approved', 'rejected'));
@@ -0,0 +1,12 @@
+-- Proofs of cancelled bookings are withdrawn so they leave the moderation queue
+ALTER TABLE booking_proofs DROP CONSTRAINT IF EXISTS booking_proofs_status_check;
+ALTER TABLE booking_proofs ADD CONSTRAINT booking_proofs_status_check
+    CHECK (status IN ('submitted', 'in_review', 'approved', 'rejected', 'withdrawn'));
+
+-- Withdraw the proofs of bookings cancelled before this migration
+UPDATE booking_proofs bp
+SET status = 'withdrawn', updated_at = NOW()
+FROM bookings b
+WHERE b.id = bp.booking_id
+  AND b.deleted_at IS NOT NULL
+  AND bp.status IN ('submitted', 'in_review');